@@ -17,6 +17,7 @@ import (
 	"os"
 
 	"github.com/perses/perses/internal/cli/cmd/apply"
+	"github.com/perses/perses/internal/cli/cmd/auth"
 	"github.com/perses/perses/internal/cli/cmd/conf"
 	"github.com/perses/perses/internal/cli/cmd/dac"
 	"github.com/perses/perses/internal/cli/cmd/describe"
@@ -28,6 +29,7 @@ import (
 	"github.com/perses/perses/internal/cli/cmd/project"
 	"github.com/perses/perses/internal/cli/cmd/refresh"
 	"github.com/perses/perses/internal/cli/cmd/remove"
+	"github.com/perses/perses/internal/cli/cmd/search"
 	"github.com/perses/perses/internal/cli/cmd/version"
 	"github.com/perses/perses/internal/cli/cmd/whoami"
 	"github.com/perses/perses/internal/cli/config"
@@ -48,6 +50,7 @@ func newRootCommand() *cobra.Command {
 
 	// The list of supported commands
 	cmd.AddCommand(apply.NewCMD())
+	cmd.AddCommand(auth.NewCMD())
 	cmd.AddCommand(conf.NewCMD())
 	cmd.AddCommand(dac.NewCMD())
 	cmd.AddCommand(describe.NewCMD())
@@ -59,6 +62,7 @@ func newRootCommand() *cobra.Command {
 	cmd.AddCommand(project.NewCMD())
 	cmd.AddCommand(refresh.NewCMD())
 	cmd.AddCommand(remove.NewCMD())
+	cmd.AddCommand(search.NewCMD())
 	cmd.AddCommand(version.NewCMD())
 	cmd.AddCommand(whoami.NewCMD())
 
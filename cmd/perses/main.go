@@ -64,6 +64,9 @@ func main() {
 		logrus.Fatal(err)
 	}
 	defer func() {
+		if indexCloseErr := dependencyManager.Service().GetIndex().Close(); indexCloseErr != nil {
+			logrus.WithError(indexCloseErr).Error("unable to close the search index")
+		}
 		if daoCloseErr := dependencyManager.Persistence().GetPersesDAO().Close(); daoCloseErr != nil {
 			logrus.WithError(daoCloseErr).Error("unable to close the connection to the database")
 		}
@@ -0,0 +1,69 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// This package offers the assertions to write the test suites run by
+// `percli dac test`. The rules are the custom lint rules also used by the
+// API and `percli lint`: a JSONPath `target` and a CEL `assertion`.
+
+package test
+
+// a custom lint rule
+#rule: {
+	name:      string
+	target:    string
+	assertion: string
+	message:   string
+	disable?:  bool
+}
+
+// a test case: the rules to check and/or the folder of the golden files,
+// against every dashboard or only the listed ones.
+#case: {
+	name: string
+	dashboards?: [...string]
+	rules?: [...#rule]
+	golden?: string
+}
+
+// checks that every panel of the dashboard has a description.
+#everyPanelHasDescription: #rule & {
+	name:      "every panel has a description"
+	target:    "$.spec"
+	assertion: #"!has(value.panels) || value.panels.all(k, has(value.panels[k].spec.display) && has(value.panels[k].spec.display.description) && value.panels[k].spec.display.description != "")"#
+	message:   "every panel must have a description"
+}
+
+// checks that the query of every panel uses the given variable, either as
+// $name or as ${name}.
+#everyQueryUsesVariable: {
+	#variable: string
+
+	#rule & {
+		name:      "every query uses the variable \(#variable)"
+		target:    "$.spec.panels[*].spec.queries[*].spec.plugin.spec.query"
+		assertion: #"dyn(value).all(q, q.matches("\\$(\#(#variable)\\b|\\{\#(#variable)[:}])"))"#
+		message:   "every query must use the variable $\(#variable)"
+	}
+}
+
+// checks that the dashboard defines the given variable.
+#hasVariable: {
+	#variable: string
+
+	#rule & {
+		name:      "variable \(#variable) exists"
+		target:    "$.spec"
+		assertion: #"has(value.variables) && value.variables.exists(v, v.spec.name == "\#(#variable)")"#
+		message:   "the dashboard must define the variable \(#variable)"
+	}
+}
@@ -8,10 +8,13 @@ package v1
 
 #enumKind:
 	#KindDashboard |
+	#KindDashboardTemplate |
 	#KindDatasource |
 	#KindEphemeralDashboard |
 	#KindFolder |
+	#KindGlobalDashboardTemplate |
 	#KindGlobalDatasource |
+	#KindGlobalPolicy |
 	#KindGlobalRole |
 	#KindGlobalRoleBinding |
 	#KindGlobalVariable |
@@ -23,18 +26,21 @@ package v1
 	#KindUser |
 	#KindVariable
 
-#KindDashboard:          #Kind & "Dashboard"
-#KindDatasource:         #Kind & "Datasource"
-#KindEphemeralDashboard: #Kind & "EphemeralDashboard"
-#KindFolder:             #Kind & "Folder"
-#KindGlobalDatasource:   #Kind & "GlobalDatasource"
-#KindGlobalRole:         #Kind & "GlobalRole"
-#KindGlobalRoleBinding:  #Kind & "GlobalRoleBinding"
-#KindGlobalVariable:     #Kind & "GlobalVariable"
-#KindGlobalSecret:       #Kind & "GlobalSecret"
-#KindProject:            #Kind & "Project"
-#KindRole:               #Kind & "Role"
-#KindRoleBinding:        #Kind & "RoleBinding"
-#KindSecret:             #Kind & "Secret"
-#KindUser:               #Kind & "User"
-#KindVariable:           #Kind & "Variable"
+#KindDashboard:               #Kind & "Dashboard"
+#KindDashboardTemplate:       #Kind & "DashboardTemplate"
+#KindDatasource:              #Kind & "Datasource"
+#KindEphemeralDashboard:      #Kind & "EphemeralDashboard"
+#KindFolder:                  #Kind & "Folder"
+#KindGlobalDashboardTemplate: #Kind & "GlobalDashboardTemplate"
+#KindGlobalDatasource:        #Kind & "GlobalDatasource"
+#KindGlobalPolicy:            #Kind & "GlobalPolicy"
+#KindGlobalRole:              #Kind & "GlobalRole"
+#KindGlobalRoleBinding:       #Kind & "GlobalRoleBinding"
+#KindGlobalVariable:          #Kind & "GlobalVariable"
+#KindGlobalSecret:            #Kind & "GlobalSecret"
+#KindProject:                 #Kind & "Project"
+#KindRole:                    #Kind & "Role"
+#KindRoleBinding:             #Kind & "RoleBinding"
+#KindSecret:                  #Kind & "Secret"
+#KindUser:                    #Kind & "User"
+#KindVariable:                #Kind & "Variable"
@@ -8,9 +8,49 @@ import "github.com/perses/spec/cue/module"
 
 #PluginModuleKind: "PluginModule"
 
+// ModuleDependency describes another plugin module required by a plugin module.
+#ModuleDependency: {
+	// Name is the name of the module required.
+	name: string @go(Name)
+
+	// Version is the semver range of the accepted versions (e.g. ">=0.5.0 <1.0.0"). Empty means any version.
+	version?: string @go(Version)
+
+	// Registry is the registry of the module required. Empty means the default registry.
+	registry?: string @go(Registry)
+}
+
+// ModuleRequirements is what a plugin module declares to be compatible with the Perses server and with the other modules.
+// It is read from the `perses` section of the package.json of the module.
+#ModuleRequirements: {
+	// PersesVersion is the semver range of the Perses versions supported by the module (e.g. ">=0.52.0 <0.60.0"). Empty means any version.
+	persesVersion?: string @go(PersesVersion)
+
+	// Dependencies is the list of the other modules required by the module.
+	dependencies?: [...#ModuleDependency] @go(Dependencies,[]ModuleDependency)
+}
+
+// ModuleBackend describes the server-side part of a plugin module.
+#ModuleBackend: {
+	// Wasm is the path, relative to the root of the module, of the WASM module used by the server to transform the response of the datasources.
+	wasm: string @go(Wasm)
+}
+
 #ModuleSpec: {
 	schemasPath: string @go(SchemasPath)
 	plugins: [...module.#Plugin] @go(Plugins,[]module.Plugin)
+
+	#ModuleRequirements
+	backend?: null | #ModuleBackend @go(Backend,*ModuleBackend)
+}
+
+// PluginConflict describes a plugin that is provided by more than one module.
+#PluginConflict: {
+	kind: string @go(Kind)
+	name: string @go(Name)
+
+	// Modules is the list of the other modules providing the same plugin.
+	modules: [...module.#Metadata] @go(Modules,[]module.Metadata)
 }
 
 #PluginModule: {
@@ -18,6 +58,10 @@ import "github.com/perses/spec/cue/module"
 	metadata: module.#Metadata      @go(Metadata)
 	spec:     #ModuleSpec           @go(Spec)
 	status?:  null | module.#Status @go(Status,*module.Status)
+
+	// Conflicts is computed when the modules are loaded.
+	// It lists the plugins of this module that are also registered by another loaded module.
+	conflicts?: [...#PluginConflict] @go(Conflicts,[]PluginConflict)
 }
 
 #PluginInDevelopment: _
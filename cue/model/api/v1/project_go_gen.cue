@@ -6,8 +6,23 @@ package v1
 
 import "github.com/perses/spec/cue/common"
 
+// ProjectPluginPolicy restricts the plugins (panels, datasources, queries, variables, annotations) that can be used in a project.
+// A name can be the name of a plugin (e.g. `TimeSeriesChart`) or the name of the module providing it (e.g. `Prometheus`).
+// It works like the `enabled` / `disabled` attributes of the plugin configuration, but only for the resources of the project.
+// Use either Enabled or Disabled. Both can not be used at the same time.
+#ProjectPluginPolicy: {
+	// Enabled is the list of plugins or modules allowed in the project. If not empty, any other plugin is refused.
+	enabled?: [...string] @go(Enabled,[]string)
+
+	// Disabled is the list of plugins or modules refused in the project.
+	disabled?: [...string] @go(Disabled,[]string)
+}
+
 #ProjectSpec: {
 	display?: null | common.#Display @go(Display,*common.Display)
+
+	// Plugins is the policy restricting the plugins that can be used in the project.
+	plugins?: null | #ProjectPluginPolicy @go(Plugins,*ProjectPluginPolicy)
 }
 
 #Project: _
@@ -11,10 +11,15 @@ package role
 	#CreateAction |
 	#UpdateAction |
 	#DeleteAction |
+	#QueryAction |
 	#WildcardAction
 
-#ReadAction:     #Action & "read"
-#CreateAction:   #Action & "create"
-#UpdateAction:   #Action & "update"
-#DeleteAction:   #Action & "delete"
+#ReadAction:   #Action & "read"
+#CreateAction: #Action & "create"
+#UpdateAction: #Action & "update"
+#DeleteAction: #Action & "delete"
+
+// QueryAction is the permission to send queries to a datasource through the Perses proxy.
+// It is distinct from ReadAction, so a user can see the definition of a datasource without being able to query it.
+#QueryAction:    #Action & "query"
 #WildcardAction: #Action & "*"
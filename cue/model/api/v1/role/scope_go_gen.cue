@@ -8,10 +8,13 @@ package role
 
 #enumScope:
 	#DashboardScope |
+	#DashboardTemplateScope |
 	#DatasourceScope |
 	#EphemeralDashboardScope |
 	#FolderScope |
+	#GlobalDashboardTemplateScope |
 	#GlobalDatasourceScope |
+	#GlobalPolicyScope |
 	#GlobalRoleScope |
 	#GlobalRoleBindingScope |
 	#GlobalSecretScope |
@@ -24,19 +27,22 @@ package role
 	#VariableScope |
 	#WildcardScope
 
-#DashboardScope:          #Scope & "Dashboard"
-#DatasourceScope:         #Scope & "Datasource"
-#EphemeralDashboardScope: #Scope & "EphemeralDashboard"
-#FolderScope:             #Scope & "Folder"
-#GlobalDatasourceScope:   #Scope & "GlobalDatasource"
-#GlobalRoleScope:         #Scope & "GlobalRole"
-#GlobalRoleBindingScope:  #Scope & "GlobalRoleBinding"
-#GlobalSecretScope:       #Scope & "GlobalSecret"
-#GlobalVariableScope:     #Scope & "GlobalVariable"
-#ProjectScope:            #Scope & "Project"
-#RoleScope:               #Scope & "Role"
-#RoleBindingScope:        #Scope & "RoleBinding"
-#SecretScope:             #Scope & "Secret"
-#UserScope:               #Scope & "User"
-#VariableScope:           #Scope & "Variable"
-#WildcardScope:           #Scope & "*"
+#DashboardScope:               #Scope & "Dashboard"
+#DashboardTemplateScope:       #Scope & "DashboardTemplate"
+#DatasourceScope:              #Scope & "Datasource"
+#EphemeralDashboardScope:      #Scope & "EphemeralDashboard"
+#FolderScope:                  #Scope & "Folder"
+#GlobalDashboardTemplateScope: #Scope & "GlobalDashboardTemplate"
+#GlobalDatasourceScope:        #Scope & "GlobalDatasource"
+#GlobalPolicyScope:            #Scope & "GlobalPolicy"
+#GlobalRoleScope:              #Scope & "GlobalRole"
+#GlobalRoleBindingScope:       #Scope & "GlobalRoleBinding"
+#GlobalSecretScope:            #Scope & "GlobalSecret"
+#GlobalVariableScope:          #Scope & "GlobalVariable"
+#ProjectScope:                 #Scope & "Project"
+#RoleScope:                    #Scope & "Role"
+#RoleBindingScope:             #Scope & "RoleBinding"
+#SecretScope:                  #Scope & "Secret"
+#UserScope:                    #Scope & "User"
+#VariableScope:                #Scope & "Variable"
+#WildcardScope:                #Scope & "*"
@@ -4,6 +4,12 @@
 
 package v1
 
+import "time"
+
+#MaxRecentlyViewedDashboards: 20
+
+#MaxStarredDashboards: 100
+
 #WildcardProject: "*"
 
 #NativeProvider: {
@@ -16,11 +22,29 @@ package v1
 	subject?: string @go(Subject)
 }
 
+#DashboardReference: {
+	project:   string @go(Project)
+	dashboard: string @go(Dashboard)
+}
+
+#RecentDashboard: {
+	#DashboardReference
+	viewedAt: time.Time @go(ViewedAt)
+}
+
+#UserPreferences: {
+	timezone?:      string                     @go(Timezone)
+	homeDashboard?: null | #DashboardReference @go(HomeDashboard,*DashboardReference)
+	starredDashboards?: [...#DashboardReference] @go(StarredDashboards,[]DashboardReference)
+	recentlyViewedDashboards?: [...#RecentDashboard] @go(RecentlyViewedDashboards,[]RecentDashboard)
+}
+
 #UserSpec: {
 	firstName?:      string          @go(FirstName)
 	lastName?:       string          @go(LastName)
 	nativeProvider?: #NativeProvider @go(NativeProvider)
 	oauthProviders?: [...#OAuthProvider] @go(OauthProviders,[]OAuthProvider)
+	preferences?: null | #UserPreferences @go(Preferences,*UserPreferences)
 }
 
 #User: _
@@ -0,0 +1,57 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dactest
+
+import (
+	"os"
+
+	"github.com/perses/perses/go-sdk/dashboard"
+	"github.com/perses/perses/pkg/model/api/config"
+)
+
+// UpdateGoldenEnv is the environment variable to set to "true" so AssertGolden writes the golden files instead of comparing them.
+const UpdateGoldenEnv = "PERSES_DAC_UPDATE_GOLDEN"
+
+// TestingT is the subset of testing.TB used by the assertions.
+type TestingT interface {
+	Helper()
+	Errorf(format string, args ...any)
+}
+
+// AssertDashboard reports a test error for every rule the dashboard doesn't comply with.
+func AssertDashboard(t TestingT, builder dashboard.Builder, rules ...*config.CustomLintRule) {
+	t.Helper()
+	for _, err := range Evaluate(&builder.Dashboard, rules...) {
+		t.Errorf("dashboard %q: %s", builder.Dashboard.Metadata.Name, err)
+	}
+}
+
+// AssertGolden reports a test error when the dashboard differs from the one stored in the golden file.
+func AssertGolden(t TestingT, builder dashboard.Builder, goldenPath string) {
+	t.Helper()
+	if os.Getenv(UpdateGoldenEnv) == "true" {
+		if err := UpdateGolden(&builder.Dashboard, goldenPath); err != nil {
+			t.Errorf("unable to update the golden file %s: %s", goldenPath, err)
+		}
+		return
+	}
+	result, err := CompareGolden(&builder.Dashboard, goldenPath)
+	if err != nil {
+		t.Errorf("%s", err)
+		return
+	}
+	if len(result) > 0 {
+		t.Errorf("dashboard %q differs from the golden file %s:\n%s", builder.Dashboard.Metadata.Name, goldenPath, result)
+	}
+}
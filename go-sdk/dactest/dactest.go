@@ -0,0 +1,210 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package dactest provides the assertions to test the dashboards built as code.
+//
+// The assertions are custom lint rules, the ones the API and `percli lint` use to enforce the conventions of an organization.
+// They can be run from Go tests with AssertDashboard and AssertGolden, or described in a test suite run by `percli dac test`.
+package dactest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/kylelemons/godebug/diff"
+	"github.com/perses/perses/pkg/model/api/config"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+)
+
+// Suite is a list of test cases, as described in the test files consumed by `percli dac test`.
+type Suite struct {
+	Tests []*Case `json:"tests" yaml:"tests"`
+}
+
+func (s *Suite) Verify() error {
+	if len(s.Tests) == 0 {
+		return errors.New("the test suite doesn't contain any test")
+	}
+	for _, c := range s.Tests {
+		if err := c.Verify(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Case is a test run against every dashboard it targets.
+type Case struct {
+	// Name of the test
+	Name string `json:"name" yaml:"name"`
+	// Dashboards is the list of the names of the dashboards tested. When empty, every dashboard is tested.
+	Dashboards []string `json:"dashboards,omitempty" yaml:"dashboards,omitempty"`
+	// Rules are the assertions every dashboard must comply with.
+	Rules []*config.CustomLintRule `json:"rules,omitempty" yaml:"rules,omitempty"`
+	// Golden is the folder containing the expected version of each dashboard, stored in the file <name>.json.
+	Golden string `json:"golden,omitempty" yaml:"golden,omitempty"`
+}
+
+func (c *Case) Verify() error {
+	if len(c.Name) == 0 {
+		return errors.New("name is required for every test")
+	}
+	if len(c.Rules) == 0 && len(c.Golden) == 0 {
+		return fmt.Errorf("the test %q must provide some rules or a golden folder", c.Name)
+	}
+	for _, rule := range c.Rules {
+		if err := rule.Verify(); err != nil {
+			return fmt.Errorf("invalid rule in the test %q: %w", c.Name, err)
+		}
+	}
+	return nil
+}
+
+// Result is the outcome of a test case for a single dashboard.
+type Result struct {
+	Case      string   `json:"case" yaml:"case"`
+	Project   string   `json:"project,omitempty" yaml:"project,omitempty"`
+	Dashboard string   `json:"dashboard" yaml:"dashboard"`
+	Failures  []string `json:"failures,omitempty" yaml:"failures,omitempty"`
+}
+
+func (r Result) Passed() bool {
+	return len(r.Failures) == 0
+}
+
+// Run executes the test case against the dashboards it targets.
+// When updateGolden is true, the golden files are written instead of being compared.
+func (c *Case) Run(dashboards []*v1.Dashboard, updateGolden bool) []Result {
+	var results []Result
+	found := make(map[string]bool)
+	for _, dashboard := range dashboards {
+		if !c.targets(dashboard.Metadata.Name) {
+			continue
+		}
+		found[dashboard.Metadata.Name] = true
+		result := Result{
+			Case:      c.Name,
+			Project:   dashboard.Metadata.Project,
+			Dashboard: dashboard.Metadata.Name,
+		}
+		for _, err := range Evaluate(dashboard, c.Rules...) {
+			result.Failures = append(result.Failures, err.Error())
+		}
+		if len(c.Golden) > 0 {
+			if failure := c.checkGolden(dashboard, updateGolden); len(failure) > 0 {
+				result.Failures = append(result.Failures, failure)
+			}
+		}
+		results = append(results, result)
+	}
+	for _, name := range c.Dashboards {
+		if !found[name] {
+			results = append(results, Result{
+				Case:      c.Name,
+				Dashboard: name,
+				Failures:  []string{"dashboard not found"},
+			})
+		}
+	}
+	return results
+}
+
+func (c *Case) targets(name string) bool {
+	return len(c.Dashboards) == 0 || slices.Contains(c.Dashboards, name)
+}
+
+func (c *Case) checkGolden(dashboard *v1.Dashboard, updateGolden bool) string {
+	goldenPath := filepath.Join(c.Golden, fmt.Sprintf("%s.json", dashboard.Metadata.Name))
+	if updateGolden {
+		if err := UpdateGolden(dashboard, goldenPath); err != nil {
+			return err.Error()
+		}
+		return ""
+	}
+	result, err := CompareGolden(dashboard, goldenPath)
+	if err != nil {
+		return err.Error()
+	}
+	if len(result) > 0 {
+		return fmt.Sprintf("the dashboard differs from the golden file %s:\n%s", goldenPath, result)
+	}
+	return ""
+}
+
+// Evaluate checks the dashboard against every rule and returns the failures.
+// Unlike the validation made by the API, it doesn't stop at the first failing rule.
+func Evaluate(dashboard *v1.Dashboard, rules ...*config.CustomLintRule) []error {
+	if len(rules) == 0 {
+		return nil
+	}
+	data, err := json.Marshal(dashboard)
+	if err != nil {
+		return []error{fmt.Errorf("error while marshalling the dashboard: %w", err)}
+	}
+	var jsonRaw map[string]any
+	if unmarshalErr := json.Unmarshal(data, &jsonRaw); unmarshalErr != nil {
+		return []error{fmt.Errorf("error while unmarshalling the dashboard: %w", unmarshalErr)}
+	}
+	var errs []error
+	for _, rule := range rules {
+		if rule.Disable {
+			continue
+		}
+		if evaluateErr := rule.Evaluate(jsonRaw); evaluateErr != nil {
+			errs = append(errs, evaluateErr)
+		}
+	}
+	return errs
+}
+
+func marshalGolden(dashboard *v1.Dashboard) ([]byte, error) {
+	data, err := json.MarshalIndent(dashboard, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// CompareGolden compares the dashboard with the one stored in the golden file.
+// It returns the diff between both, or an empty string when they are identical.
+func CompareGolden(dashboard *v1.Dashboard, goldenPath string) (string, error) {
+	expected, err := os.ReadFile(goldenPath) // nolint: gosec
+	if err != nil {
+		return "", fmt.Errorf("unable to read the golden file: %w", err)
+	}
+	result, err := marshalGolden(dashboard)
+	if err != nil {
+		return "", err
+	}
+	if bytes.Equal(expected, result) {
+		return "", nil
+	}
+	return diff.Diff(string(expected), string(result)), nil
+}
+
+// UpdateGolden writes the dashboard in the golden file, creating the parent folders if needed.
+func UpdateGolden(dashboard *v1.Dashboard, goldenPath string) error {
+	data, err := marshalGolden(dashboard)
+	if err != nil {
+		return err
+	}
+	if mkdirErr := os.MkdirAll(filepath.Dir(goldenPath), 0750); mkdirErr != nil {
+		return fmt.Errorf("unable to create the folder of the golden file: %w", mkdirErr)
+	}
+	return os.WriteFile(goldenPath, data, 0644) // nolint: gosec
+}
@@ -0,0 +1,124 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dactest
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/perses/perses/pkg/model/api/config"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func readDashboard(t *testing.T) *v1.Dashboard {
+	data, err := os.ReadFile(filepath.Join("testdata", "dashboard.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	dashboard := &v1.Dashboard{}
+	if unmarshalErr := json.Unmarshal(data, dashboard); unmarshalErr != nil {
+		t.Fatal(unmarshalErr)
+	}
+	return dashboard
+}
+
+func TestEvaluate(t *testing.T) {
+	testSuite := []struct {
+		title          string
+		rules          []*config.CustomLintRule
+		expectedErrors []string
+	}{
+		{
+			title: "no rule",
+		},
+		{
+			title: "every rule passes",
+			rules: []*config.CustomLintRule{
+				HasVariable("cluster"),
+				Rule("at least one panel", "$.spec.panels", "value.size() > 0", "the dashboard must have a panel"),
+			},
+		},
+		{
+			title: "every failure is reported",
+			rules: []*config.CustomLintRule{
+				EveryPanelHasDescription(),
+				EveryQueryUsesVariable("cluster"),
+				HasVariable("instance"),
+			},
+			expectedErrors: []string{
+				"every panel must have a description",
+				"every query must use the variable $cluster",
+				"the dashboard must define the variable instance",
+			},
+		},
+		{
+			title: "disabled rule",
+			rules: []*config.CustomLintRule{
+				{
+					Name:      "disabled",
+					Target:    "$.spec",
+					Assertion: "false",
+					Message:   "should not be evaluated",
+					Disable:   true,
+				},
+			},
+		},
+	}
+	for _, test := range testSuite {
+		t.Run(test.title, func(t *testing.T) {
+			var result []string
+			for _, err := range Evaluate(readDashboard(t), test.rules...) {
+				result = append(result, err.Error())
+			}
+			assert.Equal(t, test.expectedErrors, result)
+		})
+	}
+}
+
+func TestCaseRun(t *testing.T) {
+	dashboard := readDashboard(t)
+	goldenDir := t.TempDir()
+	c := &Case{
+		Name:       "golden",
+		Dashboards: []string{"node-exporter", "missing"},
+		Rules:      []*config.CustomLintRule{HasVariable("cluster")},
+		Golden:     goldenDir,
+	}
+
+	// The golden file doesn't exist yet.
+	results := c.Run([]*v1.Dashboard{dashboard}, false)
+	assert.Len(t, results, 2)
+	assert.False(t, results[0].Passed())
+	assert.Equal(t, []string{"dashboard not found"}, results[1].Failures)
+
+	// Once written, the dashboard matches the golden file.
+	c.Run([]*v1.Dashboard{dashboard}, true)
+	results = c.Run([]*v1.Dashboard{dashboard}, false)
+	assert.True(t, results[0].Passed())
+
+	// Any change is reported.
+	dashboard.Metadata.Project = "other"
+	results = c.Run([]*v1.Dashboard{dashboard}, false)
+	assert.False(t, results[0].Passed())
+	assert.Contains(t, results[0].Failures[0], "the dashboard differs from the golden file")
+}
+
+func TestSuiteVerify(t *testing.T) {
+	assert.EqualError(t, (&Suite{}).Verify(), "the test suite doesn't contain any test")
+	assert.EqualError(t, (&Suite{Tests: []*Case{{Name: "empty"}}}).Verify(), "the test \"empty\" must provide some rules or a golden folder")
+	assert.NoError(t, (&Suite{Tests: []*Case{{Name: "conventions", Rules: []*config.CustomLintRule{EveryPanelHasDescription()}}}}).Verify())
+}
@@ -0,0 +1,64 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dactest
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/perses/perses/pkg/model/api/config"
+)
+
+// Rule returns a custom lint rule. See the documentation of config.CustomLintRule for the syntax of the target and the assertion.
+func Rule(name, target, assertion, message string) *config.CustomLintRule {
+	return &config.CustomLintRule{
+		Name:      name,
+		Target:    target,
+		Assertion: assertion,
+		Message:   message,
+	}
+}
+
+// EveryPanelHasDescription checks that every panel of the dashboard has a description.
+func EveryPanelHasDescription() *config.CustomLintRule {
+	return Rule(
+		"every panel has a description",
+		"$.spec",
+		`!has(value.panels) || value.panels.all(k, has(value.panels[k].spec.display) && has(value.panels[k].spec.display.description) && value.panels[k].spec.display.description != "")`,
+		"every panel must have a description",
+	)
+}
+
+// EveryQueryUsesVariable checks that the query of every panel uses the given variable, either as $name or as ${name}.
+// Only the queries providing their expression in the attribute `query` of their plugin spec are checked.
+func EveryQueryUsesVariable(variable string) *config.CustomLintRule {
+	name := regexp.QuoteMeta(variable)
+	return Rule(
+		fmt.Sprintf("every query uses the variable %s", variable),
+		"$.spec.panels[*].spec.queries[*].spec.plugin.spec.query",
+		fmt.Sprintf(`dyn(value).all(q, q.matches(%s))`, strconv.Quote(fmt.Sprintf(`\$(%s\b|\{%s[:}])`, name, name))),
+		fmt.Sprintf("every query must use the variable $%s", variable),
+	)
+}
+
+// HasVariable checks that the dashboard defines the given variable.
+func HasVariable(variable string) *config.CustomLintRule {
+	return Rule(
+		fmt.Sprintf("variable %s exists", variable),
+		"$.spec",
+		fmt.Sprintf(`has(value.variables) && value.variables.exists(v, v.spec.name == %s)`, strconv.Quote(variable)),
+		fmt.Sprintf("the dashboard must define the variable %s", variable),
+	)
+}
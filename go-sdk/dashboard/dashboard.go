@@ -17,6 +17,7 @@ import (
 	"fmt"
 	"time"
 
+	modelAPI "github.com/perses/perses/pkg/model/api"
 	v1 "github.com/perses/perses/pkg/model/api/v1"
 	"github.com/perses/spec/go/common"
 )
@@ -51,3 +52,8 @@ func New(name string, options ...Option) (Builder, error) {
 type Builder struct {
 	Dashboard v1.Dashboard `json:"-" yaml:"-"`
 }
+
+// Entity returns the dashboard built, so it can be printed by sdk.Exec along with other resources.
+func (b Builder) Entity() modelAPI.Entity {
+	return &b.Dashboard
+}
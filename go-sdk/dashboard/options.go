@@ -52,6 +52,18 @@ func Name(name string) Option {
 	}
 }
 
+// DisplayName sets the name of the dashboard displayed in the UI, whatever the value is.
+// Unlike Name, it never changes the name of the dashboard used in the metadata.
+func DisplayName(name string) Option {
+	return func(builder *Builder) error {
+		if builder.Dashboard.Spec.Display == nil {
+			builder.Dashboard.Spec.Display = &common.Display{}
+		}
+		builder.Dashboard.Spec.Display.Name = name
+		return nil
+	}
+}
+
 func Description(description string) Option {
 	return func(builder *Builder) error {
 		if err := common.ValidateDescription(description); err != nil {
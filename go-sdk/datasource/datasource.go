@@ -14,6 +14,7 @@
 package datasource
 
 import (
+	modelAPI "github.com/perses/perses/pkg/model/api"
 	v1 "github.com/perses/perses/pkg/model/api/v1"
 )
 
@@ -42,3 +43,8 @@ func New(name string, options ...Option) (Builder, error) {
 type Builder struct {
 	v1.Datasource `json:",inline" yaml:",inline"`
 }
+
+// Entity returns the datasource built, so it can be printed by sdk.Exec along with other resources.
+func (b Builder) Entity() modelAPI.Entity {
+	return &b.Datasource
+}
@@ -13,7 +13,10 @@
 
 package datasource
 
-import "github.com/perses/spec/go/plugin"
+import (
+	"github.com/perses/spec/go/common"
+	"github.com/perses/spec/go/plugin"
+)
 
 func Name(name string) Option {
 	return func(datasource *Builder) error {
@@ -29,6 +32,16 @@ func ProjectName(name string) Option {
 	}
 }
 
+func DisplayName(name string) Option {
+	return func(datasource *Builder) error {
+		if datasource.Spec.Display == nil {
+			datasource.Spec.Display = &common.Display{}
+		}
+		datasource.Spec.Display.Name = name
+		return nil
+	}
+}
+
 func Default(isDefault bool) Option {
 	return func(datasource *Builder) error {
 		datasource.Spec.Default = isDefault
@@ -0,0 +1,47 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ephemeraldashboard
+
+import (
+	"time"
+
+	"github.com/perses/perses/go-sdk/dashboard"
+	modelAPI "github.com/perses/perses/pkg/model/api"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/perses/spec/go/common"
+)
+
+// New builds an ephemeral dashboard, deleted by Perses once the ttl is reached.
+// It accepts the same options as a dashboard.
+func New(name string, ttl time.Duration, options ...dashboard.Option) (Builder, error) {
+	builder := &Builder{
+		EphemeralDashboard: v1.EphemeralDashboard{
+			Kind: v1.KindEphemeralDashboard,
+		},
+	}
+	dashboardBuilder, err := dashboard.New(name, options...)
+	builder.EphemeralDashboard.Metadata = dashboardBuilder.Dashboard.Metadata
+	builder.EphemeralDashboard.Spec.TTL = common.Duration(ttl)
+	builder.EphemeralDashboard.Spec.Spec = dashboardBuilder.Dashboard.Spec
+	return *builder, err
+}
+
+type Builder struct {
+	EphemeralDashboard v1.EphemeralDashboard `json:"-" yaml:"-"`
+}
+
+// Entity returns the ephemeral dashboard built, so it can be printed by sdk.Exec along with other resources.
+func (b Builder) Entity() modelAPI.Entity {
+	return &b.EphemeralDashboard
+}
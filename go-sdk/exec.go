@@ -14,6 +14,7 @@
 package sdk
 
 import (
+	"bytes"
 	"encoding/json"
 	"flag"
 	"fmt"
@@ -21,6 +22,7 @@ import (
 	"os"
 
 	"github.com/perses/perses/go-sdk/dashboard"
+	modelAPI "github.com/perses/perses/pkg/model/api"
 	"gopkg.in/yaml.v3"
 )
 
@@ -33,19 +35,43 @@ func init() {
 	flag.String("output", YAMLOutput, "output format of the exec")
 }
 
-func executeDashboardBuilder(builder dashboard.Builder, outputFormat string, writer io.Writer, errWriter io.Writer) {
-	var err error
-	var output []byte
+// ResourceBuilder is implemented by the builder of every Perses resource.
+type ResourceBuilder interface {
+	Entity() modelAPI.Entity
+}
 
+// marshalResources encodes the resources in the given format.
+// Several resources are encoded as a multi-document YAML stream or as a JSON array,
+// both being accepted by `percli apply` and `percli dac build`.
+func marshalResources(resources []modelAPI.Entity, outputFormat string) ([]byte, error) {
 	switch outputFormat {
 	case YAMLOutput:
-		output, err = yaml.Marshal(builder.Dashboard)
+		if len(resources) == 1 {
+			return yaml.Marshal(resources[0])
+		}
+		var buffer bytes.Buffer
+		encoder := yaml.NewEncoder(&buffer)
+		for _, resource := range resources {
+			if err := encoder.Encode(resource); err != nil {
+				return nil, err
+			}
+		}
+		if err := encoder.Close(); err != nil {
+			return nil, err
+		}
+		return buffer.Bytes(), nil
 	case JSONOutput:
-		output, err = json.Marshal(builder.Dashboard)
+		if len(resources) == 1 {
+			return json.Marshal(resources[0])
+		}
+		return json.Marshal(resources)
 	default:
-		err = fmt.Errorf("--output must be %q or %q", JSONOutput, YAMLOutput)
+		return nil, fmt.Errorf("--output must be %q or %q", JSONOutput, YAMLOutput)
 	}
+}
 
+func executeResources(resources []modelAPI.Entity, outputFormat string, writer io.Writer, errWriter io.Writer) {
+	output, err := marshalResources(resources, outputFormat)
 	if err != nil {
 		_, _ = fmt.Fprint(errWriter, err)
 		os.Exit(-1)
@@ -63,6 +89,7 @@ func NewExec() Exec {
 
 type Exec struct {
 	outputFormat string
+	resources    []modelAPI.Entity
 }
 
 // BuildDashboard is a helper to print the result of a dashboard builder in stdout and errors to stderr
@@ -71,5 +98,25 @@ func (b *Exec) BuildDashboard(builder dashboard.Builder, err error) {
 		_, _ = fmt.Fprint(os.Stderr, err)
 		os.Exit(-1)
 	}
-	executeDashboardBuilder(builder, b.outputFormat, os.Stdout, os.Stderr)
+	executeResources([]modelAPI.Entity{&builder.Dashboard}, b.outputFormat, os.Stdout, os.Stderr)
+}
+
+// AddResource registers the result of a resource builder, to be printed by BuildResources.
+// In case of error, it is printed in stderr and the program exits.
+func (b *Exec) AddResource(builder ResourceBuilder, err error) {
+	if err != nil {
+		_, _ = fmt.Fprint(os.Stderr, err)
+		os.Exit(-1)
+	}
+	b.resources = append(b.resources, builder.Entity())
+}
+
+// BuildResources is a helper to print the registered resources in stdout and errors to stderr.
+// The resources can be of any kind, they are printed in the order they have been added.
+func (b *Exec) BuildResources() {
+	if len(b.resources) == 0 {
+		_, _ = fmt.Fprint(os.Stderr, "no resource to build, use AddResource to register them")
+		os.Exit(-1)
+	}
+	executeResources(b.resources, b.outputFormat, os.Stdout, os.Stderr)
 }
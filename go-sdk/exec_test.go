@@ -0,0 +1,96 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sdk
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/perses/perses/go-sdk/project"
+	"github.com/perses/perses/go-sdk/role"
+	modelAPI "github.com/perses/perses/pkg/model/api"
+	roleModel "github.com/perses/perses/pkg/model/api/v1/role"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+type document struct {
+	Kind     string `json:"kind" yaml:"kind"`
+	Metadata struct {
+		Name    string `json:"name" yaml:"name"`
+		Project string `json:"project" yaml:"project"`
+	} `json:"metadata" yaml:"metadata"`
+}
+
+func buildResources(t *testing.T) []modelAPI.Entity {
+	t.Helper()
+	projectBuilder, err := project.New("myproject", project.DisplayName("My project"))
+	require.NoError(t, err)
+	roleBuilder, err := role.New("viewer",
+		role.ProjectName("myproject"),
+		role.AddPermission([]roleModel.Action{roleModel.ReadAction}, []roleModel.Scope{roleModel.DashboardScope}),
+	)
+	require.NoError(t, err)
+	return []modelAPI.Entity{projectBuilder.Entity(), roleBuilder.Entity()}
+}
+
+func TestMarshalResourcesYAML(t *testing.T) {
+	output, err := marshalResources(buildResources(t), YAMLOutput)
+	require.NoError(t, err)
+
+	var documents []document
+	decoder := yaml.NewDecoder(bytes.NewReader(output))
+	for {
+		var doc document
+		if decodeErr := decoder.Decode(&doc); decodeErr != nil {
+			require.True(t, errors.Is(decodeErr, io.EOF), decodeErr)
+			break
+		}
+		documents = append(documents, doc)
+	}
+	require.Len(t, documents, 2)
+	assert.Equal(t, "Project", documents[0].Kind)
+	assert.Equal(t, "myproject", documents[0].Metadata.Name)
+	assert.Equal(t, "Role", documents[1].Kind)
+	assert.Equal(t, "viewer", documents[1].Metadata.Name)
+	assert.Equal(t, "myproject", documents[1].Metadata.Project)
+}
+
+func TestMarshalResourcesJSON(t *testing.T) {
+	resources := buildResources(t)
+
+	// A single resource is printed as an object, like BuildDashboard always did.
+	output, err := marshalResources(resources[:1], JSONOutput)
+	require.NoError(t, err)
+	var doc document
+	require.NoError(t, json.Unmarshal(output, &doc))
+	assert.Equal(t, "Project", doc.Kind)
+
+	output, err = marshalResources(resources, JSONOutput)
+	require.NoError(t, err)
+	var documents []document
+	require.NoError(t, json.Unmarshal(output, &documents))
+	require.Len(t, documents, 2)
+	assert.Equal(t, "Project", documents[0].Kind)
+	assert.Equal(t, "Role", documents[1].Kind)
+}
+
+func TestMarshalResourcesUnknownFormat(t *testing.T) {
+	_, err := marshalResources(buildResources(t), "toml")
+	assert.EqualError(t, err, `--output must be "json" or "yaml"`)
+}
@@ -0,0 +1,68 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package folder
+
+import (
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+)
+
+func ProjectName(name string) Option {
+	return func(builder *Builder) error {
+		builder.Folder.Metadata.Project = name
+		return nil
+	}
+}
+
+func DisplayName(name string) Option {
+	return func(builder *Builder) error {
+		if builder.Folder.Spec.Display == nil {
+			builder.Folder.Spec.Display = &v1.FolderDisplay{}
+		}
+		builder.Folder.Spec.Display.Name = name
+		return nil
+	}
+}
+
+// AddDashboard adds a reference to the dashboard with the given name at the root of the folder.
+func AddDashboard(name string) Option {
+	return func(builder *Builder) error {
+		builder.Folder.Spec.Items = append(builder.Folder.Spec.Items, DashboardItem(name))
+		return nil
+	}
+}
+
+// AddFolder adds a sub-folder containing the given items at the root of the folder.
+func AddFolder(name string, items ...v1.FolderItem) Option {
+	return func(builder *Builder) error {
+		builder.Folder.Spec.Items = append(builder.Folder.Spec.Items, FolderItem(name, items...))
+		return nil
+	}
+}
+
+// DashboardItem is a reference to a dashboard, to be used in a sub-folder.
+func DashboardItem(name string) v1.FolderItem {
+	return v1.FolderItem{
+		Kind: v1.KindDashboard,
+		Name: name,
+	}
+}
+
+// FolderItem is a sub-folder containing the given items.
+func FolderItem(name string, items ...v1.FolderItem) v1.FolderItem {
+	return v1.FolderItem{
+		Kind:  v1.KindFolder,
+		Name:  name,
+		Items: items,
+	}
+}
@@ -0,0 +1,43 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package globaldatasource
+
+import (
+	"github.com/perses/spec/go/common"
+	"github.com/perses/spec/go/plugin"
+)
+
+func DisplayName(name string) Option {
+	return func(builder *Builder) error {
+		if builder.GlobalDatasource.Spec.Display == nil {
+			builder.GlobalDatasource.Spec.Display = &common.Display{}
+		}
+		builder.GlobalDatasource.Spec.Display.Name = name
+		return nil
+	}
+}
+
+func Default(isDefault bool) Option {
+	return func(builder *Builder) error {
+		builder.GlobalDatasource.Spec.Default = isDefault
+		return nil
+	}
+}
+
+func Plugin(plugin plugin.Plugin) Option {
+	return func(builder *Builder) error {
+		builder.GlobalDatasource.Spec.Plugin = plugin
+		return nil
+	}
+}
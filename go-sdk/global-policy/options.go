@@ -0,0 +1,40 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package globalpolicy
+
+import (
+	"github.com/perses/perses/pkg/model/api/v1/policy"
+)
+
+func Description(description string) Option {
+	return func(builder *Builder) error {
+		builder.GlobalPolicy.Spec.Description = description
+		return nil
+	}
+}
+
+func Effect(effect policy.Effect) Option {
+	return func(builder *Builder) error {
+		builder.GlobalPolicy.Spec.Effect = effect
+		return nil
+	}
+}
+
+// Expression is the CEL expression deciding whether the policy applies to a request.
+func Expression(expression string) Option {
+	return func(builder *Builder) error {
+		builder.GlobalPolicy.Spec.Expression = expression
+		return nil
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package globalrolebinding
+
+import (
+	"fmt"
+
+	modelAPI "github.com/perses/perses/pkg/model/api"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/perses/spec/go/common"
+)
+
+type Option func(builder *Builder) error
+
+func New(name string, options ...Option) (Builder, error) {
+	builder := &Builder{
+		GlobalRoleBinding: v1.GlobalRoleBinding{
+			Kind: v1.KindGlobalRoleBinding,
+		},
+	}
+	builder.GlobalRoleBinding.Metadata.Name = name
+
+	for _, opt := range options {
+		if err := opt(builder); err != nil {
+			return *builder, err
+		}
+	}
+
+	if err := common.ValidateID(builder.GlobalRoleBinding.Metadata.Name); err != nil {
+		return *builder, fmt.Errorf("invalid global role binding metadata name %q: %w", builder.GlobalRoleBinding.Metadata.Name, err)
+	}
+
+	return *builder, nil
+}
+
+type Builder struct {
+	GlobalRoleBinding v1.GlobalRoleBinding `json:"-" yaml:"-"`
+}
+
+// Entity returns the global role binding built, so it can be printed by sdk.Exec along with other resources.
+func (b Builder) Entity() modelAPI.Entity {
+	return &b.GlobalRoleBinding
+}
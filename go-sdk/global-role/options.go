@@ -0,0 +1,36 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package globalrole
+
+import (
+	"github.com/perses/perses/pkg/model/api/v1/role"
+)
+
+// AddPermission grants the given actions on the given scopes.
+func AddPermission(actions []role.Action, scopes []role.Scope) Option {
+	return func(builder *Builder) error {
+		builder.GlobalRole.Spec.Permissions = append(builder.GlobalRole.Spec.Permissions, role.Permission{
+			Actions: actions,
+			Scopes:  scopes,
+		})
+		return nil
+	}
+}
+
+func Permissions(permissions ...role.Permission) Option {
+	return func(builder *Builder) error {
+		builder.GlobalRole.Spec.Permissions = append(builder.GlobalRole.Spec.Permissions, permissions...)
+		return nil
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package globalsecret
+
+import (
+	"fmt"
+
+	modelAPI "github.com/perses/perses/pkg/model/api"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/perses/spec/go/common"
+)
+
+type Option func(builder *Builder) error
+
+func New(name string, options ...Option) (Builder, error) {
+	builder := &Builder{
+		GlobalSecret: v1.GlobalSecret{
+			Kind: v1.KindGlobalSecret,
+		},
+	}
+	builder.GlobalSecret.Metadata.Name = name
+
+	for _, opt := range options {
+		if err := opt(builder); err != nil {
+			return *builder, err
+		}
+	}
+
+	if err := common.ValidateID(builder.GlobalSecret.Metadata.Name); err != nil {
+		return *builder, fmt.Errorf("invalid global secret metadata name %q: %w", builder.GlobalSecret.Metadata.Name, err)
+	}
+
+	return *builder, nil
+}
+
+type Builder struct {
+	GlobalSecret v1.GlobalSecret `json:"-" yaml:"-"`
+}
+
+// Entity returns the global secret built, so it can be printed by sdk.Exec along with other resources.
+func (b Builder) Entity() modelAPI.Entity {
+	return &b.GlobalSecret
+}
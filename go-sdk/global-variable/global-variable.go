@@ -0,0 +1,43 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package globalvariable
+
+import (
+	"github.com/perses/perses/go-sdk/variable"
+	modelAPI "github.com/perses/perses/pkg/model/api"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+)
+
+// New builds a global variable. It accepts the same options as a project variable,
+// so the list and text variable builders can be used to define it.
+func New(name string, options ...variable.Option) (Builder, error) {
+	builder := &Builder{
+		GlobalVariable: v1.GlobalVariable{
+			Kind: v1.KindGlobalVariable,
+		},
+	}
+	varBuilder, err := variable.New(name, options...)
+	builder.GlobalVariable.Metadata = varBuilder.Variable.Metadata.Metadata
+	builder.GlobalVariable.Spec = varBuilder.Variable.Spec
+	return *builder, err
+}
+
+type Builder struct {
+	GlobalVariable v1.GlobalVariable `json:"-" yaml:"-"`
+}
+
+// Entity returns the global variable built, so it can be printed by sdk.Exec along with other resources.
+func (b Builder) Entity() modelAPI.Entity {
+	return &b.GlobalVariable
+}
@@ -0,0 +1,61 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package project
+
+import (
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/perses/spec/go/common"
+)
+
+func DisplayName(name string) Option {
+	return func(builder *Builder) error {
+		if builder.Project.Spec.Display == nil {
+			builder.Project.Spec.Display = &common.Display{}
+		}
+		builder.Project.Spec.Display.Name = name
+		return nil
+	}
+}
+
+func Description(description string) Option {
+	return func(builder *Builder) error {
+		if builder.Project.Spec.Display == nil {
+			builder.Project.Spec.Display = &common.Display{}
+		}
+		builder.Project.Spec.Display.Description = description
+		return nil
+	}
+}
+
+// EnablePlugins restricts the plugins that can be used in the project to the given ones.
+func EnablePlugins(plugins ...string) Option {
+	return func(builder *Builder) error {
+		if builder.Project.Spec.Plugins == nil {
+			builder.Project.Spec.Plugins = &v1.ProjectPluginPolicy{}
+		}
+		builder.Project.Spec.Plugins.Enabled = append(builder.Project.Spec.Plugins.Enabled, plugins...)
+		return nil
+	}
+}
+
+// DisablePlugins refuses the given plugins in the project.
+func DisablePlugins(plugins ...string) Option {
+	return func(builder *Builder) error {
+		if builder.Project.Spec.Plugins == nil {
+			builder.Project.Spec.Plugins = &v1.ProjectPluginPolicy{}
+		}
+		builder.Project.Spec.Plugins.Disabled = append(builder.Project.Spec.Plugins.Disabled, plugins...)
+		return nil
+	}
+}
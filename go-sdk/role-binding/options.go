@@ -0,0 +1,46 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rolebinding
+
+import (
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+)
+
+func ProjectName(name string) Option {
+	return func(builder *Builder) error {
+		builder.RoleBinding.Metadata.Project = name
+		return nil
+	}
+}
+
+// Role is the name of the role granted to the subjects.
+func Role(name string) Option {
+	return func(builder *Builder) error {
+		builder.RoleBinding.Spec.Role = name
+		return nil
+	}
+}
+
+// AddUser adds the users with the given names to the subjects.
+func AddUser(names ...string) Option {
+	return func(builder *Builder) error {
+		for _, name := range names {
+			builder.RoleBinding.Spec.Subjects = append(builder.RoleBinding.Spec.Subjects, v1.Subject{
+				Kind: v1.KindUser,
+				Name: name,
+			})
+		}
+		return nil
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secret
+
+import (
+	"github.com/perses/perses/pkg/model/api/v1/secret"
+)
+
+func ProjectName(name string) Option {
+	return func(builder *Builder) error {
+		builder.Secret.Metadata.Project = name
+		return nil
+	}
+}
+
+func BasicAuth(username string, password string) Option {
+	return func(builder *Builder) error {
+		builder.Secret.Spec.BasicAuth = &secret.BasicAuth{
+			Username: username,
+			Password: password,
+		}
+		return nil
+	}
+}
+
+// BasicAuthFromFile is like BasicAuth, except the password is read by Perses from the given file.
+func BasicAuthFromFile(username string, passwordFile string) Option {
+	return func(builder *Builder) error {
+		builder.Secret.Spec.BasicAuth = &secret.BasicAuth{
+			Username:     username,
+			PasswordFile: passwordFile,
+		}
+		return nil
+	}
+}
+
+func Authorization(authorization secret.Authorization) Option {
+	return func(builder *Builder) error {
+		builder.Secret.Spec.Authorization = &authorization
+		return nil
+	}
+}
+
+func OAuth(oauth secret.OAuth) Option {
+	return func(builder *Builder) error {
+		builder.Secret.Spec.OAuth = &oauth
+		return nil
+	}
+}
+
+func TLSConfig(tlsConfig secret.TLSConfig) Option {
+	return func(builder *Builder) error {
+		builder.Secret.Spec.TLSConfig = &tlsConfig
+		return nil
+	}
+}
@@ -0,0 +1,36 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+func FirstName(name string) Option {
+	return func(builder *Builder) error {
+		builder.User.Spec.FirstName = name
+		return nil
+	}
+}
+
+func LastName(name string) Option {
+	return func(builder *Builder) error {
+		builder.User.Spec.LastName = name
+		return nil
+	}
+}
+
+// Password sets the password used to log in with the native provider. Perses hashes it when the user is created.
+func Password(password string) Option {
+	return func(builder *Builder) error {
+		builder.User.Spec.NativeProvider.Password = password
+		return nil
+	}
+}
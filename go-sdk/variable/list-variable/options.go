@@ -16,6 +16,7 @@ package listvariable
 import (
 	v1 "github.com/perses/perses/pkg/model/api/v1"
 	"github.com/perses/spec/go/dashboard/variable"
+	"github.com/perses/spec/go/plugin"
 )
 
 func DefaultValue(value string) Option {
@@ -101,6 +102,14 @@ func Hidden(isHidden bool) Option {
 	}
 }
 
+// Plugin sets the plugin of the variable as is. Prefer the builder provided by the SDK of the plugin when it exists.
+func Plugin(plugin plugin.Plugin) Option {
+	return func(builder *Builder) error {
+		builder.ListVariableSpec.Plugin = plugin
+		return nil
+	}
+}
+
 func Filter(variables ...v1.Variable) Option {
 	return func(builder *Builder) error {
 		builder.Filters = variables
@@ -28,6 +28,13 @@ func Name(name string) Option {
 	}
 }
 
+func ProjectName(name string) Option {
+	return func(builder *Builder) error {
+		builder.Variable.Metadata.Project = name
+		return nil
+	}
+}
+
 func Filter(variables ...v1.Variable) Option {
 	return func(builder *Builder) error {
 		builder.Filters = variables
@@ -14,6 +14,7 @@
 package variable
 
 import (
+	modelAPI "github.com/perses/perses/pkg/model/api"
 	v1 "github.com/perses/perses/pkg/model/api/v1"
 )
 
@@ -44,3 +45,8 @@ func New(name string, options ...Option) (Builder, error) {
 
 	return *builder, nil
 }
+
+// Entity returns the variable built, so it can be printed by sdk.Exec along with other resources.
+func (b Builder) Entity() modelAPI.Entity {
+	return &b.Variable
+}
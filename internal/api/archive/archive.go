@@ -16,7 +16,10 @@ package archive
 import (
 	"context"
 	"fmt"
+	"io"
+	"io/fs"
 	"os"
+	"path"
 	"strings"
 
 	"github.com/mholt/archives"
@@ -69,6 +72,48 @@ func Build(archiveName string, archiveFormat Format, files []archives.FileInfo)
 	return format.Archive(context.Background(), out, files)
 }
 
+// ReadFile returns the content of the file stored at the given path in the archive, without extracting the whole archive.
+func ReadFile(archivePath string, fileName string) ([]byte, error) {
+	stream, err := os.Open(archivePath) //nolint: gosec
+	if err != nil {
+		return nil, fmt.Errorf("unable to open the archive %q: %w", archivePath, err)
+	}
+	defer stream.Close() //nolint: errcheck
+	format, newStream, err := archives.Identify(context.Background(), archivePath, stream)
+	if err != nil {
+		return nil, fmt.Errorf("unable to identify the type of the archive %q: %w", archivePath, err)
+	}
+	ex, ok := format.(archives.Extractor)
+	if !ok {
+		return nil, fmt.Errorf("archive %q cannot be extracted", archivePath)
+	}
+	var data []byte
+	found := false
+	extractErr := ex.Extract(context.Background(), newStream, func(_ context.Context, f archives.FileInfo) error {
+		if f.IsDir() || path.Clean(f.NameInArchive) != fileName {
+			return nil
+		}
+		file, openErr := f.Open()
+		if openErr != nil {
+			return openErr
+		}
+		defer file.Close() //nolint: errcheck
+		var readErr error
+		if data, readErr = io.ReadAll(file); readErr != nil {
+			return readErr
+		}
+		found = true
+		return fs.SkipAll
+	})
+	if extractErr != nil {
+		return nil, fmt.Errorf("unable to read the file %q in the archive %q: %w", fileName, archivePath, extractErr)
+	}
+	if !found {
+		return nil, fmt.Errorf("file %q not found in the archive %q", fileName, archivePath)
+	}
+	return data, nil
+}
+
 func buildCompressedArchiveStruct(format Format) archives.CompressedArchive {
 	switch format {
 	case TAR:
@@ -0,0 +1,197 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package archive
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+const (
+	// SignatureFileExtension is the extension of the detached signature file that sits next to an archive.
+	// For an archive named `foo-1.0.0.tar.gz`, the signature is expected in `foo-1.0.0.tar.gz.sig`.
+	SignatureFileExtension = ".sig"
+	// SignatureAlgorithm is the only signing algorithm supported for the moment.
+	SignatureAlgorithm = "ed25519"
+	checksumPrefix     = "sha256:"
+)
+
+var (
+	// ErrNotSigned is returned when no detached signature has been found for an archive.
+	ErrNotSigned = errors.New("archive is not signed")
+	// ErrInvalidSignature is returned when the signature doesn't match the archive or any of the trusted public keys.
+	ErrInvalidSignature = errors.New("archive signature is invalid")
+)
+
+// Signature is the content of the detached signature file.
+// It is a checksum manifest of the archive, signed with a private key.
+type Signature struct {
+	Algorithm string `json:"algorithm"`
+	// Name is the name of the plugin module contained in the archive.
+	Name string `json:"name"`
+	// Version is the version of the plugin module contained in the archive.
+	Version string `json:"version"`
+	// Checksum is the SHA-256 checksum of the archive, prefixed by "sha256:"
+	Checksum string `json:"checksum"`
+	// Signature is the base64 encoded signature of the payload `<name>|<version>|<checksum>`.
+	// Signing the name and the version with the checksum prevents a signed archive from being replayed under another name or version.
+	Signature string `json:"signature"`
+}
+
+func (s *Signature) payload() []byte {
+	return []byte(fmt.Sprintf("%s|%s|%s", s.Name, s.Version, s.Checksum))
+}
+
+// SignatureFilePath returns the path of the detached signature associated with the given archive.
+func SignatureFilePath(archivePath string) string {
+	return archivePath + SignatureFileExtension
+}
+
+// Sign computes the checksum of the archive, signs it along with the name and the version of the plugin module it contains,
+// and writes the detached signature next to the archive.
+func Sign(archivePath string, name string, version string, key ed25519.PrivateKey) (string, error) {
+	checksum, err := Checksum(archivePath)
+	if err != nil {
+		return "", err
+	}
+	sig := Signature{
+		Algorithm: SignatureAlgorithm,
+		Name:      name,
+		Version:   version,
+		Checksum:  checksum,
+	}
+	sig.Signature = base64.StdEncoding.EncodeToString(ed25519.Sign(key, sig.payload()))
+	data, err := json.MarshalIndent(sig, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	sigPath := SignatureFilePath(archivePath)
+	if writeErr := os.WriteFile(sigPath, data, 0644); writeErr != nil { // nolint: gosec
+		return "", fmt.Errorf("unable to write the signature file %q: %w", sigPath, writeErr)
+	}
+	return sigPath, nil
+}
+
+// Verify checks the detached signature of the archive against the list of trusted public keys.
+// name and version are the ones of the plugin module contained in the archive. They must match the ones signed.
+// It returns ErrNotSigned if there is no signature file, and ErrInvalidSignature (wrapped) if the signature cannot be trusted.
+func Verify(archivePath string, name string, version string, keys []ed25519.PublicKey) error {
+	data, err := os.ReadFile(SignatureFilePath(archivePath)) //nolint: gosec
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return ErrNotSigned
+		}
+		return fmt.Errorf("unable to read the signature file: %w", err)
+	}
+	sig := &Signature{}
+	if unmarshalErr := json.Unmarshal(data, sig); unmarshalErr != nil {
+		return fmt.Errorf("%w: unable to decode the signature file: %s", ErrInvalidSignature, unmarshalErr)
+	}
+	if sig.Algorithm != SignatureAlgorithm {
+		return fmt.Errorf("%w: algorithm %q not supported", ErrInvalidSignature, sig.Algorithm)
+	}
+	if sig.Name != name || sig.Version != version {
+		return fmt.Errorf("%w: the signature is for the plugin %q in version %q, got %q in version %q", ErrInvalidSignature, sig.Name, sig.Version, name, version)
+	}
+	checksum, err := Checksum(archivePath)
+	if err != nil {
+		return err
+	}
+	if checksum != sig.Checksum {
+		return fmt.Errorf("%w: checksum mismatch, expected %q, got %q", ErrInvalidSignature, sig.Checksum, checksum)
+	}
+	rawSignature, err := base64.StdEncoding.DecodeString(sig.Signature)
+	if err != nil {
+		return fmt.Errorf("%w: unable to decode the signature: %s", ErrInvalidSignature, err)
+	}
+	for _, key := range keys {
+		if ed25519.Verify(key, sig.payload(), rawSignature) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: no trusted public key matches the signature", ErrInvalidSignature)
+}
+
+// ReadPrivateKey reads a PEM encoded (PKCS #8) ed25519 private key.
+func ReadPrivateKey(path string) (ed25519.PrivateKey, error) {
+	block, err := readPEM(path)
+	if err != nil {
+		return nil, err
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse the private key %q: %w", path, err)
+	}
+	edKey, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("private key %q is not an ed25519 key", path)
+	}
+	return edKey, nil
+}
+
+// ReadPublicKeys reads a list of PEM encoded (PKIX) ed25519 public keys.
+func ReadPublicKeys(paths []string) ([]ed25519.PublicKey, error) {
+	keys := make([]ed25519.PublicKey, 0, len(paths))
+	for _, path := range paths {
+		block, err := readPEM(path)
+		if err != nil {
+			return nil, err
+		}
+		key, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse the public key %q: %w", path, err)
+		}
+		edKey, ok := key.(ed25519.PublicKey)
+		if !ok {
+			return nil, fmt.Errorf("public key %q is not an ed25519 key", path)
+		}
+		keys = append(keys, edKey)
+	}
+	return keys, nil
+}
+
+func readPEM(path string) (*pem.Block, error) {
+	data, err := os.ReadFile(path) //nolint: gosec
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the key %q: %w", path, err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM data found in %q", path)
+	}
+	return block, nil
+}
+
+// Checksum returns the sha256 checksum of the archive, formatted as `sha256:<hex>`.
+func Checksum(archivePath string) (string, error) {
+	f, err := os.Open(archivePath) //nolint: gosec
+	if err != nil {
+		return "", fmt.Errorf("unable to open the archive %q: %w", archivePath, err)
+	}
+	defer f.Close() //nolint: errcheck
+	h := sha256.New()
+	if _, copyErr := io.Copy(h, f); copyErr != nil {
+		return "", fmt.Errorf("unable to compute the checksum of the archive %q: %w", archivePath, copyErr)
+	}
+	return checksumPrefix + hex.EncodeToString(h.Sum(nil)), nil
+}
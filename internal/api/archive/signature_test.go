@@ -0,0 +1,122 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package archive
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mholt/archives"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignAndVerify(t *testing.T) {
+	dir := t.TempDir()
+	archivePath := filepath.Join(dir, "plugin-1.0.0.tar.gz")
+	require.NoError(t, os.WriteFile(archivePath, []byte("archive content"), 0600))
+
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	otherPub, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	assert.ErrorIs(t, Verify(archivePath, "plugin", "1.0.0", []ed25519.PublicKey{pub}), ErrNotSigned)
+
+	sigPath, err := Sign(archivePath, "plugin", "1.0.0", priv)
+	require.NoError(t, err)
+	assert.Equal(t, archivePath+SignatureFileExtension, sigPath)
+	assert.False(t, IsArchiveFile(sigPath))
+
+	assert.NoError(t, Verify(archivePath, "plugin", "1.0.0", []ed25519.PublicKey{otherPub, pub}))
+	assert.ErrorIs(t, Verify(archivePath, "plugin", "1.0.0", []ed25519.PublicKey{otherPub}), ErrInvalidSignature)
+
+	// The signature cannot be replayed for another plugin or another version.
+	assert.ErrorIs(t, Verify(archivePath, "other", "1.0.0", []ed25519.PublicKey{pub}), ErrInvalidSignature)
+	assert.ErrorIs(t, Verify(archivePath, "plugin", "2.0.0", []ed25519.PublicKey{pub}), ErrInvalidSignature)
+
+	// Changing the name or the version in the signature file must be detected as well.
+	data, err := os.ReadFile(sigPath)
+	require.NoError(t, err)
+	sig := &Signature{}
+	require.NoError(t, json.Unmarshal(data, sig))
+	sig.Version = "2.0.0"
+	data, err = json.Marshal(sig)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(sigPath, data, 0600))
+	assert.ErrorIs(t, Verify(archivePath, "plugin", "2.0.0", []ed25519.PublicKey{pub}), ErrInvalidSignature)
+
+	// Altering the archive after the signature must be detected.
+	_, err = Sign(archivePath, "plugin", "1.0.0", priv)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(archivePath, []byte("tampered content"), 0600))
+	assert.ErrorIs(t, Verify(archivePath, "plugin", "1.0.0", []ed25519.PublicKey{pub}), ErrInvalidSignature)
+}
+
+func TestReadFile(t *testing.T) {
+	dir := t.TempDir()
+	manifestPath := filepath.Join(dir, "mf-manifest.json")
+	require.NoError(t, os.WriteFile(manifestPath, []byte(`{"name":"plugin"}`), 0600))
+	files, err := archives.FilesFromDisk(context.Background(), nil, map[string]string{manifestPath: "mf-manifest.json"})
+	require.NoError(t, err)
+	for _, format := range supportedArchiveFormat {
+		t.Run(string(format), func(t *testing.T) {
+			archiveName := filepath.Join(dir, "plugin-1.0.0")
+			require.NoError(t, Build(archiveName, format, files))
+			archivePath := fmt.Sprintf("%s.%s", archiveName, format)
+
+			data, readErr := ReadFile(archivePath, "mf-manifest.json")
+			require.NoError(t, readErr)
+			assert.Equal(t, `{"name":"plugin"}`, string(data))
+
+			_, readErr = ReadFile(archivePath, "package.json")
+			assert.Error(t, readErr)
+		})
+	}
+}
+
+func TestReadKeys(t *testing.T) {
+	dir := t.TempDir()
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	privBytes, err := x509.MarshalPKCS8PrivateKey(priv)
+	require.NoError(t, err)
+	privPath := filepath.Join(dir, "private.pem")
+	require.NoError(t, os.WriteFile(privPath, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privBytes}), 0600))
+
+	pubBytes, err := x509.MarshalPKIXPublicKey(pub)
+	require.NoError(t, err)
+	pubPath := filepath.Join(dir, "public.pem")
+	require.NoError(t, os.WriteFile(pubPath, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubBytes}), 0600))
+
+	readPriv, err := ReadPrivateKey(privPath)
+	require.NoError(t, err)
+	assert.Equal(t, priv, readPriv)
+
+	readPub, err := ReadPublicKeys([]string{pubPath})
+	require.NoError(t, err)
+	assert.Equal(t, []ed25519.PublicKey{pub}, readPub)
+
+	_, err = ReadPublicKeys([]string{privPath})
+	assert.Error(t, err)
+}
@@ -16,16 +16,21 @@ package authorization
 import (
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
+	"github.com/perses/perses/internal/api/authorization/cel"
 	"github.com/perses/perses/internal/api/authorization/k8s"
 	"github.com/perses/perses/internal/api/authorization/native"
 	"github.com/perses/perses/internal/api/crypto"
+	"github.com/perses/perses/internal/api/interface/v1/folder"
+	"github.com/perses/perses/internal/api/interface/v1/globalpolicy"
 	"github.com/perses/perses/internal/api/interface/v1/globalrole"
 	"github.com/perses/perses/internal/api/interface/v1/globalrolebinding"
+	"github.com/perses/perses/internal/api/interface/v1/project"
 	"github.com/perses/perses/internal/api/interface/v1/role"
 	"github.com/perses/perses/internal/api/interface/v1/rolebinding"
 	"github.com/perses/perses/internal/api/interface/v1/user"
 	"github.com/perses/perses/pkg/model/api/config"
 	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/perses/perses/pkg/model/api/v1/policy"
 	v1Role "github.com/perses/perses/pkg/model/api/v1/role"
 )
 
@@ -64,6 +69,15 @@ type Authorization interface {
 	// In case the endpoint is anonymous, or the context is empty, it will return true.
 	// In case the user information is not found in the context, the implementation should return false.
 	HasPermission(ctx echo.Context, requestAction v1Role.Action, requestProject string, requestScope v1Role.Scope) bool
+	// HasResourcePermission checks if the user has the permission to perform the action on the given resource of the project with the given scope.
+	// Contrary to HasPermission that only considers the permissions applying to every resource of a scope,
+	// it also considers the permissions restricted to some resources (by name, tags or folders).
+	// In case the endpoint is anonymous, or the context is empty, it will return true.
+	HasResourcePermission(ctx echo.Context, requestAction v1Role.Action, requestProject string, requestScope v1Role.Scope, resource v1Role.Resource) bool
+	// GetUserRestrictedProjects returns the list of the projects where the user has permissions restricted to some resources in the context of the role and the scope requested.
+	// It follows the same rules as GetUserProjects regarding the wildcard value ("*").
+	// The resources of these projects must be filtered with HasResourcePermission before being returned to the user.
+	GetUserRestrictedProjects(ctx echo.Context, requestAction v1Role.Action, requestScope v1Role.Scope) ([]string, error)
 	// HasCreateProjectPermission checks if the user has the permission to create a Perses project.
 	// This is separated from HasPermission because the way project creation permission is evaluated differs
 	// between authorization providers:
@@ -75,6 +89,11 @@ type Authorization interface {
 	// Be aware that this function cannot be called from an anonymous endpoint.
 	// In case the user information is not found in the context, the implementation should return an error.
 	GetPermissions(ctx echo.Context) (map[string][]*v1Role.Permission, error)
+	// Explain returns the decision taken when the given user requests to perform the action on the project with the given scope,
+	// along with what granted, or would grant, the permission (roles, policies, etc.).
+	// Contrary to HasPermission, the user evaluated is not the one of the context,
+	// so the caller must check that the user of the context is allowed to get this explanation.
+	Explain(ctx echo.Context, username string, requestAction v1Role.Action, requestProject string, requestScope v1Role.Scope) (*v1Role.Explanation, error)
 	// RefreshPermissions refreshes the permissions.
 	// We know this method is relative to the implementation and should not appear in the interface.
 	// This is convenient to have it here when the implementation is keeping the permissions in memory.
@@ -83,8 +102,15 @@ type Authorization interface {
 	RefreshPermissions() error
 }
 
+// PolicyEvaluator is implemented by the authorization providers relying on policies.
+// It allows evaluating a request on behalf of any user, without performing it.
+type PolicyEvaluator interface {
+	Evaluate(input policy.Input) policy.Decision
+}
+
 func New(userDAO user.DAO, roleDAO role.DAO, roleBindingDAO rolebinding.DAO,
-	globalRoleDAO globalrole.DAO, globalRoleBindingDAO globalrolebinding.DAO, conf config.Config) (Authorization, error) {
+	globalRoleDAO globalrole.DAO, globalRoleBindingDAO globalrolebinding.DAO, folderDAO folder.DAO,
+	projectDAO project.DAO, globalPolicyDAO globalpolicy.DAO, conf config.Config) (Authorization, error) {
 	// If the higher level auth enabled is false then ignore all authorization configuration
 	if !conf.Security.EnableAuth {
 		return &disabledImpl{}, nil
@@ -94,7 +120,16 @@ func New(userDAO user.DAO, roleDAO role.DAO, roleBindingDAO rolebinding.DAO,
 		return k8s.New(conf)
 	}
 
+	if conf.Security.Authorization.Provider.CEL.Enable {
+		// The users are authenticated exactly like with the native authorization, only the permissions are computed differently.
+		authn, err := native.New(userDAO, roleDAO, roleBindingDAO, globalRoleDAO, globalRoleBindingDAO, folderDAO, conf)
+		if err != nil {
+			return nil, err
+		}
+		return cel.New(authn, projectDAO, globalPolicyDAO, conf), nil
+	}
+
 	// If no providers are explicitly set but auth is enabled, then use the perses native authz
-	return native.New(userDAO, roleDAO, roleBindingDAO, globalRoleDAO, globalRoleBindingDAO, conf)
+	return native.New(userDAO, roleDAO, roleBindingDAO, globalRoleDAO, globalRoleBindingDAO, folderDAO, conf)
 
 }
@@ -0,0 +1,338 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package cel provides an authorization based on policies written in CEL.
+//
+// The policies are loaded from the configuration and from the GlobalPolicy resources. The users are authenticated
+// with the JWT issued by Perses, exactly like with the native authorization.
+package cel
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+	"github.com/perses/perses/internal/api/crypto"
+	apiInterface "github.com/perses/perses/internal/api/interface"
+	"github.com/perses/perses/internal/api/interface/v1/globalpolicy"
+	"github.com/perses/perses/internal/api/interface/v1/project"
+	"github.com/perses/perses/internal/api/utils"
+	"github.com/perses/perses/pkg/model/api/config"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/perses/perses/pkg/model/api/v1/policy"
+	v1Role "github.com/perses/perses/pkg/model/api/v1/role"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	actions = []v1Role.Action{v1Role.ReadAction, v1Role.CreateAction, v1Role.UpdateAction, v1Role.DeleteAction, v1Role.QueryAction}
+	scopes  = []v1Role.Scope{
+		v1Role.DashboardScope, v1Role.DashboardTemplateScope, v1Role.DatasourceScope, v1Role.EphemeralDashboardScope,
+		v1Role.FolderScope, v1Role.GlobalDashboardTemplateScope, v1Role.GlobalDatasourceScope, v1Role.GlobalPolicyScope,
+		v1Role.GlobalRoleScope, v1Role.GlobalRoleBindingScope, v1Role.GlobalSecretScope, v1Role.GlobalVariableScope,
+		v1Role.ProjectScope, v1Role.RoleScope, v1Role.RoleBindingScope, v1Role.SecretScope, v1Role.UserScope,
+		v1Role.VariableScope,
+	}
+)
+
+// authenticator is the part of the authorization in charge of identifying the user.
+type authenticator interface {
+	GetUser(ctx echo.Context) (any, error)
+	GetUsername(ctx echo.Context) (string, error)
+	GetPublicUser(ctx echo.Context) (*v1.PublicUser, error)
+	GetProviderInfo(ctx echo.Context) (crypto.ProviderInfo, error)
+	Middleware(skipper middleware.Skipper) echo.MiddlewareFunc
+}
+
+func New(authn authenticator, projectDAO project.DAO, globalPolicyDAO globalpolicy.DAO, conf config.Config) *celAuthz {
+	celConfig := conf.Security.Authorization.Provider.CEL
+	configPolicies := make([]policy.Policy, 0, len(celConfig.Policies))
+	for i := range celConfig.Policies {
+		configPolicies = append(configPolicies, celConfig.Policies[i].Policy())
+	}
+	groups := make(map[string][]string)
+	for group, members := range celConfig.Groups {
+		for _, member := range members {
+			groups[member] = append(groups[member], group)
+		}
+	}
+	for _, userGroups := range groups {
+		slices.Sort(userGroups)
+	}
+	return &celAuthz{
+		authenticator:   authn,
+		projectDAO:      projectDAO,
+		globalPolicyDAO: globalPolicyDAO,
+		configPolicies:  configPolicies,
+		policies:        configPolicies,
+		groups:          groups,
+		decisionLogging: celConfig.DecisionLogging,
+	}
+}
+
+type celAuthz struct {
+	// authenticator is used to identify the user. The policies only take care of the authorization.
+	authenticator
+	projectDAO      project.DAO
+	globalPolicyDAO globalpolicy.DAO
+	// configPolicies are the policies defined in the configuration.
+	configPolicies []policy.Policy
+	// groups associates each user to the groups they belong to.
+	groups          map[string][]string
+	decisionLogging bool
+	// policies are the policies of the configuration followed by the GlobalPolicy resources.
+	policies []policy.Policy
+	// mutex is used to protect the policies from concurrent access.
+	mutex sync.RWMutex
+}
+
+func (c *celAuthz) IsEnabled() bool {
+	return true
+}
+
+// IsNativeAuthz returns false as the permissions are defined by the policies of the configuration, not by the roles
+// stored in the database. Creating a project must not create its default roles and role bindings.
+func (c *celAuthz) IsNativeAuthz() bool {
+	return false
+}
+
+// Evaluate returns the decision of the policies for the given input, without logging it.
+// When the groups of the input are not provided, the groups of the user defined in the configuration are used.
+func (c *celAuthz) Evaluate(input policy.Input) policy.Decision {
+	if input.Groups == nil {
+		input.Groups = c.groups[input.User]
+	}
+	c.mutex.RLock()
+	policies := c.policies
+	c.mutex.RUnlock()
+	return policy.Evaluate(policies, input)
+}
+
+func (c *celAuthz) GetUserProjects(ctx echo.Context, requestAction v1Role.Action, requestScope v1Role.Scope) ([]string, error) {
+	if v1Role.IsGlobalScope(requestScope) {
+		return []string{v1.WildcardProject}, nil
+	}
+	username, err := c.GetUsername(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if username == "" {
+		// This method should not be called if the endpoint is anonymous or the username is not found.
+		logrus.Error("failed to get username from context to list the user projects")
+		return nil, apiInterface.InternalError
+	}
+	projects, err := c.projectDAO.List(&project.Query{})
+	if err != nil {
+		return nil, err
+	}
+	var result []string
+	for _, p := range projects {
+		if c.Evaluate(newInput(username, requestAction, p.Metadata.Name, requestScope, nil)).Allowed {
+			result = append(result, p.Metadata.Name)
+		}
+	}
+	return result, nil
+}
+
+func (c *celAuthz) HasPermission(ctx echo.Context, requestAction v1Role.Action, requestProject string, requestScope v1Role.Scope) bool {
+	return c.decide(ctx, requestAction, requestProject, requestScope, nil)
+}
+
+func (c *celAuthz) HasResourcePermission(ctx echo.Context, requestAction v1Role.Action, requestProject string, requestScope v1Role.Scope, resource v1Role.Resource) bool {
+	return c.decide(ctx, requestAction, requestProject, requestScope, &policy.Resource{Name: resource.Name, Tags: resource.Tags})
+}
+
+func (c *celAuthz) GetUserRestrictedProjects(_ echo.Context, _ v1Role.Action, _ v1Role.Scope) ([]string, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	// When a policy depends on the resource, the decision can be different for each resource of every project.
+	if policy.DependsOnResource(c.policies) {
+		return []string{v1.WildcardProject}, nil
+	}
+	return []string{}, nil
+}
+
+func (c *celAuthz) HasCreateProjectPermission(ctx echo.Context, projectName string) bool {
+	return c.decide(ctx, v1Role.CreateAction, projectName, v1Role.ProjectScope, nil)
+}
+
+// GetPermissions returns the actions allowed on every resource of each scope.
+// The global scopes are listed under the wildcard project, while the other ones are listed under each project.
+func (c *celAuthz) GetPermissions(ctx echo.Context) (map[string][]*v1Role.Permission, error) {
+	username, err := c.GetUsername(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if username == "" {
+		// This use case should not happen.
+		logrus.Error("No username found in the context, this should not happen in a CEL authorization implementation")
+		return nil, apiInterface.InternalError
+	}
+	projects, err := c.projectDAO.List(&project.Query{})
+	if err != nil {
+		return nil, err
+	}
+	userPermissions := map[string][]*v1Role.Permission{
+		v1.WildcardProject: c.listPermissions(username, "", v1Role.IsGlobalScope),
+	}
+	for _, p := range projects {
+		if permissions := c.listPermissions(username, p.Metadata.Name, func(scope v1Role.Scope) bool { return !v1Role.IsGlobalScope(scope) }); len(permissions) > 0 {
+			userPermissions[p.Metadata.Name] = permissions
+		}
+	}
+	return userPermissions, nil
+}
+
+func (c *celAuthz) listPermissions(username string, projectName string, keepScope func(v1Role.Scope) bool) []*v1Role.Permission {
+	var permissions []*v1Role.Permission
+	for _, scope := range scopes {
+		if !keepScope(scope) {
+			continue
+		}
+		var allowedActions []v1Role.Action
+		for _, action := range actions {
+			if c.Evaluate(newInput(username, action, projectName, scope, nil)).Allowed {
+				allowedActions = append(allowedActions, action)
+			}
+		}
+		if len(allowedActions) > 0 {
+			permissions = append(permissions, &v1Role.Permission{Actions: allowedActions, Scopes: []v1Role.Scope{scope}})
+		}
+	}
+	return permissions
+}
+
+// RefreshPermissions reloads the GlobalPolicy resources.
+func (c *celAuthz) RefreshPermissions() error {
+	globalPolicies, err := c.globalPolicyDAO.List(&globalpolicy.Query{})
+	if err != nil {
+		return err
+	}
+	slices.SortFunc(globalPolicies, func(a, b *v1.GlobalPolicy) int {
+		return strings.Compare(a.Metadata.Name, b.Metadata.Name)
+	})
+	policies := slices.Clone(c.configPolicies)
+	for _, globalPolicy := range globalPolicies {
+		program, compileErr := policy.Compile(globalPolicy.Spec.Expression)
+		if compileErr != nil {
+			// Ignoring the policy could grant a permission it was supposed to deny, so the previous policies are kept.
+			logrus.WithError(compileErr).Errorf("unable to compile the GlobalPolicy %q", globalPolicy.Metadata.Name)
+			return compileErr
+		}
+		policies = append(policies, policy.Policy{Name: globalPolicy.Metadata.Name, Effect: globalPolicy.Spec.Effect, Program: program})
+	}
+	c.mutex.Lock()
+	c.policies = policies
+	c.mutex.Unlock()
+	return nil
+}
+
+// Explain evaluates the policies for the given user.
+// The grants are the allow policies matching the request. The deny policies and the evaluation errors are given in the reason.
+func (c *celAuthz) Explain(_ echo.Context, username string, requestAction v1Role.Action, requestProject string, requestScope v1Role.Scope) (*v1Role.Explanation, error) {
+	decision := c.Evaluate(newInput(username, requestAction, requestProject, requestScope, nil))
+	result := &v1Role.Explanation{
+		User:    username,
+		Action:  requestAction,
+		Scope:   requestScope,
+		Project: requestProject,
+		Allowed: decision.Allowed,
+	}
+	var denyPolicies []string
+	for _, p := range decision.Policies {
+		if p.Effect == policy.DenyEffect {
+			denyPolicies = append(denyPolicies, p.Name)
+			continue
+		}
+		result.Grants = append(result.Grants, v1Role.Grant{
+			Source: v1Role.PolicySource,
+			Name:   p.Name,
+			Bound:  true,
+		})
+	}
+	var reasons []string
+	if len(denyPolicies) > 0 {
+		reasons = append(reasons, fmt.Sprintf("denied by the policies %s", strings.Join(denyPolicies, ", ")))
+	} else if len(result.Grants) == 0 {
+		reasons = append(reasons, "no policy allows the request")
+	}
+	reasons = append(reasons, decision.Errors...)
+	result.Reason = strings.Join(reasons, "; ")
+	return result, nil
+}
+
+// decide evaluates the policies for the user found in the context.
+func (c *celAuthz) decide(ctx echo.Context, requestAction v1Role.Action, requestProject string, requestScope v1Role.Scope, resource *policy.Resource) bool {
+	// If the context is nil, it means the function is called internally without a request context.
+	// And in this case, we assume we want to bypass the authorization check.
+	if ctx == nil || utils.IsAnonymous(ctx) {
+		return true
+	}
+	username, err := c.GetUsername(ctx)
+	if err != nil {
+		logrus.WithError(err).Error("failed to get username from context to check the user permissions")
+		return false
+	}
+	if username == "" {
+		logrus.Error("no username found in the context, this should not happen in a CEL authorization implementation")
+		return false
+	}
+	input := newInput(username, requestAction, requestProject, requestScope, resource)
+	input.Groups = c.groups[username]
+	decision := c.Evaluate(input)
+	if c.decisionLogging {
+		logDecision(input, decision)
+	}
+	return decision.Allowed
+}
+
+func newInput(username string, requestAction v1Role.Action, requestProject string, requestScope v1Role.Scope, resource *policy.Resource) policy.Input {
+	if v1Role.IsGlobalScope(requestScope) {
+		// A global resource doesn't belong to any project.
+		requestProject = ""
+	}
+	return policy.Input{
+		User:     username,
+		Action:   requestAction,
+		Scope:    requestScope,
+		Project:  requestProject,
+		Resource: resource,
+	}
+}
+
+func logDecision(input policy.Input, decision policy.Decision) {
+	policies := make([]string, 0, len(decision.Policies))
+	for _, p := range decision.Policies {
+		policies = append(policies, p.Name)
+	}
+	fields := logrus.Fields{
+		"user":     input.User,
+		"groups":   input.Groups,
+		"action":   input.Action,
+		"scope":    input.Scope,
+		"project":  input.Project,
+		"allowed":  decision.Allowed,
+		"policies": policies,
+	}
+	if input.Resource != nil {
+		fields["resource"] = input.Resource.Name
+	}
+	entry := logrus.WithFields(fields)
+	for _, evalErr := range decision.Errors {
+		entry.Warn(evalErr)
+	}
+	entry.Info("authorization decision")
+}
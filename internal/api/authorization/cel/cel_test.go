@@ -0,0 +1,170 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cel
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/perses/perses/internal/api/interface/v1/globalpolicy"
+	"github.com/perses/perses/internal/api/interface/v1/project"
+	"github.com/perses/perses/pkg/model/api/config"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/perses/perses/pkg/model/api/v1/policy"
+	"github.com/perses/perses/pkg/model/api/v1/role"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockAuthenticator struct {
+	authenticator
+	username string
+}
+
+func (m *mockAuthenticator) GetUsername(_ echo.Context) (string, error) {
+	return m.username, nil
+}
+
+type mockProjectDAO struct {
+	project.DAO
+	projects []string
+}
+
+func (m *mockProjectDAO) List(_ *project.Query) ([]*v1.Project, error) {
+	var result []*v1.Project
+	for _, name := range m.projects {
+		result = append(result, &v1.Project{Kind: v1.KindProject, Metadata: *v1.NewMetadata(name)})
+	}
+	return result, nil
+}
+
+type mockGlobalPolicyDAO struct {
+	globalpolicy.DAO
+	policies []*v1.GlobalPolicy
+}
+
+func (m *mockGlobalPolicyDAO) List(_ *globalpolicy.Query) ([]*v1.GlobalPolicy, error) {
+	return m.policies, nil
+}
+
+func newConfig(t *testing.T, policies ...config.CELPolicy) config.Config {
+	for i := range policies {
+		require.NoError(t, policies[i].Verify())
+	}
+	conf := config.Config{}
+	conf.Security.Authorization.Provider.CEL = config.CELAuthorizationProvider{
+		Enable: true,
+		Groups: map[string][]string{
+			"on-call": {"bob"},
+			"admin":   {"alice"},
+		},
+		Policies: policies,
+	}
+	return conf
+}
+
+func newContext() echo.Context {
+	return echo.New().NewContext(httptest.NewRequest(http.MethodGet, "/", nil), httptest.NewRecorder())
+}
+
+func TestHasPermission(t *testing.T) {
+	conf := newConfig(t,
+		config.CELPolicy{Name: "admins", Expression: `"admin" in groups`},
+		config.CELPolicy{Name: "on-call", Expression: `"on-call" in groups && scope == "Dashboard" && action == "update" && "on-call" in resource.tags`},
+		config.CELPolicy{Name: "no-secret", Effect: policy.DenyEffect, Expression: `scope == "GlobalSecret" && project == ""`},
+	)
+	ctx := newContext()
+	authn := &mockAuthenticator{username: "alice"}
+	authz := New(authn, &mockProjectDAO{}, &mockGlobalPolicyDAO{}, conf)
+
+	assert.True(t, authz.HasPermission(ctx, role.DeleteAction, "demo", role.DashboardScope))
+	assert.False(t, authz.HasPermission(ctx, role.ReadAction, v1.WildcardProject, role.GlobalSecretScope))
+	assert.True(t, authz.HasPermission(nil, role.ReadAction, v1.WildcardProject, role.GlobalSecretScope))
+
+	authn.username = "bob"
+	assert.False(t, authz.HasPermission(ctx, role.UpdateAction, "demo", role.DashboardScope))
+	assert.True(t, authz.HasResourcePermission(ctx, role.UpdateAction, "demo", role.DashboardScope, role.Resource{Name: "incidents", Tags: []string{"on-call"}}))
+	assert.False(t, authz.HasResourcePermission(ctx, role.UpdateAction, "demo", role.DashboardScope, role.Resource{Name: "sales"}))
+
+	restrictedProjects, err := authz.GetUserRestrictedProjects(ctx, role.UpdateAction, role.DashboardScope)
+	require.NoError(t, err)
+	assert.Equal(t, []string{v1.WildcardProject}, restrictedProjects)
+}
+
+func TestGetUserProjects(t *testing.T) {
+	conf := newConfig(t,
+		config.CELPolicy{Name: "readers", Expression: `action == "read" && project.startsWith("team-")`},
+	)
+	ctx := newContext()
+	authz := New(&mockAuthenticator{username: "carol"}, &mockProjectDAO{projects: []string{"demo", "team-a", "team-b"}}, &mockGlobalPolicyDAO{}, conf)
+
+	projects, err := authz.GetUserProjects(ctx, role.ReadAction, role.DashboardScope)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"team-a", "team-b"}, projects)
+
+	projects, err = authz.GetUserProjects(ctx, role.UpdateAction, role.DashboardScope)
+	require.NoError(t, err)
+	assert.Empty(t, projects)
+
+	restrictedProjects, err := authz.GetUserRestrictedProjects(ctx, role.ReadAction, role.DashboardScope)
+	require.NoError(t, err)
+	assert.Empty(t, restrictedProjects)
+}
+
+func TestRefreshPermissions(t *testing.T) {
+	conf := newConfig(t)
+	ctx := newContext()
+	policyDAO := &mockGlobalPolicyDAO{}
+	authz := New(&mockAuthenticator{username: "alice"}, &mockProjectDAO{}, policyDAO, conf)
+	assert.False(t, authz.HasPermission(ctx, role.ReadAction, "demo", role.DashboardScope))
+
+	policyDAO.policies = []*v1.GlobalPolicy{
+		{
+			Kind:     v1.KindGlobalPolicy,
+			Metadata: *v1.NewMetadata("admins"),
+			Spec:     v1.PolicySpec{Effect: policy.AllowEffect, Expression: `"admin" in groups`},
+		},
+	}
+	require.NoError(t, authz.RefreshPermissions())
+	assert.True(t, authz.HasPermission(ctx, role.ReadAction, "demo", role.DashboardScope))
+
+	decision := authz.Evaluate(policy.Input{User: "alice", Action: role.ReadAction, Scope: role.DashboardScope, Project: "demo"})
+	assert.Equal(t, policy.Decision{Allowed: true, Policies: []policy.MatchedPolicy{{Name: "admins", Effect: policy.AllowEffect}}}, decision)
+}
+
+func TestExplain(t *testing.T) {
+	conf := newConfig(t,
+		config.CELPolicy{Name: "admins", Expression: `"admin" in groups`},
+		config.CELPolicy{Name: "no-secret", Effect: policy.DenyEffect, Expression: `scope == "GlobalSecret"`},
+	)
+	authz := New(&mockAuthenticator{username: "bob"}, &mockProjectDAO{}, &mockGlobalPolicyDAO{}, conf)
+
+	explanation, err := authz.Explain(newContext(), "alice", role.ReadAction, "demo", role.DashboardScope)
+	require.NoError(t, err)
+	assert.True(t, explanation.Allowed)
+	assert.Equal(t, []role.Grant{{Source: role.PolicySource, Name: "admins", Bound: true}}, explanation.Grants)
+	assert.Empty(t, explanation.Reason)
+
+	explanation, err = authz.Explain(newContext(), "alice", role.ReadAction, v1.WildcardProject, role.GlobalSecretScope)
+	require.NoError(t, err)
+	assert.False(t, explanation.Allowed)
+	assert.Equal(t, "denied by the policies no-secret", explanation.Reason)
+
+	explanation, err = authz.Explain(newContext(), "bob", role.ReadAction, "demo", role.DashboardScope)
+	require.NoError(t, err)
+	assert.False(t, explanation.Allowed)
+	assert.Equal(t, "no policy allows the request", explanation.Reason)
+}
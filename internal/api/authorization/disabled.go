@@ -59,6 +59,14 @@ func (r *disabledImpl) HasPermission(_ echo.Context, _ v1Role.Action, _ string,
 	return true
 }
 
+func (r *disabledImpl) HasResourcePermission(_ echo.Context, _ v1Role.Action, _ string, _ v1Role.Scope, _ v1Role.Resource) bool {
+	return true
+}
+
+func (r *disabledImpl) GetUserRestrictedProjects(_ echo.Context, _ v1Role.Action, _ v1Role.Scope) ([]string, error) {
+	return []string{}, nil
+}
+
 func (r *disabledImpl) HasProjectCreatePermission(_ echo.Context, _ string) bool {
 	return true
 }
@@ -67,6 +75,17 @@ func (r *disabledImpl) GetPermissions(_ echo.Context) (map[string][]*v1Role.Perm
 	return nil, nil
 }
 
+func (r *disabledImpl) Explain(_ echo.Context, username string, requestAction v1Role.Action, requestProject string, requestScope v1Role.Scope) (*v1Role.Explanation, error) {
+	return &v1Role.Explanation{
+		User:    username,
+		Action:  requestAction,
+		Scope:   requestScope,
+		Project: requestProject,
+		Allowed: true,
+		Reason:  "the authorization is disabled",
+	}, nil
+}
+
 func (r *disabledImpl) RefreshPermissions() error {
 	return nil
 }
@@ -18,6 +18,7 @@ import (
 	"errors"
 	"fmt"
 	"slices"
+	"strings"
 	"time"
 
 	"github.com/labstack/echo/v4"
@@ -77,9 +78,10 @@ func New(conf config.Config) (*k8sImpl, error) {
 	}
 
 	return &k8sImpl{
-		authenticator: kubernetesAuthenticator,
-		authorizer:    k8sAuthorizer,
-		kubeClient:    kubeClient,
+		authenticator:   kubernetesAuthenticator,
+		authorizer:      k8sAuthorizer,
+		kubeClient:      kubeClient,
+		readGrantsQuery: conf.Security.Authorization.IsReadGrantingQuery(),
 	}, nil
 }
 
@@ -87,6 +89,8 @@ type k8sImpl struct {
 	authenticator authenticator.Request
 	authorizer    authorizer.Authorizer
 	kubeClient    kubernetes.Interface
+	// readGrantsQuery is true when the `get` verb also grants the permission to query a datasource.
+	readGrantsQuery bool
 }
 
 // IsEnabled implements [Authorization]
@@ -264,10 +268,25 @@ func (k *k8sImpl) HasPermission(ctx echo.Context, requestAction v1Role.Action, r
 	}
 
 	authorized, _ := k.checkSpecificPermission(ctx, requestProject, kubernetesUser, requestAction, requestScope)
+	if authorized != authorizer.DecisionAllow && requestAction == v1Role.QueryAction && k.readGrantsQuery {
+		authorized, _ = k.checkSpecificPermission(ctx, requestProject, kubernetesUser, v1Role.ReadAction, requestScope)
+	}
 
 	return authorized == authorizer.DecisionAllow
 }
 
+// The Kubernetes RBAC has no equivalent to the permissions restricted to some resources,
+// so the permission on a resource is the permission on its kind.
+// HasResourcePermission implements [Authorization]
+func (k *k8sImpl) HasResourcePermission(ctx echo.Context, requestAction v1Role.Action, requestProject string, requestScope v1Role.Scope, _ v1Role.Resource) bool {
+	return k.HasPermission(ctx, requestAction, requestProject, requestScope)
+}
+
+// GetUserRestrictedProjects implements [Authorization]
+func (k *k8sImpl) GetUserRestrictedProjects(_ echo.Context, _ v1Role.Action, _ v1Role.Scope) ([]string, error) {
+	return []string{}, nil
+}
+
 // For k8s auth, the permission to create a project is driven by having write access
 // to the corresponding namespace, since Perses projects map 1:1 to k8s namespaces.
 // We check if the user can create a dashboard in the target namespace as a proxy for
@@ -373,6 +392,11 @@ func (k *k8sImpl) getPermittedActions(ctx echo.Context, namespace string, user u
 			return newlyValidActions
 		}
 
+		if action == v1Role.QueryAction && authorized != authorizer.DecisionAllow && k.readGrantsQuery &&
+			(slices.Contains(knownActions, v1Role.ReadAction) || slices.Contains(newlyValidActions, v1Role.ReadAction)) {
+			authorized = authorizer.DecisionAllow
+		}
+
 		if authorized == authorizer.DecisionAllow {
 			newlyValidActions = append(newlyValidActions, action)
 		}
@@ -391,6 +415,7 @@ func getUnknownActions(knownActions []v1Role.Action) []v1Role.Action {
 		v1Role.CreateAction,
 		v1Role.UpdateAction,
 		v1Role.DeleteAction,
+		v1Role.QueryAction,
 	}
 	return slices.DeleteFunc(allActions, func(actionToCheck v1Role.Action) bool {
 		return slices.Contains(knownActions, actionToCheck)
@@ -401,7 +426,12 @@ func (k *k8sImpl) checkSpecificPermission(ctx echo.Context, namespace string, us
 	if scope == v1Role.ProjectScope {
 		return k.checkNamespaceAccess(ctx, namespace, user, action)
 	}
+	authorized, _, err = k.authorizer.Authorize(ctx.Request().Context(), getK8sAttributes(namespace, user, action, scope))
+	return authorized, err
+}
 
+// getK8sAttributes returns the attributes of the kubernetes request equivalent to the action on the scope requested.
+func getK8sAttributes(namespace string, user user.Info, action v1Role.Action, scope v1Role.Scope) authorizer.AttributesRecord {
 	translatedK8sScope := getK8sScope(scope)
 
 	// For resources without a K8s CRD (e.g. Variable, Folder, etc.),
@@ -419,7 +449,7 @@ func (k *k8sImpl) checkSpecificPermission(ctx echo.Context, namespace string, us
 		namespace = v1.WildcardProject
 	}
 
-	attributes := authorizer.AttributesRecord{
+	return authorizer.AttributesRecord{
 		User:            user,
 		Verb:            string(getK8sAction(action)),
 		Namespace:       namespace,
@@ -430,8 +460,6 @@ func (k *k8sImpl) checkSpecificPermission(ctx echo.Context, namespace string, us
 		Name:            "",
 		ResourceRequest: true,
 	}
-	authorized, _, err = k.authorizer.Authorize(ctx.Request().Context(), attributes)
-	return authorized, err
 }
 
 // Kubernetes requires both a namespace and a resource to check permissions against. Checking the permissions
@@ -455,6 +483,88 @@ func (k *k8sImpl) checkNamespaceAccess(ctx echo.Context, namespace string, user
 	return decision, err
 }
 
+// The explanation is the status of the SubjectAccessReview sent to Kubernetes, whose reason usually names the
+// (Cluster)RoleBinding granting the permission.
+// Explain implements [Authorization]
+func (k *k8sImpl) Explain(ctx echo.Context, username string, requestAction v1Role.Action, requestProject string, requestScope v1Role.Scope) (*v1Role.Explanation, error) {
+	var target user.Info = &user.DefaultInfo{Name: username}
+	// The groups of the user are only known when it is the user of the context.
+	if usr, err := k.GetUser(ctx); err == nil && usr != nil {
+		if currentUser, userErr := getK8sUser(usr); userErr == nil && currentUser.GetName() == username {
+			target = currentUser
+		}
+	}
+	actions := []v1Role.Action{requestAction}
+	if requestAction == v1Role.QueryAction && k.readGrantsQuery {
+		actions = append(actions, v1Role.ReadAction)
+	}
+	// Like in checkNamespaceAccess, the access to a project is the access to any perses resource of the namespace.
+	scopes := []v1Role.Scope{requestScope}
+	if requestScope == v1Role.ProjectScope {
+		scopes = kubernetesResourcesProjectScopesToCheck
+	}
+	result := &v1Role.Explanation{
+		User:    username,
+		Action:  requestAction,
+		Scope:   requestScope,
+		Project: requestProject,
+	}
+	var reasons []string
+	for _, action := range actions {
+		for _, scope := range scopes {
+			attributes := getK8sAttributes(requestProject, target, action, scope)
+			status, err := k.subjectAccessReview(ctx.Request().Context(), attributes)
+			if err != nil {
+				return nil, err
+			}
+			reason := fmt.Sprintf("%q on %q in the namespace %q: %s", attributes.Verb, attributes.Resource, attributes.Namespace, describeSARStatus(status))
+			if status.Allowed {
+				result.Allowed = true
+				result.Reason = reason
+				return result, nil
+			}
+			reasons = append(reasons, reason)
+		}
+	}
+	result.Reason = strings.Join(reasons, "; ")
+	return result, nil
+}
+
+func (k *k8sImpl) subjectAccessReview(ctx context.Context, attributes authorizer.AttributesRecord) (*authv1.SubjectAccessReviewStatus, error) {
+	sar := &authv1.SubjectAccessReview{
+		Spec: authv1.SubjectAccessReviewSpec{
+			User:   attributes.User.GetName(),
+			Groups: attributes.User.GetGroups(),
+			ResourceAttributes: &authv1.ResourceAttributes{
+				Namespace: attributes.Namespace,
+				Verb:      attributes.Verb,
+				Group:     attributes.APIGroup,
+				Version:   attributes.APIVersion,
+				Resource:  attributes.Resource,
+			},
+		},
+	}
+	result, err := k.kubeClient.AuthorizationV1().SubjectAccessReviews().Create(ctx, sar, metav1.CreateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the SubjectAccessReview: %w", err)
+	}
+	return &result.Status, nil
+}
+
+func describeSARStatus(status *authv1.SubjectAccessReviewStatus) string {
+	decision := "denied"
+	if status.Allowed {
+		decision = "allowed"
+	}
+	if len(status.Reason) > 0 {
+		decision = fmt.Sprintf("%s (%s)", decision, status.Reason)
+	}
+	if len(status.EvaluationError) > 0 {
+		decision = fmt.Sprintf("%s, evaluation error: %s", decision, status.EvaluationError)
+	}
+	return decision
+}
+
 // RefreshPermissions implements [Authorization]
 func (k *k8sImpl) RefreshPermissions() error {
 	return nil
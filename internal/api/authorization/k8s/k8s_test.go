@@ -606,6 +606,22 @@ func TestHasPermission(t *testing.T) {
 			reqScope:       v1Role.GlobalVariableScope,
 			expectedResult: true,
 		},
+		{
+			title:          "admin has query datasource perm in project0",
+			user:           userAdmin,
+			reqAction:      v1Role.QueryAction,
+			reqProject:     projectZero,
+			reqScope:       v1Role.DatasourceScope,
+			expectedResult: true,
+		},
+		{
+			title:          "user4 doesn't have query datasource perm in project0 (read doesn't grant query)",
+			user:           userFour,
+			reqAction:      v1Role.QueryAction,
+			reqProject:     projectZero,
+			reqScope:       v1Role.DatasourceScope,
+			expectedResult: false,
+		},
 		{
 			title:          "user3 doesn't have read variable perm in project0 (no namedashboardspace access)",
 			user:           userThree,
@@ -1129,3 +1145,61 @@ func TestGetUserImpersonation(t *testing.T) {
 		})
 	}
 }
+
+func TestExplain(t *testing.T) {
+	mockK8s := newK8sMock(t)
+
+	e := echo.New()
+
+	testSuites := []struct {
+		title          string
+		user           string
+		reqAction      v1Role.Action
+		reqProject     string
+		reqScope       v1Role.Scope
+		expectedResult bool
+		expectedReason string
+	}{
+		{
+			title:          "user0 is allowed to read dashboards in project0",
+			user:           userZero,
+			reqAction:      v1Role.ReadAction,
+			reqProject:     projectZero,
+			reqScope:       v1Role.DashboardScope,
+			expectedResult: true,
+			expectedReason: `"get" on "persesdashboards" in the namespace "project0": allowed`,
+		},
+		{
+			title:          "user0 is not allowed to create dashboards in project0",
+			user:           userZero,
+			reqAction:      v1Role.CreateAction,
+			reqProject:     projectZero,
+			reqScope:       v1Role.DashboardScope,
+			expectedResult: false,
+			expectedReason: "Mock RBAC: user0 cannot",
+		},
+		{
+			title:          "user4 has access to project0 through its datasources",
+			user:           userFour,
+			reqAction:      v1Role.ReadAction,
+			reqProject:     projectZero,
+			reqScope:       v1Role.ProjectScope,
+			expectedResult: true,
+			expectedReason: `"persesdatasources" in the namespace "project0": allowed`,
+		},
+	}
+	for i := range testSuites {
+		test := testSuites[i]
+		t.Run(test.title, func(t *testing.T) {
+			// The explanation is requested by the admin on behalf of the user.
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set("Authorization", "bearer admin-token")
+			rec := httptest.NewRecorder()
+			explanation, err := mockK8s.Explain(e.NewContext(req, rec), test.user, test.reqAction, test.reqProject, test.reqScope)
+			assert.NoError(t, err)
+			assert.Equal(t, test.user, explanation.User)
+			assert.Equal(t, test.expectedResult, explanation.Allowed)
+			assert.Contains(t, explanation.Reason, test.expectedReason)
+		})
+	}
+}
@@ -20,10 +20,12 @@ import (
 type k8sAction string
 
 const (
-	k8sReadAction     k8sAction = "get"
-	k8sCreateAction   k8sAction = "create"
-	k8sUpdateAction   k8sAction = "patch"
-	k8sDeleteAction   k8sAction = "delete"
+	k8sReadAction   k8sAction = "get"
+	k8sCreateAction k8sAction = "create"
+	k8sUpdateAction k8sAction = "patch"
+	k8sDeleteAction k8sAction = "delete"
+	// k8sQueryAction is a custom verb, to be granted on the Perses datasource resources in the Kubernetes roles.
+	k8sQueryAction    k8sAction = "query"
 	k8sWildcardAction k8sAction = "*"
 )
 
@@ -80,6 +82,8 @@ func getK8sAction(action v1Role.Action) k8sAction {
 		return k8sUpdateAction
 	case v1Role.DeleteAction:
 		return k8sDeleteAction
+	case v1Role.QueryAction:
+		return k8sQueryAction
 	case v1Role.WildcardAction:
 		return k8sWildcardAction
 	default: // not reachable
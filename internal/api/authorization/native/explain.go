@@ -0,0 +1,150 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package native
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/perses/perses/internal/api/interface/v1/globalrole"
+	"github.com/perses/perses/internal/api/interface/v1/globalrolebinding"
+	"github.com/perses/perses/internal/api/interface/v1/role"
+	"github.com/perses/perses/internal/api/interface/v1/rolebinding"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	v1Role "github.com/perses/perses/pkg/model/api/v1/role"
+)
+
+func (n *native) Explain(_ echo.Context, username string, requestAction v1Role.Action, requestProject string, requestScope v1Role.Scope) (*v1Role.Explanation, error) {
+	globalRoles, err := n.globalRoleDAO.List(&globalrole.Query{})
+	if err != nil {
+		return nil, err
+	}
+	globalRoleBindings, err := n.globalRoleBindingDAO.List(&globalrolebinding.Query{})
+	if err != nil {
+		return nil, err
+	}
+	var roles []*v1.Role
+	var roleBindings []*v1.RoleBinding
+	// Only the global roles can grant a global permission, or a permission on every project.
+	if !v1Role.IsGlobalScope(requestScope) && len(requestProject) > 0 && requestProject != v1.WildcardProject {
+		roles, err = n.roleDAO.List(&role.Query{Project: requestProject})
+		if err != nil {
+			return nil, err
+		}
+		roleBindings, err = n.roleBindingDAO.List(&rolebinding.Query{Project: requestProject})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return n.explain(username, requestAction, requestProject, requestScope, globalRoles, globalRoleBindings, roles, roleBindings), nil
+}
+
+// explain builds the explanation of the request from the roles and the bindings that can grant the permission.
+func (n *native) explain(username string, requestAction v1Role.Action, requestProject string, requestScope v1Role.Scope,
+	globalRoles []*v1.GlobalRole, globalRoleBindings []*v1.GlobalRoleBinding, roles []*v1.Role, roleBindings []*v1.RoleBinding) *v1Role.Explanation {
+	result := &v1Role.Explanation{
+		User:    username,
+		Action:  requestAction,
+		Scope:   requestScope,
+		Project: requestProject,
+	}
+	if granted, isRestricted := grantsPermission(n.guestPermissions, requestAction, requestScope); granted {
+		result.Grants = append(result.Grants, v1Role.Grant{
+			Source:     v1Role.GuestPermissionsSource,
+			Bound:      true,
+			Restricted: isRestricted,
+		})
+	}
+	for _, globalRole := range globalRoles {
+		granted, isRestricted := grantsPermission(n.mapPermissions(globalRole.Spec.Permissions), requestAction, requestScope)
+		if !granted {
+			continue
+		}
+		var bindings []string
+		for _, binding := range globalRoleBindings {
+			if binding.Spec.Role == globalRole.Metadata.Name && bindsUser(&binding.Spec, username) {
+				bindings = append(bindings, binding.Metadata.Name)
+			}
+		}
+		result.Grants = append(result.Grants, v1Role.Grant{
+			Source:     v1Role.GlobalRoleSource,
+			Name:       globalRole.Metadata.Name,
+			Bindings:   bindings,
+			Bound:      len(bindings) > 0,
+			Restricted: isRestricted,
+		})
+	}
+	for _, projectRole := range roles {
+		granted, isRestricted := grantsPermission(n.mapPermissions(projectRole.Spec.Permissions), requestAction, requestScope)
+		if !granted {
+			continue
+		}
+		var bindings []string
+		for _, binding := range roleBindings {
+			if binding.Metadata.Project == projectRole.Metadata.Project && binding.Spec.Role == projectRole.Metadata.Name && bindsUser(&binding.Spec, username) {
+				bindings = append(bindings, binding.Metadata.Name)
+			}
+		}
+		result.Grants = append(result.Grants, v1Role.Grant{
+			Source:     v1Role.RoleSource,
+			Name:       projectRole.Metadata.Name,
+			Project:    projectRole.Metadata.Project,
+			Bindings:   bindings,
+			Bound:      len(bindings) > 0,
+			Restricted: isRestricted,
+		})
+	}
+
+	boundGrants := result.BoundGrants()
+	for _, grant := range boundGrants {
+		if !grant.Restricted {
+			result.Allowed = true
+		}
+	}
+	switch {
+	case result.Allowed:
+	case len(boundGrants) > 0:
+		result.Reason = "the permission is only granted on some resources of the scope"
+	case len(result.Grants) > 0:
+		result.Reason = "the user is not bound to any role granting the permission"
+	default:
+		result.Reason = "no role grants the permission"
+	}
+	return result
+}
+
+// bindsUser returns true if the binding applies to the user.
+// It is shared by the permission cache and the explanation, so both always agree on the bindings of a user.
+func bindsUser(spec *v1.RoleBindingSpec, username string) bool {
+	return spec.Has(v1.KindUser, username)
+}
+
+// grantsPermission returns true if one of the permissions grants the action on the scope.
+// isRestricted is true when none of them grants it on every resource of the scope.
+func grantsPermission(permissions []*v1Role.Permission, requestAction v1Role.Action, requestScope v1Role.Scope) (granted bool, isRestricted bool) {
+	if listHasPermission(permissions, requestAction, requestScope) {
+		return true, false
+	}
+	if listHasMatchingPermission(permissions, requestAction, requestScope, restricted) {
+		return true, true
+	}
+	return false, false
+}
+
+// mapPermissions returns the permissions of a role as they must be evaluated, according to the configuration.
+func (n *native) mapPermissions(permissions []v1Role.Permission) []*v1Role.Permission {
+	result := make([]*v1Role.Permission, 0, len(permissions))
+	for i := range permissions {
+		result = append(result, n.mapPermission(&permissions[i]))
+	}
+	return result
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/labstack/echo/v4/middleware"
 	"github.com/perses/perses/internal/api/crypto"
 	apiInterface "github.com/perses/perses/internal/api/interface"
+	"github.com/perses/perses/internal/api/interface/v1/folder"
 	"github.com/perses/perses/internal/api/interface/v1/globalrole"
 	"github.com/perses/perses/internal/api/interface/v1/globalrolebinding"
 	"github.com/perses/perses/internal/api/interface/v1/role"
@@ -39,11 +40,18 @@ import (
 )
 
 func New(userDAO user.DAO, roleDAO role.DAO, roleBindingDAO rolebinding.DAO,
-	globalRoleDAO globalrole.DAO, globalRoleBindingDAO globalrolebinding.DAO, conf config.Config) (*native, error) {
+	globalRoleDAO globalrole.DAO, globalRoleBindingDAO globalrolebinding.DAO, folderDAO folder.DAO, conf config.Config) (*native, error) {
 	key, err := hex.DecodeString(string(conf.Security.EncryptionKey))
 	if err != nil {
 		return nil, err
 	}
+	guestPermissions := conf.Security.Authorization.Provider.Native.GuestPermissions
+	if conf.Security.Authorization.IsReadGrantingQuery() {
+		guestPermissions = make([]*v1Role.Permission, 0, len(conf.Security.Authorization.Provider.Native.GuestPermissions))
+		for _, permission := range conf.Security.Authorization.Provider.Native.GuestPermissions {
+			guestPermissions = append(guestPermissions, withQueryAction(permission))
+		}
+	}
 	return &native{
 		cache:                &cache{},
 		userDAO:              userDAO,
@@ -51,7 +59,9 @@ func New(userDAO user.DAO, roleDAO role.DAO, roleBindingDAO rolebinding.DAO,
 		roleBindingDAO:       roleBindingDAO,
 		globalRoleDAO:        globalRoleDAO,
 		globalRoleBindingDAO: globalRoleBindingDAO,
-		guestPermissions:     conf.Security.Authorization.Provider.Native.GuestPermissions,
+		folderDAO:            folderDAO,
+		guestPermissions:     guestPermissions,
+		readGrantsQuery:      conf.Security.Authorization.IsReadGrantingQuery(),
 		accessKey:            key,
 	}, err
 }
@@ -67,7 +77,11 @@ type native struct {
 	roleBindingDAO       rolebinding.DAO
 	globalRoleDAO        globalrole.DAO
 	globalRoleBindingDAO globalrolebinding.DAO
-	guestPermissions     []*v1Role.Permission
+	// folderDAO is used to find the folders containing a dashboard when a permission is restricted to some folders.
+	folderDAO        folder.DAO
+	guestPermissions []*v1Role.Permission
+	// readGrantsQuery is true when the read action also grants the query action.
+	readGrantsQuery bool
 	// mutex is used to protect the cache from concurrent access.
 	mutex sync.RWMutex
 }
@@ -223,6 +237,87 @@ func (n *native) HasPermission(ctx echo.Context, requestAction v1Role.Action, re
 	return n.cache.hasPermission(username, requestAction, requestProject, requestScope)
 }
 
+func (n *native) GetUserRestrictedProjects(ctx echo.Context, requestAction v1Role.Action, requestScope v1Role.Scope) ([]string, error) {
+	if listHasMatchingPermission(n.guestPermissions, requestAction, requestScope, restricted) {
+		return []string{v1.WildcardProject}, nil
+	}
+
+	username, err := n.GetUsername(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if username == "" {
+		// This method should not be called if the endpoint is anonymous or the username is not found.
+		logrus.Error("failed to get username from context to list the user projects")
+		return nil, apiInterface.InternalError
+	}
+	n.mutex.RLock()
+	defer n.mutex.RUnlock()
+	projectPermission := n.cache.permissions[username]
+	if globalPermissions, ok := projectPermission[v1.WildcardProject]; ok && listHasMatchingPermission(globalPermissions, requestAction, requestScope, restricted) {
+		return []string{v1.WildcardProject}, nil
+	}
+
+	var projects []string
+	for project, permList := range projectPermission {
+		// A project where the user has an unrestricted permission is already returned by GetUserProjects.
+		if project != v1.WildcardProject && listHasMatchingPermission(permList, requestAction, requestScope, restricted) && !listHasPermission(permList, requestAction, requestScope) {
+			projects = append(projects, project)
+		}
+	}
+	return projects, nil
+}
+
+func (n *native) HasResourcePermission(ctx echo.Context, requestAction v1Role.Action, requestProject string, requestScope v1Role.Scope, resource v1Role.Resource) bool {
+	// HasPermission is also taking care of the internal calls and of the anonymous endpoints.
+	if n.HasPermission(ctx, requestAction, requestProject, requestScope) {
+		return true
+	}
+	username, err := n.GetUsername(ctx)
+	if err != nil || username == "" {
+		return false
+	}
+	if requestScope == v1Role.DashboardScope && resource.Folders == nil {
+		n.mutex.RLock()
+		needFolders := listHasFolderRestriction(n.guestPermissions) || n.cache.hasFolderRestriction(username)
+		n.mutex.RUnlock()
+		if needFolders {
+			resource.Folders = n.findDashboardFolders(ctx, requestProject, resource.Name)
+		}
+	}
+	n.mutex.RLock()
+	defer n.mutex.RUnlock()
+	match := matchResource(resource)
+	if listHasMatchingPermission(n.guestPermissions, requestAction, requestScope, match) {
+		return true
+	}
+	return n.cache.hasMatchingPermission(username, requestAction, requestProject, requestScope, match)
+}
+
+// folderContextKeyPrefix prefixes the key used to keep the folders of a project in the context of the request.
+const folderContextKeyPrefix = "native.folders."
+
+// findDashboardFolders returns the paths of every folder of the project containing the dashboard.
+// The folders of the project are loaded once per request, as filtering a list of dashboards calls it for each of them.
+func (n *native) findDashboardFolders(ctx echo.Context, project string, dashboard string) []string {
+	key := folderContextKeyPrefix + project
+	folders, ok := ctx.Get(key).([]*v1.Folder)
+	if !ok {
+		var err error
+		folders, err = n.folderDAO.List(&folder.Query{Project: project})
+		if err != nil {
+			logrus.WithError(err).Errorf("unable to list the folders of the project %q to check the permissions", project)
+			return nil
+		}
+		ctx.Set(key, folders)
+	}
+	var paths []string
+	for _, f := range folders {
+		paths = append(paths, f.DashboardPaths(dashboard)...)
+	}
+	return paths
+}
+
 // For native auth, creating a project requires a global permission.
 func (n *native) HasCreateProjectPermission(ctx echo.Context, projectName string) bool {
 	return n.HasPermission(ctx, v1Role.CreateAction, v1.WildcardProject, v1Role.ProjectScope)
@@ -286,7 +381,7 @@ func (n *native) loadAllPermissions() (usersPermissions, error) {
 	permissionBuild := make(usersPermissions)
 	for _, usr := range users {
 		for _, globalRoleBinding := range globalRoleBindings {
-			if globalRoleBinding.Spec.Has(v1.KindUser, usr.Metadata.Name) {
+			if bindsUser(&globalRoleBinding.Spec, usr.Metadata.Name) {
 				globalRole := findGlobalRole(globalRoles, globalRoleBinding.Spec.Role)
 				if globalRole == nil {
 					logrus.Warningf("global role %q listed in the global role binding %q does not exist", globalRoleBinding.Spec.Role, globalRoleBinding.Metadata.Name)
@@ -294,7 +389,7 @@ func (n *native) loadAllPermissions() (usersPermissions, error) {
 				}
 				globalRolePermissions := globalRole.Spec.Permissions
 				for i := range globalRolePermissions {
-					permissionBuild.addEntry(usr.Metadata.Name, v1.WildcardProject, &globalRolePermissions[i])
+					permissionBuild.addEntry(usr.Metadata.Name, v1.WildcardProject, n.mapPermission(&globalRolePermissions[i]))
 				}
 			}
 		}
@@ -302,7 +397,7 @@ func (n *native) loadAllPermissions() (usersPermissions, error) {
 
 	for _, usr := range users {
 		for _, roleBinding := range roleBindings {
-			if roleBinding.Spec.Has(v1.KindUser, usr.Metadata.Name) {
+			if bindsUser(&roleBinding.Spec, usr.Metadata.Name) {
 				projectRole := findRole(roles, roleBinding.Metadata.Project, roleBinding.Spec.Role)
 				if projectRole == nil {
 					logrus.Warningf("role %q listed in the role binding %s/%s does not exist", roleBinding.Spec.Role, roleBinding.Metadata.Project, roleBinding.Metadata.Name)
@@ -310,10 +405,18 @@ func (n *native) loadAllPermissions() (usersPermissions, error) {
 				}
 				rolePermissions := projectRole.Spec.Permissions
 				for i := range rolePermissions {
-					permissionBuild.addEntry(usr.Metadata.Name, roleBinding.Metadata.Project, &rolePermissions[i])
+					permissionBuild.addEntry(usr.Metadata.Name, roleBinding.Metadata.Project, n.mapPermission(&rolePermissions[i]))
 				}
 			}
 		}
 	}
 	return permissionBuild, nil
 }
+
+// mapPermission returns the permission as it must be evaluated, according to the configuration.
+func (n *native) mapPermission(permission *v1Role.Permission) *v1Role.Permission {
+	if n.readGrantsQuery {
+		return withQueryAction(permission)
+	}
+	return permission
+}
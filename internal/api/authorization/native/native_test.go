@@ -15,8 +15,12 @@ package native
 
 import (
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
+	"github.com/labstack/echo/v4"
+	"github.com/perses/perses/internal/api/interface/v1/folder"
 	v1 "github.com/perses/perses/pkg/model/api/v1"
 	"github.com/perses/perses/pkg/model/api/v1/role"
 	"github.com/stretchr/testify/assert"
@@ -211,6 +215,86 @@ func TestCacheHasPermission(t *testing.T) {
 	}
 }
 
+func TestCacheHasRestrictedPermission(t *testing.T) {
+	permissions := make(usersPermissions)
+	permissions.addEntry("contractor", "shop", &role.Permission{
+		Actions: []role.Action{role.ReadAction},
+		Scopes:  []role.Scope{role.DashboardScope},
+	})
+	permissions.addEntry("contractor", "shop", &role.Permission{
+		Actions:   []role.Action{role.UpdateAction},
+		Scopes:    []role.Scope{role.DashboardScope},
+		Resources: &role.ResourceSelector{Folders: []string{"payments/*"}},
+	})
+	permissions.addEntry("contractor", v1.WildcardProject, &role.Permission{
+		Actions:   []role.Action{role.ReadAction},
+		Scopes:    []role.Scope{role.GlobalDatasourceScope},
+		Resources: &role.ResourceSelector{Tags: []string{"public"}},
+	})
+	c := cache{permissions: permissions}
+
+	assert.True(t, c.hasPermission("contractor", role.ReadAction, "shop", role.DashboardScope))
+	// A restricted permission doesn't grant the permission on every resource of the scope.
+	assert.False(t, c.hasPermission("contractor", role.UpdateAction, "shop", role.DashboardScope))
+	assert.False(t, c.hasPermission("contractor", role.ReadAction, v1.WildcardProject, role.GlobalDatasourceScope))
+	assert.True(t, c.hasFolderRestriction("contractor"))
+
+	testSuites := []struct {
+		title          string
+		reqAction      role.Action
+		reqProject     string
+		reqScope       role.Scope
+		resource       role.Resource
+		expectedResult bool
+	}{
+		{
+			title:          "dashboard in a sub folder of payments",
+			reqAction:      role.UpdateAction,
+			reqProject:     "shop",
+			reqScope:       role.DashboardScope,
+			resource:       role.Resource{Name: "checkout", Folders: []string{"payments", "payments/checkout"}},
+			expectedResult: true,
+		},
+		{
+			title:          "dashboard outside of the payments folder",
+			reqAction:      role.UpdateAction,
+			reqProject:     "shop",
+			reqScope:       role.DashboardScope,
+			resource:       role.Resource{Name: "catalog", Folders: []string{"catalog"}},
+			expectedResult: false,
+		},
+		{
+			title:          "restricted permission on another project",
+			reqAction:      role.UpdateAction,
+			reqProject:     "bank",
+			reqScope:       role.DashboardScope,
+			resource:       role.Resource{Name: "checkout", Folders: []string{"payments", "payments/checkout"}},
+			expectedResult: false,
+		},
+		{
+			title:          "global datasource with the tag",
+			reqAction:      role.ReadAction,
+			reqProject:     v1.WildcardProject,
+			reqScope:       role.GlobalDatasourceScope,
+			resource:       role.Resource{Name: "prometheus", Tags: []string{"public"}},
+			expectedResult: true,
+		},
+		{
+			title:          "global datasource without the tag",
+			reqAction:      role.ReadAction,
+			reqProject:     v1.WildcardProject,
+			reqScope:       role.GlobalDatasourceScope,
+			resource:       role.Resource{Name: "billing"},
+			expectedResult: false,
+		},
+	}
+	for _, test := range testSuites {
+		t.Run(test.title, func(t *testing.T) {
+			assert.Equal(t, test.expectedResult, c.hasMatchingPermission("contractor", test.reqAction, test.reqProject, test.reqScope, matchResource(test.resource)))
+		})
+	}
+}
+
 func BenchmarkCacheHasPermission(b *testing.B) {
 	benchSuites := []struct {
 		userCount          int
@@ -267,3 +351,156 @@ func BenchmarkCacheHasPermission(b *testing.B) {
 		})
 	}
 }
+
+func TestWithQueryAction(t *testing.T) {
+	readOnly := &role.Permission{Actions: []role.Action{role.ReadAction}, Scopes: []role.Scope{role.DatasourceScope}}
+	result := withQueryAction(readOnly)
+	assert.Equal(t, []role.Action{role.ReadAction, role.QueryAction}, result.Actions)
+	// The original permission is left untouched as it belongs to the role.
+	assert.Equal(t, []role.Action{role.ReadAction}, readOnly.Actions)
+
+	createOnly := &role.Permission{Actions: []role.Action{role.CreateAction}, Scopes: []role.Scope{role.DatasourceScope}}
+	assert.Same(t, createOnly, withQueryAction(createOnly))
+}
+
+func TestExplain(t *testing.T) {
+	n := &native{
+		guestPermissions: []*role.Permission{{Actions: []role.Action{role.ReadAction}, Scopes: []role.Scope{role.ProjectScope}}},
+	}
+	globalRoles := []*v1.GlobalRole{
+		{Metadata: *v1.NewMetadata("admin"), Spec: v1.RoleSpec{Permissions: []role.Permission{{Actions: []role.Action{role.WildcardAction}, Scopes: []role.Scope{role.WildcardScope}}}}},
+		{Metadata: *v1.NewMetadata("viewer"), Spec: v1.RoleSpec{Permissions: []role.Permission{{Actions: []role.Action{role.ReadAction}, Scopes: []role.Scope{role.DashboardScope}}}}},
+	}
+	globalRoleBindings := []*v1.GlobalRoleBinding{
+		{Metadata: *v1.NewMetadata("admins"), Spec: v1.RoleBindingSpec{Role: "admin", Subjects: []v1.Subject{{Kind: v1.KindUser, Name: "admin"}}}},
+	}
+	roles := []*v1.Role{
+		{Metadata: *v1.NewProjectMetadata("demo", "editor"), Spec: v1.RoleSpec{Permissions: []role.Permission{{Actions: []role.Action{role.UpdateAction}, Scopes: []role.Scope{role.DashboardScope}}}}},
+		{Metadata: *v1.NewProjectMetadata("demo", "on-call"), Spec: v1.RoleSpec{Permissions: []role.Permission{{
+			Actions:   []role.Action{role.UpdateAction},
+			Scopes:    []role.Scope{role.DashboardScope},
+			Resources: &role.ResourceSelector{Tags: []string{"on-call"}},
+		}}}},
+	}
+	roleBindings := []*v1.RoleBinding{
+		{Metadata: *v1.NewProjectMetadata("demo", "editors"), Spec: v1.RoleBindingSpec{Role: "editor", Subjects: []v1.Subject{{Kind: v1.KindUser, Name: "alice"}}}},
+		{Metadata: *v1.NewProjectMetadata("demo", "on-call"), Spec: v1.RoleBindingSpec{Role: "on-call", Subjects: []v1.Subject{{Kind: v1.KindUser, Name: "bob"}}}},
+	}
+
+	testSuites := []struct {
+		title    string
+		user     string
+		action   role.Action
+		scope    role.Scope
+		expected *role.Explanation
+	}{
+		{
+			title:  "permission granted by a role",
+			user:   "alice",
+			action: role.UpdateAction,
+			scope:  role.DashboardScope,
+			expected: &role.Explanation{
+				User: "alice", Action: role.UpdateAction, Scope: role.DashboardScope, Project: "demo", Allowed: true,
+				Grants: []role.Grant{
+					{Source: role.GlobalRoleSource, Name: "admin"},
+					{Source: role.RoleSource, Name: "editor", Project: "demo", Bindings: []string{"editors"}, Bound: true},
+					{Source: role.RoleSource, Name: "on-call", Project: "demo", Restricted: true},
+				},
+			},
+		},
+		{
+			title:  "permission restricted to some resources",
+			user:   "bob",
+			action: role.UpdateAction,
+			scope:  role.DashboardScope,
+			expected: &role.Explanation{
+				User: "bob", Action: role.UpdateAction, Scope: role.DashboardScope, Project: "demo",
+				Grants: []role.Grant{
+					{Source: role.GlobalRoleSource, Name: "admin"},
+					{Source: role.RoleSource, Name: "editor", Project: "demo"},
+					{Source: role.RoleSource, Name: "on-call", Project: "demo", Bindings: []string{"on-call"}, Bound: true, Restricted: true},
+				},
+				Reason: "the permission is only granted on some resources of the scope",
+			},
+		},
+		{
+			title:  "permission granted by a global role",
+			user:   "admin",
+			action: role.DeleteAction,
+			scope:  role.DatasourceScope,
+			expected: &role.Explanation{
+				User: "admin", Action: role.DeleteAction, Scope: role.DatasourceScope, Project: "demo", Allowed: true,
+				Grants: []role.Grant{{Source: role.GlobalRoleSource, Name: "admin", Bindings: []string{"admins"}, Bound: true}},
+			},
+		},
+		{
+			title:  "permission granted to the guests",
+			user:   "carol",
+			action: role.ReadAction,
+			scope:  role.ProjectScope,
+			expected: &role.Explanation{
+				User: "carol", Action: role.ReadAction, Scope: role.ProjectScope, Project: "demo", Allowed: true,
+				Grants: []role.Grant{
+					{Source: role.GuestPermissionsSource, Bound: true},
+					{Source: role.GlobalRoleSource, Name: "admin"},
+				},
+			},
+		},
+		{
+			title:  "user not bound to the roles granting the permission",
+			user:   "carol",
+			action: role.ReadAction,
+			scope:  role.DashboardScope,
+			expected: &role.Explanation{
+				User: "carol", Action: role.ReadAction, Scope: role.DashboardScope, Project: "demo",
+				Grants: []role.Grant{
+					{Source: role.GlobalRoleSource, Name: "admin"},
+					{Source: role.GlobalRoleSource, Name: "viewer"},
+				},
+				Reason: "the user is not bound to any role granting the permission",
+			},
+		},
+	}
+	for _, test := range testSuites {
+		t.Run(test.title, func(t *testing.T) {
+			assert.Equal(t, test.expected, n.explain(test.user, test.action, "demo", test.scope, globalRoles, globalRoleBindings, roles, roleBindings))
+		})
+	}
+}
+
+// countingFolderDAO returns the same folders for every project and counts the calls to List.
+type countingFolderDAO struct {
+	folder.DAO
+	folders []*v1.Folder
+	calls   int
+}
+
+func (c *countingFolderDAO) List(_ *folder.Query) ([]*v1.Folder, error) {
+	c.calls++
+	return c.folders, nil
+}
+
+func TestFindDashboardFoldersLoadsFoldersOncePerRequest(t *testing.T) {
+	dao := &countingFolderDAO{folders: []*v1.Folder{{
+		Metadata: v1.ProjectMetadata{Metadata: v1.Metadata{Name: "payments"}},
+		Spec: v1.FolderSpec{Items: []v1.FolderItem{
+			{Kind: v1.KindDashboard, Name: "checkout"},
+			{Kind: v1.KindDashboard, Name: "refund"},
+		}},
+	}}}
+	n := &native{folderDAO: dao}
+	newContext := func() echo.Context {
+		return echo.New().NewContext(httptest.NewRequest(http.MethodGet, "/", nil), httptest.NewRecorder())
+	}
+
+	ctx := newContext()
+	assert.Equal(t, []string{"payments"}, n.findDashboardFolders(ctx, "perses", "checkout"))
+	assert.Equal(t, []string{"payments"}, n.findDashboardFolders(ctx, "perses", "refund"))
+	assert.Empty(t, n.findDashboardFolders(ctx, "perses", "catalog"))
+	assert.Equal(t, 1, dao.calls)
+
+	// another project, or another request, loads the folders again.
+	n.findDashboardFolders(ctx, "other", "checkout")
+	n.findDashboardFolders(newContext(), "perses", "checkout")
+	assert.Equal(t, 3, dao.calls)
+}
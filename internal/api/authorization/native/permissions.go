@@ -14,6 +14,8 @@
 package native
 
 import (
+	"slices"
+
 	v1 "github.com/perses/perses/pkg/model/api/v1"
 	v1Role "github.com/perses/perses/pkg/model/api/v1/role"
 )
@@ -39,7 +41,31 @@ type cache struct {
 	permissions usersPermissions
 }
 
+// permissionMatcher decides if a permission can be used to evaluate a request.
+type permissionMatcher func(permission *v1Role.Permission) bool
+
+// unrestricted only keeps the permissions applying to every resource of their scopes.
+func unrestricted(permission *v1Role.Permission) bool {
+	return !permission.IsRestricted()
+}
+
+// restricted only keeps the permissions applying to some resources of their scopes.
+func restricted(permission *v1Role.Permission) bool {
+	return permission.IsRestricted()
+}
+
+// matchResource keeps the permissions applying to the given resource, restricted or not.
+func matchResource(resource v1Role.Resource) permissionMatcher {
+	return func(permission *v1Role.Permission) bool {
+		return permission.Resources.Match(resource)
+	}
+}
+
 func (c *cache) hasPermission(user string, requestAction v1Role.Action, requestProject string, requestScope v1Role.Scope) bool {
+	return c.hasMatchingPermission(user, requestAction, requestProject, requestScope, unrestricted)
+}
+
+func (c *cache) hasMatchingPermission(user string, requestAction v1Role.Action, requestProject string, requestScope v1Role.Scope, match permissionMatcher) bool {
 	usrPermissions, ok := c.permissions[user]
 	if !ok {
 		return false
@@ -48,7 +74,7 @@ func (c *cache) hasPermission(user string, requestAction v1Role.Action, requestP
 	// Checking global perm first
 	if requestProject != v1.WildcardProject {
 		if globalPermissions, ok := usrPermissions[v1.WildcardProject]; ok {
-			if listHasPermission(globalPermissions, requestAction, requestScope) {
+			if listHasMatchingPermission(globalPermissions, requestAction, requestScope, match) {
 				return true
 			}
 		}
@@ -58,11 +84,28 @@ func (c *cache) hasPermission(user string, requestAction v1Role.Action, requestP
 	if !ok {
 		return false
 	}
-	return listHasPermission(projectPermissions, requestAction, requestScope)
+	return listHasMatchingPermission(projectPermissions, requestAction, requestScope, match)
+}
+
+// hasFolderRestriction returns true if one of the permissions of the user is restricted to some folders.
+func (c *cache) hasFolderRestriction(user string) bool {
+	for _, permissions := range c.permissions[user] {
+		if listHasFolderRestriction(permissions) {
+			return true
+		}
+	}
+	return false
 }
 
 func listHasPermission(permissions []*v1Role.Permission, requestAction v1Role.Action, requestScope v1Role.Scope) bool {
+	return listHasMatchingPermission(permissions, requestAction, requestScope, unrestricted)
+}
+
+func listHasMatchingPermission(permissions []*v1Role.Permission, requestAction v1Role.Action, requestScope v1Role.Scope, match permissionMatcher) bool {
 	for _, permission := range permissions {
+		if !match(permission) {
+			continue
+		}
 		for _, action := range permission.Actions {
 			if action == requestAction || action == v1Role.WildcardAction {
 				for _, scope := range permission.Scopes {
@@ -76,6 +119,26 @@ func listHasPermission(permissions []*v1Role.Permission, requestAction v1Role.Ac
 	return false
 }
 
+func listHasFolderRestriction(permissions []*v1Role.Permission) bool {
+	for _, permission := range permissions {
+		if permission.Resources.HasFolders() {
+			return true
+		}
+	}
+	return false
+}
+
+// withQueryAction returns a copy of the permission granting also the query action when it grants the read action.
+// It keeps working the roles written before the query action was introduced.
+func withQueryAction(permission *v1Role.Permission) *v1Role.Permission {
+	if !slices.Contains(permission.Actions, v1Role.ReadAction) || slices.Contains(permission.Actions, v1Role.QueryAction) {
+		return permission
+	}
+	result := *permission
+	result.Actions = append(slices.Clone(permission.Actions), v1Role.QueryAction)
+	return &result
+}
+
 // findRole is a helper to find a role in a slice
 func findRole(roles []*v1.Role, project string, name string) *v1.Role {
 	for _, rle := range roles {
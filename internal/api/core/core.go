@@ -44,7 +44,15 @@ func New(conf config.Config, enablePprof bool, registry *prometheus.Registry, ba
 	if dbInitError := persesDAO.Init(); dbInitError != nil {
 		return nil, nil, fmt.Errorf("unable to initialize the database: %w", dbInitError)
 	}
-	persesAPI := NewPersesAPI(dependencyManager, conf)
+	var gitProvisioning *provisioning.Git
+	if len(conf.Provisioning.Git) > 0 {
+		gitProvisioning = provisioning.NewGit(dependencyManager.Service(), dependencyManager.Persistence().GetGlobalSecret(), conf.Provisioning, persesDAO.IsCaseSensitive())
+	}
+	var provisioningDrift *provisioning.Drift
+	if len(conf.Provisioning.Folders) > 0 {
+		provisioningDrift = provisioning.NewDrift(dependencyManager.Service(), conf.Provisioning.Folders, persesDAO.IsCaseSensitive())
+	}
+	persesAPI := NewPersesAPI(dependencyManager, gitProvisioning, provisioningDrift, conf)
 	persesFrontend := ui.NewPersesFrontend(conf, dependencyManager.Service().GetPlugin())
 	runner := app.NewRunner().WithDefaultHTTPServerAndPrometheusRegisterer(utils.MetricNamespace, registry, registry).SetBanner(banner)
 
@@ -57,15 +65,31 @@ func New(conf config.Config, enablePprof bool, registry *prometheus.Registry, ba
 		runner.WithTimerTasks(time.Duration(conf.EphemeralDashboard.CleanupInterval), ephemeralDashboardsCleaner)
 	}
 
+	// enable the removal of the dashboard views older than the retention
+	if conf.Dashboard.Usage != nil {
+		usageCleaner := dashboard.NewUsageCleaner(dependencyManager.Persistence().GetView(), time.Duration(conf.Dashboard.Usage.Retention))
+		runner.WithTimerTasks(time.Duration(conf.Dashboard.Usage.CleanupInterval), usageCleaner)
+	}
+
+	// enable the lifecycle policies of the stale dashboards. In dry-run mode, the actions are only reported by the API.
+	if conf.Dashboard.Lifecycle != nil && !conf.Dashboard.Lifecycle.DryRun {
+		runner.WithTimerTasks(time.Duration(conf.Dashboard.Lifecycle.Interval), dependencyManager.Service().GetLifecycle())
+	}
+
 	// Enable the provisioning of the resources from the folders defined in the configuration file.
 	if len(conf.Provisioning.Folders) > 0 {
-		provisioningTask, provisioningWatcher := provisioning.New(dependencyManager.Service(), conf.Provisioning.Folders, persesDAO.IsCaseSensitive())
+		provisioningTask, provisioningWatcher := provisioning.New(dependencyManager.Service(), conf.Provisioning, persesDAO.IsCaseSensitive())
 		runner.WithTimerTasks(time.Duration(conf.Provisioning.Interval), provisioningTask)
 		if conf.Provisioning.EnableWatch {
 			runner.WithTasks(provisioningWatcher)
 		}
 	}
 
+	// Enable the provisioning of the resources from the Git repositories defined in the configuration file.
+	if gitProvisioning != nil {
+		runner.WithTimerTasks(time.Duration(conf.Provisioning.Interval), gitProvisioning)
+	}
+
 	// Enable the discovery of the datasources.
 	if len(conf.Datasource.Global.Discovery) > 0 {
 		datasourceDiscoveryTasks, sdErr := discovery.New(conf, dependencyManager.Service(), persesDAO.IsCaseSensitive())
@@ -84,8 +108,26 @@ func New(conf config.Config, enablePprof bool, registry *prometheus.Registry, ba
 		runner.WithTimerTasks(time.Duration(conf.Security.Authorization.Provider.Native.CheckLatestUpdateInterval), rbacTask)
 	}
 
+	// Enable the reload of the GlobalPolicy resources if the CEL provider is enabled.
+	if conf.Security.Authorization.Provider.CEL.Enable {
+		policyTask := refresh.New(persesDAO,
+			dependencyManager.Service().GetAuthorization().RefreshPermissions,
+			[]modelV1.Kind{modelV1.KindGlobalPolicy},
+		)
+		runner.WithTimerTasks(time.Duration(conf.Security.Authorization.Provider.CEL.CheckLatestUpdateInterval), policyTask)
+	}
+
 	// Enable the refresh of the search index.
-	runner.WithTimerTasks(time.Duration(conf.Search.CheckLatestUpdateInterval), refresh.New(persesDAO, dependencyManager.Service().GetIndex().Refresh, []modelV1.Kind{modelV1.KindDashboard}))
+	// The first execution reconciles the whole index with the database. When the index is persisted on disk, it is
+	// searchable before that, and the reconciliation removes the resources deleted while the server was stopped.
+	indexTask := refresh.New(persesDAO,
+		dependencyManager.Service().GetIndex().Refresh,
+		[]modelV1.Kind{
+			modelV1.KindDashboard, modelV1.KindProject, modelV1.KindDatasource, modelV1.KindGlobalDatasource,
+			modelV1.KindVariable, modelV1.KindGlobalVariable, modelV1.KindFolder,
+		},
+	)
+	runner.WithTimerTasks(time.Duration(conf.Search.CheckLatestUpdateInterval), indexTask)
 
 	// Extract the plugin archives and load the plugins.
 	// Loading plugin is not mandatory, so we don't return an error if the plugin can't be loaded.
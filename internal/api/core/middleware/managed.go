@@ -0,0 +1,99 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/perses/perses/internal/api/authorization"
+	databaseModel "github.com/perses/perses/internal/api/database/model"
+	apiInterface "github.com/perses/perses/internal/api/interface"
+	"github.com/perses/perses/internal/api/provisioning/managed"
+	"github.com/perses/perses/internal/api/toolbox"
+	"github.com/perses/perses/internal/api/utils"
+	modelAPI "github.com/perses/perses/pkg/model/api"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/perses/perses/pkg/model/api/v1/role"
+)
+
+// kindFromPath returns the kind of the resource targeted by a path like /api/v1/projects/:project/dashboards/:name.
+func kindFromPath(path string) (v1.Kind, bool) {
+	segments := strings.Split(strings.TrimSuffix(path, "/"), "/")
+	if len(segments) < 2 || segments[len(segments)-1] != ":"+utils.ParamName {
+		return "", false
+	}
+	plural := segments[len(segments)-2]
+	for kind, p := range v1.PluralKindMap {
+		if p == plural {
+			return kind, true
+		}
+	}
+	return "", false
+}
+
+// CheckManagedResource is a middleware that rejects the update and the deletion of the resources managed by the provisioning.
+// The provisioning would otherwise silently overwrite the change at its next execution.
+// The permission is verified first, so a user without the permission cannot learn whether the resource exists.
+func CheckManagedResource(dao databaseModel.DAO, authz authorization.Authorization, caseSensitive bool) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			method := c.Request().Method
+			if method != http.MethodPut && method != http.MethodDelete {
+				return next(c)
+			}
+			kind, ok := kindFromPath(c.Path())
+			if !ok {
+				return next(c)
+			}
+			action := role.UpdateAction
+			if method == http.MethodDelete {
+				action = role.DeleteAction
+			}
+			parameters := toolbox.ExtractParameters(c, caseSensitive)
+			getStored := func() (modelAPI.Entity, error) {
+				var metadata modelAPI.Metadata
+				var entity modelAPI.Entity
+				if len(parameters.Project) > 0 {
+					metadata = v1.NewProjectMetadata(parameters.Project, parameters.Name)
+					entity = &v1.PartialProjectEntity{}
+				} else {
+					metadata = v1.NewMetadata(parameters.Name)
+					entity = &v1.PartialEntity{}
+				}
+				if err := dao.Get(kind, metadata, entity); err != nil {
+					return nil, err
+				}
+				return entity, nil
+			}
+			if err := toolbox.CheckPermission(c, authz, kind, nil, parameters, action, getStored); err != nil {
+				return err
+			}
+			entity, err := getStored()
+			if err != nil {
+				if databaseModel.IsKeyNotFound(err) {
+					// The handler is in charge of returning the appropriate error.
+					return next(c)
+				}
+				return err
+			}
+			if managed.IsManaged(entity.GetMetadata()) {
+				return apiInterface.HandleForbiddenError(fmt.Sprintf("the %s %q is managed by the provisioning and cannot be modified through the API", kind, parameters.Name))
+			}
+			return next(c)
+		}
+	}
+}
@@ -16,24 +16,31 @@ package core
 import (
 	"github.com/labstack/echo/v4"
 	echoUtils "github.com/perses/common/echo"
+	"github.com/perses/perses/internal/api/authorization"
 	"github.com/perses/perses/internal/api/core/middleware"
 	"github.com/perses/perses/internal/api/dependency"
 	authendpoint "github.com/perses/perses/internal/api/impl/auth"
 	configendpoint "github.com/perses/perses/internal/api/impl/config"
 	migrateendpoint "github.com/perses/perses/internal/api/impl/migrate"
 	"github.com/perses/perses/internal/api/impl/proxy"
+	"github.com/perses/perses/internal/api/impl/v1/authz"
 	"github.com/perses/perses/internal/api/impl/v1/dashboard"
+	"github.com/perses/perses/internal/api/impl/v1/dashboardtemplate"
 	"github.com/perses/perses/internal/api/impl/v1/datasource"
 	"github.com/perses/perses/internal/api/impl/v1/ephemeraldashboard"
 	"github.com/perses/perses/internal/api/impl/v1/folder"
+	"github.com/perses/perses/internal/api/impl/v1/globaldashboardtemplate"
 	"github.com/perses/perses/internal/api/impl/v1/globaldatasource"
+	"github.com/perses/perses/internal/api/impl/v1/globalpolicy"
 	"github.com/perses/perses/internal/api/impl/v1/globalrole"
 	"github.com/perses/perses/internal/api/impl/v1/globalrolebinding"
 	"github.com/perses/perses/internal/api/impl/v1/globalsecret"
 	"github.com/perses/perses/internal/api/impl/v1/globalvariable"
 	"github.com/perses/perses/internal/api/impl/v1/health"
+	"github.com/perses/perses/internal/api/impl/v1/lifecycle"
 	"github.com/perses/perses/internal/api/impl/v1/plugin"
 	"github.com/perses/perses/internal/api/impl/v1/project"
+	provisioningendpoint "github.com/perses/perses/internal/api/impl/v1/provisioning"
 	"github.com/perses/perses/internal/api/impl/v1/role"
 	"github.com/perses/perses/internal/api/impl/v1/rolebinding"
 	"github.com/perses/perses/internal/api/impl/v1/search"
@@ -42,6 +49,7 @@ import (
 	"github.com/perses/perses/internal/api/impl/v1/variable"
 	"github.com/perses/perses/internal/api/impl/v1/view"
 	validateendpoint "github.com/perses/perses/internal/api/impl/validate"
+	"github.com/perses/perses/internal/api/provisioning"
 	"github.com/perses/perses/internal/api/route"
 	"github.com/perses/perses/internal/api/utils"
 	"github.com/perses/perses/pkg/model/api/config"
@@ -54,30 +62,41 @@ type api struct {
 	apiEndpoints           []route.Endpoint
 	proxyEndpoint          route.Endpoint
 	authorizationMiddlware echo.MiddlewareFunc
-	apiPrefix              string
+	// managedMiddleware rejects the changes of the resources managed by the provisioning. It is nil when they are not locked.
+	managedMiddleware echo.MiddlewareFunc
+	apiPrefix         string
 }
 
-func NewPersesAPI(dependencyManager dependency.Manager, cfg config.Config) echoUtils.Register {
+// NewPersesAPI creates the API. gitProvisioning is nil when no Git repository is provisioned,
+// and provisioningDrift is nil when no folder is provisioned.
+func NewPersesAPI(dependencyManager dependency.Manager, gitProvisioning *provisioning.Git, provisioningDrift *provisioning.Drift, cfg config.Config) echoUtils.Register {
 	readonly := cfg.Security.Readonly
 	persistenceManager := dependencyManager.Persistence()
 	serviceManager := dependencyManager.Service()
 	caseSensitive := persistenceManager.GetPersesDAO().IsCaseSensitive()
+	// evaluator is nil when the authorization doesn't rely on policies.
+	evaluator, _ := serviceManager.GetAuthorization().(authorization.PolicyEvaluator)
 	apiV1Endpoints := []route.Endpoint{
+		authz.NewEndpoint(serviceManager.GetAuthorization(), evaluator),
 		dashboard.NewEndpoint(serviceManager.GetDashboard(), serviceManager.GetAuthorization(), readonly, caseSensitive),
+		dashboardtemplate.NewEndpoint(serviceManager.GetDashboardTemplate(), serviceManager.GetAuthorization(), readonly, caseSensitive),
 		datasource.NewEndpoint(cfg.Datasource, serviceManager.GetDatasource(), serviceManager.GetAuthorization(), readonly, caseSensitive),
 		ephemeraldashboard.NewEndpoint(serviceManager.GetEphemeralDashboard(), serviceManager.GetAuthorization(), readonly, caseSensitive, cfg.EphemeralDashboard.Enable),
 		folder.NewEndpoint(serviceManager.GetFolder(), serviceManager.GetAuthorization(), readonly, caseSensitive),
+		globaldashboardtemplate.NewEndpoint(serviceManager.GetGlobalDashboardTemplate(), serviceManager.GetAuthorization(), readonly, caseSensitive),
 		globaldatasource.NewEndpoint(cfg.Datasource, serviceManager.GetGlobalDatasource(), serviceManager.GetAuthorization(), readonly, caseSensitive),
 		globalsecret.NewEndpoint(serviceManager.GetGlobalSecret(), serviceManager.GetAuthorization(), readonly, caseSensitive),
 		globalvariable.NewEndpoint(cfg.Variable, serviceManager.GetGlobalVariable(), serviceManager.GetAuthorization(), readonly, caseSensitive),
 		health.NewEndpoint(serviceManager.GetHealth()),
-		plugin.NewEndpoint(serviceManager.GetPlugin(), cfg.Plugin.EnableDev),
+		lifecycle.NewEndpoint(serviceManager.GetLifecycle(), serviceManager.GetAuthorization()),
+		plugin.NewEndpoint(serviceManager.GetPlugin(), serviceManager.GetProject(), serviceManager.GetAuthorization(), cfg.Plugin.EnableDev),
 		project.NewEndpoint(serviceManager.GetProject(), serviceManager.GetAuthorization(), readonly, caseSensitive),
+		provisioningendpoint.NewEndpoint(gitProvisioning, provisioningDrift, serviceManager.GetAuthorization()),
 		search.NewEndpoint(serviceManager.GetIndex()),
 		secret.NewEndpoint(serviceManager.GetSecret(), serviceManager.GetAuthorization(), readonly, caseSensitive),
 		user.NewEndpoint(serviceManager.GetUser(), serviceManager.GetAuthorization(), cfg.Security.Authentication.DisableSignUp, readonly, caseSensitive),
 		variable.NewEndpoint(cfg.Variable, serviceManager.GetVariable(), serviceManager.GetAuthorization(), readonly, caseSensitive),
-		view.NewEndpoint(serviceManager.GetView(), serviceManager.GetAuthorization(), serviceManager.GetDashboard()),
+		view.NewEndpoint(serviceManager.GetView(), serviceManager.GetAuthorization(), serviceManager.GetDashboard(), serviceManager.GetUser(), cfg.Dashboard.Usage != nil),
 	}
 
 	if cfg.Security.Authorization.Provider.Native.Enable {
@@ -91,6 +110,13 @@ func NewPersesAPI(dependencyManager dependency.Manager, cfg config.Config) echoU
 		)
 	}
 
+	if evaluator != nil {
+		// Likewise, the policies are managed by the Perses API only if the authorization relies on them.
+		apiV1Endpoints = append(apiV1Endpoints,
+			globalpolicy.NewEndpoint(serviceManager.GetGlobalPolicy(), serviceManager.GetAuthorization(), readonly, caseSensitive),
+		)
+	}
+
 	authEndpoint, err := authendpoint.New(
 		persistenceManager.GetUser(),
 		serviceManager.GetJWT(),
@@ -108,15 +134,20 @@ func NewPersesAPI(dependencyManager dependency.Manager, cfg config.Config) echoU
 		validateendpoint.New(serviceManager.GetSchema(), serviceManager.GetDashboard()),
 		authEndpoint,
 	}
+	var managedMiddleware echo.MiddlewareFunc
+	if cfg.Provisioning.Lock {
+		managedMiddleware = middleware.CheckManagedResource(persistenceManager.GetPersesDAO(), serviceManager.GetAuthorization(), caseSensitive)
+	}
 	return &api{
 		apiV1Endpoints: apiV1Endpoints,
 		apiEndpoints:   apiEndpoints,
 		proxyEndpoint: proxy.New(cfg.Datasource, persistenceManager.GetDashboard(), persistenceManager.GetSecret(), persistenceManager.GetGlobalSecret(),
-			persistenceManager.GetDatasource(), persistenceManager.GetGlobalDatasource(), serviceManager.GetCrypto(), serviceManager.GetAuthorization()),
+			persistenceManager.GetDatasource(), persistenceManager.GetGlobalDatasource(), serviceManager.GetCrypto(), serviceManager.GetAuthorization(), serviceManager.GetPlugin().Backend()),
 		authorizationMiddlware: serviceManager.GetAuthorization().Middleware(func(_ echo.Context) bool {
 			return !cfg.Security.EnableAuth
 		}),
-		apiPrefix: cfg.APIPrefix,
+		managedMiddleware: managedMiddleware,
+		apiPrefix:         cfg.APIPrefix,
 	}
 }
 
@@ -157,6 +188,10 @@ func (a *api) RegisterRoute(e *echo.Echo) {
 			mdws := []echo.MiddlewareFunc{middleware.HandleAnonymous(rte.IsAnonymous)}
 			if !rte.IsAnonymous {
 				mdws = append(mdws, a.authorizationMiddlware)
+				// The managed resources are checked once the user is authenticated, as the check requires the permissions.
+				if a.managedMiddleware != nil {
+					mdws = append(mdws, a.managedMiddleware)
+				}
 			}
 			mdws = append(mdws, rte.Middlewares...)
 			rte.Register(group, mdws...)
@@ -0,0 +1,46 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dashboard
+
+import (
+	"context"
+	"time"
+
+	"github.com/perses/common/async"
+	databaseModel "github.com/perses/perses/internal/api/database/model"
+	"github.com/perses/perses/internal/api/interface/v1/view"
+)
+
+// NewUsageCleaner returns the task removing the dashboard views older than the retention.
+func NewUsageCleaner(dao view.DAO, retention time.Duration) async.SimpleTask {
+	return &usageCleaner{
+		dao:       dao,
+		retention: retention,
+	}
+}
+
+type usageCleaner struct {
+	dao       view.DAO
+	retention time.Duration
+}
+
+func (c *usageCleaner) String() string {
+	return "dashboard usage cleaner"
+}
+
+func (c *usageCleaner) Execute(_ context.Context, _ context.CancelFunc) error {
+	// The views are stored per day, so a day is removed once all of it is older than the retention.
+	before := time.Now().UTC().Add(-c.retention).Format(databaseModel.DayFormat)
+	return c.dao.DeleteBefore(before)
+}
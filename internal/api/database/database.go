@@ -99,6 +99,24 @@ func (d *dao) HealthCheck() bool {
 func (d *dao) GetLatestUpdateTime(kind []modelV1.Kind) (*string, error) {
 	return d.client.GetLatestUpdateTime(kind)
 }
+func (d *dao) AddDashboardUsage(usage *databaseModel.DashboardUsage) error {
+	return d.client.AddDashboardUsage(usage)
+}
+func (d *dao) QueryDashboardUsage(project string, from string) ([]*databaseModel.DashboardUsage, error) {
+	return d.client.QueryDashboardUsage(project, from)
+}
+func (d *dao) DeleteDashboardUsage(before string) error {
+	return d.client.DeleteDashboardUsage(before)
+}
+func (d *dao) UpsertRecentDashboard(recent *databaseModel.RecentDashboard) error {
+	return d.client.UpsertRecentDashboard(recent)
+}
+func (d *dao) QueryRecentDashboards(user string, limit int) ([]*databaseModel.RecentDashboard, error) {
+	return d.client.QueryRecentDashboards(user, limit)
+}
+func (d *dao) DeleteRecentDashboards(user string) error {
+	return d.client.DeleteRecentDashboards(user)
+}
 
 func New(conf config.Database) (databaseModel.DAO, error) {
 	var client databaseModel.DAO
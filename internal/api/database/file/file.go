@@ -21,6 +21,7 @@ import (
 	"path/filepath"
 	"reflect"
 	"strings"
+	"sync"
 
 	databaseModel "github.com/perses/perses/internal/api/database/model"
 	modelAPI "github.com/perses/perses/pkg/model/api"
@@ -44,6 +45,10 @@ type DAO struct {
 	Folder        string
 	Extension     config.FileExtension
 	CaseSensitive bool
+	// usageMutex protects the dashboard usage files, as each view is a read-modify-write of the file of the day.
+	usageMutex sync.Mutex
+	// recentMutex protects the recent dashboards files, as each view is a read-modify-write of the file of the user.
+	recentMutex sync.Mutex
 }
 
 func (d *DAO) Init() error {
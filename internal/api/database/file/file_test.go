@@ -16,6 +16,7 @@ package databasefile
 import (
 	"os"
 	"testing"
+	"time"
 
 	databaseModel "github.com/perses/perses/internal/api/database/model"
 	"github.com/perses/perses/internal/api/interface/v1/project"
@@ -110,3 +111,59 @@ func TestDAO_Delete(t *testing.T) {
 	assert.True(t, databaseModel.IsKeyNotFound(d.Get(modelV1.KindProject, projectEntity.GetMetadata(), result)))
 	removeAllFiles(t)
 }
+
+func TestDAO_DashboardUsage(t *testing.T) {
+	d := newDAO()
+	view := func(project string, day string, user string, renderTime float64) *databaseModel.DashboardUsage {
+		usage := &databaseModel.DashboardUsage{Project: project, Dashboard: "demo", User: user, Day: day, Views: 1}
+		if renderTime > 0 {
+			usage.RenderTimeSecs = renderTime
+			usage.RenderedViews = 1
+		}
+		return usage
+	}
+	assert.NoError(t, d.AddDashboardUsage(view("perses", "2026-10-17", "jane", 0)))
+	assert.NoError(t, d.AddDashboardUsage(view("perses", "2026-10-18", "jane", 1)))
+	assert.NoError(t, d.AddDashboardUsage(view("perses", "2026-10-18", "jane", 2)))
+	assert.NoError(t, d.AddDashboardUsage(view("perses", "2026-10-18", "john", 0)))
+	assert.NoError(t, d.AddDashboardUsage(view("infra", "2026-10-18", "jane", 0)))
+
+	result, err := d.QueryDashboardUsage("perses", "2026-10-18")
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []*databaseModel.DashboardUsage{
+		{Project: "perses", Dashboard: "demo", User: "jane", Day: "2026-10-18", Views: 2, RenderTimeSecs: 3, RenderedViews: 2},
+		{Project: "perses", Dashboard: "demo", User: "john", Day: "2026-10-18", Views: 1},
+	}, result)
+
+	result, err = d.QueryDashboardUsage("", "2026-10-01")
+	assert.NoError(t, err)
+	assert.Len(t, result, 4)
+
+	assert.NoError(t, d.DeleteDashboardUsage("2026-10-18"))
+	result, err = d.QueryDashboardUsage("", "2026-10-01")
+	assert.NoError(t, err)
+	assert.Len(t, result, 3)
+	removeAllFiles(t)
+}
+
+func TestDAO_RecentDashboards(t *testing.T) {
+	d := newDAO()
+	viewedAt := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	view := func(dashboard string, minutes int) *databaseModel.RecentDashboard {
+		return &databaseModel.RecentDashboard{User: "jane", Project: "perses", Dashboard: dashboard, ViewedAt: viewedAt.Add(time.Duration(minutes) * time.Minute)}
+	}
+	assert.NoError(t, d.UpsertRecentDashboard(view("demo", 0)))
+	assert.NoError(t, d.UpsertRecentDashboard(view("kafka", 1)))
+	assert.NoError(t, d.UpsertRecentDashboard(view("redis", 2)))
+	assert.NoError(t, d.UpsertRecentDashboard(view("demo", 3)))
+
+	result, err := d.QueryRecentDashboards("jane", 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []*databaseModel.RecentDashboard{view("demo", 3), view("redis", 2)}, result)
+
+	assert.NoError(t, d.DeleteRecentDashboards("jane"))
+	result, err = d.QueryRecentDashboards("jane", 2)
+	assert.NoError(t, err)
+	assert.Empty(t, result)
+	removeAllFiles(t)
+}
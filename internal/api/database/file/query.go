@@ -21,10 +21,13 @@ import (
 
 	databaseModel "github.com/perses/perses/internal/api/database/model"
 	"github.com/perses/perses/internal/api/interface/v1/dashboard"
+	"github.com/perses/perses/internal/api/interface/v1/dashboardtemplate"
 	"github.com/perses/perses/internal/api/interface/v1/datasource"
 	"github.com/perses/perses/internal/api/interface/v1/ephemeraldashboard"
 	"github.com/perses/perses/internal/api/interface/v1/folder"
+	"github.com/perses/perses/internal/api/interface/v1/globaldashboardtemplate"
 	"github.com/perses/perses/internal/api/interface/v1/globaldatasource"
+	"github.com/perses/perses/internal/api/interface/v1/globalpolicy"
 	"github.com/perses/perses/internal/api/interface/v1/globalrole"
 	"github.com/perses/perses/internal/api/interface/v1/globalrolebinding"
 	"github.com/perses/perses/internal/api/interface/v1/globalsecret"
@@ -68,6 +71,9 @@ func (d *DAO) buildQuery(query databaseModel.Query) (pathFolder string, prefix s
 	case *dashboard.Query:
 		pathFolder = d.generateProjectResourceQuery(v1.KindDashboard, qt.Project)
 		prefix = qt.NamePrefix
+	case *dashboardtemplate.Query:
+		pathFolder = d.generateProjectResourceQuery(v1.KindDashboardTemplate, qt.Project)
+		prefix = qt.NamePrefix
 	case *datasource.Query:
 		pathFolder = d.generateProjectResourceQuery(v1.KindDatasource, qt.Project)
 		prefix = qt.NamePrefix
@@ -77,9 +83,15 @@ func (d *DAO) buildQuery(query databaseModel.Query) (pathFolder string, prefix s
 	case *folder.Query:
 		pathFolder = d.generateProjectResourceQuery(v1.KindFolder, qt.Project)
 		prefix = qt.NamePrefix
+	case *globaldashboardtemplate.Query:
+		pathFolder = d.generateResourceQuery(v1.KindGlobalDashboardTemplate)
+		prefix = qt.NamePrefix
 	case *globaldatasource.Query:
 		pathFolder = d.generateResourceQuery(v1.KindGlobalDatasource)
 		prefix = qt.NamePrefix
+	case *globalpolicy.Query:
+		pathFolder = d.generateResourceQuery(v1.KindGlobalPolicy)
+		prefix = qt.NamePrefix
 	case *globalrole.Query:
 		pathFolder = d.generateResourceQuery(v1.KindGlobalRole)
 		prefix = qt.NamePrefix
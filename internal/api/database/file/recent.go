@@ -0,0 +1,102 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databasefile
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	databaseModel "github.com/perses/perses/internal/api/database/model"
+)
+
+// recentFolder contains a file per user, listing the last view of each dashboard the user viewed.
+const recentFolder = "recent"
+
+func (d *DAO) UpsertRecentDashboard(recent *databaseModel.RecentDashboard) error {
+	d.recentMutex.Lock()
+	defer d.recentMutex.Unlock()
+	filePath := d.buildRecentPath(recent.User)
+	list, err := d.readRecent(filePath)
+	if err != nil {
+		return err
+	}
+	found := false
+	for _, r := range list {
+		if r.Project == recent.Project && r.Dashboard == recent.Dashboard {
+			r.ViewedAt = recent.ViewedAt
+			found = true
+			break
+		}
+	}
+	if !found {
+		list = append(list, recent)
+	}
+	if mkdirErr := os.MkdirAll(filepath.Dir(filePath), 0750); mkdirErr != nil {
+		return mkdirErr
+	}
+	data, err := d.marshal(list)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filePath, data, 0600)
+}
+
+func (d *DAO) QueryRecentDashboards(user string, limit int) ([]*databaseModel.RecentDashboard, error) {
+	d.recentMutex.Lock()
+	defer d.recentMutex.Unlock()
+	list, err := d.readRecent(d.buildRecentPath(user))
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(list, func(i, j int) bool {
+		return list[i].ViewedAt.After(list[j].ViewedAt)
+	})
+	if len(list) > limit {
+		list = list[:limit]
+	}
+	if list == nil {
+		list = make([]*databaseModel.RecentDashboard, 0)
+	}
+	return list, nil
+}
+
+func (d *DAO) DeleteRecentDashboards(user string) error {
+	d.recentMutex.Lock()
+	defer d.recentMutex.Unlock()
+	if err := os.Remove(d.buildRecentPath(user)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+func (d *DAO) buildRecentPath(user string) string {
+	return filepath.Join(d.Folder, recentFolder, fmt.Sprintf("%s.%s", user, d.Extension))
+}
+
+func (d *DAO) readRecent(filePath string) ([]*databaseModel.RecentDashboard, error) {
+	data, err := os.ReadFile(filePath) //nolint: gosec
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var list []*databaseModel.RecentDashboard
+	if unmarshalErr := d.unmarshal(data, &list); unmarshalErr != nil {
+		return nil, fmt.Errorf("unable to read the recent dashboards file %s: %w", filePath, unmarshalErr)
+	}
+	return list, nil
+}
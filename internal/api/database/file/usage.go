@@ -0,0 +1,144 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databasefile
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	databaseModel "github.com/perses/perses/internal/api/database/model"
+)
+
+// usageFolder contains a folder per project, with a file per day listing the usage of the dashboards by user.
+const usageFolder = "usage"
+
+func (d *DAO) AddDashboardUsage(usage *databaseModel.DashboardUsage) error {
+	d.usageMutex.Lock()
+	defer d.usageMutex.Unlock()
+	filePath := d.buildUsagePath(usage.Project, usage.Day)
+	list, err := d.readUsage(filePath)
+	if err != nil {
+		return err
+	}
+	found := false
+	for _, u := range list {
+		if u.Dashboard == usage.Dashboard && u.User == usage.User {
+			u.Add(usage)
+			found = true
+			break
+		}
+	}
+	if !found {
+		list = append(list, usage)
+	}
+	if mkdirErr := os.MkdirAll(filepath.Dir(filePath), 0750); mkdirErr != nil {
+		return mkdirErr
+	}
+	data, err := d.marshal(list)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filePath, data, 0600)
+}
+
+func (d *DAO) QueryDashboardUsage(project string, from string) ([]*databaseModel.DashboardUsage, error) {
+	d.usageMutex.Lock()
+	defer d.usageMutex.Unlock()
+	result := make([]*databaseModel.DashboardUsage, 0)
+	err := d.visitUsage(project, func(filePath string, day string) error {
+		if day < from {
+			return nil
+		}
+		list, readErr := d.readUsage(filePath)
+		if readErr != nil {
+			return readErr
+		}
+		result = append(result, list...)
+		return nil
+	})
+	return result, err
+}
+
+func (d *DAO) DeleteDashboardUsage(before string) error {
+	d.usageMutex.Lock()
+	defer d.usageMutex.Unlock()
+	return d.visitUsage("", func(filePath string, day string) error {
+		if day >= before {
+			return nil
+		}
+		return os.Remove(filePath)
+	})
+}
+
+func (d *DAO) buildUsagePath(project string, day string) string {
+	return filepath.Join(d.Folder, usageFolder, project, fmt.Sprintf("%s.%s", day, d.Extension))
+}
+
+func (d *DAO) readUsage(filePath string) ([]*databaseModel.DashboardUsage, error) {
+	data, err := os.ReadFile(filePath) //nolint: gosec
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var list []*databaseModel.DashboardUsage
+	if unmarshalErr := d.unmarshal(data, &list); unmarshalErr != nil {
+		return nil, fmt.Errorf("unable to read the dashboard usage file %s: %w", filePath, unmarshalErr)
+	}
+	return list, nil
+}
+
+// visitUsage calls the function for every usage file of the project, or of every project when it is empty.
+func (d *DAO) visitUsage(project string, fn func(filePath string, day string) error) error {
+	root := filepath.Join(d.Folder, usageFolder)
+	var projectFolders []string
+	if len(project) > 0 {
+		projectFolders = []string{filepath.Join(root, project)}
+	} else {
+		entries, err := os.ReadDir(root)
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		for _, entry := range entries {
+			if entry.IsDir() {
+				projectFolders = append(projectFolders, filepath.Join(root, entry.Name()))
+			}
+		}
+	}
+	extension := fmt.Sprintf(".%s", d.Extension)
+	for _, folder := range projectFolders {
+		entries, err := os.ReadDir(folder)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return err
+		}
+		for _, entry := range entries {
+			if entry.IsDir() || filepath.Ext(entry.Name()) != extension {
+				continue
+			}
+			if fnErr := fn(filepath.Join(folder, entry.Name()), strings.TrimSuffix(entry.Name(), extension)); fnErr != nil {
+				return fnErr
+			}
+		}
+	}
+	return nil
+}
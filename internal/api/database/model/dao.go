@@ -53,4 +53,17 @@ type DAO interface {
 	DeleteByQuery(query Query) error
 	HealthCheck() bool
 	GetLatestUpdateTime(kind []modelV1.Kind) (*string, error)
+	// AddDashboardUsage adds the usage to the one already stored for the same project, dashboard, user and day.
+	AddDashboardUsage(usage *DashboardUsage) error
+	// QueryDashboardUsage returns the usage stored from the given day, included.
+	// When the project is empty, the usage of every project is returned.
+	QueryDashboardUsage(project string, from string) ([]*DashboardUsage, error)
+	// DeleteDashboardUsage removes the usage stored before the given day, excluded.
+	DeleteDashboardUsage(before string) error
+	// UpsertRecentDashboard stores the view of the dashboard by the user, replacing the previous view of the same dashboard.
+	UpsertRecentDashboard(recent *RecentDashboard) error
+	// QueryRecentDashboards returns the dashboards the user viewed last, the most recent view first.
+	QueryRecentDashboards(user string, limit int) ([]*RecentDashboard, error)
+	// DeleteRecentDashboards removes the views of the user.
+	DeleteRecentDashboards(user string) error
 }
@@ -0,0 +1,25 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import "time"
+
+// RecentDashboard is the last view of a dashboard by a user.
+// It is stored apart from the user, so a view doesn't rewrite the user.
+type RecentDashboard struct {
+	User      string    `json:"user" yaml:"user"`
+	Project   string    `json:"project" yaml:"project"`
+	Dashboard string    `json:"dashboard" yaml:"dashboard"`
+	ViewedAt  time.Time `json:"viewed_at" yaml:"viewed_at"`
+}
@@ -0,0 +1,43 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import "time"
+
+// DayFormat is the layout of DashboardUsage.Day.
+const DayFormat = time.DateOnly
+
+// DashboardUsage is the usage of a dashboard by a user during a day.
+type DashboardUsage struct {
+	Project   string `json:"project" yaml:"project"`
+	Dashboard string `json:"dashboard" yaml:"dashboard"`
+	// User is empty when the authentication is disabled.
+	User string `json:"user" yaml:"user"`
+	// Day is the day in UTC, formatted with DayFormat. As the format is sortable, days can be compared as strings.
+	Day          string `json:"day" yaml:"day"`
+	Views        int64  `json:"views" yaml:"views"`
+	RenderErrors int64  `json:"render_errors" yaml:"render_errors"`
+	// RenderTimeSecs is the sum of the render times reported by the RenderedViews views.
+	// Not every view reports a render time, so it cannot be averaged on Views.
+	RenderTimeSecs float64 `json:"render_time" yaml:"render_time"`
+	RenderedViews  int64   `json:"rendered_views" yaml:"rendered_views"`
+}
+
+// Add is adding the counters of the other usage to this one.
+func (u *DashboardUsage) Add(other *DashboardUsage) {
+	u.Views += other.Views
+	u.RenderErrors += other.RenderErrors
+	u.RenderTimeSecs += other.RenderTimeSecs
+	u.RenderedViews += other.RenderedViews
+}
@@ -21,10 +21,13 @@ import (
 	"github.com/huandu/go-sqlbuilder"
 	databaseModel "github.com/perses/perses/internal/api/database/model"
 	"github.com/perses/perses/internal/api/interface/v1/dashboard"
+	"github.com/perses/perses/internal/api/interface/v1/dashboardtemplate"
 	"github.com/perses/perses/internal/api/interface/v1/datasource"
 	"github.com/perses/perses/internal/api/interface/v1/ephemeraldashboard"
 	"github.com/perses/perses/internal/api/interface/v1/folder"
+	"github.com/perses/perses/internal/api/interface/v1/globaldashboardtemplate"
 	"github.com/perses/perses/internal/api/interface/v1/globaldatasource"
+	"github.com/perses/perses/internal/api/interface/v1/globalpolicy"
 	"github.com/perses/perses/internal/api/interface/v1/globalrole"
 	"github.com/perses/perses/internal/api/interface/v1/globalrolebinding"
 	"github.com/perses/perses/internal/api/interface/v1/globalsecret"
@@ -126,14 +129,20 @@ func (d *DAO) buildQuery(query databaseModel.Query) (string, []any, error) {
 	switch qt := query.(type) {
 	case *dashboard.Query:
 		sqlQuery, args = d.generateSelectQuery(d.generateCompleteTableName(tableDashboard), qt.Project, qt.NamePrefix)
+	case *dashboardtemplate.Query:
+		sqlQuery, args = d.generateSelectQuery(d.generateCompleteTableName(tableDashboardTemplate), qt.Project, qt.NamePrefix)
 	case *datasource.Query:
 		sqlQuery, args = d.generateSelectQuery(d.generateCompleteTableName(tableDatasource), qt.Project, qt.NamePrefix)
 	case *ephemeraldashboard.Query:
 		sqlQuery, args = d.generateSelectQuery(d.generateCompleteTableName(tableEphemeralDashboard), qt.Project, qt.NamePrefix)
 	case *folder.Query:
 		sqlQuery, args = d.generateSelectQuery(d.generateCompleteTableName(tableFolder), qt.Project, qt.NamePrefix)
+	case *globaldashboardtemplate.Query:
+		sqlQuery, args = d.generateSelectQuery(d.generateCompleteTableName(tableGlobalDashboardTemplate), "", qt.NamePrefix)
 	case *globaldatasource.Query:
 		sqlQuery, args = d.generateSelectQuery(d.generateCompleteTableName(tableGlobalDatasource), "", qt.NamePrefix)
+	case *globalpolicy.Query:
+		sqlQuery, args = d.generateSelectQuery(d.generateCompleteTableName(tableGlobalPolicy), "", qt.NamePrefix)
 	case *globalrole.Query:
 		sqlQuery, args = d.generateSelectQuery(d.generateCompleteTableName(tableGlobalRole), "", qt.NamePrefix)
 	case *globalrolebinding.Query:
@@ -185,14 +194,20 @@ func (d *DAO) buildDeleteQuery(query databaseModel.Query) (string, []any, error)
 	switch qt := query.(type) {
 	case *dashboard.Query:
 		sqlQuery, args = d.generateDeleteQuery(d.generateCompleteTableName(tableDashboard), qt.Project, qt.NamePrefix)
+	case *dashboardtemplate.Query:
+		sqlQuery, args = d.generateDeleteQuery(d.generateCompleteTableName(tableDashboardTemplate), qt.Project, qt.NamePrefix)
 	case *datasource.Query:
 		sqlQuery, args = d.generateDeleteQuery(d.generateCompleteTableName(tableDatasource), qt.Project, qt.NamePrefix)
 	case *ephemeraldashboard.Query:
 		sqlQuery, args = d.generateDeleteQuery(d.generateCompleteTableName(tableEphemeralDashboard), qt.Project, qt.NamePrefix)
 	case *folder.Query:
 		sqlQuery, args = d.generateDeleteQuery(d.generateCompleteTableName(tableFolder), qt.Project, qt.NamePrefix)
+	case *globaldashboardtemplate.Query:
+		sqlQuery, args = d.generateDeleteQuery(d.generateCompleteTableName(tableGlobalDashboardTemplate), "", qt.NamePrefix)
 	case *globaldatasource.Query:
 		sqlQuery, args = d.generateDeleteQuery(d.generateCompleteTableName(tableGlobalDatasource), "", qt.NamePrefix)
+	case *globalpolicy.Query:
+		sqlQuery, args = d.generateDeleteQuery(d.generateCompleteTableName(tableGlobalPolicy), "", qt.NamePrefix)
 	case *globalrole.Query:
 		sqlQuery, args = d.generateDeleteQuery(d.generateCompleteTableName(tableGlobalRole), "", qt.NamePrefix)
 	case *globalrolebinding.Query:
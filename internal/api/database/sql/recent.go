@@ -0,0 +1,98 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databasesql
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/huandu/go-sqlbuilder"
+	databaseModel "github.com/perses/perses/internal/api/database/model"
+)
+
+// colViewedAt is the time of the view in milliseconds since the epoch.
+const colViewedAt = "viewed_at"
+
+func (d *DAO) createRecentDashboardTable() string {
+	return sqlbuilder.CreateTable(d.generateCompleteTableName(tableRecentDashboard)).IfNotExists().
+		Define(colUser, "VARCHAR(128)", "NOT NULL").
+		Define(colProject, "VARCHAR(128)", "NOT NULL").
+		Define(colDashboard, "VARCHAR(128)", "NOT NULL").
+		Define(colViewedAt, "BIGINT", "NOT NULL").
+		Define("PRIMARY KEY", fmt.Sprintf("(%s, %s, %s)", colUser, colProject, colDashboard)).
+		String()
+}
+
+func generateRecentDashboardInsertQuery(tableName string, recent *databaseModel.RecentDashboard) (string, []any) {
+	ib := sqlbuilder.NewInsertBuilder().
+		InsertInto(tableName).
+		Cols(colUser, colProject, colDashboard, colViewedAt).
+		Values(recent.User, recent.Project, recent.Dashboard, recent.ViewedAt.UnixMilli())
+	// A single row is kept per user and dashboard, so the concurrent views of a user don't override each other.
+	ib.SQL(fmt.Sprintf("ON DUPLICATE KEY UPDATE %[1]s = VALUES(%[1]s)", colViewedAt))
+	return ib.Build()
+}
+
+func generateRecentDashboardSelectQuery(tableName string, user string, limit int) (string, []any) {
+	sb := sqlbuilder.NewSelectBuilder().
+		Select(colUser, colProject, colDashboard, colViewedAt).
+		From(tableName)
+	sb.Where(sb.Equal(colUser, user))
+	sb.OrderBy(colViewedAt).Desc().Limit(limit)
+	return sb.Build()
+}
+
+func generateRecentDashboardDeleteQuery(tableName string, user string) (string, []any) {
+	deleteBuilder := sqlbuilder.NewDeleteBuilder().DeleteFrom(tableName)
+	deleteBuilder.Where(deleteBuilder.Equal(colUser, user))
+	return deleteBuilder.Build()
+}
+
+func (d *DAO) UpsertRecentDashboard(recent *databaseModel.RecentDashboard) error {
+	sqlQuery, args := generateRecentDashboardInsertQuery(d.generateCompleteTableName(tableRecentDashboard), recent)
+	query, err := d.DB.Query(sqlQuery, args...)
+	if err != nil {
+		return err
+	}
+	return query.Close()
+}
+
+func (d *DAO) QueryRecentDashboards(user string, limit int) ([]*databaseModel.RecentDashboard, error) {
+	sqlQuery, args := generateRecentDashboardSelectQuery(d.generateCompleteTableName(tableRecentDashboard), user, limit)
+	rows, err := d.DB.Query(sqlQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close() //nolint:errcheck
+	result := make([]*databaseModel.RecentDashboard, 0)
+	for rows.Next() {
+		recent := &databaseModel.RecentDashboard{}
+		var viewedAt int64
+		if scanErr := rows.Scan(&recent.User, &recent.Project, &recent.Dashboard, &viewedAt); scanErr != nil {
+			return nil, scanErr
+		}
+		recent.ViewedAt = time.UnixMilli(viewedAt).UTC()
+		result = append(result, recent)
+	}
+	return result, rows.Err()
+}
+
+func (d *DAO) DeleteRecentDashboards(user string) error {
+	sqlQuery, args := generateRecentDashboardDeleteQuery(d.generateCompleteTableName(tableRecentDashboard), user)
+	query, err := d.DB.Query(sqlQuery, args...)
+	if err != nil {
+		return err
+	}
+	return query.Close()
+}
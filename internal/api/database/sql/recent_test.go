@@ -0,0 +1,40 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databasesql
+
+import (
+	"testing"
+	"time"
+
+	databaseModel "github.com/perses/perses/internal/api/database/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateRecentDashboardInsertQuery(t *testing.T) {
+	recent := &databaseModel.RecentDashboard{
+		User:      "jane",
+		Project:   "perses",
+		Dashboard: "demo",
+		ViewedAt:  time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC),
+	}
+	sqlQuery, args := generateRecentDashboardInsertQuery("perses.recentdashboard", recent)
+	assert.Equal(t, "INSERT INTO perses.recentdashboard (username, project, dashboard, viewed_at) VALUES (?, ?, ?, ?) ON DUPLICATE KEY UPDATE viewed_at = VALUES(viewed_at)", sqlQuery)
+	assert.Equal(t, []any{"jane", "perses", "demo", int64(1792324800000)}, args)
+}
+
+func TestGenerateRecentDashboardSelectQuery(t *testing.T) {
+	sqlQuery, args := generateRecentDashboardSelectQuery("perses.recentdashboard", "jane", 20)
+	assert.Equal(t, "SELECT username, project, dashboard, viewed_at FROM perses.recentdashboard WHERE username = ? ORDER BY viewed_at DESC LIMIT ?", sqlQuery)
+	assert.Equal(t, []any{"jane", 20}, args)
+}
@@ -29,21 +29,26 @@ import (
 )
 
 const (
-	tableDashboard          = "dashboard"
-	tableDatasource         = "datasource"
-	tableEphemeralDashboard = "ephemeraldashboard"
-	tableFolder             = "folder"
-	tableGlobalDatasource   = "globaldatasource"
-	tableGlobalRole         = "globalrole"
-	tableGlobalRoleBinding  = "globalrolebinding"
-	tableGlobalSecret       = "globalsecret"
-	tableGlobalVariable     = "globalvariable"
-	tableProject            = "project"
-	tableRole               = "role"
-	tableRoleBinding        = "rolebinding"
-	tableSecret             = "secret"
-	tableUser               = "user"
-	tableVariable           = "variable"
+	tableDashboard               = "dashboard"
+	tableDashboardTemplate       = "dashboardtemplate"
+	tableDashboardUsage          = "dashboardusage"
+	tableDatasource              = "datasource"
+	tableEphemeralDashboard      = "ephemeraldashboard"
+	tableFolder                  = "folder"
+	tableGlobalDashboardTemplate = "globaldashboardtemplate"
+	tableGlobalDatasource        = "globaldatasource"
+	tableGlobalPolicy            = "globalpolicy"
+	tableGlobalRole              = "globalrole"
+	tableGlobalRoleBinding       = "globalrolebinding"
+	tableGlobalSecret            = "globalsecret"
+	tableGlobalVariable          = "globalvariable"
+	tableProject                 = "project"
+	tableRecentDashboard         = "recentdashboard"
+	tableRole                    = "role"
+	tableRoleBinding             = "rolebinding"
+	tableSecret                  = "secret"
+	tableUser                    = "user"
+	tableVariable                = "variable"
 
 	colID      = "id"
 	colDoc     = "doc"
@@ -55,14 +60,20 @@ func getTableName(kind modelV1.Kind) (string, error) {
 	switch kind {
 	case modelV1.KindDashboard:
 		return tableDashboard, nil
+	case modelV1.KindDashboardTemplate:
+		return tableDashboardTemplate, nil
 	case modelV1.KindDatasource:
 		return tableDatasource, nil
 	case modelV1.KindEphemeralDashboard:
 		return tableEphemeralDashboard, nil
 	case modelV1.KindFolder:
 		return tableFolder, nil
+	case modelV1.KindGlobalDashboardTemplate:
+		return tableGlobalDashboardTemplate, nil
 	case modelV1.KindGlobalDatasource:
 		return tableGlobalDatasource, nil
+	case modelV1.KindGlobalPolicy:
+		return tableGlobalPolicy, nil
 	case modelV1.KindGlobalRole:
 		return tableGlobalRole, nil
 	case modelV1.KindGlobalRoleBinding:
@@ -107,7 +118,9 @@ type DAO struct {
 
 func (d *DAO) Init() error {
 	tables := []string{
+		d.createResourceTable(tableGlobalDashboardTemplate),
 		d.createResourceTable(tableGlobalDatasource),
+		d.createResourceTable(tableGlobalPolicy),
 		d.createResourceTable(tableGlobalRole),
 		d.createResourceTable(tableGlobalRoleBinding),
 		d.createResourceTable(tableGlobalSecret),
@@ -116,6 +129,7 @@ func (d *DAO) Init() error {
 		d.createResourceTable(tableUser),
 
 		d.createProjectResourceTable(tableDashboard),
+		d.createProjectResourceTable(tableDashboardTemplate),
 		d.createProjectResourceTable(tableDatasource),
 		d.createProjectResourceTable(tableEphemeralDashboard),
 		d.createProjectResourceTable(tableFolder),
@@ -123,6 +137,9 @@ func (d *DAO) Init() error {
 		d.createProjectResourceTable(tableRoleBinding),
 		d.createProjectResourceTable(tableSecret),
 		d.createProjectResourceTable(tableVariable),
+
+		d.createDashboardUsageTable(),
+		d.createRecentDashboardTable(),
 	}
 
 	for _, table := range tables {
@@ -0,0 +1,109 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databasesql
+
+import (
+	"fmt"
+
+	"github.com/huandu/go-sqlbuilder"
+	databaseModel "github.com/perses/perses/internal/api/database/model"
+)
+
+const (
+	colDashboard     = "dashboard"
+	colUser          = "username"
+	colDay           = "day"
+	colViews         = "views"
+	colRenderErrors  = "render_errors"
+	colRenderTime    = "render_time"
+	colRenderedViews = "rendered_views"
+)
+
+func (d *DAO) createDashboardUsageTable() string {
+	return sqlbuilder.CreateTable(d.generateCompleteTableName(tableDashboardUsage)).IfNotExists().
+		Define(colProject, "VARCHAR(128)", "NOT NULL").
+		Define(colDashboard, "VARCHAR(128)", "NOT NULL").
+		Define(colUser, "VARCHAR(128)", "NOT NULL").
+		Define(colDay, "CHAR(10)", "NOT NULL").
+		Define(colViews, "BIGINT", "NOT NULL").
+		Define(colRenderErrors, "BIGINT", "NOT NULL").
+		Define(colRenderTime, "DOUBLE", "NOT NULL").
+		Define(colRenderedViews, "BIGINT", "NOT NULL").
+		Define("PRIMARY KEY", fmt.Sprintf("(%s, %s, %s, %s)", colProject, colDashboard, colUser, colDay)).
+		String()
+}
+
+func generateDashboardUsageInsertQuery(tableName string, usage *databaseModel.DashboardUsage) (string, []any) {
+	ib := sqlbuilder.NewInsertBuilder().
+		InsertInto(tableName).
+		Cols(colProject, colDashboard, colUser, colDay, colViews, colRenderErrors, colRenderTime, colRenderedViews).
+		Values(usage.Project, usage.Dashboard, usage.User, usage.Day, usage.Views, usage.RenderErrors, usage.RenderTimeSecs, usage.RenderedViews)
+	// The counters are summed with the ones already stored for the same project, dashboard, user and day.
+	ib.SQL(fmt.Sprintf("ON DUPLICATE KEY UPDATE %[1]s = %[1]s + VALUES(%[1]s), %[2]s = %[2]s + VALUES(%[2]s), %[3]s = %[3]s + VALUES(%[3]s), %[4]s = %[4]s + VALUES(%[4]s)",
+		colViews, colRenderErrors, colRenderTime, colRenderedViews))
+	return ib.Build()
+}
+
+func generateDashboardUsageSelectQuery(tableName string, project string, from string) (string, []any) {
+	sb := sqlbuilder.NewSelectBuilder().
+		Select(colProject, colDashboard, colUser, colDay, colViews, colRenderErrors, colRenderTime, colRenderedViews).
+		From(tableName)
+	sb.Where(sb.GreaterEqualThan(colDay, from))
+	if len(project) > 0 {
+		sb.Where(sb.Equal(colProject, project))
+	}
+	return sb.Build()
+}
+
+func generateDashboardUsageDeleteQuery(tableName string, before string) (string, []any) {
+	deleteBuilder := sqlbuilder.NewDeleteBuilder().DeleteFrom(tableName)
+	deleteBuilder.Where(deleteBuilder.LessThan(colDay, before))
+	return deleteBuilder.Build()
+}
+
+func (d *DAO) AddDashboardUsage(usage *databaseModel.DashboardUsage) error {
+	sqlQuery, args := generateDashboardUsageInsertQuery(d.generateCompleteTableName(tableDashboardUsage), usage)
+	query, err := d.DB.Query(sqlQuery, args...)
+	if err != nil {
+		return err
+	}
+	return query.Close()
+}
+
+func (d *DAO) QueryDashboardUsage(project string, from string) ([]*databaseModel.DashboardUsage, error) {
+	sqlQuery, args := generateDashboardUsageSelectQuery(d.generateCompleteTableName(tableDashboardUsage), project, from)
+	rows, err := d.DB.Query(sqlQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close() //nolint:errcheck
+	result := make([]*databaseModel.DashboardUsage, 0)
+	for rows.Next() {
+		usage := &databaseModel.DashboardUsage{}
+		if scanErr := rows.Scan(&usage.Project, &usage.Dashboard, &usage.User, &usage.Day, &usage.Views, &usage.RenderErrors, &usage.RenderTimeSecs, &usage.RenderedViews); scanErr != nil {
+			return nil, scanErr
+		}
+		result = append(result, usage)
+	}
+	return result, rows.Err()
+}
+
+func (d *DAO) DeleteDashboardUsage(before string) error {
+	sqlQuery, args := generateDashboardUsageDeleteQuery(d.generateCompleteTableName(tableDashboardUsage), before)
+	query, err := d.DB.Query(sqlQuery, args...)
+	if err != nil {
+		return err
+	}
+	return query.Close()
+}
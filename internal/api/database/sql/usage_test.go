@@ -0,0 +1,67 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databasesql
+
+import (
+	"testing"
+
+	databaseModel "github.com/perses/perses/internal/api/database/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateDashboardUsageInsertQuery(t *testing.T) {
+	usage := &databaseModel.DashboardUsage{
+		Project:        "perses",
+		Dashboard:      "demo",
+		User:           "jane",
+		Day:            "2026-10-18",
+		Views:          1,
+		RenderErrors:   2,
+		RenderTimeSecs: 1.5,
+		RenderedViews:  1,
+	}
+	sqlQuery, args := generateDashboardUsageInsertQuery("perses.dashboardusage", usage)
+	assert.Equal(t, "INSERT INTO perses.dashboardusage (project, dashboard, username, day, views, render_errors, render_time, rendered_views) VALUES (?, ?, ?, ?, ?, ?, ?, ?) "+
+		"ON DUPLICATE KEY UPDATE views = views + VALUES(views), render_errors = render_errors + VALUES(render_errors), render_time = render_time + VALUES(render_time), rendered_views = rendered_views + VALUES(rendered_views)", sqlQuery)
+	assert.Equal(t, []any{"perses", "demo", "jane", "2026-10-18", int64(1), int64(2), 1.5, int64(1)}, args)
+}
+
+func TestGenerateDashboardUsageSelectQuery(t *testing.T) {
+	testSuite := []struct {
+		title    string
+		project  string
+		sqlQuery string
+		sqlArgs  []any
+	}{
+		{
+			title:    "every project",
+			project:  "",
+			sqlQuery: "SELECT project, dashboard, username, day, views, render_errors, render_time, rendered_views FROM perses.dashboardusage WHERE day >= ?",
+			sqlArgs:  []any{"2026-10-01"},
+		},
+		{
+			title:    "a single project",
+			project:  "perses",
+			sqlQuery: "SELECT project, dashboard, username, day, views, render_errors, render_time, rendered_views FROM perses.dashboardusage WHERE day >= ? AND project = ?",
+			sqlArgs:  []any{"2026-10-01", "perses"},
+		},
+	}
+	for _, test := range testSuite {
+		t.Run(test.title, func(t *testing.T) {
+			sqlQuery, args := generateDashboardUsageSelectQuery("perses.dashboardusage", test.project, "2026-10-01")
+			assert.Equal(t, test.sqlQuery, sqlQuery)
+			assert.Equal(t, test.sqlArgs, args)
+		})
+	}
+}
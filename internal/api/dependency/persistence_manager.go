@@ -17,10 +17,13 @@ import (
 	"github.com/perses/perses/internal/api/database"
 	databaseModel "github.com/perses/perses/internal/api/database/model"
 	dashboardImpl "github.com/perses/perses/internal/api/impl/v1/dashboard"
+	dashboardTemplateImpl "github.com/perses/perses/internal/api/impl/v1/dashboardtemplate"
 	datasourceImpl "github.com/perses/perses/internal/api/impl/v1/datasource"
 	ephemeralDashboardImpl "github.com/perses/perses/internal/api/impl/v1/ephemeraldashboard"
 	folderImpl "github.com/perses/perses/internal/api/impl/v1/folder"
+	globalDashboardTemplateImpl "github.com/perses/perses/internal/api/impl/v1/globaldashboardtemplate"
 	globalDatasourceImpl "github.com/perses/perses/internal/api/impl/v1/globaldatasource"
+	globalPolicyImpl "github.com/perses/perses/internal/api/impl/v1/globalpolicy"
 	globalRoleImpl "github.com/perses/perses/internal/api/impl/v1/globalrole"
 	globalRoleBindingImpl "github.com/perses/perses/internal/api/impl/v1/globalrolebinding"
 	globalSecretImpl "github.com/perses/perses/internal/api/impl/v1/globalsecret"
@@ -32,11 +35,15 @@ import (
 	secretImpl "github.com/perses/perses/internal/api/impl/v1/secret"
 	userImpl "github.com/perses/perses/internal/api/impl/v1/user"
 	variableImpl "github.com/perses/perses/internal/api/impl/v1/variable"
+	viewImpl "github.com/perses/perses/internal/api/impl/v1/view"
 	"github.com/perses/perses/internal/api/interface/v1/dashboard"
+	"github.com/perses/perses/internal/api/interface/v1/dashboardtemplate"
 	"github.com/perses/perses/internal/api/interface/v1/datasource"
 	"github.com/perses/perses/internal/api/interface/v1/ephemeraldashboard"
 	"github.com/perses/perses/internal/api/interface/v1/folder"
+	"github.com/perses/perses/internal/api/interface/v1/globaldashboardtemplate"
 	"github.com/perses/perses/internal/api/interface/v1/globaldatasource"
+	"github.com/perses/perses/internal/api/interface/v1/globalpolicy"
 	"github.com/perses/perses/internal/api/interface/v1/globalrole"
 	"github.com/perses/perses/internal/api/interface/v1/globalrolebinding"
 	"github.com/perses/perses/internal/api/interface/v1/globalsecret"
@@ -48,15 +55,19 @@ import (
 	"github.com/perses/perses/internal/api/interface/v1/secret"
 	"github.com/perses/perses/internal/api/interface/v1/user"
 	"github.com/perses/perses/internal/api/interface/v1/variable"
+	"github.com/perses/perses/internal/api/interface/v1/view"
 	"github.com/perses/perses/pkg/model/api/config"
 )
 
 type PersistenceManager interface {
 	GetDashboard() dashboard.DAO
+	GetDashboardTemplate() dashboardtemplate.DAO
 	GetDatasource() datasource.DAO
 	GetEphemeralDashboard() ephemeraldashboard.DAO
 	GetFolder() folder.DAO
+	GetGlobalDashboardTemplate() globaldashboardtemplate.DAO
 	GetGlobalDatasource() globaldatasource.DAO
+	GetGlobalPolicy() globalpolicy.DAO
 	GetGlobalRole() globalrole.DAO
 	GetGlobalRoleBinding() globalrolebinding.DAO
 	GetGlobalSecret() globalsecret.DAO
@@ -69,27 +80,32 @@ type PersistenceManager interface {
 	GetSecret() secret.DAO
 	GetUser() user.DAO
 	GetVariable() variable.DAO
+	GetView() view.DAO
 }
 
 type persistence struct {
 	PersistenceManager
-	dashboard          dashboard.DAO
-	datasource         datasource.DAO
-	ephemeralDashboard ephemeraldashboard.DAO
-	folder             folder.DAO
-	globalDatasource   globaldatasource.DAO
-	globalRole         globalrole.DAO
-	globalRoleBinding  globalrolebinding.DAO
-	globalSecret       globalsecret.DAO
-	globalVariable     globalvariable.DAO
-	health             health.DAO
-	perses             databaseModel.DAO
-	project            project.DAO
-	role               role.DAO
-	roleBinding        rolebinding.DAO
-	secret             secret.DAO
-	user               user.DAO
-	variable           variable.DAO
+	dashboard               dashboard.DAO
+	dashboardTemplate       dashboardtemplate.DAO
+	datasource              datasource.DAO
+	ephemeralDashboard      ephemeraldashboard.DAO
+	folder                  folder.DAO
+	globalDashboardTemplate globaldashboardtemplate.DAO
+	globalDatasource        globaldatasource.DAO
+	globalPolicy            globalpolicy.DAO
+	globalRole              globalrole.DAO
+	globalRoleBinding       globalrolebinding.DAO
+	globalSecret            globalsecret.DAO
+	globalVariable          globalvariable.DAO
+	health                  health.DAO
+	perses                  databaseModel.DAO
+	project                 project.DAO
+	role                    role.DAO
+	roleBinding             rolebinding.DAO
+	secret                  secret.DAO
+	user                    user.DAO
+	variable                variable.DAO
+	view                    view.DAO
 }
 
 func newPersistenceManager(conf config.Database) (PersistenceManager, error) {
@@ -98,10 +114,13 @@ func newPersistenceManager(conf config.Database) (PersistenceManager, error) {
 		return nil, err
 	}
 	dashboardDAO := dashboardImpl.NewDAO(persesDAO)
+	dashboardTemplateDAO := dashboardTemplateImpl.NewDAO(persesDAO)
 	datasourceDAO := datasourceImpl.NewDAO(persesDAO)
 	ephemeralDashboardDAO := ephemeralDashboardImpl.NewDAO(persesDAO)
 	folderDAO := folderImpl.NewDAO(persesDAO)
+	globalDashboardTemplateDAO := globalDashboardTemplateImpl.NewDAO(persesDAO)
 	globalDatatasourceDAO := globalDatasourceImpl.NewDAO(persesDAO)
+	globalPolicyDAO := globalPolicyImpl.NewDAO(persesDAO)
 	globalRoleDAO := globalRoleImpl.NewDAO(persesDAO)
 	globalRoleBindingDAO := globalRoleBindingImpl.NewDAO(persesDAO)
 	globalSecretDAO := globalSecretImpl.NewDAO(persesDAO)
@@ -113,24 +132,29 @@ func newPersistenceManager(conf config.Database) (PersistenceManager, error) {
 	secretDAO := secretImpl.NewDAO(persesDAO)
 	userDAO := userImpl.NewDAO(persesDAO)
 	variableDAO := variableImpl.NewDAO(persesDAO)
+	viewDAO := viewImpl.NewDAO(persesDAO)
 	return &persistence{
-		dashboard:          dashboardDAO,
-		datasource:         datasourceDAO,
-		ephemeralDashboard: ephemeralDashboardDAO,
-		folder:             folderDAO,
-		globalDatasource:   globalDatatasourceDAO,
-		globalRole:         globalRoleDAO,
-		globalRoleBinding:  globalRoleBindingDAO,
-		globalSecret:       globalSecretDAO,
-		globalVariable:     globalVariableDAO,
-		health:             healthDAO,
-		perses:             persesDAO,
-		project:            projectDAO,
-		role:               roleDAO,
-		roleBinding:        roleBindingDAO,
-		secret:             secretDAO,
-		user:               userDAO,
-		variable:           variableDAO,
+		dashboard:               dashboardDAO,
+		dashboardTemplate:       dashboardTemplateDAO,
+		datasource:              datasourceDAO,
+		ephemeralDashboard:      ephemeralDashboardDAO,
+		folder:                  folderDAO,
+		globalDashboardTemplate: globalDashboardTemplateDAO,
+		globalDatasource:        globalDatatasourceDAO,
+		globalPolicy:            globalPolicyDAO,
+		globalRole:              globalRoleDAO,
+		globalRoleBinding:       globalRoleBindingDAO,
+		globalSecret:            globalSecretDAO,
+		globalVariable:          globalVariableDAO,
+		health:                  healthDAO,
+		perses:                  persesDAO,
+		project:                 projectDAO,
+		role:                    roleDAO,
+		roleBinding:             roleBindingDAO,
+		secret:                  secretDAO,
+		user:                    userDAO,
+		variable:                variableDAO,
+		view:                    viewDAO,
 	}, nil
 }
 
@@ -138,6 +162,10 @@ func (p *persistence) GetDashboard() dashboard.DAO {
 	return p.dashboard
 }
 
+func (p *persistence) GetDashboardTemplate() dashboardtemplate.DAO {
+	return p.dashboardTemplate
+}
+
 func (p *persistence) GetDatasource() datasource.DAO {
 	return p.datasource
 }
@@ -150,10 +178,18 @@ func (p *persistence) GetEphemeralDashboard() ephemeraldashboard.DAO {
 	return p.ephemeralDashboard
 }
 
+func (p *persistence) GetGlobalDashboardTemplate() globaldashboardtemplate.DAO {
+	return p.globalDashboardTemplate
+}
+
 func (p *persistence) GetGlobalDatasource() globaldatasource.DAO {
 	return p.globalDatasource
 }
 
+func (p *persistence) GetGlobalPolicy() globalpolicy.DAO {
+	return p.globalPolicy
+}
+
 func (p *persistence) GetGlobalRole() globalrole.DAO {
 	return p.globalRole
 }
@@ -201,3 +237,7 @@ func (p *persistence) GetUser() user.DAO {
 func (p *persistence) GetVariable() variable.DAO {
 	return p.variable
 }
+
+func (p *persistence) GetView() view.DAO {
+	return p.view
+}
@@ -19,10 +19,13 @@ import (
 	"github.com/perses/perses/internal/api/authorization"
 	"github.com/perses/perses/internal/api/crypto"
 	dashboardImpl "github.com/perses/perses/internal/api/impl/v1/dashboard"
+	dashboardTemplateImpl "github.com/perses/perses/internal/api/impl/v1/dashboardtemplate"
 	datasourceImpl "github.com/perses/perses/internal/api/impl/v1/datasource"
 	ephemeralDashboardImpl "github.com/perses/perses/internal/api/impl/v1/ephemeraldashboard"
 	folderImpl "github.com/perses/perses/internal/api/impl/v1/folder"
+	globalDashboardTemplateImpl "github.com/perses/perses/internal/api/impl/v1/globaldashboardtemplate"
 	globalDatasourceImpl "github.com/perses/perses/internal/api/impl/v1/globaldatasource"
+	globalPolicyImpl "github.com/perses/perses/internal/api/impl/v1/globalpolicy"
 	globalRoleImpl "github.com/perses/perses/internal/api/impl/v1/globalrole"
 	globalRoleBindingImpl "github.com/perses/perses/internal/api/impl/v1/globalrolebinding"
 	globalSecretImpl "github.com/perses/perses/internal/api/impl/v1/globalsecret"
@@ -37,10 +40,13 @@ import (
 	viewImpl "github.com/perses/perses/internal/api/impl/v1/view"
 	"github.com/perses/perses/internal/api/index"
 	"github.com/perses/perses/internal/api/interface/v1/dashboard"
+	"github.com/perses/perses/internal/api/interface/v1/dashboardtemplate"
 	"github.com/perses/perses/internal/api/interface/v1/datasource"
 	"github.com/perses/perses/internal/api/interface/v1/ephemeraldashboard"
 	"github.com/perses/perses/internal/api/interface/v1/folder"
+	"github.com/perses/perses/internal/api/interface/v1/globaldashboardtemplate"
 	"github.com/perses/perses/internal/api/interface/v1/globaldatasource"
+	"github.com/perses/perses/internal/api/interface/v1/globalpolicy"
 	"github.com/perses/perses/internal/api/interface/v1/globalrole"
 	"github.com/perses/perses/internal/api/interface/v1/globalrolebinding"
 	"github.com/perses/perses/internal/api/interface/v1/globalsecret"
@@ -53,6 +59,7 @@ import (
 	"github.com/perses/perses/internal/api/interface/v1/user"
 	"github.com/perses/perses/internal/api/interface/v1/variable"
 	"github.com/perses/perses/internal/api/interface/v1/view"
+	"github.com/perses/perses/internal/api/lifecycle"
 	"github.com/perses/perses/internal/api/plugin"
 	"github.com/perses/perses/internal/api/plugin/migrate"
 	"github.com/perses/perses/internal/api/plugin/schema"
@@ -63,10 +70,13 @@ type ServiceManager interface {
 	GetAuthorization() authorization.Authorization
 	GetCrypto() crypto.Crypto
 	GetDashboard() dashboard.Service
+	GetDashboardTemplate() dashboardtemplate.Service
 	GetDatasource() datasource.Service
 	GetEphemeralDashboard() ephemeraldashboard.Service
 	GetFolder() folder.Service
+	GetGlobalDashboardTemplate() globaldashboardtemplate.Service
 	GetGlobalDatasource() globaldatasource.Service
+	GetGlobalPolicy() globalpolicy.Service
 	GetGlobalRole() globalrole.Service
 	GetGlobalRoleBinding() globalrolebinding.Service
 	GetGlobalSecret() globalsecret.Service
@@ -74,6 +84,8 @@ type ServiceManager interface {
 	GetHealth() health.Service
 	GetIndex() index.Client
 	GetJWT() crypto.JWT
+	// GetLifecycle returns nil when the dashboard lifecycle policies are disabled.
+	GetLifecycle() *lifecycle.Lifecycle
 	GetMigration() migrate.Migration
 	GetPlugin() plugin.Plugin
 	GetProject() project.Service
@@ -88,30 +100,34 @@ type ServiceManager interface {
 
 type service struct {
 	ServiceManager
-	authorization      authorization.Authorization
-	crypto             crypto.Crypto
-	dashboard          dashboard.Service
-	datasource         datasource.Service
-	ephemeralDashboard ephemeraldashboard.Service
-	folder             folder.Service
-	globalDatasource   globaldatasource.Service
-	globalRole         globalrole.Service
-	globalRoleBinding  globalrolebinding.Service
-	globalSecret       globalsecret.Service
-	globalVariable     globalvariable.Service
-	health             health.Service
-	index              index.Client
-	jwt                crypto.JWT
-	migrate            migrate.Migration
-	plugin             plugin.Plugin
-	project            project.Service
-	schema             schema.Schema
-	role               role.Service
-	roleBinding        rolebinding.Service
-	secret             secret.Service
-	user               user.Service
-	variable           variable.Service
-	view               view.Service
+	authorization           authorization.Authorization
+	crypto                  crypto.Crypto
+	dashboard               dashboard.Service
+	dashboardTemplate       dashboardtemplate.Service
+	datasource              datasource.Service
+	ephemeralDashboard      ephemeraldashboard.Service
+	folder                  folder.Service
+	globalDashboardTemplate globaldashboardtemplate.Service
+	globalDatasource        globaldatasource.Service
+	globalPolicy            globalpolicy.Service
+	globalRole              globalrole.Service
+	globalRoleBinding       globalrolebinding.Service
+	globalSecret            globalsecret.Service
+	globalVariable          globalvariable.Service
+	health                  health.Service
+	index                   index.Client
+	jwt                     crypto.JWT
+	lifecycle               *lifecycle.Lifecycle
+	migrate                 migrate.Migration
+	plugin                  plugin.Plugin
+	project                 project.Service
+	schema                  schema.Schema
+	role                    role.Service
+	roleBinding             rolebinding.Service
+	secret                  secret.Service
+	user                    user.Service
+	variable                variable.Service
+	view                    view.Service
 }
 
 func newServiceManager(dao PersistenceManager, conf config.Config) (ServiceManager, error) {
@@ -119,57 +135,75 @@ func newServiceManager(dao PersistenceManager, conf config.Config) (ServiceManag
 	if err != nil {
 		return nil, err
 	}
-	authzService, err := authorization.New(dao.GetUser(), dao.GetRole(), dao.GetRoleBinding(), dao.GetGlobalRole(), dao.GetGlobalRoleBinding(), conf)
+	authzService, err := authorization.New(dao.GetUser(), dao.GetRole(), dao.GetRoleBinding(), dao.GetGlobalRole(), dao.GetGlobalRoleBinding(), dao.GetFolder(), dao.GetProject(), dao.GetGlobalPolicy(), conf)
+	if err != nil {
+		return nil, err
+	}
+	indexService, err := index.New(conf.Search, authzService, dao.GetPersesDAO())
 	if err != nil {
 		return nil, err
 	}
-	indexService := index.New(conf.Search, authzService, dao.GetPersesDAO())
 	pluginService := plugin.New(conf.Plugin)
 	schemaService := pluginService.Schema()
 	migrateService := pluginService.Migration()
-	dashboardService := dashboardImpl.NewService(conf, dao.GetDashboard(), dao.GetGlobalVariable(), dao.GetVariable(), schemaService, indexService)
-	datasourceService := datasourceImpl.NewService(dao.GetDatasource(), schemaService, authzService)
-	ephemeralDashboardService := ephemeralDashboardImpl.NewService(dao.GetEphemeralDashboard(), dao.GetGlobalVariable(), dao.GetVariable(), schemaService)
-	folderService := folderImpl.NewService(dao.GetFolder())
-	variableService := variableImpl.NewService(dao.GetVariable(), schemaService)
-	globalDatasourceService := globalDatasourceImpl.NewService(dao.GetGlobalDatasource(), schemaService, authzService)
+	dashboardService := dashboardImpl.NewService(conf, dao.GetDashboard(), dao.GetGlobalVariable(), dao.GetVariable(), dao.GetProject(), pluginService, indexService)
+	dashboardTemplateService := dashboardTemplateImpl.NewService(dao.GetDashboardTemplate(), dashboardService, authzService)
+	datasourceService := datasourceImpl.NewService(dao.GetDatasource(), dao.GetProject(), pluginService, authzService, indexService)
+	ephemeralDashboardService := ephemeralDashboardImpl.NewService(dao.GetEphemeralDashboard(), dao.GetGlobalVariable(), dao.GetVariable(), dao.GetProject(), pluginService)
+	folderService := folderImpl.NewService(dao.GetFolder(), indexService)
+	variableService := variableImpl.NewService(dao.GetVariable(), dao.GetProject(), pluginService, indexService)
+	globalDashboardTemplateService := globalDashboardTemplateImpl.NewService(dao.GetGlobalDashboardTemplate(), dashboardService, authzService)
+	globalDatasourceService := globalDatasourceImpl.NewService(dao.GetGlobalDatasource(), schemaService, authzService, indexService)
+	globalPolicy := globalPolicyImpl.NewService(dao.GetGlobalPolicy(), authzService)
 	globalRole := globalRoleImpl.NewService(dao.GetGlobalRole(), authzService, schemaService)
 	globalRoleBinding := globalRoleBindingImpl.NewService(dao.GetGlobalRoleBinding(), dao.GetGlobalRole(), dao.GetUser(), authzService, schemaService)
 	globalSecret := globalSecretImpl.NewService(dao.GetGlobalSecret(), cryptoService)
-	globalVariableService := globalVariableImpl.NewService(dao.GetGlobalVariable(), schemaService)
+	globalVariableService := globalVariableImpl.NewService(dao.GetGlobalVariable(), schemaService, indexService)
 	healthService := healthImpl.NewService(dao.GetHealth())
-	projectService := projectImpl.NewService(dao.GetProject(), dao.GetFolder(), dao.GetDatasource(), dao.GetDashboard(), dao.GetRole(), dao.GetRoleBinding(), dao.GetSecret(), dao.GetVariable(), authzService)
+	projectService := projectImpl.NewService(dao.GetProject(), dao.GetFolder(), dao.GetDatasource(), dao.GetDashboard(), dao.GetDashboardTemplate(), dao.GetRole(), dao.GetRoleBinding(), dao.GetSecret(), dao.GetVariable(), authzService, indexService)
 	roleService := roleImpl.NewService(dao.GetRole(), authzService, schemaService)
 	roleBindingService := roleBindingImpl.NewService(dao.GetRoleBinding(), dao.GetRole(), dao.GetUser(), authzService, schemaService)
 	secretService := secretImpl.NewService(dao.GetSecret(), cryptoService)
 	userService := userImpl.NewService(dao.GetUser(), authzService)
 	viewService := viewImpl.NewMetricsViewService()
+	if conf.Dashboard.Usage != nil {
+		viewService = viewImpl.NewService(dao.GetView(), dao.GetDashboard())
+	}
+	var lifecycleService *lifecycle.Lifecycle
+	if conf.Dashboard.Lifecycle != nil {
+		// The configuration makes sure the dashboard usage is enabled along with the lifecycle policies.
+		lifecycleService = lifecycle.New(conf.Dashboard.Lifecycle, dashboardService, folderService, projectService, dao.GetView())
+	}
 
 	svc := &service{
-		authorization:      authzService,
-		crypto:             cryptoService,
-		dashboard:          dashboardService,
-		datasource:         datasourceService,
-		ephemeralDashboard: ephemeralDashboardService,
-		folder:             folderService,
-		globalDatasource:   globalDatasourceService,
-		globalRole:         globalRole,
-		globalRoleBinding:  globalRoleBinding,
-		globalSecret:       globalSecret,
-		globalVariable:     globalVariableService,
-		health:             healthService,
-		index:              indexService,
-		jwt:                jwtService,
-		migrate:            migrateService,
-		plugin:             pluginService,
-		project:            projectService,
-		role:               roleService,
-		roleBinding:        roleBindingService,
-		schema:             schemaService,
-		secret:             secretService,
-		user:               userService,
-		variable:           variableService,
-		view:               viewService,
+		authorization:           authzService,
+		crypto:                  cryptoService,
+		dashboard:               dashboardService,
+		dashboardTemplate:       dashboardTemplateService,
+		datasource:              datasourceService,
+		ephemeralDashboard:      ephemeralDashboardService,
+		folder:                  folderService,
+		globalDashboardTemplate: globalDashboardTemplateService,
+		globalDatasource:        globalDatasourceService,
+		globalPolicy:            globalPolicy,
+		globalRole:              globalRole,
+		globalRoleBinding:       globalRoleBinding,
+		globalSecret:            globalSecret,
+		globalVariable:          globalVariableService,
+		health:                  healthService,
+		index:                   indexService,
+		jwt:                     jwtService,
+		lifecycle:               lifecycleService,
+		migrate:                 migrateService,
+		plugin:                  pluginService,
+		project:                 projectService,
+		role:                    roleService,
+		roleBinding:             roleBindingService,
+		schema:                  schemaService,
+		secret:                  secretService,
+		user:                    userService,
+		variable:                variableService,
+		view:                    viewService,
 	}
 	return svc, nil
 }
@@ -186,6 +220,10 @@ func (s *service) GetDashboard() dashboard.Service {
 	return s.dashboard
 }
 
+func (s *service) GetDashboardTemplate() dashboardtemplate.Service {
+	return s.dashboardTemplate
+}
+
 func (s *service) GetDatasource() datasource.Service {
 	return s.datasource
 }
@@ -198,10 +236,18 @@ func (s *service) GetFolder() folder.Service {
 	return s.folder
 }
 
+func (s *service) GetGlobalDashboardTemplate() globaldashboardtemplate.Service {
+	return s.globalDashboardTemplate
+}
+
 func (s *service) GetGlobalDatasource() globaldatasource.Service {
 	return s.globalDatasource
 }
 
+func (s *service) GetGlobalPolicy() globalpolicy.Service {
+	return s.globalPolicy
+}
+
 func (s *service) GetGlobalRole() globalrole.Service {
 	return s.globalRole
 }
@@ -230,6 +276,10 @@ func (s *service) GetJWT() crypto.JWT {
 	return s.jwt
 }
 
+func (s *service) GetLifecycle() *lifecycle.Lifecycle {
+	return s.lifecycle
+}
+
 func (s *service) GetMigration() migrate.Migration {
 	return s.migrate
 }
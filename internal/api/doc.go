@@ -15,10 +15,13 @@ package api
 
 // this file is just there to run the command generate
 //go:generate go run generate.go -package=dashboard -plural=dashboards -kind=Dashboard -isProjectResource=true
+//go:generate go run generate.go -package=dashboardtemplate -plural=dashboardtemplates -kind=DashboardTemplate -isProjectResource=true
 //go:generate go run generate.go -package=datasource -plural=datasources -kind=Datasource -isProjectResource=true
 //go:generate go run generate.go -package=ephemeraldashboard -plural=ephemeraldashboards -kind=EphemeralDashboard -isProjectResource=true
 //go:generate go run generate.go -package=folder -plural=folders -kind=Folder -isProjectResource=true
+//go:generate go run generate.go -package=globaldashboardtemplate -plural=globaldashboardtemplates -kind=GlobalDashboardTemplate
 //go:generate go run generate.go -package=globaldatasource -plural=globaldatasources -kind=GlobalDatasource
+//go:generate go run generate.go -package=globalpolicy -plural=globalpolicies -kind=GlobalPolicy
 //go:generate go run generate.go -package=globalrole -plural=globalroles -kind=GlobalRole
 //go:generate go run generate.go -package=globalrolebinding -plural=globalrolebindings -kind=GlobalRoleBinding
 //go:generate go run generate.go -package=globalsecret -plural=globalsecrets -kind=GlobalSecret
@@ -0,0 +1,122 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build integration
+
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gavv/httpexpect/v2"
+	"github.com/perses/perses/internal/api/dependency"
+	e2eframework "github.com/perses/perses/internal/api/e2e/framework"
+	"github.com/perses/perses/internal/api/utils"
+	testUtils "github.com/perses/perses/internal/test"
+	"github.com/perses/perses/pkg/model/api"
+	modelV1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMainScenarioDashboardTemplate(t *testing.T) {
+	e2eframework.MainTestScenarioWithProject(t, utils.PathDashboardTemplate, func(projectName string, name string) (api.Entity, api.Entity) {
+		return e2eframework.NewProject(projectName), e2eframework.NewDashboardTemplate(projectName, name)
+	})
+}
+
+func TestMainScenarioGlobalDashboardTemplate(t *testing.T) {
+	e2eframework.MainTestScenario(t, utils.PathGlobalDashboardTemplate, func(name string) api.Entity {
+		return e2eframework.NewGlobalDashboardTemplate(name)
+	})
+}
+
+func TestInstantiateAndRenderDashboardTemplate(t *testing.T) {
+	e2eframework.WithServer(t, func(_ *httptest.Server, expect *httpexpect.Expect, manager dependency.Manager) []api.Entity {
+		project := e2eframework.NewProject("perses")
+		tmpl := e2eframework.NewDashboardTemplate("perses", "service-overview")
+		e2eframework.CreateAndWaitUntilEntitiesExist(t, manager.Persistence(), project, tmpl)
+		tmplPath := fmt.Sprintf("%s/%s/%s/%s/%s", utils.APIV1Prefix, utils.PathProject, "perses", utils.PathDashboardTemplate, tmpl.Metadata.Name)
+
+		dashboard := extractDashboardFromHTTPBody(expect.POST(fmt.Sprintf("%s/%s", tmplPath, utils.PathInstantiate)).
+			WithJSON(modelV1.DashboardTemplateInstantiation{Name: "checkout", Parameters: map[string]string{"service": "checkout"}}).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Raw())
+		assert.Equal(t, "checkout overview", dashboard.Spec.Display.Name)
+
+		// A required parameter is missing
+		expect.POST(fmt.Sprintf("%s/%s", tmplPath, utils.PathInstantiate)).
+			WithJSON(modelV1.DashboardTemplateInstantiation{Name: "payment"}).
+			Expect().
+			Status(http.StatusBadRequest)
+
+		// The instance is tracked with the version of the template
+		updatedTmpl := extractDashboardTemplateFromHTTPBody(expect.GET(tmplPath).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Raw())
+		assert.Equal(t, []modelV1.DashboardTemplateInstance{
+			{Project: "perses", Name: "checkout", Version: 0, Parameters: map[string]string{"service": "checkout"}},
+		}, updatedTmpl.Status.Instances)
+
+		// Once the template updated, the instance is outdated and rendered again
+		updatedTmpl.Spec.Dashboard["display"] = map[string]interface{}{"name": "[[ service ]] service"}
+		expect.PUT(tmplPath).
+			WithJSON(updatedTmpl).
+			Expect().
+			Status(http.StatusOK)
+		expect.POST(fmt.Sprintf("%s/%s", tmplPath, utils.PathRender)).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			IsEqual([]modelV1.DashboardTemplateRenderResult{
+				{Project: "perses", Name: "checkout", Status: modelV1.DashboardTemplateRenderUpdated},
+			})
+		dashboard = extractDashboardFromHTTPBody(expect.GET(fmt.Sprintf("%s/%s/%s/%s/%s", utils.APIV1Prefix, utils.PathProject, "perses", utils.PathDashboard, "checkout")).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Raw())
+		assert.Equal(t, "checkout service", dashboard.Spec.Display.Name)
+
+		return []api.Entity{project, tmpl, dashboard}
+	})
+}
+
+func TestInstantiateDashboardTemplateOverExistingDashboard(t *testing.T) {
+	e2eframework.WithServer(t, func(_ *httptest.Server, expect *httpexpect.Expect, manager dependency.Manager) []api.Entity {
+		project := e2eframework.NewProject("perses")
+		dashboard := e2eframework.NewDashboard(t, "perses", "checkout")
+		tmpl := e2eframework.NewGlobalDashboardTemplate("service-overview")
+		e2eframework.CreateAndWaitUntilEntitiesExist(t, manager.Persistence(), project, dashboard, tmpl)
+
+		expect.POST(fmt.Sprintf("%s/%s/%s/%s", utils.APIV1Prefix, utils.PathGlobalDashboardTemplate, tmpl.Metadata.Name, utils.PathInstantiate)).
+			WithJSON(modelV1.DashboardTemplateInstantiation{Project: "perses", Name: "checkout", Parameters: map[string]string{"service": "checkout"}}).
+			Expect().
+			Status(http.StatusConflict)
+
+		return []api.Entity{project, dashboard, tmpl}
+	})
+}
+
+func extractDashboardTemplateFromHTTPBody(body interface{}) *modelV1.DashboardTemplate {
+	b := testUtils.JSONMarshalStrict(body)
+	tmpl := &modelV1.DashboardTemplate{}
+	testUtils.JSONUnmarshal(b, tmpl)
+	return tmpl
+}
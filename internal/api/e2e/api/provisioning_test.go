@@ -24,13 +24,22 @@ import (
 	"time"
 
 	"github.com/gavv/httpexpect/v2"
+	"github.com/go-git/go-git/v5"
+	gitConfig "github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
 	databaseModel "github.com/perses/perses/internal/api/database/model"
 	"github.com/perses/perses/internal/api/dependency"
 	e2eframework "github.com/perses/perses/internal/api/e2e/framework"
+	apiInterface "github.com/perses/perses/internal/api/interface"
 	"github.com/perses/perses/internal/api/provisioning"
+	"github.com/perses/perses/internal/api/provisioning/managed"
 	testUtils "github.com/perses/perses/internal/test"
 	modelAPI "github.com/perses/perses/pkg/model/api"
+	"github.com/perses/perses/pkg/model/api/config"
+	"github.com/perses/perses/pkg/model/api/v1/role"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestProvisioningSkipsResourcesInUnknownProject(t *testing.T) {
@@ -47,7 +56,7 @@ func TestProvisioningSkipsResourcesInUnknownProject(t *testing.T) {
 		writeEntityToFile(t, filepath.Join(provisioningDir, "valid.json"), roleInExistingProject)
 		writeEntityToFile(t, filepath.Join(provisioningDir, "orphan.json"), roleInUnknownProject)
 
-		task, _ := provisioning.New(manager.Service(), []string{provisioningDir}, true)
+		task, _ := provisioning.New(manager.Service(), config.ProvisioningConfig{Folders: []string{provisioningDir}}, true)
 		if err := task.Execute(context.Background(), func() {}); err != nil {
 			t.Fatal(err)
 		}
@@ -77,7 +86,7 @@ func TestProvisioningCreatesProjectBeforeItsResources(t *testing.T) {
 		writeEntityToFile(t, filepath.Join(provisioningDir, "a_role.json"), roleInProject)
 		writeEntityToFile(t, filepath.Join(provisioningDir, "z_project.json"), project)
 
-		task, _ := provisioning.New(manager.Service(), []string{provisioningDir}, true)
+		task, _ := provisioning.New(manager.Service(), config.ProvisioningConfig{Folders: []string{provisioningDir}}, true)
 		if err := task.Execute(context.Background(), func() {}); err != nil {
 			t.Fatal(err)
 		}
@@ -95,7 +104,7 @@ func TestProvisioningCreatesProjectBeforeItsResources(t *testing.T) {
 func TestProvisioningWatcher(t *testing.T) {
 	e2eframework.WithServer(t, func(_ *httptest.Server, _ *httpexpect.Expect, manager dependency.Manager) []modelAPI.Entity {
 		provisioningDir := t.TempDir()
-		task, watcher := provisioning.New(manager.Service(), []string{provisioningDir}, true)
+		task, watcher := provisioning.New(manager.Service(), config.ProvisioningConfig{Folders: []string{provisioningDir}}, true)
 
 		// Initial load like core.go does at startup.
 		if err := task.Execute(context.Background(), func() {}); err != nil {
@@ -127,6 +136,125 @@ func TestProvisioningWatcher(t *testing.T) {
 	})
 }
 
+func TestProvisioningPruneAndDrift(t *testing.T) {
+	e2eframework.WithServer(t, func(_ *httptest.Server, _ *httpexpect.Expect, manager dependency.Manager) []modelAPI.Entity {
+		project := e2eframework.NewProject("fileproject")
+		roleInProject := e2eframework.NewRole("fileproject", "filerole")
+
+		provisioningDir := t.TempDir()
+		writeEntityToFile(t, filepath.Join(provisioningDir, "project.json"), project)
+		writeEntityToFile(t, filepath.Join(provisioningDir, "role.json"), roleInProject)
+
+		cfg := config.ProvisioningConfig{Folders: []string{provisioningDir}, Prune: true}
+		task, _ := provisioning.New(manager.Service(), cfg, true)
+		if err := task.Execute(context.Background(), func() {}); err != nil {
+			t.Fatal(err)
+		}
+		drift := provisioning.NewDrift(manager.Service(), cfg.Folders, true)
+
+		storedRole, err := manager.Persistence().GetRole().Get("fileproject", "filerole")
+		require.NoError(t, err)
+		assert.True(t, managed.IsManaged(&storedRole.Metadata), "provisioned resource must be marked as managed")
+		report := drift.Report()
+		assert.Empty(t, report.Errors)
+		assert.ElementsMatch(t, []provisioning.DriftResource{
+			{Kind: "Project", Name: "fileproject", Source: filepath.Join(provisioningDir, "project.json"), Status: provisioning.DriftStatusInSync},
+			{Kind: "Role", Project: "fileproject", Name: "filerole", Source: filepath.Join(provisioningDir, "role.json"), Status: provisioning.DriftStatusInSync},
+		}, report.Resources)
+
+		// A change made outside the provisioning is reported as a drift.
+		storedRole.Spec.Permissions[0].Actions = []role.Action{role.ReadAction}
+		_, err = manager.Service().GetRole().Update(nil, storedRole, apiInterface.Parameters{Project: "fileproject", Name: "filerole"})
+		require.NoError(t, err)
+		report = drift.Report()
+		assert.Contains(t, report.Resources, provisioning.DriftResource{Kind: "Role", Project: "fileproject", Name: "filerole", Source: filepath.Join(provisioningDir, "role.json"), Status: provisioning.DriftStatusDrifted})
+
+		// Once its file is removed, the role is orphaned until it is pruned.
+		require.NoError(t, os.Remove(filepath.Join(provisioningDir, "role.json")))
+		report = drift.Report()
+		assert.Contains(t, report.Resources, provisioning.DriftResource{Kind: "Role", Project: "fileproject", Name: "filerole", Status: provisioning.DriftStatusOrphaned})
+		if err := task.Execute(context.Background(), func() {}); err != nil {
+			t.Fatal(err)
+		}
+		_, err = manager.Persistence().GetRole().Get("fileproject", "filerole")
+		assert.True(t, databaseModel.IsKeyNotFound(err), "role removed from the folder must be pruned, got err: %v", err)
+
+		return []modelAPI.Entity{project}
+	})
+}
+
+func TestGitProvisioning(t *testing.T) {
+	e2eframework.WithServer(t, func(_ *httptest.Server, _ *httpexpect.Expect, manager dependency.Manager) []modelAPI.Entity {
+		// A project created by hand must never be pruned by the Git provisioning.
+		handmadeProject := e2eframework.NewProject("handmadeproject")
+		e2eframework.CreateAndWaitUntilEntityExists(t, manager.Persistence(), handmadeProject)
+
+		project := e2eframework.NewProject("gitproject")
+		roleInProject := e2eframework.NewRole("gitproject", "gitrole")
+
+		// The remote is a local bare repository, fed by commits pushed from a working copy.
+		remoteDir := t.TempDir()
+		_, err := git.PlainInitWithOptions(remoteDir, &git.PlainInitOptions{Bare: true, InitOptions: git.InitOptions{DefaultBranch: plumbing.Main}})
+		require.NoError(t, err)
+		workDir := t.TempDir()
+		workRepo, err := git.PlainInitWithOptions(workDir, &git.PlainInitOptions{InitOptions: git.InitOptions{DefaultBranch: plumbing.Main}})
+		require.NoError(t, err)
+		_, err = workRepo.CreateRemote(&gitConfig.RemoteConfig{Name: git.DefaultRemoteName, URLs: []string{remoteDir}})
+		require.NoError(t, err)
+		worktree, err := workRepo.Worktree()
+		require.NoError(t, err)
+		commitAndPush := func() string {
+			_, addErr := worktree.Add(".")
+			require.NoError(t, addErr)
+			hash, commitErr := worktree.Commit("update", &git.CommitOptions{All: true, Author: &object.Signature{Name: "perses", Email: "perses@perses.dev", When: time.Now()}})
+			require.NoError(t, commitErr)
+			require.NoError(t, workRepo.Push(&git.PushOptions{RemoteName: git.DefaultRemoteName, RefSpecs: []gitConfig.RefSpec{"refs/heads/main:refs/heads/main"}}))
+			return hash.String()
+		}
+
+		require.NoError(t, os.MkdirAll(filepath.Join(workDir, "resources"), 0750))
+		writeEntityToFile(t, filepath.Join(workDir, "resources", "project.json"), project)
+		writeEntityToFile(t, filepath.Join(workDir, "resources", "role.json"), roleInProject)
+		firstCommit := commitAndPush()
+
+		gitTask := provisioning.NewGit(manager.Service(), manager.Persistence().GetGlobalSecret(), config.ProvisioningConfig{
+			Git:       []config.GitProvisioningConfig{{Name: "e2e", URL: remoteDir, Branch: "main", Path: "resources"}},
+			GitFolder: t.TempDir(),
+		}, true)
+		if err := gitTask.Execute(context.Background(), func() {}); err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = manager.Persistence().GetProject().Get("gitproject")
+		assert.NoError(t, err)
+		_, err = manager.Persistence().GetRole().Get("gitproject", "gitrole")
+		assert.NoError(t, err)
+		status := gitTask.Status()
+		if assert.Len(t, status, 1) {
+			assert.Equal(t, firstCommit, status[0].LastCommit)
+			assert.Empty(t, status[0].Errors)
+			assert.NotNil(t, status[0].LastSuccessfulSyncTime)
+		}
+
+		// Removing the role from the repository prunes it at the next synchronization.
+		require.NoError(t, os.Remove(filepath.Join(workDir, "resources", "role.json")))
+		secondCommit := commitAndPush()
+		if err := gitTask.Execute(context.Background(), func() {}); err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = manager.Persistence().GetRole().Get("gitproject", "gitrole")
+		assert.True(t, databaseModel.IsKeyNotFound(err), "role removed from the repository must be pruned, got err: %v", err)
+		_, err = manager.Persistence().GetProject().Get("gitproject")
+		assert.NoError(t, err)
+		_, err = manager.Persistence().GetProject().Get("handmadeproject")
+		assert.NoError(t, err, "resources not provisioned from the repository must not be pruned")
+		assert.Equal(t, secondCommit, gitTask.Status()[0].LastCommit)
+
+		return []modelAPI.Entity{handmadeProject, project}
+	})
+}
+
 func writeEntityToFile(t *testing.T, path string, entity modelAPI.Entity) {
 	t.Helper()
 	if err := os.WriteFile(path, testUtils.JSONMarshalStrict(entity), 0600); err != nil {
@@ -29,6 +29,7 @@ import (
 	"github.com/perses/perses/pkg/model/api/v1/datasource"
 	"github.com/perses/perses/pkg/model/api/v1/role"
 	"github.com/perses/perses/pkg/model/api/v1/secret"
+	"github.com/perses/perses/pkg/model/api/v1/template"
 	"github.com/perses/spec/go/common"
 	"github.com/perses/spec/go/dashboard/variable"
 	datasourceSpec "github.com/perses/spec/go/datasource"
@@ -51,6 +52,13 @@ func CreateGetFunc(t *testing.T, persistenceManager dependency.PersistenceManage
 		upsertFunc = func() error {
 			return persistenceManager.GetDashboard().Update(entity)
 		}
+	case *v1.DashboardTemplate:
+		getFunc = func() (api.Entity, error) {
+			return persistenceManager.GetDashboardTemplate().Get(entity.Metadata.Project, entity.Metadata.Name)
+		}
+		upsertFunc = func() error {
+			return persistenceManager.GetDashboardTemplate().Update(entity)
+		}
 	case *v1.Datasource:
 		getFunc = func() (api.Entity, error) {
 			return persistenceManager.GetDatasource().Get(entity.Metadata.Project, entity.Metadata.Name)
@@ -65,6 +73,13 @@ func CreateGetFunc(t *testing.T, persistenceManager dependency.PersistenceManage
 		upsertFunc = func() error {
 			return persistenceManager.GetEphemeralDashboard().Update(entity)
 		}
+	case *v1.GlobalDashboardTemplate:
+		getFunc = func() (api.Entity, error) {
+			return persistenceManager.GetGlobalDashboardTemplate().Get(entity.Metadata.Name)
+		}
+		upsertFunc = func() error {
+			return persistenceManager.GetGlobalDashboardTemplate().Update(entity)
+		}
 	case *v1.GlobalDatasource:
 		getFunc = func() (api.Entity, error) {
 			return persistenceManager.GetGlobalDatasource().Get(entity.Metadata.Name)
@@ -72,6 +87,13 @@ func CreateGetFunc(t *testing.T, persistenceManager dependency.PersistenceManage
 		upsertFunc = func() error {
 			return persistenceManager.GetGlobalDatasource().Update(entity)
 		}
+	case *v1.GlobalPolicy:
+		getFunc = func() (api.Entity, error) {
+			return persistenceManager.GetGlobalPolicy().Get(entity.Metadata.Name)
+		}
+		upsertFunc = func() error {
+			return persistenceManager.GetGlobalPolicy().Update(entity)
+		}
 	case *v1.GlobalRole:
 		getFunc = func() (api.Entity, error) {
 			return persistenceManager.GetGlobalRole().Get(entity.Metadata.Name)
@@ -329,6 +351,54 @@ func NewDashboard(t *testing.T, projectName string, name string) *v1.Dashboard {
 	return dashboard
 }
 
+func newDashboardTemplateSpec() v1.DashboardTemplateSpec {
+	defaultEnv := "production"
+	return v1.DashboardTemplateSpec{
+		Parameters: []template.Parameter{
+			{Name: "service"},
+			{Name: "env", Default: &defaultEnv},
+		},
+		Dashboard: map[string]interface{}{
+			"display": map[string]interface{}{
+				"name": "[[ service ]] overview",
+			},
+			"duration": "1h",
+			"variables": []interface{}{
+				map[string]interface{}{
+					"kind": "TextVariable",
+					"spec": map[string]interface{}{
+						"name":     "env",
+						"value":    "[[ env ]]",
+						"constant": true,
+					},
+				},
+			},
+			"panels":  map[string]interface{}{},
+			"layouts": []interface{}{},
+		},
+	}
+}
+
+func NewDashboardTemplate(projectName string, name string) *v1.DashboardTemplate {
+	entity := &v1.DashboardTemplate{
+		Kind:     v1.KindDashboardTemplate,
+		Metadata: *v1.NewProjectMetadata(projectName, name),
+		Spec:     newDashboardTemplateSpec(),
+	}
+	entity.Metadata.CreateNow()
+	return entity
+}
+
+func NewGlobalDashboardTemplate(name string) *v1.GlobalDashboardTemplate {
+	entity := &v1.GlobalDashboardTemplate{
+		Kind:     v1.KindGlobalDashboardTemplate,
+		Metadata: *v1.NewMetadata(name),
+		Spec:     newDashboardTemplateSpec(),
+	}
+	entity.Metadata.CreateNow()
+	return entity
+}
+
 func newRoleSpec() v1.RoleSpec {
 	return v1.RoleSpec{
 		Permissions: []role.Permission{
@@ -0,0 +1,103 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	apiinterface "github.com/perses/perses/internal/api/interface"
+	"github.com/perses/perses/pkg/model/api/config"
+)
+
+// findUnsavedQueryAllowList returns the query allow-list of a datasource that is not stored as such, like a datasource
+// embedded in a dashboard or a datasource tested before being saved. Its name is chosen by the caller, so it must not be a
+// way around the query allow-lists: when some are defined for the project (or for the global datasources if the project is
+// empty), the datasource must have one as well.
+func (e *endpoint) findUnsavedQueryAllowList(project string, dtsName string) (*config.DatasourceQueryAllowList, error) {
+	allowList := e.cfg.FindQueryAllowList(project, dtsName)
+	if allowList != nil || !e.cfg.HasProjectQueryAllowList(project) {
+		return allowList, nil
+	}
+	if len(project) == 0 {
+		return nil, apiinterface.HandleForbiddenError(fmt.Sprintf("some global datasources have query allow-lists, the datasource %q must have one as well", dtsName))
+	}
+	return nil, apiinterface.HandleForbiddenError(fmt.Sprintf("the project %q has query allow-lists, the datasource %q must have one as well", project, dtsName))
+}
+
+// maxFormSize is the maximum size of the form read from the body of a request to check its queries.
+const maxFormSize int64 = 10 * 1024 * 1024
+
+// checkQueryAllowList verifies the request is accepted by the query allow-list of the datasource.
+// A nil allow-list accepts every request.
+func checkQueryAllowList(res http.ResponseWriter, req *http.Request, path string, allowList *config.DatasourceQueryAllowList) error {
+	if allowList == nil {
+		return nil
+	}
+	if !allowList.IsEndpointAllowed(req.Method, path) {
+		return apiinterface.HandleForbiddenError(fmt.Sprintf("the endpoint %q with the HTTP method %s is not in the query allow-list of the datasource", path, req.Method))
+	}
+	if len(allowList.Queries) == 0 {
+		return nil
+	}
+	queries, err := requestParameterValues(res, req, allowList.Parameter)
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return echo.NewHTTPError(http.StatusRequestEntityTooLarge, fmt.Sprintf("the body of the request is bigger than %d bytes", maxBytesErr.Limit))
+		}
+		return apiinterface.HandleBadRequestError(err.Error())
+	}
+	for _, query := range queries {
+		if !allowList.IsQueryAllowed(query) {
+			return apiinterface.HandleForbiddenError(fmt.Sprintf("the query %q is not in the query allow-list of the datasource", query))
+		}
+	}
+	return nil
+}
+
+// requestParameterValues returns the values of the parameter, either in the URL or in the form sent in the body.
+// The body is restored, so it can still be forwarded to the datasource. It can't be bigger than maxFormSize.
+func requestParameterValues(res http.ResponseWriter, req *http.Request, name string) ([]string, error) {
+	values := req.URL.Query()[name]
+	if req.Body == nil || !isFormContentType(req.Header.Get(echo.HeaderContentType)) {
+		return values, nil
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(res, req.Body, maxFormSize))
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the body of the request: %w", err)
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode the form sent in the body of the request: %w", err)
+	}
+	return append(values, form[name]...), nil
+}
+
+// isFormContentType returns true if the content type is the one of a URL-encoded form, whatever its case and its parameters.
+func isFormContentType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return strings.EqualFold(mediaType, echo.MIMEApplicationForm)
+}
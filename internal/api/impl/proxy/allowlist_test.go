@@ -0,0 +1,122 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/perses/perses/pkg/model/api/config"
+	"github.com/perses/spec/go/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckQueryAllowList(t *testing.T) {
+	allowList := &config.DatasourceQueryAllowList{
+		Datasource: "prometheus",
+		Endpoints: []config.QueryAllowedEndpoint{
+			{EndpointPattern: common.MustNewRegexp("/api/v1/query"), Method: http.MethodGet},
+			{EndpointPattern: common.MustNewRegexp("/api/v1/query"), Method: http.MethodPost},
+		},
+		Queries: []string{`up\{job="[a-z]+"\}`},
+	}
+	for i := range allowList.Endpoints {
+		require.NoError(t, allowList.Endpoints[i].Verify())
+	}
+	require.NoError(t, allowList.Verify())
+
+	assert.NoError(t, checkQueryAllowList(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/v1/series", nil), "/api/v1/series", nil))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/series", nil)
+	assert.Error(t, checkQueryAllowList(httptest.NewRecorder(), req, "/api/v1/series", allowList))
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/query?query="+url.QueryEscape(`up{job="api"}`), nil)
+	assert.NoError(t, checkQueryAllowList(httptest.NewRecorder(), req, "/api/v1/query", allowList))
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/query?query="+url.QueryEscape(`secret_metric`), nil)
+	assert.Error(t, checkQueryAllowList(httptest.NewRecorder(), req, "/api/v1/query", allowList))
+
+	// The query sent in a form is checked, and the body can still be forwarded afterward.
+	form := url.Values{"query": []string{`secret_metric`}}.Encode()
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/query", strings.NewReader(form))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+	assert.Error(t, checkQueryAllowList(httptest.NewRecorder(), req, "/api/v1/query", allowList))
+
+	form = url.Values{"query": []string{`up{job="api"}`}}.Encode()
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/query", strings.NewReader(form))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+	assert.NoError(t, checkQueryAllowList(httptest.NewRecorder(), req, "/api/v1/query", allowList))
+	body, err := io.ReadAll(req.Body)
+	require.NoError(t, err)
+	assert.Equal(t, form, string(body))
+}
+
+func TestCheckQueryAllowListFormContentType(t *testing.T) {
+	allowList := &config.DatasourceQueryAllowList{Datasource: "prometheus", Queries: []string{"up"}}
+	require.NoError(t, allowList.Verify())
+	form := url.Values{"query": []string{`secret_metric`}}.Encode()
+	for _, contentType := range []string{
+		echo.MIMEApplicationForm,
+		"Application/X-WWW-Form-Urlencoded",
+		"application/x-www-form-urlencoded; charset=UTF-8",
+		"APPLICATION/X-WWW-FORM-URLENCODED;charset=utf-8",
+	} {
+		t.Run(contentType, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/query", strings.NewReader(form))
+			req.Header.Set(echo.HeaderContentType, contentType)
+			assert.Error(t, checkQueryAllowList(httptest.NewRecorder(), req, "/api/v1/query", allowList))
+		})
+	}
+}
+
+func TestCheckQueryAllowListFormTooLarge(t *testing.T) {
+	allowList := &config.DatasourceQueryAllowList{Datasource: "prometheus", Queries: []string{"up"}}
+	require.NoError(t, allowList.Verify())
+	form := "query=up&padding=" + strings.Repeat("a", int(maxFormSize))
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/query", strings.NewReader(form))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+	err := checkQueryAllowList(httptest.NewRecorder(), req, "/api/v1/query", allowList)
+	var httpErr *echo.HTTPError
+	require.ErrorAs(t, err, &httpErr)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, httpErr.Code)
+}
+
+func TestFindUnsavedQueryAllowList(t *testing.T) {
+	e := &endpoint{cfg: config.DatasourceConfig{
+		QueryAllowLists: []config.DatasourceQueryAllowList{
+			{Project: "shop", Datasource: "prometheus"},
+			{Datasource: "thanos"},
+		},
+	}}
+
+	allowList, err := e.findUnsavedQueryAllowList("shop", "prometheus")
+	require.NoError(t, err)
+	assert.Equal(t, "prometheus", allowList.Datasource)
+
+	// The name is chosen by the caller, so a datasource without allow-list is refused where some are defined.
+	_, err = e.findUnsavedQueryAllowList("shop", "my-own-prometheus")
+	assert.Error(t, err)
+	_, err = e.findUnsavedQueryAllowList("", "my-own-thanos")
+	assert.Error(t, err)
+
+	allowList, err = e.findUnsavedQueryAllowList("bank", "prometheus")
+	require.NoError(t, err)
+	assert.Nil(t, allowList)
+}
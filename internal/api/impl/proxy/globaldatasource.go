@@ -20,20 +20,21 @@ import (
 	databaseModel "github.com/perses/perses/internal/api/database/model"
 	apiinterface "github.com/perses/perses/internal/api/interface"
 	"github.com/perses/perses/internal/api/utils"
+	"github.com/perses/perses/pkg/model/api/config"
 	v1 "github.com/perses/perses/pkg/model/api/v1"
 	"github.com/perses/perses/pkg/model/api/v1/role"
 	"github.com/perses/spec/go/datasource"
 	"github.com/sirupsen/logrus"
 )
 
-func (e *endpoint) proxyGlobalDatasource(ctx echo.Context, datasourceName string, spec datasource.Spec, retrieveSecret func(name string) (*v1.SecretSpec, error)) error {
+func (e *endpoint) proxyGlobalDatasource(ctx echo.Context, datasourceName string, spec datasource.Spec, allowList *config.DatasourceQueryAllowList, retrieveSecret func(name string) (*v1.SecretSpec, error)) error {
 	path := ctx.Param("*")
 
-	pr, err := newProxy(datasourceName, "", spec, path, e.crypto, retrieveSecret)
+	pr, err := newProxy(datasourceName, "", spec, path, allowList, e.crypto, retrieveSecret)
 	if err != nil {
 		return err
 	}
-	return pr.serve(ctx)
+	return e.serve(ctx, pr)
 }
 
 func (e *endpoint) proxyUnsavedGlobalDatasource(ctx echo.Context) error {
@@ -45,6 +46,9 @@ func (e *endpoint) proxyUnsavedGlobalDatasource(ctx echo.Context) error {
 	if err := e.checkPermission(ctx, v1.WildcardProject, role.GlobalDatasourceScope, role.CreateAction); err != nil {
 		return err
 	}
+	if err := e.checkPermission(ctx, v1.WildcardProject, role.GlobalDatasourceScope, role.QueryAction); err != nil {
+		return err
+	}
 
 	body.setRequestParams(ctx)
 
@@ -52,8 +56,12 @@ func (e *endpoint) proxyUnsavedGlobalDatasource(ctx echo.Context) error {
 	if body.Spec.Display != nil {
 		dtsName = body.Spec.Display.Name
 	}
+	allowList, err := e.findUnsavedQueryAllowList("", dtsName)
+	if err != nil {
+		return err
+	}
 
-	return e.proxyGlobalDatasource(ctx, dtsName, body.Spec, func(name string) (*v1.SecretSpec, error) {
+	return e.proxyGlobalDatasource(ctx, dtsName, body.Spec, allowList, func(name string) (*v1.SecretSpec, error) {
 		if err := e.checkPermission(ctx, v1.WildcardProject, role.GlobalSecretScope, role.ReadAction); err != nil {
 			return nil, err
 		}
@@ -62,7 +70,7 @@ func (e *endpoint) proxyUnsavedGlobalDatasource(ctx echo.Context) error {
 }
 
 func (e *endpoint) proxySavedGlobalDatasource(ctx echo.Context) error {
-	if err := e.checkPermission(ctx, v1.WildcardProject, role.GlobalDatasourceScope, role.ReadAction); err != nil {
+	if err := e.checkPermission(ctx, v1.WildcardProject, role.GlobalDatasourceScope, role.QueryAction); err != nil {
 		return err
 	}
 
@@ -72,7 +80,7 @@ func (e *endpoint) proxySavedGlobalDatasource(ctx echo.Context) error {
 		return err
 	}
 
-	return e.proxyGlobalDatasource(ctx, dts.Metadata.Name, dts.Spec, func(name string) (*v1.SecretSpec, error) {
+	return e.proxyGlobalDatasource(ctx, dts.Metadata.Name, dts.Spec, e.cfg.FindQueryAllowList("", dts.Metadata.Name), func(name string) (*v1.SecretSpec, error) {
 		return e.getGlobalSecret(dtsName, name)
 	})
 }
@@ -28,12 +28,17 @@ import (
 
 func (e *endpoint) proxyDashboardDatasource(ctx echo.Context, projectName, dtsName string, spec datasource.Spec, retrieveSecret func(name string) (*v1.SecretSpec, error)) error {
 	path := ctx.Param("*")
+	// A datasource embedded in a dashboard is written by the users, like an unsaved one.
+	allowList, err := e.findUnsavedQueryAllowList(projectName, dtsName)
+	if err != nil {
+		return err
+	}
 
-	pr, err := newProxy(dtsName, projectName, spec, path, e.crypto, retrieveSecret)
+	pr, err := newProxy(dtsName, projectName, spec, path, allowList, e.crypto, retrieveSecret)
 	if err != nil {
 		return err
 	}
-	return pr.serve(ctx)
+	return e.serve(ctx, pr)
 }
 
 func (e *endpoint) proxyUnsavedDashboardDatasource(ctx echo.Context) error {
@@ -46,6 +51,9 @@ func (e *endpoint) proxyUnsavedDashboardDatasource(ctx echo.Context) error {
 	if err := e.checkPermission(ctx, projectName, role.DatasourceScope, role.CreateAction); err != nil {
 		return err
 	}
+	if err := e.checkPermission(ctx, projectName, role.DatasourceScope, role.QueryAction); err != nil {
+		return err
+	}
 
 	body.setRequestParams(ctx)
 
@@ -64,7 +72,7 @@ func (e *endpoint) proxyUnsavedDashboardDatasource(ctx echo.Context) error {
 
 func (e *endpoint) proxySavedDashboardDatasource(ctx echo.Context) error {
 	projectName := ctx.Param(utils.ParamProject)
-	if err := e.checkPermission(ctx, projectName, role.DatasourceScope, role.ReadAction); err != nil {
+	if err := e.checkPermission(ctx, projectName, role.DatasourceScope, role.QueryAction); err != nil {
 		return err
 	}
 
@@ -20,19 +20,20 @@ import (
 	databaseModel "github.com/perses/perses/internal/api/database/model"
 	apiinterface "github.com/perses/perses/internal/api/interface"
 	"github.com/perses/perses/internal/api/utils"
+	"github.com/perses/perses/pkg/model/api/config"
 	v1 "github.com/perses/perses/pkg/model/api/v1"
 	"github.com/perses/perses/pkg/model/api/v1/role"
 	"github.com/perses/spec/go/datasource"
 	"github.com/sirupsen/logrus"
 )
 
-func (e *endpoint) proxyProjectDatasource(ctx echo.Context, projectName, dtsName string, spec datasource.Spec, retrieveSecret func(name string) (*v1.SecretSpec, error)) error {
+func (e *endpoint) proxyProjectDatasource(ctx echo.Context, projectName, dtsName string, spec datasource.Spec, allowList *config.DatasourceQueryAllowList, retrieveSecret func(name string) (*v1.SecretSpec, error)) error {
 	path := ctx.Param("*")
-	pr, err := newProxy(dtsName, projectName, spec, path, e.crypto, retrieveSecret)
+	pr, err := newProxy(dtsName, projectName, spec, path, allowList, e.crypto, retrieveSecret)
 	if err != nil {
 		return err
 	}
-	return pr.serve(ctx)
+	return e.serve(ctx, pr)
 }
 
 func (e *endpoint) proxyUnsavedProjectDatasource(ctx echo.Context) error {
@@ -45,6 +46,9 @@ func (e *endpoint) proxyUnsavedProjectDatasource(ctx echo.Context) error {
 	if err := e.checkPermission(ctx, projectName, role.DatasourceScope, role.CreateAction); err != nil {
 		return err
 	}
+	if err := e.checkPermission(ctx, projectName, role.DatasourceScope, role.QueryAction); err != nil {
+		return err
+	}
 
 	body.setRequestParams(ctx)
 
@@ -52,8 +56,12 @@ func (e *endpoint) proxyUnsavedProjectDatasource(ctx echo.Context) error {
 	if body.Spec.Display != nil {
 		dtsName = body.Spec.Display.Name
 	}
+	allowList, err := e.findUnsavedQueryAllowList(projectName, dtsName)
+	if err != nil {
+		return err
+	}
 
-	return e.proxyProjectDatasource(ctx, projectName, dtsName, body.Spec, func(name string) (*v1.SecretSpec, error) {
+	return e.proxyProjectDatasource(ctx, projectName, dtsName, body.Spec, allowList, func(name string) (*v1.SecretSpec, error) {
 		if err := e.checkPermission(ctx, projectName, role.SecretScope, role.ReadAction); err != nil {
 			return nil, err
 		}
@@ -63,7 +71,7 @@ func (e *endpoint) proxyUnsavedProjectDatasource(ctx echo.Context) error {
 
 func (e *endpoint) proxySavedProjectDatasource(ctx echo.Context) error {
 	projectName := ctx.Param(utils.ParamProject)
-	if err := e.checkPermission(ctx, projectName, role.DatasourceScope, role.ReadAction); err != nil {
+	if err := e.checkPermission(ctx, projectName, role.DatasourceScope, role.QueryAction); err != nil {
 		return err
 	}
 
@@ -73,7 +81,7 @@ func (e *endpoint) proxySavedProjectDatasource(ctx echo.Context) error {
 		return err
 	}
 
-	return e.proxyProjectDatasource(ctx, projectName, dtsName, dts, func(name string) (*v1.SecretSpec, error) {
+	return e.proxyProjectDatasource(ctx, projectName, dtsName, dts, e.cfg.FindQueryAllowList(projectName, dtsName), func(name string) (*v1.SecretSpec, error) {
 		return e.getProjectSecret(projectName, dtsName, name)
 	})
 }
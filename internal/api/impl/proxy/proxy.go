@@ -40,6 +40,7 @@ import (
 	"github.com/perses/perses/internal/api/interface/v1/globaldatasource"
 	"github.com/perses/perses/internal/api/interface/v1/globalsecret"
 	"github.com/perses/perses/internal/api/interface/v1/secret"
+	"github.com/perses/perses/internal/api/plugin/backend"
 	"github.com/perses/perses/internal/api/route"
 	"github.com/perses/perses/internal/api/utils"
 	"github.com/perses/perses/pkg/model/api/config"
@@ -131,10 +132,11 @@ type endpoint struct {
 	globalDTS    globaldatasource.DAO
 	crypto       crypto.Crypto
 	authz        authorization.Authorization
+	backend      backend.Runtime
 }
 
 func New(cfg config.DatasourceConfig, dashboardDAO dashboard.DAO, secretDAO secret.DAO, globalSecretDAO globalsecret.DAO,
-	dtsDAO datasource.DAO, globalDtsDAO globaldatasource.DAO, crypto crypto.Crypto, authz authorization.Authorization) route.Endpoint {
+	dtsDAO datasource.DAO, globalDtsDAO globaldatasource.DAO, crypto crypto.Crypto, authz authorization.Authorization, backendRuntime backend.Runtime) route.Endpoint {
 	return &endpoint{
 		cfg:          cfg,
 		dashboard:    dashboardDAO,
@@ -144,6 +146,7 @@ func New(cfg config.DatasourceConfig, dashboardDAO dashboard.DAO, secretDAO secr
 		globalDTS:    globalDtsDAO,
 		crypto:       crypto,
 		authz:        authz,
+		backend:      backendRuntime,
 	}
 }
 
@@ -194,7 +197,7 @@ type proxy interface {
 	serve(c echo.Context) error
 }
 
-func newProxy(datasourceName, projectName string, spec datasourceSpec.Spec, path string, crypto crypto.Crypto, retrieveSecret func(name string) (*v1.SecretSpec, error)) (proxy, error) {
+func newProxy(datasourceName, projectName string, spec datasourceSpec.Spec, path string, allowList *config.DatasourceQueryAllowList, crypto crypto.Crypto, retrieveSecret func(name string) (*v1.SecretSpec, error)) (proxy, error) {
 	cfg, kind, err := datasourcev1.ValidateAndExtract(spec.Plugin.Spec)
 	if err != nil {
 		logrus.WithError(err).WithFields(map[string]interface{}{
@@ -228,6 +231,7 @@ func newProxy(datasourceName, projectName string, spec datasourceSpec.Spec, path
 		}
 		return &httpProxy{
 			config:         httpConfig,
+			allowList:      allowList,
 			datasourceName: datasourceName,
 			path:           path,
 			secret:         scrt,
@@ -260,7 +264,9 @@ func newProxy(datasourceName, projectName string, spec datasourceSpec.Spec, path
 }
 
 type httpProxy struct {
-	config         *datasourceHTTP.Config
+	config *datasourceHTTP.Config
+	// allowList is the optional query allow-list of the datasource, layered on the allowed endpoints of the config.
+	allowList      *config.DatasourceQueryAllowList
 	secret         *v1.SecretSpec
 	datasourceName string
 	path           string
@@ -289,6 +295,10 @@ func (h *httpProxy) serve(c echo.Context) error {
 		return apiinterface.HandleForbiddenError(fmt.Sprintf("you are not allowed to use this endpoint %q with the HTTP method %s", h.path, req.Method))
 	}
 
+	if err := checkQueryAllowList(res, req, h.path, h.allowList); err != nil {
+		return err
+	}
+
 	if err := h.prepareRequest(c); err != nil {
 		h.logWithDefaultEntry().WithError(err).Error("unable to prepare the HTTP request")
 		return apiinterface.InternalError
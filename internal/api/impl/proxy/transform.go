@@ -0,0 +1,144 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	apiinterface "github.com/perses/perses/internal/api/interface"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// HeaderTransform is the header used to ask for the response of the datasource to be transformed by the backend of a plugin module.
+	// Its value is the name of the plugin module.
+	HeaderTransform = "X-Perses-Transform"
+	// HeaderTransformParam is the header used to pass a parameter to the backend, formatted as `key=value`. It can be repeated.
+	HeaderTransformParam = "X-Perses-Transform-Param"
+)
+
+// bufferedResponseWriter keeps the response of the datasource in memory, so it can be transformed before being sent to the client.
+// Once the response exceeds maxSize (when positive), the rest of it is dropped and exceeded is set.
+type bufferedResponseWriter struct {
+	header   http.Header
+	status   int
+	body     bytes.Buffer
+	maxSize  int
+	exceeded bool
+}
+
+func (b *bufferedResponseWriter) Header() http.Header {
+	return b.header
+}
+
+func (b *bufferedResponseWriter) WriteHeader(status int) {
+	if b.status == 0 {
+		b.status = status
+	}
+}
+
+func (b *bufferedResponseWriter) Write(p []byte) (int, error) {
+	if b.status == 0 {
+		b.status = http.StatusOK
+	}
+	if b.exceeded || (b.maxSize > 0 && b.body.Len()+len(p) > b.maxSize) {
+		b.exceeded = true
+		// Pretend the data has been written, otherwise the reverse proxy aborts the request with a panic.
+		return len(p), nil
+	}
+	return b.body.Write(p)
+}
+
+// Flush is a no-op. It is required as the reverse proxy flushes the response when it is streamed.
+func (b *bufferedResponseWriter) Flush() {}
+
+func parseTransformParams(values []string) (map[string]string, error) {
+	params := make(map[string]string, len(values))
+	for _, v := range values {
+		key, value, found := strings.Cut(v, "=")
+		if !found || len(key) == 0 {
+			return nil, fmt.Errorf("invalid %s header %q, it must be formatted as key=value", HeaderTransformParam, v)
+		}
+		params[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+	return params, nil
+}
+
+// serve forwards the request to the datasource. When the request asks for it, the response of the datasource is then
+// transformed by the backend of a plugin module before being sent to the client.
+// As it is called once the permissions have been checked, a transformation doesn't require any additional permission.
+func (e *endpoint) serve(ctx echo.Context, pr proxy) error {
+	req := ctx.Request()
+	moduleName := req.Header.Get(HeaderTransform)
+	if len(moduleName) == 0 {
+		return pr.serve(ctx)
+	}
+	if e.backend == nil || !e.backend.Has(moduleName) {
+		return apiinterface.HandleBadRequestError(fmt.Sprintf("no backend is available for the plugin module %q", moduleName))
+	}
+	params, err := parseTransformParams(req.Header.Values(HeaderTransformParam))
+	if err != nil {
+		return apiinterface.HandleBadRequestError(err.Error())
+	}
+	// These headers are only meant for Perses, they must not be forwarded to the datasource.
+	req.Header.Del(HeaderTransform)
+	req.Header.Del(HeaderTransformParam)
+	// The backend needs to read the response, so it must not be compressed.
+	req.Header.Del(echo.HeaderAcceptEncoding)
+
+	res := ctx.Response()
+	originalWriter := res.Writer
+	buffer := &bufferedResponseWriter{header: make(http.Header), maxSize: e.backend.MaxInputSize()}
+	res.Writer = buffer
+	serveErr := pr.serve(ctx)
+	// Give back the original writer, and make echo forget about the response written in the buffer.
+	res.Writer = originalWriter
+	res.Committed = false
+	res.Status = http.StatusOK
+	res.Size = 0
+	if serveErr != nil {
+		return serveErr
+	}
+	if buffer.exceeded {
+		logrus.Errorf("the response of the datasource exceeds the maximum size (%d bytes) accepted by the plugin module %q", buffer.maxSize, moduleName)
+		return echo.NewHTTPError(http.StatusBadGateway, "the response of the datasource is too large to be transformed by the plugin backend")
+	}
+
+	body := buffer.body.Bytes()
+	if buffer.status >= http.StatusOK && buffer.status < http.StatusMultipleChoices {
+		body, err = e.backend.Transform(req.Context(), moduleName, body, params)
+		if err != nil {
+			logrus.WithError(err).Errorf("unable to transform the response of the datasource with the plugin module %q", moduleName)
+			return echo.NewHTTPError(http.StatusBadGateway, err.Error())
+		}
+	}
+	for k, values := range buffer.header {
+		if k == echo.HeaderContentLength {
+			continue
+		}
+		for _, v := range values {
+			res.Header().Add(k, v)
+		}
+	}
+	if buffer.status == 0 {
+		buffer.status = http.StatusOK
+	}
+	res.WriteHeader(buffer.status)
+	_, err = res.Write(body)
+	return err
+}
@@ -0,0 +1,130 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/perses/perses/internal/api/plugin/backend"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeProxy answers with a fixed response, and keeps the request it received.
+type fakeProxy struct {
+	status  int
+	body    string
+	request *http.Request
+}
+
+func (f *fakeProxy) serve(c echo.Context) error {
+	f.request = c.Request()
+	c.Response().Header().Set(echo.HeaderContentLength, "1000")
+	return c.String(f.status, f.body)
+}
+
+// upperBackend turns the response in uppercase, and appends the parameters received.
+type upperBackend struct {
+	backend.Runtime
+}
+
+func (u *upperBackend) Has(moduleName string) bool {
+	return moduleName == "Upper"
+}
+
+// MaxInputSize is deliberately small, so the tests can exceed it easily.
+func (u *upperBackend) MaxInputSize() int {
+	return 16
+}
+
+func (u *upperBackend) Transform(_ context.Context, _ string, input []byte, params map[string]string) ([]byte, error) {
+	if params["fail"] == "true" {
+		return nil, errors.New("transformation failed")
+	}
+	return []byte(strings.ToUpper(string(input)) + params["suffix"]), nil
+}
+
+func executeTransform(t *testing.T, pr *fakeProxy, headers map[string][]string) (*httptest.ResponseRecorder, error) {
+	req := httptest.NewRequest(http.MethodGet, "/proxy/projects/perses/datasources/prom/api/v1/query", nil)
+	for k, values := range headers {
+		for _, v := range values {
+			req.Header.Add(k, v)
+		}
+	}
+	rec := httptest.NewRecorder()
+	ctx := echo.New().NewContext(req, rec)
+	e := &endpoint{backend: &upperBackend{}}
+	err := e.serve(ctx, pr)
+	if pr.request != nil {
+		require.Empty(t, pr.request.Header.Get(HeaderTransform))
+		require.Empty(t, pr.request.Header.Get(HeaderTransformParam))
+	}
+	return rec, err
+}
+
+func TestServeWithoutTransform(t *testing.T) {
+	pr := &fakeProxy{status: http.StatusOK, body: "ok"}
+	rec, err := executeTransform(t, pr, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "ok", rec.Body.String())
+}
+
+func TestServeWithTransform(t *testing.T) {
+	pr := &fakeProxy{status: http.StatusOK, body: "ok"}
+	rec, err := executeTransform(t, pr, map[string][]string{
+		HeaderTransform:           {"Upper"},
+		HeaderTransformParam:      {"suffix=!"},
+		echo.HeaderAcceptEncoding: {"gzip"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "OK!", rec.Body.String())
+	assert.Empty(t, rec.Header().Get(echo.HeaderContentLength))
+	assert.Empty(t, pr.request.Header.Get(echo.HeaderAcceptEncoding))
+}
+
+func TestServeWithTransformErrors(t *testing.T) {
+	// an error returned by the datasource is not transformed
+	pr := &fakeProxy{status: http.StatusBadRequest, body: "bad query"}
+	rec, err := executeTransform(t, pr, map[string][]string{HeaderTransform: {"Upper"}})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, "bad query", rec.Body.String())
+
+	_, err = executeTransform(t, &fakeProxy{status: http.StatusOK}, map[string][]string{HeaderTransform: {"Unknown"}})
+	assert.ErrorContains(t, err, `no backend is available for the plugin module "Unknown"`)
+
+	_, err = executeTransform(t, &fakeProxy{status: http.StatusOK}, map[string][]string{HeaderTransform: {"Upper"}, HeaderTransformParam: {"invalid"}})
+	assert.ErrorContains(t, err, "it must be formatted as key=value")
+
+	_, err = executeTransform(t, &fakeProxy{status: http.StatusOK}, map[string][]string{HeaderTransform: {"Upper"}, HeaderTransformParam: {"fail=true"}})
+	var httpErr *echo.HTTPError
+	require.True(t, errors.As(err, &httpErr))
+	assert.Equal(t, http.StatusBadGateway, httpErr.Code)
+}
+
+func TestServeWithTransformTooLargeInput(t *testing.T) {
+	pr := &fakeProxy{status: http.StatusOK, body: "a response bigger than the limit"}
+	rec, err := executeTransform(t, pr, map[string][]string{HeaderTransform: {"Upper"}})
+	var httpErr *echo.HTTPError
+	require.True(t, errors.As(err, &httpErr))
+	assert.Equal(t, http.StatusBadGateway, httpErr.Code)
+	assert.Empty(t, rec.Body.String())
+}
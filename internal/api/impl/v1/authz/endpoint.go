@@ -0,0 +1,130 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authz
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/perses/perses/internal/api/authorization"
+	apiInterface "github.com/perses/perses/internal/api/interface"
+	"github.com/perses/perses/internal/api/route"
+	"github.com/perses/perses/internal/api/utils"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/perses/perses/pkg/model/api/v1/policy"
+	"github.com/perses/perses/pkg/model/api/v1/role"
+)
+
+type endpoint struct {
+	authz authorization.Authorization
+	// evaluator is nil when the authorization doesn't rely on policies.
+	evaluator authorization.PolicyEvaluator
+}
+
+// NewEndpoint creates the endpoints explaining the decisions of the authorization.
+// The dry-run of the policies is only available when the evaluator is not nil.
+func NewEndpoint(authz authorization.Authorization, evaluator authorization.PolicyEvaluator) route.Endpoint {
+	return &endpoint{
+		authz:     authz,
+		evaluator: evaluator,
+	}
+}
+
+func (e *endpoint) CollectRoutes(g *route.Group) {
+	group := g.Group(fmt.Sprintf("/%s", utils.PathAuthz))
+	group.GET(fmt.Sprintf("/%s", utils.PathExplain), e.Explain, false)
+	if e.evaluator != nil {
+		group.POST(fmt.Sprintf("/%s", utils.PathCheck), e.Check, false)
+	}
+}
+
+// Explain returns the decision taken for the request described by the query parameters, and what granted,
+// or would grant, the permission. When no user is provided, the request is explained for the current user.
+func (e *endpoint) Explain(ctx echo.Context) error {
+	if !e.authz.IsEnabled() {
+		return apiInterface.HandleBadRequestError("the authorization is disabled")
+	}
+	query := explainQuery{}
+	if err := ctx.Bind(&query); err != nil {
+		return apiInterface.HandleBadRequestError(err.Error())
+	}
+	if len(query.Action) == 0 || len(query.Scope) == 0 {
+		return apiInterface.HandleBadRequestError("action and scope are required")
+	}
+	action, err := role.GetAction(query.Action)
+	if err != nil {
+		return apiInterface.HandleBadRequestError(err.Error())
+	}
+	scope, err := role.GetScope(query.Scope)
+	if err != nil {
+		return apiInterface.HandleBadRequestError(err.Error())
+	}
+	if role.IsGlobalScope(*scope) || len(query.Project) == 0 {
+		query.Project = v1.WildcardProject
+	}
+	username, err := e.authz.GetUsername(ctx)
+	if err != nil {
+		return err
+	}
+	if len(query.User) == 0 {
+		query.User = username
+	}
+	// Explaining the permissions of another user reveals who is bound to which role.
+	if query.User != username {
+		bindingScope := role.GlobalRoleBindingScope
+		if e.evaluator != nil {
+			bindingScope = role.GlobalPolicyScope
+		}
+		if !e.authz.HasPermission(ctx, role.ReadAction, v1.WildcardProject, bindingScope) {
+			return apiInterface.HandleForbiddenError(fmt.Sprintf("missing '%s' global permission for '%s' kind", role.ReadAction, bindingScope))
+		}
+	}
+	explanation, err := e.authz.Explain(ctx, query.User, *action, query.Project, *scope)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, explanation)
+}
+
+type explainQuery struct {
+	User    string `query:"user"`
+	Action  string `query:"action"`
+	Scope   string `query:"scope"`
+	Project string `query:"project"`
+}
+
+// Check evaluates the policies for the request described in the body, without performing it.
+// When no user is provided, the request is evaluated for the current user.
+func (e *endpoint) Check(ctx echo.Context) error {
+	input := policy.Input{}
+	if err := ctx.Bind(&input); err != nil {
+		return apiInterface.HandleBadRequestError(err.Error())
+	}
+	if len(input.Action) == 0 || len(input.Scope) == 0 {
+		return apiInterface.HandleBadRequestError("action and scope are required")
+	}
+	username, err := e.authz.GetUsername(ctx)
+	if err != nil {
+		return err
+	}
+	if len(input.User) == 0 {
+		input.User = username
+	}
+	// Checking the request of another user, or with other groups, reveals what the policies contain.
+	if (input.User != username || input.Groups != nil) && !e.authz.HasPermission(ctx, role.ReadAction, v1.WildcardProject, role.GlobalPolicyScope) {
+		return apiInterface.HandleForbiddenError(fmt.Sprintf("missing '%s' global permission for '%s' kind", role.ReadAction, role.GlobalPolicyScope))
+	}
+	return ctx.JSON(http.StatusOK, e.evaluator.Evaluate(input))
+}
@@ -23,8 +23,9 @@ import (
 	apiInterface "github.com/perses/perses/internal/api/interface"
 	"github.com/perses/perses/internal/api/interface/v1/dashboard"
 	"github.com/perses/perses/internal/api/interface/v1/globalvariable"
+	"github.com/perses/perses/internal/api/interface/v1/project"
 	"github.com/perses/perses/internal/api/interface/v1/variable"
-	"github.com/perses/perses/internal/api/plugin/schema"
+	"github.com/perses/perses/internal/api/plugin"
 	"github.com/perses/perses/internal/api/validate"
 	"github.com/perses/perses/pkg/model/api"
 	"github.com/perses/perses/pkg/model/api/config"
@@ -37,19 +38,21 @@ type service struct {
 	dao                 dashboard.DAO
 	globalVarDAO        globalvariable.DAO
 	projectVarDAO       variable.DAO
-	sch                 schema.Schema
+	projectDAO          project.DAO
+	plugin              plugin.Plugin
 	isDatasourceDisable bool
 	isVariableDisable   bool
 	customRules         []*config.CustomLintRule
 	index               index.Client
 }
 
-func NewService(cfg config.Config, dao dashboard.DAO, globalVarDAO globalvariable.DAO, projectVarDAO variable.DAO, sch schema.Schema, indexClient index.Client) dashboard.Service {
+func NewService(cfg config.Config, dao dashboard.DAO, globalVarDAO globalvariable.DAO, projectVarDAO variable.DAO, projectDAO project.DAO, pluginService plugin.Plugin, indexClient index.Client) dashboard.Service {
 	return &service{
 		dao:                 dao,
 		globalVarDAO:        globalVarDAO,
 		projectVarDAO:       projectVarDAO,
-		sch:                 sch,
+		projectDAO:          projectDAO,
+		plugin:              pluginService,
 		isDatasourceDisable: cfg.Datasource.DisableLocal,
 		isVariableDisable:   cfg.Variable.DisableLocal,
 		customRules:         cfg.Dashboard.CustomLintRules,
@@ -172,7 +175,13 @@ func (s *service) Validate(entity *v1.Dashboard) error {
 		return apiInterface.HandleError(globalVarsErr)
 	}
 
-	if err := validate.DashboardSpecWithVars(entity.Spec, s.sch, projectVars, globalVars); err != nil {
+	// The schema is restricted by the plugin policy of the project.
+	sch, schErr := plugin.ProjectSchema(s.plugin, s.projectDAO, entity.Metadata.Project)
+	if schErr != nil {
+		return apiInterface.HandleError(schErr)
+	}
+
+	if err := validate.DashboardSpecWithVars(entity.Spec, sch, projectVars, globalVars); err != nil {
 		return apiInterface.HandleBadRequestError(err.Error())
 	}
 	if err := validate.DashboardWithCustomRules(entity, s.customRules); err != nil {
@@ -0,0 +1,175 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dashboardtemplate
+
+import (
+	"fmt"
+
+	"github.com/labstack/echo/v4"
+	"github.com/perses/perses/internal/api/authorization"
+	databaseModel "github.com/perses/perses/internal/api/database/model"
+	apiInterface "github.com/perses/perses/internal/api/interface"
+	"github.com/perses/perses/internal/api/interface/v1/dashboard"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/perses/perses/pkg/model/api/v1/role"
+	"github.com/sirupsen/logrus"
+)
+
+// Instantiator renders the dashboards of a template.
+// It is shared by the DashboardTemplate and the GlobalDashboardTemplate services.
+// The status of the template is not modified: the caller is in charge of recording the instances,
+// on the template read again, so the changes made on the template meanwhile are not lost.
+type Instantiator struct {
+	dashboard dashboard.Service
+	authz     authorization.Authorization
+}
+
+func NewInstantiator(dashboardService dashboard.Service, authz authorization.Authorization) *Instantiator {
+	return &Instantiator{
+		dashboard: dashboardService,
+		authz:     authz,
+	}
+}
+
+// Instantiation is the outcome of the instantiation of a template.
+type Instantiation struct {
+	Dashboard *v1.Dashboard
+	// Instance must be recorded in the status of the template.
+	Instance v1.DashboardTemplateInstance
+	// Created is true when the dashboard didn't exist before.
+	Created bool
+}
+
+// Instantiate renders the dashboard requested and creates it or updates it.
+// A dashboard that already exists can only be updated if it has been rendered from the same template.
+func (i *Instantiator) Instantiate(ctx echo.Context, tmpl v1.DashboardTemplateInterface, version uint64, request *v1.DashboardTemplateInstantiation) (*Instantiation, error) {
+	if len(request.Name) == 0 {
+		return nil, apiInterface.HandleBadRequestError("name cannot be empty")
+	}
+	instance := v1.DashboardTemplateInstance{
+		Project:    request.Project,
+		Name:       request.Name,
+		Version:    version,
+		Parameters: request.Parameters,
+	}
+	result, created, err := i.render(ctx, tmpl.GetTemplateSpec(), instance, tmpl.GetStatus().GetInstance(instance.Project, instance.Name) != nil)
+	if err != nil {
+		return nil, err
+	}
+	return &Instantiation{Dashboard: result, Instance: instance, Created: created}, nil
+}
+
+// Rollback deletes the dashboard created by the instantiation when it cannot be recorded in the status of the template.
+// Otherwise, the dashboard would not be considered as coming from the template, and it could not be instantiated again.
+func (i *Instantiator) Rollback(ctx echo.Context, instantiation *Instantiation) {
+	if !instantiation.Created {
+		return
+	}
+	parameters := apiInterface.Parameters{Project: instantiation.Instance.Project, Name: instantiation.Instance.Name}
+	if err := i.dashboard.Delete(ctx, parameters); err != nil && !databaseModel.IsKeyNotFound(err) {
+		logrus.WithError(err).Errorf("unable to delete the dashboard %q in the project %q that cannot be recorded as an instance", parameters.Name, parameters.Project)
+	}
+}
+
+// Render renders again the instances of the template and reports the outcome for each of them.
+// Unless force is true, the instances already rendered from the current version of the template are skipped.
+// The outcome must be recorded in the status of the template with ApplyRenderResults.
+func (i *Instantiator) Render(ctx echo.Context, tmpl v1.DashboardTemplateInterface, version uint64, force bool) []v1.DashboardTemplateRenderResult {
+	status := tmpl.GetStatus()
+	if status == nil {
+		return []v1.DashboardTemplateRenderResult{}
+	}
+	results := make([]v1.DashboardTemplateRenderResult, 0, len(status.Instances))
+	for _, instance := range status.Instances {
+		result := v1.DashboardTemplateRenderResult{Project: instance.Project, Name: instance.Name}
+		if !force && instance.Version == version {
+			result.Status = v1.DashboardTemplateRenderUpToDate
+			results = append(results, result)
+			continue
+		}
+		if _, err := i.dashboard.Get(apiInterface.Parameters{Project: instance.Project, Name: instance.Name}); err != nil {
+			if databaseModel.IsKeyNotFound(err) {
+				result.Status = v1.DashboardTemplateRenderRemoved
+			} else {
+				logrus.WithError(err).Errorf("unable to get the dashboard %q in the project %q", instance.Name, instance.Project)
+				result.Status = v1.DashboardTemplateRenderFailed
+				result.Error = err.Error()
+			}
+			results = append(results, result)
+			continue
+		}
+		if _, _, err := i.render(ctx, tmpl.GetTemplateSpec(), instance, true); err != nil {
+			logrus.WithError(err).Debugf("unable to render the dashboard %q in the project %q", instance.Name, instance.Project)
+			result.Status = v1.DashboardTemplateRenderFailed
+			result.Error = err.Error()
+			results = append(results, result)
+			continue
+		}
+		result.Status = v1.DashboardTemplateRenderUpdated
+		results = append(results, result)
+	}
+	return results
+}
+
+// ApplyRenderResults records in the status the outcome of Render:
+// the instances updated are now rendered from the given version, and the instances whose dashboard has been deleted are no longer tracked.
+func ApplyRenderResults(status *v1.DashboardTemplateStatus, results []v1.DashboardTemplateRenderResult, version uint64) {
+	for _, result := range results {
+		switch result.Status {
+		case v1.DashboardTemplateRenderUpdated:
+			if instance := status.GetInstance(result.Project, result.Name); instance != nil {
+				instance.Version = version
+			}
+		case v1.DashboardTemplateRenderRemoved:
+			status.RemoveInstance(result.Project, result.Name)
+		}
+	}
+}
+
+// render creates or updates the dashboard of the instance. created is true when the dashboard didn't exist before.
+func (i *Instantiator) render(ctx echo.Context, spec v1.DashboardTemplateSpec, instance v1.DashboardTemplateInstance, isInstance bool) (result *v1.Dashboard, created bool, err error) {
+	entity, err := spec.Render(instance.Project, instance.Name, instance.Parameters)
+	if err != nil {
+		return nil, false, apiInterface.HandleBadRequestError(err.Error())
+	}
+	parameters := apiInterface.Parameters{Project: instance.Project, Name: instance.Name}
+	if _, getErr := i.dashboard.Get(parameters); getErr != nil {
+		if !databaseModel.IsKeyNotFound(getErr) {
+			return nil, false, getErr
+		}
+		if permErr := i.checkPermission(ctx, role.CreateAction, instance.Project); permErr != nil {
+			return nil, false, permErr
+		}
+		result, err = i.dashboard.Create(ctx, entity)
+		return result, err == nil, err
+	}
+	if !isInstance {
+		return nil, false, apiInterface.HandleConflictError(fmt.Sprintf("dashboard %q already exists in the project %q and doesn't come from the template", instance.Name, instance.Project))
+	}
+	if permErr := i.checkPermission(ctx, role.UpdateAction, instance.Project); permErr != nil {
+		return nil, false, permErr
+	}
+	result, err = i.dashboard.Update(ctx, entity, parameters)
+	return result, false, err
+}
+
+func (i *Instantiator) checkPermission(ctx echo.Context, action role.Action, project string) error {
+	if !i.authz.IsEnabled() {
+		return nil
+	}
+	if ok := i.authz.HasPermission(ctx, action, project, role.DashboardScope); !ok {
+		return apiInterface.HandleForbiddenError(fmt.Sprintf("missing '%s' permission in '%s' project for '%s' kind", action, project, role.DashboardScope))
+	}
+	return nil
+}
@@ -0,0 +1,181 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dashboardtemplate
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/brunoga/deep"
+	"github.com/labstack/echo/v4"
+	"github.com/perses/perses/internal/api/authorization"
+	apiInterface "github.com/perses/perses/internal/api/interface"
+	"github.com/perses/perses/internal/api/interface/v1/dashboard"
+	"github.com/perses/perses/internal/api/interface/v1/dashboardtemplate"
+	"github.com/perses/perses/pkg/model/api"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/sirupsen/logrus"
+)
+
+type service struct {
+	dashboardtemplate.Service
+	dao          dashboardtemplate.DAO
+	instantiator *Instantiator
+	// mutex serializes the writes of the templates, so an update and the record of the instances don't overwrite each other.
+	mutex sync.Mutex
+}
+
+func NewService(dao dashboardtemplate.DAO, dashboardService dashboard.Service, authz authorization.Authorization) dashboardtemplate.Service {
+	return &service{
+		dao:          dao,
+		instantiator: NewInstantiator(dashboardService, authz),
+	}
+}
+
+func (s *service) Create(_ echo.Context, entity *v1.DashboardTemplate) (*v1.DashboardTemplate, error) {
+	copyEntity, err := deep.Copy(entity)
+	if err != nil {
+		return nil, fmt.Errorf("failed to copy entity: %w", err)
+	}
+	return s.create(copyEntity)
+}
+
+func (s *service) create(entity *v1.DashboardTemplate) (*v1.DashboardTemplate, error) {
+	// The status is maintained by the server only.
+	entity.Status = nil
+	// Update the time contains in the entity
+	entity.Metadata.CreateNow()
+	if err := s.dao.Create(entity); err != nil {
+		return nil, err
+	}
+	return entity, nil
+}
+
+func (s *service) Update(_ echo.Context, entity *v1.DashboardTemplate, parameters apiInterface.Parameters) (*v1.DashboardTemplate, error) {
+	copyEntity, err := deep.Copy(entity)
+	if err != nil {
+		return nil, fmt.Errorf("failed to copy entity: %w", err)
+	}
+	return s.update(copyEntity, parameters)
+}
+
+func (s *service) update(entity *v1.DashboardTemplate, parameters apiInterface.Parameters) (*v1.DashboardTemplate, error) {
+	if entity.Metadata.Name != parameters.Name {
+		logrus.Debugf("name in DashboardTemplate %q and name from the http request %q don't match", entity.Metadata.Name, parameters.Name)
+		return nil, apiInterface.HandleBadRequestError("metadata.name and the name in the http path request don't match")
+	}
+	if len(entity.Metadata.Project) == 0 {
+		entity.Metadata.Project = parameters.Project
+	} else if entity.Metadata.Project != parameters.Project {
+		logrus.Debugf("project in DashboardTemplate %q and project from the http request %q don't match", entity.Metadata.Project, parameters.Project)
+		return nil, apiInterface.HandleBadRequestError("metadata.project and the project name in the http path request don't match")
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	// find the previous version of the DashboardTemplate
+	oldEntity, err := s.dao.Get(parameters.Project, parameters.Name)
+	if err != nil {
+		return nil, err
+	}
+	entity.Metadata.Update(oldEntity.Metadata)
+	// The instances are kept. They are now outdated until they are rendered again.
+	entity.Status = oldEntity.Status
+	if updateErr := s.dao.Update(entity); updateErr != nil {
+		logrus.WithError(updateErr).Errorf("unable to perform the update of the DashboardTemplate %q, something wrong with the database", entity.Metadata.Name)
+		return nil, updateErr
+	}
+	return entity, nil
+}
+
+func (s *service) Delete(_ echo.Context, parameters apiInterface.Parameters) error {
+	return s.dao.Delete(parameters.Project, parameters.Name)
+}
+
+func (s *service) Get(parameters apiInterface.Parameters) (*v1.DashboardTemplate, error) {
+	return s.dao.Get(parameters.Project, parameters.Name)
+}
+
+func (s *service) List(q *dashboardtemplate.Query) ([]*v1.DashboardTemplate, error) {
+	return s.dao.List(q)
+}
+
+func (s *service) RawList(q *dashboardtemplate.Query) ([]json.RawMessage, error) {
+	return s.dao.RawList(q)
+}
+
+func (s *service) MetadataList(q *dashboardtemplate.Query) ([]api.Entity, error) {
+	return s.dao.MetadataList(q)
+}
+
+func (s *service) RawMetadataList(q *dashboardtemplate.Query) ([]json.RawMessage, error) {
+	return s.dao.RawMetadataList(q)
+}
+
+func (s *service) Instantiate(ctx echo.Context, parameters apiInterface.Parameters, request *v1.DashboardTemplateInstantiation) (*v1.Dashboard, error) {
+	if len(request.Project) == 0 {
+		request.Project = parameters.Project
+	} else if request.Project != parameters.Project {
+		return nil, apiInterface.HandleBadRequestError("a DashboardTemplate can only be instantiated in its own project, use a GlobalDashboardTemplate instead")
+	}
+	entity, err := s.dao.Get(parameters.Project, parameters.Name)
+	if err != nil {
+		return nil, err
+	}
+	instantiation, err := s.instantiator.Instantiate(ctx, entity, entity.Metadata.Version, request)
+	if err != nil {
+		return nil, err
+	}
+	if updateErr := s.updateStatus(parameters, func(status *v1.DashboardTemplateStatus) {
+		status.SetInstance(instantiation.Instance)
+	}); updateErr != nil {
+		logrus.WithError(updateErr).Errorf("unable to record the instance %q of the DashboardTemplate %q", request.Name, entity.Metadata.Name)
+		s.instantiator.Rollback(ctx, instantiation)
+		return nil, updateErr
+	}
+	return instantiation.Dashboard, nil
+}
+
+func (s *service) Render(ctx echo.Context, parameters apiInterface.Parameters, force bool) ([]v1.DashboardTemplateRenderResult, error) {
+	entity, err := s.dao.Get(parameters.Project, parameters.Name)
+	if err != nil {
+		return nil, err
+	}
+	results := s.instantiator.Render(ctx, entity, entity.Metadata.Version, force)
+	if updateErr := s.updateStatus(parameters, func(status *v1.DashboardTemplateStatus) {
+		ApplyRenderResults(status, results, entity.Metadata.Version)
+	}); updateErr != nil {
+		logrus.WithError(updateErr).Errorf("unable to record the instances of the DashboardTemplate %q", entity.Metadata.Name)
+		return nil, updateErr
+	}
+	return results, nil
+}
+
+// updateStatus applies the change on the status of the DashboardTemplate read again from the database,
+// as it can have been updated while the dashboards were rendered.
+// The version of the DashboardTemplate is not bumped since its spec doesn't change.
+func (s *service) updateStatus(parameters apiInterface.Parameters, apply func(status *v1.DashboardTemplateStatus)) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	entity, err := s.dao.Get(parameters.Project, parameters.Name)
+	if err != nil {
+		return err
+	}
+	if entity.Status == nil {
+		entity.Status = &v1.DashboardTemplateStatus{}
+	}
+	apply(entity.Status)
+	return s.dao.Update(entity)
+}
@@ -21,9 +21,11 @@ import (
 	"github.com/brunoga/deep"
 	"github.com/labstack/echo/v4"
 	"github.com/perses/perses/internal/api/authorization"
+	"github.com/perses/perses/internal/api/index"
 	apiInterface "github.com/perses/perses/internal/api/interface"
 	"github.com/perses/perses/internal/api/interface/v1/datasource"
-	"github.com/perses/perses/internal/api/plugin/schema"
+	"github.com/perses/perses/internal/api/interface/v1/project"
+	"github.com/perses/perses/internal/api/plugin"
 	"github.com/perses/perses/internal/api/validate"
 	"github.com/perses/perses/pkg/model/api"
 	v1 "github.com/perses/perses/pkg/model/api/v1"
@@ -34,16 +36,20 @@ import (
 
 type service struct {
 	datasource.Service
-	dao   datasource.DAO
-	sch   schema.Schema
-	authz authorization.Authorization
+	dao        datasource.DAO
+	projectDAO project.DAO
+	plugin     plugin.Plugin
+	authz      authorization.Authorization
+	index      index.Client
 }
 
-func NewService(dao datasource.DAO, sch schema.Schema, authz authorization.Authorization) datasource.Service {
+func NewService(dao datasource.DAO, projectDAO project.DAO, pluginService plugin.Plugin, authz authorization.Authorization, indexClient index.Client) datasource.Service {
 	return &service{
-		dao:   dao,
-		sch:   sch,
-		authz: authz,
+		dao:        dao,
+		projectDAO: projectDAO,
+		plugin:     pluginService,
+		authz:      authz,
+		index:      indexClient,
 	}
 }
 
@@ -67,6 +73,10 @@ func (s *service) create(entity *v1.Datasource) (*v1.Datasource, error) {
 	if err := s.dao.Create(entity); err != nil {
 		return nil, err
 	}
+	// Add the datasource to the index
+	if err := s.index.Add(entity); err != nil {
+		logrus.WithError(err).Errorf("unable to add the datasource %q to the index", entity.Metadata.Name)
+	}
 	return entity, nil
 }
 
@@ -82,9 +92,6 @@ func (s *service) Update(ctx echo.Context, entity *v1.Datasource, parameters api
 }
 
 func (s *service) update(entity *v1.Datasource, parameters apiInterface.Parameters) (*v1.Datasource, error) {
-	if err := s.validate(entity); err != nil {
-		return nil, apiInterface.HandleBadRequestError(err.Error())
-	}
 	if entity.Metadata.Name != parameters.Name {
 		logrus.Debugf("name in Datasource %q and name from the http request %q don't match", entity.Metadata.Name, parameters.Name)
 		return nil, apiInterface.HandleBadRequestError("metadata.name and the name in the http path request don't match")
@@ -95,6 +102,9 @@ func (s *service) update(entity *v1.Datasource, parameters apiInterface.Paramete
 		logrus.Debugf("project in datasource %q and project from the http request %q don't match", entity.Metadata.Project, parameters.Project)
 		return nil, apiInterface.HandleBadRequestError("metadata.project and the project name in the http path request don't match")
 	}
+	if err := s.validate(entity); err != nil {
+		return nil, apiInterface.HandleBadRequestError(err.Error())
+	}
 	// find the previous version of the Datasource
 	oldEntity, err := s.dao.Get(parameters.Project, parameters.Name)
 	if err != nil {
@@ -105,11 +115,27 @@ func (s *service) update(entity *v1.Datasource, parameters apiInterface.Paramete
 		logrus.WithError(updateErr).Errorf("unable to perform the update of the Datasource %q, something wrong with the database", entity.Metadata.Name)
 		return nil, updateErr
 	}
+	// Update the datasource in the index
+	if indexErr := s.index.Add(entity); indexErr != nil {
+		logrus.WithError(indexErr).Errorf("unable to update the datasource %q in the index", entity.Metadata.Name)
+	}
 	return entity, nil
 }
 
 func (s *service) Delete(_ echo.Context, parameters apiInterface.Parameters) error {
-	return s.dao.Delete(parameters.Project, parameters.Name)
+	if err := s.dao.Delete(parameters.Project, parameters.Name); err != nil {
+		return err
+	}
+	// Remove the datasource from the index
+	s.index.Delete(v1.KindDatasource, &v1.ProjectMetadata{
+		Metadata: v1.Metadata{
+			Name: parameters.Name,
+		},
+		ProjectMetadataWrapper: v1.ProjectMetadataWrapper{
+			Project: parameters.Project,
+		},
+	})
+	return nil
 }
 
 func (s *service) Get(parameters apiInterface.Parameters) (*v1.Datasource, error) {
@@ -146,7 +172,12 @@ func (s *service) validate(entity *v1.Datasource) error {
 			return err
 		}
 	}
-	return validate.Datasource(entity, list, s.sch)
+	// The schema is restricted by the plugin policy of the project.
+	sch, err := plugin.ProjectSchema(s.plugin, s.projectDAO, entity.Metadata.Project)
+	if err != nil {
+		return err
+	}
+	return validate.Datasource(entity, list, sch)
 }
 
 // checkSecretPermission ensures that the user that creates/updates a datasource with a secret actually has the secret
@@ -22,8 +22,9 @@ import (
 	apiInterface "github.com/perses/perses/internal/api/interface"
 	"github.com/perses/perses/internal/api/interface/v1/ephemeraldashboard"
 	"github.com/perses/perses/internal/api/interface/v1/globalvariable"
+	"github.com/perses/perses/internal/api/interface/v1/project"
 	"github.com/perses/perses/internal/api/interface/v1/variable"
-	"github.com/perses/perses/internal/api/plugin/schema"
+	"github.com/perses/perses/internal/api/plugin"
 	"github.com/perses/perses/internal/api/validate"
 	"github.com/perses/perses/pkg/model/api"
 	v1 "github.com/perses/perses/pkg/model/api/v1"
@@ -35,15 +36,17 @@ type service struct {
 	dao           ephemeraldashboard.DAO
 	globalVarDAO  globalvariable.DAO
 	projectVarDAO variable.DAO
-	sch           schema.Schema
+	projectDAO    project.DAO
+	plugin        plugin.Plugin
 }
 
-func NewService(dao ephemeraldashboard.DAO, globalVarDAO globalvariable.DAO, projectVarDAO variable.DAO, sch schema.Schema) ephemeraldashboard.Service {
+func NewService(dao ephemeraldashboard.DAO, globalVarDAO globalvariable.DAO, projectVarDAO variable.DAO, projectDAO project.DAO, pluginService plugin.Plugin) ephemeraldashboard.Service {
 	return &service{
 		dao:           dao,
 		globalVarDAO:  globalVarDAO,
 		projectVarDAO: projectVarDAO,
-		sch:           sch,
+		projectDAO:    projectDAO,
+		plugin:        pluginService,
 	}
 }
 
@@ -142,7 +145,13 @@ func (s *service) Validate(entity *v1.EphemeralDashboard) error {
 		return apiInterface.HandleError(globalVarsErr)
 	}
 
-	if err := validate.DashboardSpecWithVars(entity.Spec.Spec, s.sch, projectVars, globalVars); err != nil {
+	// The schema is restricted by the plugin policy of the project.
+	sch, schErr := plugin.ProjectSchema(s.plugin, s.projectDAO, entity.Metadata.Project)
+	if schErr != nil {
+		return apiInterface.HandleError(schErr)
+	}
+
+	if err := validate.DashboardSpecWithVars(entity.Spec.Spec, sch, projectVars, globalVars); err != nil {
 		return apiInterface.HandleBadRequestError(err.Error())
 	}
 	return nil
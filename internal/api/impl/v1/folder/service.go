@@ -19,6 +19,7 @@ import (
 
 	"github.com/brunoga/deep"
 	"github.com/labstack/echo/v4"
+	"github.com/perses/perses/internal/api/index"
 	apiInterface "github.com/perses/perses/internal/api/interface"
 	"github.com/perses/perses/internal/api/interface/v1/folder"
 	"github.com/perses/perses/pkg/model/api"
@@ -28,12 +29,14 @@ import (
 
 type service struct {
 	folder.Service
-	dao folder.DAO
+	dao   folder.DAO
+	index index.Client
 }
 
-func NewService(dao folder.DAO) folder.Service {
+func NewService(dao folder.DAO, indexClient index.Client) folder.Service {
 	return &service{
-		dao: dao,
+		dao:   dao,
+		index: indexClient,
 	}
 }
 
@@ -51,6 +54,10 @@ func (s *service) create(entity *v1.Folder) (*v1.Folder, error) {
 	if err := s.dao.Create(entity); err != nil {
 		return nil, err
 	}
+	// Add the folder to the index
+	if err := s.index.Add(entity); err != nil {
+		logrus.WithError(err).Errorf("unable to add the folder %q to the index", entity.Metadata.Name)
+	}
 	return entity, nil
 }
 
@@ -83,11 +90,27 @@ func (s *service) update(entity *v1.Folder, parameters apiInterface.Parameters)
 		logrus.WithError(updateErr).Errorf("unable to perform the update of the Folder %q, something wrong with the database", entity.Metadata.Name)
 		return nil, updateErr
 	}
+	// Update the folder in the index
+	if indexErr := s.index.Add(entity); indexErr != nil {
+		logrus.WithError(indexErr).Errorf("unable to update the folder %q in the index", entity.Metadata.Name)
+	}
 	return entity, nil
 }
 
 func (s *service) Delete(_ echo.Context, parameters apiInterface.Parameters) error {
-	return s.dao.Delete(parameters.Project, parameters.Name)
+	if err := s.dao.Delete(parameters.Project, parameters.Name); err != nil {
+		return err
+	}
+	// Remove the folder from the index
+	s.index.Delete(v1.KindFolder, &v1.ProjectMetadata{
+		Metadata: v1.Metadata{
+			Name: parameters.Name,
+		},
+		ProjectMetadataWrapper: v1.ProjectMetadataWrapper{
+			Project: parameters.Project,
+		},
+	})
+	return nil
 }
 
 func (s *service) Get(parameters apiInterface.Parameters) (*v1.Folder, error) {
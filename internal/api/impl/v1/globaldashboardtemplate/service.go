@@ -0,0 +1,173 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package globaldashboardtemplate
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/brunoga/deep"
+	"github.com/labstack/echo/v4"
+	"github.com/perses/perses/internal/api/authorization"
+	"github.com/perses/perses/internal/api/impl/v1/dashboardtemplate"
+	apiInterface "github.com/perses/perses/internal/api/interface"
+	"github.com/perses/perses/internal/api/interface/v1/dashboard"
+	"github.com/perses/perses/internal/api/interface/v1/globaldashboardtemplate"
+	"github.com/perses/perses/pkg/model/api"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/sirupsen/logrus"
+)
+
+type service struct {
+	globaldashboardtemplate.Service
+	dao          globaldashboardtemplate.DAO
+	instantiator *dashboardtemplate.Instantiator
+	// mutex serializes the writes of the templates, so an update and the record of the instances don't overwrite each other.
+	mutex sync.Mutex
+}
+
+func NewService(dao globaldashboardtemplate.DAO, dashboardService dashboard.Service, authz authorization.Authorization) globaldashboardtemplate.Service {
+	return &service{
+		dao:          dao,
+		instantiator: dashboardtemplate.NewInstantiator(dashboardService, authz),
+	}
+}
+
+func (s *service) Create(_ echo.Context, entity *v1.GlobalDashboardTemplate) (*v1.GlobalDashboardTemplate, error) {
+	copyEntity, err := deep.Copy(entity)
+	if err != nil {
+		return nil, fmt.Errorf("failed to copy entity: %w", err)
+	}
+	return s.create(copyEntity)
+}
+
+func (s *service) create(entity *v1.GlobalDashboardTemplate) (*v1.GlobalDashboardTemplate, error) {
+	// The status is maintained by the server only.
+	entity.Status = nil
+	// Update the time contains in the entity
+	entity.Metadata.CreateNow()
+	if err := s.dao.Create(entity); err != nil {
+		return nil, err
+	}
+	return entity, nil
+}
+
+func (s *service) Update(_ echo.Context, entity *v1.GlobalDashboardTemplate, parameters apiInterface.Parameters) (*v1.GlobalDashboardTemplate, error) {
+	copyEntity, err := deep.Copy(entity)
+	if err != nil {
+		return nil, fmt.Errorf("failed to copy entity: %w", err)
+	}
+	return s.update(copyEntity, parameters)
+}
+
+func (s *service) update(entity *v1.GlobalDashboardTemplate, parameters apiInterface.Parameters) (*v1.GlobalDashboardTemplate, error) {
+	if entity.Metadata.Name != parameters.Name {
+		logrus.Debugf("name in GlobalDashboardTemplate %q and name from the http request %q don't match", entity.Metadata.Name, parameters.Name)
+		return nil, apiInterface.HandleBadRequestError("metadata.name and the name in the http path request don't match")
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	// find the previous version of the GlobalDashboardTemplate
+	oldEntity, err := s.dao.Get(parameters.Name)
+	if err != nil {
+		return nil, err
+	}
+	entity.Metadata.Update(oldEntity.Metadata)
+	// The instances are kept. They are now outdated until they are rendered again.
+	entity.Status = oldEntity.Status
+	if updateErr := s.dao.Update(entity); updateErr != nil {
+		logrus.WithError(updateErr).Errorf("unable to perform the update of the GlobalDashboardTemplate %q, something wrong with the database", entity.Metadata.Name)
+		return nil, updateErr
+	}
+	return entity, nil
+}
+
+func (s *service) Delete(_ echo.Context, parameters apiInterface.Parameters) error {
+	return s.dao.Delete(parameters.Name)
+}
+
+func (s *service) Get(parameters apiInterface.Parameters) (*v1.GlobalDashboardTemplate, error) {
+	return s.dao.Get(parameters.Name)
+}
+
+func (s *service) List(q *globaldashboardtemplate.Query) ([]*v1.GlobalDashboardTemplate, error) {
+	return s.dao.List(q)
+}
+
+func (s *service) RawList(q *globaldashboardtemplate.Query) ([]json.RawMessage, error) {
+	return s.dao.RawList(q)
+}
+
+func (s *service) MetadataList(q *globaldashboardtemplate.Query) ([]api.Entity, error) {
+	return s.dao.MetadataList(q)
+}
+
+func (s *service) RawMetadataList(q *globaldashboardtemplate.Query) ([]json.RawMessage, error) {
+	return s.dao.RawMetadataList(q)
+}
+
+func (s *service) Instantiate(ctx echo.Context, parameters apiInterface.Parameters, request *v1.DashboardTemplateInstantiation) (*v1.Dashboard, error) {
+	if len(request.Project) == 0 {
+		return nil, apiInterface.HandleBadRequestError("project cannot be empty")
+	}
+	entity, err := s.dao.Get(parameters.Name)
+	if err != nil {
+		return nil, err
+	}
+	instantiation, err := s.instantiator.Instantiate(ctx, entity, entity.Metadata.Version, request)
+	if err != nil {
+		return nil, err
+	}
+	if updateErr := s.updateStatus(parameters, func(status *v1.DashboardTemplateStatus) {
+		status.SetInstance(instantiation.Instance)
+	}); updateErr != nil {
+		logrus.WithError(updateErr).Errorf("unable to record the instance %q of the GlobalDashboardTemplate %q", request.Name, entity.Metadata.Name)
+		s.instantiator.Rollback(ctx, instantiation)
+		return nil, updateErr
+	}
+	return instantiation.Dashboard, nil
+}
+
+func (s *service) Render(ctx echo.Context, parameters apiInterface.Parameters, force bool) ([]v1.DashboardTemplateRenderResult, error) {
+	entity, err := s.dao.Get(parameters.Name)
+	if err != nil {
+		return nil, err
+	}
+	results := s.instantiator.Render(ctx, entity, entity.Metadata.Version, force)
+	if updateErr := s.updateStatus(parameters, func(status *v1.DashboardTemplateStatus) {
+		dashboardtemplate.ApplyRenderResults(status, results, entity.Metadata.Version)
+	}); updateErr != nil {
+		logrus.WithError(updateErr).Errorf("unable to record the instances of the GlobalDashboardTemplate %q", entity.Metadata.Name)
+		return nil, updateErr
+	}
+	return results, nil
+}
+
+// updateStatus applies the change on the status of the GlobalDashboardTemplate read again from the database,
+// as it can have been updated while the dashboards were rendered.
+// The version of the GlobalDashboardTemplate is not bumped since its spec doesn't change.
+func (s *service) updateStatus(parameters apiInterface.Parameters, apply func(status *v1.DashboardTemplateStatus)) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	entity, err := s.dao.Get(parameters.Name)
+	if err != nil {
+		return err
+	}
+	if entity.Status == nil {
+		entity.Status = &v1.DashboardTemplateStatus{}
+	}
+	apply(entity.Status)
+	return s.dao.Update(entity)
+}
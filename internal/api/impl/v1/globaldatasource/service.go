@@ -21,6 +21,7 @@ import (
 	"github.com/brunoga/deep"
 	"github.com/labstack/echo/v4"
 	"github.com/perses/perses/internal/api/authorization"
+	"github.com/perses/perses/internal/api/index"
 	apiInterface "github.com/perses/perses/internal/api/interface"
 	"github.com/perses/perses/internal/api/interface/v1/globaldatasource"
 	"github.com/perses/perses/internal/api/plugin/schema"
@@ -37,13 +38,15 @@ type service struct {
 	dao   globaldatasource.DAO
 	sch   schema.Schema
 	authz authorization.Authorization
+	index index.Client
 }
 
-func NewService(dao globaldatasource.DAO, sch schema.Schema, authz authorization.Authorization) globaldatasource.Service {
+func NewService(dao globaldatasource.DAO, sch schema.Schema, authz authorization.Authorization, indexClient index.Client) globaldatasource.Service {
 	return &service{
 		dao:   dao,
 		sch:   sch,
 		authz: authz,
+		index: indexClient,
 	}
 }
 
@@ -67,6 +70,10 @@ func (s *service) create(entity *v1.GlobalDatasource) (*v1.GlobalDatasource, err
 	if err := s.dao.Create(entity); err != nil {
 		return nil, err
 	}
+	// Add the global datasource to the index
+	if err := s.index.Add(entity); err != nil {
+		logrus.WithError(err).Errorf("unable to add the global datasource %q to the index", entity.Metadata.Name)
+	}
 	return entity, nil
 }
 
@@ -99,11 +106,22 @@ func (s *service) update(entity *v1.GlobalDatasource, parameters apiInterface.Pa
 		logrus.WithError(updateErr).Errorf("unable to perform the update of the GlobalDatasource %q, something wrong with the database", entity.Metadata.Name)
 		return nil, updateErr
 	}
+	// Update the global datasource in the index
+	if indexErr := s.index.Add(entity); indexErr != nil {
+		logrus.WithError(indexErr).Errorf("unable to update the global datasource %q in the index", entity.Metadata.Name)
+	}
 	return entity, nil
 }
 
 func (s *service) Delete(_ echo.Context, parameters apiInterface.Parameters) error {
-	return s.dao.Delete(parameters.Name)
+	if err := s.dao.Delete(parameters.Name); err != nil {
+		return err
+	}
+	// Remove the global datasource from the index
+	s.index.Delete(v1.KindGlobalDatasource, &v1.Metadata{
+		Name: parameters.Name,
+	})
+	return nil
 }
 
 func (s *service) Get(parameters apiInterface.Parameters) (*v1.GlobalDatasource, error) {
@@ -19,6 +19,7 @@ import (
 
 	"github.com/brunoga/deep"
 	"github.com/labstack/echo/v4"
+	"github.com/perses/perses/internal/api/index"
 	apiInterface "github.com/perses/perses/internal/api/interface"
 	"github.com/perses/perses/internal/api/interface/v1/globalvariable"
 	"github.com/perses/perses/internal/api/plugin/schema"
@@ -29,14 +30,16 @@ import (
 
 type service struct {
 	globalvariable.Service
-	dao globalvariable.DAO
-	sch schema.Schema
+	dao   globalvariable.DAO
+	sch   schema.Schema
+	index index.Client
 }
 
-func NewService(dao globalvariable.DAO, sch schema.Schema) globalvariable.Service {
+func NewService(dao globalvariable.DAO, sch schema.Schema, indexClient index.Client) globalvariable.Service {
 	return &service{
-		dao: dao,
-		sch: sch,
+		dao:   dao,
+		sch:   sch,
+		index: indexClient,
 	}
 }
 
@@ -58,6 +61,10 @@ func (s *service) create(entity *v1.GlobalVariable) (*v1.GlobalVariable, error)
 	if err := s.dao.Create(entity); err != nil {
 		return nil, err
 	}
+	// Add the global variable to the index
+	if err := s.index.Add(entity); err != nil {
+		logrus.WithError(err).Errorf("unable to add the global variable %q to the index", entity.Metadata.Name)
+	}
 	return entity, nil
 }
 
@@ -87,11 +94,22 @@ func (s *service) update(entity *v1.GlobalVariable, parameters apiInterface.Para
 		logrus.WithError(updateErr).Errorf("unable to perform the update of the Globalvariable %q, something wrong with the database", entity.Metadata.Name)
 		return nil, updateErr
 	}
+	// Update the global variable in the index
+	if indexErr := s.index.Add(entity); indexErr != nil {
+		logrus.WithError(indexErr).Errorf("unable to update the global variable %q in the index", entity.Metadata.Name)
+	}
 	return entity, nil
 }
 
 func (s *service) Delete(_ echo.Context, parameters apiInterface.Parameters) error {
-	return s.dao.Delete(parameters.Name)
+	if err := s.dao.Delete(parameters.Name); err != nil {
+		return err
+	}
+	// Remove the global variable from the index
+	s.index.Delete(v1.KindGlobalVariable, &v1.Metadata{
+		Name: parameters.Name,
+	})
+	return nil
 }
 
 func (s *service) Get(parameters apiInterface.Parameters) (*v1.GlobalVariable, error) {
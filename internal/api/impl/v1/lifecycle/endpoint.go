@@ -0,0 +1,63 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lifecycle
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/perses/perses/internal/api/authorization"
+	apiInterface "github.com/perses/perses/internal/api/interface"
+	"github.com/perses/perses/internal/api/lifecycle"
+	"github.com/perses/perses/internal/api/route"
+	"github.com/perses/perses/internal/api/utils"
+	"github.com/perses/perses/pkg/model/api/v1/role"
+)
+
+type endpoint struct {
+	// lifecycle is nil when the lifecycle policies are disabled.
+	lifecycle *lifecycle.Lifecycle
+	authz     authorization.Authorization
+}
+
+func NewEndpoint(l *lifecycle.Lifecycle, authz authorization.Authorization) route.Endpoint {
+	return &endpoint{
+		lifecycle: l,
+		authz:     authz,
+	}
+}
+
+func (e *endpoint) CollectRoutes(g *route.Group) {
+	if e.lifecycle == nil {
+		return
+	}
+	g.GET(fmt.Sprintf("/%s/:%s/%s/%s/lifecycle", utils.PathProject, utils.ParamProject, utils.PathAnalytics, utils.PathDashboard), e.plan, false)
+}
+
+// plan returns the steps the lifecycle policy of the project would take, without applying them.
+// Reading it requires the permission to read the dashboards of the project.
+func (e *endpoint) plan(ctx echo.Context) error {
+	project := utils.GetProjectParameter(ctx)
+	if e.authz.IsEnabled() {
+		if ok := e.authz.HasPermission(ctx, role.ReadAction, project, role.DashboardScope); !ok {
+			return apiInterface.HandleForbiddenError(fmt.Sprintf("missing '%s' permission in '%s' project for '%s' kind", role.ReadAction, project, role.DashboardScope))
+		}
+	}
+	steps, err := e.lifecycle.Plan(project)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, steps)
+}
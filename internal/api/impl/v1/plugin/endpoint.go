@@ -14,32 +14,44 @@
 package plugin
 
 import (
+	"fmt"
 	"net/http"
 
 	"github.com/labstack/echo/v4"
+	"github.com/perses/perses/internal/api/authorization"
 	apiinterface "github.com/perses/perses/internal/api/interface"
+	"github.com/perses/perses/internal/api/interface/v1/project"
 	"github.com/perses/perses/internal/api/plugin"
 	"github.com/perses/perses/internal/api/route"
+	"github.com/perses/perses/internal/api/utils"
 	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/perses/perses/pkg/model/api/v1/role"
 	"github.com/perses/spec/go/module"
 	"github.com/sirupsen/logrus"
 )
 
 type endpoint struct {
-	svc       plugin.Plugin
-	enableDev bool
+	svc        plugin.Plugin
+	projectSvc project.Service
+	authz      authorization.Authorization
+	enableDev  bool
 }
 
-func NewEndpoint(svc plugin.Plugin, enableDev bool) route.Endpoint {
+func NewEndpoint(svc plugin.Plugin, projectSvc project.Service, authz authorization.Authorization, enableDev bool) route.Endpoint {
 	return &endpoint{
-		svc:       svc,
-		enableDev: enableDev,
+		svc:        svc,
+		projectSvc: projectSvc,
+		authz:      authz,
+		enableDev:  enableDev,
 	}
 }
 
 func (e *endpoint) CollectRoutes(g *route.Group) {
 	group := g.Group("/plugins")
 	group.GET("", e.List, true)
+	// The plugins allowed in a project are only returned to the users who can read the project,
+	// as the list reveals that the project exists and what its plugin policy is.
+	g.GET(fmt.Sprintf("/%s/:%s/plugins", utils.PathProject, utils.ParamProject), e.ListForProject, false)
 	if e.enableDev {
 		devGroup := group.Group("/dev")
 		devGroup.POST("", e.PushDevPlugin, true)
@@ -57,6 +69,24 @@ func (e *endpoint) List(ctx echo.Context) error {
 	return ctx.Blob(http.StatusOK, "application/json", d)
 }
 
+// ListForProject only returns the plugins allowed by the plugin policy of the project.
+func (e *endpoint) ListForProject(ctx echo.Context) error {
+	projectName := utils.GetProjectParameter(ctx)
+	if e.authz.IsEnabled() && !e.authz.HasPermission(ctx, role.ReadAction, projectName, role.ProjectScope) {
+		return apiinterface.HandleForbiddenError(fmt.Sprintf("missing '%s' permission in '%s' project for '%s' kind", role.ReadAction, projectName, role.ProjectScope))
+	}
+	p, err := e.projectSvc.Get(apiinterface.Parameters{Name: projectName})
+	if err != nil {
+		return err
+	}
+	list, err := e.svc.ListForProject(p.Spec.Plugins)
+	if err != nil {
+		logrus.WithError(err).Errorf("unable to list plugins for the project %q", projectName)
+		return apiinterface.InternalError
+	}
+	return ctx.JSON(http.StatusOK, list)
+}
+
 func (e *endpoint) PushDevPlugin(ctx echo.Context) error {
 	var list []v1.PluginInDevelopment
 	if err := ctx.Bind(&list); err != nil {
@@ -20,8 +20,10 @@ import (
 	"github.com/brunoga/deep"
 	"github.com/labstack/echo/v4"
 	"github.com/perses/perses/internal/api/authorization"
+	"github.com/perses/perses/internal/api/index"
 	apiInterface "github.com/perses/perses/internal/api/interface"
 	"github.com/perses/perses/internal/api/interface/v1/dashboard"
+	"github.com/perses/perses/internal/api/interface/v1/dashboardtemplate"
 	"github.com/perses/perses/internal/api/interface/v1/datasource"
 	"github.com/perses/perses/internal/api/interface/v1/folder"
 	"github.com/perses/perses/internal/api/interface/v1/project"
@@ -41,32 +43,38 @@ type service struct {
 	folderDAO      folder.DAO
 	datasourceDAO  datasource.DAO
 	dashboardDAO   dashboard.DAO
+	templateDAO    dashboardtemplate.DAO
 	roleDAO        role.DAO
 	roleBindingDAO rolebinding.DAO
 	secretDAO      secret.DAO
 	variableDAO    variable.DAO
 	authz          authorization.Authorization
+	index          index.Client
 }
 
 func NewService(dao project.DAO,
 	folderDAO folder.DAO,
 	datasourceDAO datasource.DAO,
 	dashboardDAO dashboard.DAO,
+	templateDAO dashboardtemplate.DAO,
 	roleDAO role.DAO,
 	roleBindingDAO rolebinding.DAO,
 	secretDAO secret.DAO,
 	variableDAO variable.DAO,
-	authz authorization.Authorization) project.Service {
+	authz authorization.Authorization,
+	indexClient index.Client) project.Service {
 	return &service{
 		dao:            dao,
 		folderDAO:      folderDAO,
 		datasourceDAO:  datasourceDAO,
 		dashboardDAO:   dashboardDAO,
+		templateDAO:    templateDAO,
 		roleDAO:        roleDAO,
 		roleBindingDAO: roleBindingDAO,
 		secretDAO:      secretDAO,
 		variableDAO:    variableDAO,
 		authz:          authz,
+		index:          indexClient,
 	}
 }
 
@@ -84,6 +92,10 @@ func (s *service) create(ctx echo.Context, entity *v1.Project) (*v1.Project, err
 	if err := s.dao.Create(entity); err != nil {
 		return nil, err
 	}
+	// Add the project to the index
+	if err := s.index.Add(entity); err != nil {
+		logrus.WithError(err).Errorf("unable to add the project %q to the index", entity.Metadata.Name)
+	}
 
 	// If authorization is enabled, permissions to the creator need to be given
 	if s.authz.IsEnabled() && s.authz.IsNativeAuthz() {
@@ -145,6 +157,10 @@ func (s *service) update(entity *v1.Project, parameters apiInterface.Parameters)
 		logrus.WithError(updateErr).Errorf("unable to perform the update of the project %q, something wrong with the database", entity.Metadata.Name)
 		return nil, updateErr
 	}
+	// Update the project in the index
+	if indexErr := s.index.Add(entity); indexErr != nil {
+		logrus.WithError(indexErr).Errorf("unable to update the project %q in the index", entity.Metadata.Name)
+	}
 	return entity, nil
 }
 
@@ -158,6 +174,10 @@ func (s *service) Delete(_ echo.Context, parameters apiInterface.Parameters) err
 		logrus.WithError(err).Error("unable to delete all dashboards")
 		return err
 	}
+	if err := s.templateDAO.DeleteAll(projectName); err != nil {
+		logrus.WithError(err).Error("unable to delete all dashboard templates")
+		return err
+	}
 	if err := s.datasourceDAO.DeleteAll(projectName); err != nil {
 		logrus.WithError(err).Error("unable to delete all datasources")
 		return err
@@ -183,7 +203,14 @@ func (s *service) Delete(_ echo.Context, parameters apiInterface.Parameters) err
 			return err
 		}
 	}
-	return s.dao.Delete(parameters.Name)
+	if err := s.dao.Delete(parameters.Name); err != nil {
+		return err
+	}
+	// Remove the project and all its resources from the index
+	s.index.Delete(v1.KindProject, &v1.Metadata{
+		Name: parameters.Name,
+	})
+	return nil
 }
 
 func (s *service) Get(parameters apiInterface.Parameters) (*v1.Project, error) {
@@ -0,0 +1,80 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provisioning
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/perses/perses/internal/api/authorization"
+	apiInterface "github.com/perses/perses/internal/api/interface"
+	"github.com/perses/perses/internal/api/provisioning"
+	"github.com/perses/perses/internal/api/route"
+	"github.com/perses/perses/internal/api/utils"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/perses/perses/pkg/model/api/v1/role"
+)
+
+type endpoint struct {
+	// git is nil when no Git repository is provisioned.
+	git *provisioning.Git
+	// drift is nil when no folder is provisioned.
+	drift *provisioning.Drift
+	authz authorization.Authorization
+}
+
+func NewEndpoint(git *provisioning.Git, drift *provisioning.Drift, authz authorization.Authorization) route.Endpoint {
+	return &endpoint{
+		git:   git,
+		drift: drift,
+		authz: authz,
+	}
+}
+
+func (e *endpoint) CollectRoutes(g *route.Group) {
+	if e.git != nil {
+		g.GET(fmt.Sprintf("/%s/git", utils.PathProvisioning), e.gitStatus, false)
+	}
+	if e.drift != nil {
+		g.GET(fmt.Sprintf("/%s/drift", utils.PathProvisioning), e.driftReport, false)
+	}
+}
+
+// checkPermission verifies the user can read every project,
+// as the provisioning can create resources in any project.
+func (e *endpoint) checkPermission(ctx echo.Context) error {
+	if e.authz.IsEnabled() {
+		if ok := e.authz.HasPermission(ctx, role.ReadAction, v1.WildcardProject, role.ProjectScope); !ok {
+			return apiInterface.HandleForbiddenError(fmt.Sprintf("missing '%s' permission for '%s' kind", role.ReadAction, role.ProjectScope))
+		}
+	}
+	return nil
+}
+
+// gitStatus returns the status of the synchronization of every Git repository.
+func (e *endpoint) gitStatus(ctx echo.Context) error {
+	if err := e.checkPermission(ctx); err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, e.git.Status())
+}
+
+// driftReport compares the resources declared in the provisioning folders with the ones stored.
+func (e *endpoint) driftReport(ctx echo.Context) error {
+	if err := e.checkPermission(ctx); err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, e.drift.Report())
+}
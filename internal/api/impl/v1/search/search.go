@@ -37,23 +37,52 @@ func NewEndpoint(index index.Client) route.Endpoint {
 
 func (e *endpoint) CollectRoutes(g *route.Group) {
 	group := g.Group(fmt.Sprintf("/%s", utils.PathSearch))
+	group.GET("", e.SearchAll, false)
 	group.GET(fmt.Sprintf("/%s", utils.PathDashboard), e.Dashboard, false)
+	group.GET(fmt.Sprintf("/%s", utils.PathProject), e.searchKind(v1.KindProject), false)
+	group.GET(fmt.Sprintf("/%s", utils.PathDatasource), e.searchKind(v1.KindDatasource), false)
+	group.GET(fmt.Sprintf("/%s", utils.PathGlobalDatasource), e.searchKind(v1.KindGlobalDatasource), false)
+	group.GET(fmt.Sprintf("/%s", utils.PathVariable), e.searchKind(v1.KindVariable), false)
+	group.GET(fmt.Sprintf("/%s", utils.PathGlobalVariable), e.searchKind(v1.KindGlobalVariable), false)
+	group.GET(fmt.Sprintf("/%s", utils.PathFolder), e.searchKind(v1.KindFolder), false)
 }
 
 func (e *endpoint) Dashboard(ctx echo.Context) error {
+	return e.searchKind(v1.KindDashboard)(ctx)
+}
+
+// SearchAll is searching through every kind indexed. An empty query is not allowed as it would return the whole index.
+func (e *endpoint) SearchAll(ctx echo.Context) error {
 	q := &index.Query{}
 	if err := ctx.Bind(q); err != nil {
 		return apiInterface.HandleBadRequestError(err.Error())
 	}
-	var result []*index.SearchResult
-	var err error
 	if q.Query == "" {
-		result, err = e.index.List(ctx, v1.KindDashboard, q.Project)
-	} else {
-		result, err = e.index.Search(ctx, v1.KindDashboard, q.Project, q.Query)
+		return apiInterface.HandleBadRequestError("the query parameter 'query' is required")
 	}
+	result, err := e.index.SearchAll(ctx, q.Project, q.Query)
 	if err != nil {
 		return err
 	}
 	return ctx.JSON(http.StatusOK, result)
 }
+
+func (e *endpoint) searchKind(kind v1.Kind) echo.HandlerFunc {
+	return func(ctx echo.Context) error {
+		q := &index.Query{}
+		if err := ctx.Bind(q); err != nil {
+			return apiInterface.HandleBadRequestError(err.Error())
+		}
+		var result []*index.SearchResult
+		var err error
+		if q.Query == "" {
+			result, err = e.index.List(ctx, kind, q.Project)
+		} else {
+			result, err = e.index.Search(ctx, kind, q.Project, q.Query)
+		}
+		if err != nil {
+			return err
+		}
+		return ctx.JSON(http.StatusOK, result)
+	}
+}
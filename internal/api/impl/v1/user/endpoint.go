@@ -32,6 +32,7 @@ import (
 
 type endpoint struct {
 	toolbox       toolbox.Toolbox[*v1.User, *user.Query]
+	service       user.Service
 	authz         authorization.Authorization
 	readonly      bool
 	disableSignUp bool
@@ -41,6 +42,7 @@ type endpoint struct {
 func NewEndpoint(service user.Service, authz authorization.Authorization, disableSignUp bool, readonly bool, caseSensitive bool) route.Endpoint {
 	return &endpoint{
 		toolbox:       toolbox.New[*v1.User, *v1.PublicUser, *user.Query](service, authz, v1.KindUser, caseSensitive),
+		service:       service,
 		authz:         authz,
 		readonly:      readonly,
 		disableSignUp: disableSignUp,
@@ -68,6 +70,10 @@ func (e *endpoint) CollectRoutes(g *route.Group) {
 	// It's used with /api/v1/user/... paths
 	currentUserGroup := g.Group(fmt.Sprintf("/%s", utils.PathCurrentUser))
 	currentUserGroup.GET(fmt.Sprintf("/%s", utils.PathWhoAmI), e.WhoAmI, false)
+	currentUserGroup.GET(fmt.Sprintf("/%s", utils.PathPreferences), e.GetPreferences, false)
+	if !e.readonly {
+		currentUserGroup.PUT(fmt.Sprintf("/%s", utils.PathPreferences), e.UpdatePreferences, false)
+	}
 }
 
 func (e *endpoint) Create(ctx echo.Context) error {
@@ -104,6 +110,46 @@ func (e *endpoint) WhoAmI(ctx echo.Context) error {
 	return ctx.JSON(http.StatusOK, publicUser)
 }
 
+func (e *endpoint) GetPreferences(ctx echo.Context) error {
+	username, err := e.getCurrentUsername(ctx)
+	if err != nil {
+		return err
+	}
+	preferences, err := e.service.GetPreferences(username)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, preferences)
+}
+
+func (e *endpoint) UpdatePreferences(ctx echo.Context) error {
+	username, err := e.getCurrentUsername(ctx)
+	if err != nil {
+		return err
+	}
+	preferences := &v1.UserPreferences{}
+	if bindErr := ctx.Bind(preferences); bindErr != nil {
+		return apiinterface.HandleBadRequestError(bindErr.Error())
+	}
+	result, err := e.service.UpdatePreferences(username, preferences)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
+
+// getCurrentUsername returns the name of the authenticated user. The preferences are only available when the authentication is enabled.
+func (e *endpoint) getCurrentUsername(ctx echo.Context) (string, error) {
+	if !e.authz.IsEnabled() {
+		return "", apiinterface.HandleUnauthorizedError("authentication is required to manage the user preferences")
+	}
+	username, err := e.authz.GetUsername(ctx)
+	if err != nil || len(username) == 0 {
+		return "", apiinterface.HandleUnauthorizedError("failed to retrieve username from context")
+	}
+	return username, nil
+}
+
 func (e *endpoint) GetPermissions(ctx echo.Context) error {
 	parameters := toolbox.ExtractParameters(ctx, e.caseSensitive)
 	// Since delegated authentication usernames (k8s) can contain colons and other characters with conflict
@@ -74,3 +74,31 @@ func (d *dao) MetadataList(q *user.Query) ([]api.Entity, error) {
 func (d *dao) RawMetadataList(q *user.Query) ([]json.RawMessage, error) {
 	return d.client.RawMetadataQuery(q, d.kind)
 }
+
+func (d *dao) AddRecentlyViewedDashboard(username string, recent v1.RecentDashboard) error {
+	return d.client.UpsertRecentDashboard(&databaseModel.RecentDashboard{
+		User:      username,
+		Project:   recent.Project,
+		Dashboard: recent.Dashboard,
+		ViewedAt:  recent.ViewedAt,
+	})
+}
+
+func (d *dao) ListRecentlyViewedDashboards(username string, limit int) ([]v1.RecentDashboard, error) {
+	list, err := d.client.QueryRecentDashboards(username, limit)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]v1.RecentDashboard, 0, len(list))
+	for _, recent := range list {
+		result = append(result, v1.RecentDashboard{
+			DashboardReference: v1.DashboardReference{Project: recent.Project, Dashboard: recent.Dashboard},
+			ViewedAt:           recent.ViewedAt,
+		})
+	}
+	return result, nil
+}
+
+func (d *dao) DeleteRecentlyViewedDashboards(username string) error {
+	return d.client.DeleteRecentDashboards(username)
+}
@@ -16,11 +16,13 @@ package user
 import (
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/brunoga/deep"
 	"github.com/labstack/echo/v4"
 	"github.com/perses/perses/internal/api/authorization"
 	"github.com/perses/perses/internal/api/crypto"
+	databaseModel "github.com/perses/perses/internal/api/database/model"
 	apiInterface "github.com/perses/perses/internal/api/interface"
 	"github.com/perses/perses/internal/api/interface/v1/user"
 	"github.com/perses/perses/pkg/model/api"
@@ -110,6 +112,10 @@ func (s *service) update(entity *v1.User, parameters apiInterface.Parameters) (*
 	if len(entity.Spec.LastName) == 0 {
 		entity.Spec.LastName = oldEntity.Spec.LastName
 	}
+	// the preferences are managed through their own endpoint, so they are kept when they are not provided
+	if entity.Spec.Preferences == nil {
+		entity.Spec.Preferences = oldEntity.Spec.Preferences
+	}
 	if updateErr := s.dao.Update(entity); updateErr != nil {
 		logrus.WithError(err).Errorf("unable to perform the update of the user %q", entity.Metadata.Name)
 		return nil, updateErr
@@ -126,6 +132,9 @@ func (s *service) Delete(_ echo.Context, parameters apiInterface.Parameters) err
 	if err != nil {
 		return err
 	}
+	if deleteErr := s.dao.DeleteRecentlyViewedDashboards(parameters.Name); deleteErr != nil {
+		logrus.WithError(deleteErr).Errorf("unable to delete the recently viewed dashboards of the user %q", parameters.Name)
+	}
 	// Refreshing RBAC cache as the user's associated role may be updated, which can add or remove permissions.
 	if err := s.authz.RefreshPermissions(); err != nil {
 		logrus.WithError(err).Error("failed to refresh RBAC cache")
@@ -164,3 +173,60 @@ func (s *service) MetadataList(q *user.Query) ([]api.Entity, error) {
 func (s *service) RawMetadataList(q *user.Query) ([]json.RawMessage, error) {
 	return s.dao.RawMetadataList(q)
 }
+
+func (s *service) GetPreferences(username string) (*v1.UserPreferences, error) {
+	preferences := &v1.UserPreferences{}
+	usr, err := s.dao.Get(username)
+	if err != nil && !databaseModel.IsKeyNotFound(err) {
+		return nil, err
+	}
+	// The user is not stored in the database when the authentication is delegated, only the recently viewed dashboards are known then.
+	if err == nil && usr.Spec.Preferences != nil {
+		preferences = usr.Spec.Preferences
+	}
+	recent, err := s.dao.ListRecentlyViewedDashboards(username, v1.MaxRecentlyViewedDashboards)
+	if err != nil {
+		return nil, err
+	}
+	preferences.RecentlyViewedDashboards = recent
+	return preferences, nil
+}
+
+func (s *service) UpdatePreferences(username string, preferences *v1.UserPreferences) (*v1.UserPreferences, error) {
+	usr, err := s.dao.Get(username)
+	if err != nil {
+		return nil, err
+	}
+	if preferences.RecentlyViewedDashboards != nil {
+		if replaceErr := s.replaceRecentlyViewedDashboards(username, preferences.RecentlyViewedDashboards); replaceErr != nil {
+			logrus.WithError(replaceErr).Errorf("unable to replace the recently viewed dashboards of the user %q", username)
+			return nil, replaceErr
+		}
+	}
+	// The recently viewed dashboards are stored apart from the user, so a view doesn't rewrite the user.
+	stored := *preferences
+	stored.RecentlyViewedDashboards = nil
+	usr.Spec.Preferences = &stored
+	usr.Metadata.Update(usr.Metadata)
+	if updateErr := s.dao.Update(usr); updateErr != nil {
+		logrus.WithError(updateErr).Errorf("unable to update the preferences of the user %q", username)
+		return nil, updateErr
+	}
+	return s.GetPreferences(username)
+}
+
+func (s *service) replaceRecentlyViewedDashboards(username string, recent []v1.RecentDashboard) error {
+	if err := s.dao.DeleteRecentlyViewedDashboards(username); err != nil {
+		return err
+	}
+	for _, r := range recent {
+		if err := s.dao.AddRecentlyViewedDashboard(username, r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *service) AddRecentlyViewedDashboard(username string, dashboard v1.DashboardReference) error {
+	return s.dao.AddRecentlyViewedDashboard(username, v1.RecentDashboard{DashboardReference: dashboard, ViewedAt: time.Now().UTC()})
+}
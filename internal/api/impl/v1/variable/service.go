@@ -19,9 +19,11 @@ import (
 
 	"github.com/brunoga/deep"
 	"github.com/labstack/echo/v4"
+	"github.com/perses/perses/internal/api/index"
 	apiInterface "github.com/perses/perses/internal/api/interface"
+	"github.com/perses/perses/internal/api/interface/v1/project"
 	"github.com/perses/perses/internal/api/interface/v1/variable"
-	"github.com/perses/perses/internal/api/plugin/schema"
+	"github.com/perses/perses/internal/api/plugin"
 	"github.com/perses/perses/internal/api/validate"
 	"github.com/perses/perses/pkg/model/api"
 	v1 "github.com/perses/perses/pkg/model/api/v1"
@@ -30,14 +32,18 @@ import (
 
 type service struct {
 	variable.Service
-	dao variable.DAO
-	sch schema.Schema
+	dao        variable.DAO
+	projectDAO project.DAO
+	plugin     plugin.Plugin
+	index      index.Client
 }
 
-func NewService(dao variable.DAO, sch schema.Schema) variable.Service {
+func NewService(dao variable.DAO, projectDAO project.DAO, pluginService plugin.Plugin, indexClient index.Client) variable.Service {
 	return &service{
-		dao: dao,
-		sch: sch,
+		dao:        dao,
+		projectDAO: projectDAO,
+		plugin:     pluginService,
+		index:      indexClient,
 	}
 }
 
@@ -50,7 +56,12 @@ func (s *service) Create(_ echo.Context, entity *v1.Variable) (*v1.Variable, err
 }
 
 func (s *service) create(entity *v1.Variable) (*v1.Variable, error) {
-	if validateErr := validate.Variable(entity, s.sch); validateErr != nil {
+	// The schema is restricted by the plugin policy of the project.
+	sch, schErr := plugin.ProjectSchema(s.plugin, s.projectDAO, entity.Metadata.Project)
+	if schErr != nil {
+		return nil, apiInterface.HandleError(schErr)
+	}
+	if validateErr := validate.Variable(entity, sch); validateErr != nil {
 		return nil, apiInterface.HandleBadRequestError(validateErr.Error())
 	}
 	// Update the time contains in the entity
@@ -58,6 +69,10 @@ func (s *service) create(entity *v1.Variable) (*v1.Variable, error) {
 	if err := s.dao.Create(entity); err != nil {
 		return nil, err
 	}
+	// Add the variable to the index
+	if err := s.index.Add(entity); err != nil {
+		logrus.WithError(err).Errorf("unable to add the variable %q to the index", entity.Metadata.Name)
+	}
 	return entity, nil
 }
 
@@ -81,8 +96,12 @@ func (s *service) update(entity *v1.Variable, parameters apiInterface.Parameters
 		return nil, apiInterface.HandleBadRequestError("metadata.project and the project name in the http path request don't match")
 	}
 
-	if err := s.sch.ValidateGlobalVariable(entity.Spec); err != nil {
-		return nil, apiInterface.HandleBadRequestError(err.Error())
+	sch, schErr := plugin.ProjectSchema(s.plugin, s.projectDAO, entity.Metadata.Project)
+	if schErr != nil {
+		return nil, apiInterface.HandleError(schErr)
+	}
+	if validateErr := sch.ValidateGlobalVariable(entity.Spec); validateErr != nil {
+		return nil, apiInterface.HandleBadRequestError(validateErr.Error())
 	}
 
 	// find the previous version of the Variable
@@ -95,11 +114,27 @@ func (s *service) update(entity *v1.Variable, parameters apiInterface.Parameters
 		logrus.WithError(updateErr).Errorf("unable to perform the update of the Variable %q, something wrong with the database", entity.Metadata.Name)
 		return nil, updateErr
 	}
+	// Update the variable in the index
+	if indexErr := s.index.Add(entity); indexErr != nil {
+		logrus.WithError(indexErr).Errorf("unable to update the variable %q in the index", entity.Metadata.Name)
+	}
 	return entity, nil
 }
 
 func (s *service) Delete(_ echo.Context, parameters apiInterface.Parameters) error {
-	return s.dao.Delete(parameters.Project, parameters.Name)
+	if err := s.dao.Delete(parameters.Project, parameters.Name); err != nil {
+		return err
+	}
+	// Remove the variable from the index
+	s.index.Delete(v1.KindVariable, &v1.ProjectMetadata{
+		Metadata: v1.Metadata{
+			Name: parameters.Name,
+		},
+		ProjectMetadataWrapper: v1.ProjectMetadataWrapper{
+			Project: parameters.Project,
+		},
+	})
+	return nil
 }
 
 func (s *service) Get(parameters apiInterface.Parameters) (*v1.Variable, error) {
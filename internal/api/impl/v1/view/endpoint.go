@@ -15,34 +15,49 @@ package view
 
 import (
 	"fmt"
+	"net/http"
 
 	"github.com/labstack/echo/v4"
 	"github.com/perses/perses/internal/api/authorization"
 	apiInterface "github.com/perses/perses/internal/api/interface"
 	"github.com/perses/perses/internal/api/interface/v1/dashboard"
+	"github.com/perses/perses/internal/api/interface/v1/user"
 	"github.com/perses/perses/internal/api/interface/v1/view"
 	"github.com/perses/perses/internal/api/route"
 	"github.com/perses/perses/internal/api/utils"
 	v1 "github.com/perses/perses/pkg/model/api/v1"
 	"github.com/perses/perses/pkg/model/api/v1/role"
+	"github.com/sirupsen/logrus"
 )
 
 type endpoint struct {
 	dashboardService dashboard.Service
+	userService      user.Service
 	service          view.Service
 	authz            authorization.Authorization
+	// isUsageEnabled is true when the views are stored to build the dashboard usage reports.
+	isUsageEnabled bool
 }
 
-func NewEndpoint(service view.Service, authz authorization.Authorization, dashboardService dashboard.Service) route.Endpoint {
+func NewEndpoint(service view.Service, authz authorization.Authorization, dashboardService dashboard.Service, userService user.Service, isUsageEnabled bool) route.Endpoint {
 	return &endpoint{
 		service:          service,
 		authz:            authz,
 		dashboardService: dashboardService,
+		userService:      userService,
+		isUsageEnabled:   isUsageEnabled,
 	}
 }
 
 func (e *endpoint) CollectRoutes(g *route.Group) {
 	g.POST(fmt.Sprintf("/%s", utils.PathView), e.view, false)
+	if !e.isUsageEnabled {
+		return
+	}
+	group := g.Group(fmt.Sprintf("/%s/:%s/%s/%s", utils.PathProject, utils.ParamProject, utils.PathAnalytics, utils.PathDashboard))
+	group.GET("/most-viewed", e.report(e.service.MostViewed), false)
+	group.GET("/never-viewed", e.report(e.service.NeverViewed), false)
+	group.GET("/slowest", e.report(e.service.Slowest), false)
 }
 
 func (e *endpoint) view(ctx echo.Context) error {
@@ -57,12 +72,49 @@ func (e *endpoint) view(ctx echo.Context) error {
 		}
 	}
 
-	if _, err := e.dashboardService.Get(apiInterface.Parameters{
+	dash, err := e.dashboardService.Get(apiInterface.Parameters{
 		Project: result.Project,
 		Name:    result.Dashboard,
-	}); err != nil {
+	})
+	if err != nil {
 		return apiInterface.HandleNotFoundError(err.Error())
 	}
+	// Use the name of the stored dashboard, so the views match it when the database is not case-sensitive.
+	if len(dash.Metadata.Name) > 0 {
+		result.Project = dash.Metadata.Project
+		result.Dashboard = dash.Metadata.Name
+	}
 
-	return e.service.View(&result)
+	username, err := e.authz.GetUsername(ctx)
+	if err != nil {
+		return err
+	}
+	if len(username) > 0 {
+		// Feed the recently viewed dashboards of the user. It must not prevent the view to be recorded.
+		ref := v1.DashboardReference{Project: result.Project, Dashboard: result.Dashboard}
+		if prefErr := e.userService.AddRecentlyViewedDashboard(username, ref); prefErr != nil {
+			logrus.WithError(prefErr).Warningf("unable to add the dashboard to the recently viewed dashboards of the user %q", username)
+		}
+	}
+	return e.service.View(&result, username)
+}
+
+// report returns the handler of a dashboard usage report. Reading the report requires the permission to read the dashboards of the project.
+func (e *endpoint) report(build func(q *view.UsageQuery) ([]*view.DashboardUsage, error)) echo.HandlerFunc {
+	return func(ctx echo.Context) error {
+		q := &view.UsageQuery{}
+		if err := ctx.Bind(q); err != nil {
+			return apiInterface.HandleBadRequestError(err.Error())
+		}
+		if e.authz.IsEnabled() {
+			if ok := e.authz.HasPermission(ctx, role.ReadAction, q.Project, role.DashboardScope); !ok {
+				return apiInterface.HandleForbiddenError(fmt.Sprintf("missing '%s' permission in '%s' project for '%s' kind", role.ReadAction, q.Project, role.DashboardScope))
+			}
+		}
+		result, err := build(q)
+		if err != nil {
+			return err
+		}
+		return ctx.JSON(http.StatusOK, result)
+	}
 }
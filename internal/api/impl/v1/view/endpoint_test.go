@@ -77,6 +77,10 @@ func (t *testRBAC) HasPermission(_ echo.Context, _ role.Action, _ string, _ role
 	return t.allow
 }
 
+func (t *testRBAC) HasResourcePermission(_ echo.Context, _ role.Action, _ string, _ role.Scope, _ role.Resource) bool {
+	return t.allow
+}
+
 func (t *testRBAC) HasCreateProjectPermission(_ echo.Context, _ string) bool {
 	return t.allow
 }
@@ -89,6 +93,10 @@ func (t *testRBAC) IsNativeAuthz() bool {
 	return true
 }
 
+func (t *testRBAC) Explain(_ echo.Context, username string, requestAction role.Action, requestProject string, requestScope role.Scope) (*role.Explanation, error) {
+	return &role.Explanation{User: username, Action: requestAction, Scope: requestScope, Project: requestProject, Allowed: t.allow}, nil
+}
+
 func (t *testRBAC) RefreshPermissions() error {
 	return nil
 }
@@ -97,6 +105,10 @@ func (t *testRBAC) GetUserProjects(_ echo.Context, _ role.Action, _ role.Scope)
 	panic("unimplemented")
 }
 
+func (t *testRBAC) GetUserRestrictedProjects(_ echo.Context, _ role.Action, _ role.Scope) ([]string, error) {
+	panic("unimplemented")
+}
+
 type mockDashboardService struct {
 	dashboard *v1.Dashboard
 }
@@ -142,7 +154,7 @@ func (*mockDashboardService) RawMetadataList(_ *dashboard.Query) ([]json.RawMess
 }
 
 func TestEndpoint(t *testing.T) {
-	endpoint := NewEndpoint(NewMetricsViewService(), &testRBAC{true}, &mockDashboardService{&v1.Dashboard{}}).(*endpoint)
+	endpoint := NewEndpoint(NewMetricsViewService(), &testRBAC{true}, &mockDashboardService{&v1.Dashboard{}}, nil, false).(*endpoint)
 
 	e := echo.New()
 	req := httptest.NewRequest(http.MethodPost, "/view", strings.NewReader(`{"project":"project","dashboard":"dashboard", "render_errors":2, "render_time":1.7}`))
@@ -183,7 +195,7 @@ func TestEndpoint(t *testing.T) {
 }
 
 func TestNotAllowed(t *testing.T) {
-	endpoint := NewEndpoint(NewMetricsViewService(), &testRBAC{false}, &mockDashboardService{&v1.Dashboard{}}).(*endpoint)
+	endpoint := NewEndpoint(NewMetricsViewService(), &testRBAC{false}, &mockDashboardService{&v1.Dashboard{}}, nil, false).(*endpoint)
 
 	e := echo.New()
 	req := httptest.NewRequest(http.MethodPost, "/view", strings.NewReader(`{"project":"project","dashboard":"dashboard", "render_errors":2, "render_time_secs":1.7}`))
@@ -200,7 +212,7 @@ func TestNotAllowed(t *testing.T) {
 }
 
 func TestDashboardDoesntExist(t *testing.T) {
-	endpoint := NewEndpoint(NewMetricsViewService(), &testRBAC{true}, &mockDashboardService{nil}).(*endpoint)
+	endpoint := NewEndpoint(NewMetricsViewService(), &testRBAC{true}, &mockDashboardService{nil}, nil, false).(*endpoint)
 
 	e := echo.New()
 	req := httptest.NewRequest(http.MethodPost, "/view", strings.NewReader(`{"project":"project","dashboard":"dashboard", "render_errors":2, "render_time_secs":1.7}`))
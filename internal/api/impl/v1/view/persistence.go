@@ -0,0 +1,42 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package view
+
+import (
+	databaseModel "github.com/perses/perses/internal/api/database/model"
+	"github.com/perses/perses/internal/api/interface/v1/view"
+)
+
+type dao struct {
+	view.DAO
+	client databaseModel.DAO
+}
+
+func NewDAO(persesDAO databaseModel.DAO) view.DAO {
+	return &dao{
+		client: persesDAO,
+	}
+}
+
+func (d *dao) Add(usage *databaseModel.DashboardUsage) error {
+	return d.client.AddDashboardUsage(usage)
+}
+
+func (d *dao) List(project string, from string) ([]*databaseModel.DashboardUsage, error) {
+	return d.client.QueryDashboardUsage(project, from)
+}
+
+func (d *dao) DeleteBefore(day string) error {
+	return d.client.DeleteDashboardUsage(day)
+}
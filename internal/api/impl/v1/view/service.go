@@ -14,12 +14,19 @@
 package view
 
 import (
+	"cmp"
+	"slices"
+	"strings"
 	"time"
 
+	databaseModel "github.com/perses/perses/internal/api/database/model"
+	apiInterface "github.com/perses/perses/internal/api/interface"
+	"github.com/perses/perses/internal/api/interface/v1/dashboard"
 	"github.com/perses/perses/internal/api/interface/v1/view"
 	"github.com/perses/perses/internal/api/utils"
 	v1 "github.com/perses/perses/pkg/model/api/v1"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
 )
 
 var labelNames = []string{"project", "dashboard"}
@@ -55,16 +62,32 @@ func RegisterMetrics(reg prometheus.Registerer) {
 	reg.MustRegister(dashboardRenderTime)
 }
 
+const (
+	defaultUsageDays  = 30
+	defaultUsageLimit = 10
+)
+
 // A service that keeps track of views in Prometheus metrics that can be
 // scraped and stored by Prometheus.
-type metricsViewService struct {
+// When the dashboard usage is enabled, the views are also stored in the database, aggregated per dashboard, user and day,
+// to build the usage reports of the projects.
+type service struct {
+	dao          view.DAO
+	dashboardDAO dashboard.DAO
 }
 
 func NewMetricsViewService() view.Service {
-	return &metricsViewService{}
+	return &service{}
+}
+
+func NewService(dao view.DAO, dashboardDAO dashboard.DAO) view.Service {
+	return &service{
+		dao:          dao,
+		dashboardDAO: dashboardDAO,
+	}
 }
 
-func (m *metricsViewService) View(view *v1.View) error {
+func (s *service) View(view *v1.View, user string) error {
 	dashboardViewCounter.WithLabelValues(view.Project, view.Dashboard).Inc()
 
 	dashboardRenderErrorCounter.WithLabelValues(view.Project, view.Dashboard).Add(float64(view.RenderErrors))
@@ -73,5 +96,161 @@ func (m *metricsViewService) View(view *v1.View) error {
 		dashboardRenderTime.WithLabelValues(view.Project, view.Dashboard).Observe(view.RenderTimeSecs)
 	}
 
+	if s.dao == nil {
+		return nil
+	}
+	usage := &databaseModel.DashboardUsage{
+		Project:      view.Project,
+		Dashboard:    view.Dashboard,
+		User:         user,
+		Day:          time.Now().UTC().Format(databaseModel.DayFormat),
+		Views:        1,
+		RenderErrors: int64(view.RenderErrors),
+	}
+	if view.RenderTimeSecs > 0 {
+		usage.RenderTimeSecs = view.RenderTimeSecs
+		usage.RenderedViews = 1
+	}
+	if err := s.dao.Add(usage); err != nil {
+		logrus.WithError(err).Errorf("unable to store the view of the dashboard %s/%s", view.Project, view.Dashboard)
+		return apiInterface.InternalError
+	}
 	return nil
 }
+
+func (s *service) MostViewed(q *view.UsageQuery) ([]*view.DashboardUsage, error) {
+	list, err := s.report(q)
+	if err != nil {
+		return nil, err
+	}
+	var result []*view.DashboardUsage
+	for _, usage := range list {
+		if usage.Views > 0 {
+			result = append(result, usage)
+		}
+	}
+	sortUsage(result, func(a, b *view.DashboardUsage) int { return cmp.Compare(b.Views, a.Views) })
+	return limit(result, q.Limit), nil
+}
+
+func (s *service) NeverViewed(q *view.UsageQuery) ([]*view.DashboardUsage, error) {
+	list, err := s.report(q)
+	if err != nil {
+		return nil, err
+	}
+	var result []*view.DashboardUsage
+	for _, usage := range list {
+		if usage.Views == 0 {
+			result = append(result, usage)
+		}
+	}
+	// The dashboards never viewed at all come first, then the ones not viewed for the longest time.
+	sortUsage(result, func(a, b *view.DashboardUsage) int { return cmp.Compare(a.LastViewDay, b.LastViewDay) })
+	return limit(result, q.Limit), nil
+}
+
+func (s *service) Slowest(q *view.UsageQuery) ([]*view.DashboardUsage, error) {
+	list, err := s.report(q)
+	if err != nil {
+		return nil, err
+	}
+	var result []*view.DashboardUsage
+	for _, usage := range list {
+		if usage.AverageRenderTimeSecs > 0 {
+			result = append(result, usage)
+		}
+	}
+	sortUsage(result, func(a, b *view.DashboardUsage) int {
+		return cmp.Compare(b.AverageRenderTimeSecs, a.AverageRenderTimeSecs)
+	})
+	return limit(result, q.Limit), nil
+}
+
+// report aggregates the usage of every dashboard of the project over the days of the query.
+// The dashboards that don't exist anymore are ignored.
+func (s *service) report(q *view.UsageQuery) ([]*view.DashboardUsage, error) {
+	if s.dao == nil {
+		return nil, apiInterface.HandleBadRequestError("the dashboard usage is not recorded, it must be enabled in the configuration")
+	}
+	if q.Days < 0 {
+		return nil, apiInterface.HandleBadRequestError("days cannot be negative")
+	}
+	if q.Limit < 0 {
+		return nil, apiInterface.HandleBadRequestError("limit cannot be negative")
+	}
+	if q.Days == 0 {
+		q.Days = defaultUsageDays
+	}
+	if q.Limit == 0 {
+		q.Limit = defaultUsageLimit
+	}
+	dashboards, err := s.dashboardDAO.MetadataList(&dashboard.Query{Project: q.Project})
+	if err != nil {
+		logrus.WithError(err).Errorf("unable to list the dashboards of the project %s", q.Project)
+		return nil, apiInterface.InternalError
+	}
+	// The usage before the days of the query is needed as well, to know when the other dashboards were viewed for the last time.
+	usageList, err := s.dao.List(q.Project, "")
+	if err != nil {
+		logrus.WithError(err).Errorf("unable to get the dashboard usage of the project %s", q.Project)
+		return nil, apiInterface.InternalError
+	}
+	from := time.Now().UTC().AddDate(0, 0, 1-q.Days).Format(databaseModel.DayFormat)
+	result := make(map[string]*view.DashboardUsage, len(dashboards))
+	for _, entity := range dashboards {
+		name := entity.GetMetadata().GetName()
+		result[name] = &view.DashboardUsage{Project: q.Project, Dashboard: name}
+	}
+	viewers := make(map[string]map[string]bool)
+	renderTimes := make(map[string]float64)
+	renderedViews := make(map[string]int64)
+	for _, usage := range usageList {
+		current, ok := result[usage.Dashboard]
+		if !ok {
+			continue
+		}
+		if usage.Day > current.LastViewDay {
+			current.LastViewDay = usage.Day
+		}
+		if usage.Day < from {
+			continue
+		}
+		current.Views += usage.Views
+		current.RenderErrors += usage.RenderErrors
+		if viewers[usage.Dashboard] == nil {
+			viewers[usage.Dashboard] = make(map[string]bool)
+		}
+		viewers[usage.Dashboard][usage.User] = true
+		renderTimes[usage.Dashboard] += usage.RenderTimeSecs
+		renderedViews[usage.Dashboard] += usage.RenderedViews
+	}
+	list := make([]*view.DashboardUsage, 0, len(result))
+	for name, usage := range result {
+		usage.Viewers = len(viewers[name])
+		if renderedViews[name] > 0 {
+			usage.AverageRenderTimeSecs = renderTimes[name] / float64(renderedViews[name])
+		}
+		list = append(list, usage)
+	}
+	return list, nil
+}
+
+// sortUsage sorts the list with the given comparison, then by dashboard name.
+func sortUsage(list []*view.DashboardUsage, compare func(a, b *view.DashboardUsage) int) {
+	slices.SortFunc(list, func(a, b *view.DashboardUsage) int {
+		if c := compare(a, b); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Dashboard, b.Dashboard)
+	})
+}
+
+func limit(list []*view.DashboardUsage, size int) []*view.DashboardUsage {
+	if list == nil {
+		return make([]*view.DashboardUsage, 0)
+	}
+	if len(list) > size {
+		return list[:size]
+	}
+	return list
+}
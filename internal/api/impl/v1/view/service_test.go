@@ -0,0 +1,134 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package view
+
+import (
+	"testing"
+	"time"
+
+	databaseModel "github.com/perses/perses/internal/api/database/model"
+	"github.com/perses/perses/internal/api/interface/v1/dashboard"
+	"github.com/perses/perses/internal/api/interface/v1/view"
+	"github.com/perses/perses/pkg/model/api"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockUsageDAO struct {
+	view.DAO
+	usage []*databaseModel.DashboardUsage
+}
+
+func (d *mockUsageDAO) Add(usage *databaseModel.DashboardUsage) error {
+	d.usage = append(d.usage, usage)
+	return nil
+}
+
+func (d *mockUsageDAO) List(project string, from string) ([]*databaseModel.DashboardUsage, error) {
+	var result []*databaseModel.DashboardUsage
+	for _, usage := range d.usage {
+		if usage.Project == project && usage.Day >= from {
+			result = append(result, usage)
+		}
+	}
+	return result, nil
+}
+
+type mockDashboardDAO struct {
+	dashboard.DAO
+	names []string
+}
+
+func (d *mockDashboardDAO) MetadataList(q *dashboard.Query) ([]api.Entity, error) {
+	var result []api.Entity
+	for _, name := range d.names {
+		result = append(result, &v1.PartialProjectEntity{
+			Kind:     v1.KindDashboard,
+			Metadata: *v1.NewProjectMetadata(q.Project, name),
+		})
+	}
+	return result, nil
+}
+
+func daysAgo(days int) string {
+	return time.Now().UTC().AddDate(0, 0, -days).Format(databaseModel.DayFormat)
+}
+
+func newTestService(t *testing.T) view.Service {
+	usageDAO := &mockUsageDAO{
+		usage: []*databaseModel.DashboardUsage{
+			// "old" was only viewed before the last 30 days.
+			{Project: "perses", Dashboard: "old", User: "jane", Day: daysAgo(45), Views: 3},
+			// "deleted" doesn't exist anymore.
+			{Project: "perses", Dashboard: "deleted", User: "jane", Day: daysAgo(1), Views: 50},
+			{Project: "perses", Dashboard: "slow", User: "jane", Day: daysAgo(2), Views: 2, RenderTimeSecs: 12, RenderedViews: 2},
+		},
+	}
+	svc := NewService(usageDAO, &mockDashboardDAO{names: []string{"popular", "slow", "old", "unused"}})
+	for _, user := range []string{"jane", "john", "jane"} {
+		require.NoError(t, svc.View(&v1.View{Project: "perses", Dashboard: "popular", RenderTimeSecs: 1, RenderErrors: 1}, user))
+	}
+	return svc
+}
+
+func TestService_View(t *testing.T) {
+	usageDAO := &mockUsageDAO{}
+	svc := NewService(usageDAO, &mockDashboardDAO{})
+	require.NoError(t, svc.View(&v1.View{Project: "perses", Dashboard: "demo", RenderErrors: 2}, "jane"))
+	assert.Equal(t, []*databaseModel.DashboardUsage{
+		{Project: "perses", Dashboard: "demo", User: "jane", Day: daysAgo(0), Views: 1, RenderErrors: 2},
+	}, usageDAO.usage)
+}
+
+func TestService_MostViewed(t *testing.T) {
+	svc := newTestService(t)
+	result, err := svc.MostViewed(&view.UsageQuery{Project: "perses"})
+	require.NoError(t, err)
+	assert.Equal(t, []*view.DashboardUsage{
+		{Project: "perses", Dashboard: "popular", Views: 3, Viewers: 2, RenderErrors: 3, AverageRenderTimeSecs: 1, LastViewDay: daysAgo(0)},
+		{Project: "perses", Dashboard: "slow", Views: 2, Viewers: 1, AverageRenderTimeSecs: 6, LastViewDay: daysAgo(2)},
+	}, result)
+
+	result, err = svc.MostViewed(&view.UsageQuery{Project: "perses", Days: 60, Limit: 1})
+	require.NoError(t, err)
+	assert.Len(t, result, 1)
+	assert.Equal(t, "old", result[0].Dashboard)
+}
+
+func TestService_NeverViewed(t *testing.T) {
+	svc := newTestService(t)
+	result, err := svc.NeverViewed(&view.UsageQuery{Project: "perses"})
+	require.NoError(t, err)
+	assert.Equal(t, []*view.DashboardUsage{
+		{Project: "perses", Dashboard: "unused"},
+		{Project: "perses", Dashboard: "old", LastViewDay: daysAgo(45)},
+	}, result)
+}
+
+func TestService_Slowest(t *testing.T) {
+	svc := newTestService(t)
+	result, err := svc.Slowest(&view.UsageQuery{Project: "perses", Days: 1})
+	require.NoError(t, err)
+	assert.Equal(t, []*view.DashboardUsage{
+		{Project: "perses", Dashboard: "popular", Views: 3, Viewers: 2, RenderErrors: 3, AverageRenderTimeSecs: 1, LastViewDay: daysAgo(0)},
+	}, result)
+}
+
+func TestService_reportErrors(t *testing.T) {
+	_, err := NewMetricsViewService().MostViewed(&view.UsageQuery{Project: "perses"})
+	assert.Error(t, err)
+	_, err = newTestService(t).Slowest(&view.UsageQuery{Project: "perses", Days: -1})
+	assert.Error(t, err)
+}
@@ -0,0 +1,253 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package index
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/perses/perses/pkg/model/api/config"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	snapshotFileName = "snapshot.jsonl"
+	journalFileName  = "journal.jsonl"
+	stateFileName    = "state.json"
+	// minCompactionSize is the number of operations the journal must contain before being compacted.
+	minCompactionSize = 1000
+)
+
+// diskState is describing the content of the directory where the index is stored.
+type diskState struct {
+	// IndexKeysHash is the hash of the keys used to build the documents.
+	// When the keys change, the documents stored are outdated and the index is built again.
+	IndexKeysHash string `json:"indexKeysHash"`
+}
+
+// diskStore is persisting the documents of the index on disk.
+// The documents are written in a snapshot, and every change that happens after the snapshot is appended to a journal.
+// When the journal becomes bigger than the snapshot, both are merged into a new snapshot.
+// Searching is still done in memory; the disk is only used to make the index searchable right after a restart,
+// while it is reconciled with the database. That reconciliation still reads every resource: the database doesn't keep
+// track of the deleted resources, so the index can't resume from the last change it has seen.
+// The journal and the snapshot are protected by the mutex of the client.
+type diskStore struct {
+	path    string
+	journal *os.File
+	// journalSize is the number of operations in the journal.
+	journalSize int
+	// snapshotSize is the number of documents in the snapshot.
+	snapshotSize int
+	state        diskState
+}
+
+// hashIndexKeys is hashing the keys with the version of the documents, as both are defining how the documents are built.
+func hashIndexKeys(keys config.IndexKeys) (string, error) {
+	data, err := json.Marshal(struct {
+		Version int              `json:"version"`
+		Keys    config.IndexKeys `json:"keys"`
+	}{Version: documentVersion, Keys: keys})
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+func openDiskStore(path string, keys config.IndexKeys) (*diskStore, error) {
+	if err := os.MkdirAll(path, 0750); err != nil {
+		return nil, err
+	}
+	hash, err := hashIndexKeys(keys)
+	if err != nil {
+		return nil, err
+	}
+	s := &diskStore{path: path}
+	data, err := os.ReadFile(filepath.Join(path, stateFileName)) //nolint: gosec
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	if err == nil {
+		if unmarshalErr := json.Unmarshal(data, &s.state); unmarshalErr != nil {
+			logrus.WithError(unmarshalErr).Warn("the state of the search index is corrupted, the index will be built again")
+		}
+	}
+	if s.state.IndexKeysHash != hash {
+		// The documents stored have been built with other keys (or there is nothing stored), so they can't be used.
+		logrus.Info("the search index stored on disk is empty or outdated, it will be built from the database")
+		for _, name := range []string{snapshotFileName, journalFileName} {
+			if removeErr := os.Remove(filepath.Join(path, name)); removeErr != nil && !errors.Is(removeErr, os.ErrNotExist) {
+				return nil, removeErr
+			}
+		}
+		s.state = diskState{IndexKeysHash: hash}
+		if writeErr := s.writeState(); writeErr != nil {
+			return nil, writeErr
+		}
+	}
+	journal, err := os.OpenFile(filepath.Join(path, journalFileName), os.O_CREATE|os.O_RDWR|os.O_APPEND, 0600) //nolint: gosec
+	if err != nil {
+		return nil, err
+	}
+	s.journal = journal
+	return s, nil
+}
+
+// load is calling apply for every document of the snapshot, then for every operation of the journal.
+func (s *diskStore) load(apply func(op *operation) (bool, error)) error {
+	snapshot, err := os.Open(filepath.Join(s.path, snapshotFileName)) //nolint: gosec
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	if err == nil {
+		defer snapshot.Close() //nolint: errcheck
+		s.snapshotSize, err = readLines(snapshot, func(line []byte) error {
+			doc := &document{}
+			if unmarshalErr := json.Unmarshal(line, doc); unmarshalErr != nil {
+				return unmarshalErr
+			}
+			_, applyErr := apply(&operation{Type: addOperation, Document: doc})
+			return applyErr
+		})
+		if err != nil {
+			return fmt.Errorf("unable to read the snapshot: %w", err)
+		}
+	}
+	if _, seekErr := s.journal.Seek(0, io.SeekStart); seekErr != nil {
+		return seekErr
+	}
+	s.journalSize, err = readLines(s.journal, func(line []byte) error {
+		op := &operation{}
+		if unmarshalErr := json.Unmarshal(line, op); unmarshalErr != nil {
+			return unmarshalErr
+		}
+		_, applyErr := apply(op)
+		return applyErr
+	})
+	if err != nil {
+		// The last operation has likely been partially written when the server stopped.
+		// Everything before it has been applied, and the journal is dropped by the next compaction.
+		logrus.WithError(err).Warn("unable to read the whole journal of the search index")
+	}
+	return nil
+}
+
+// readLines is calling handle for every line of the reader and returns the number of lines.
+func readLines(r io.Reader, handle func(line []byte) error) (int, error) {
+	reader := bufio.NewReader(r)
+	count := 0
+	for {
+		line, err := reader.ReadBytes('\n')
+		line = bytes.TrimSpace(line)
+		if len(line) > 0 {
+			if handleErr := handle(line); handleErr != nil {
+				return count, handleErr
+			}
+			count++
+		}
+		if errors.Is(err, io.EOF) {
+			return count, nil
+		}
+		if err != nil {
+			return count, err
+		}
+	}
+}
+
+func (s *diskStore) append(op *operation) error {
+	data, err := json.Marshal(op)
+	if err != nil {
+		return err
+	}
+	if _, writeErr := s.journal.Write(append(data, '\n')); writeErr != nil {
+		return writeErr
+	}
+	s.journalSize++
+	return nil
+}
+
+func (s *diskStore) shouldCompact() bool {
+	return s.journalSize >= minCompactionSize && s.journalSize >= s.snapshotSize
+}
+
+// compact is replacing the snapshot by the given documents and is emptying the journal.
+func (s *diskStore) compact(docs []*document) error {
+	tmpPath := filepath.Join(s.path, snapshotFileName+".tmp")
+	tmp, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600) //nolint: gosec
+	if err != nil {
+		return err
+	}
+	writer := bufio.NewWriter(tmp)
+	for _, doc := range docs {
+		data, marshalErr := json.Marshal(doc)
+		if marshalErr != nil {
+			_ = tmp.Close()
+			return marshalErr
+		}
+		if _, writeErr := writer.Write(append(data, '\n')); writeErr != nil {
+			_ = tmp.Close()
+			return writeErr
+		}
+	}
+	if flushErr := writer.Flush(); flushErr != nil {
+		_ = tmp.Close()
+		return flushErr
+	}
+	if syncErr := tmp.Sync(); syncErr != nil {
+		_ = tmp.Close()
+		return syncErr
+	}
+	if closeErr := tmp.Close(); closeErr != nil {
+		return closeErr
+	}
+	if renameErr := os.Rename(tmpPath, filepath.Join(s.path, snapshotFileName)); renameErr != nil {
+		return renameErr
+	}
+	if truncateErr := s.journal.Truncate(0); truncateErr != nil {
+		return truncateErr
+	}
+	s.journalSize = 0
+	s.snapshotSize = len(docs)
+	return nil
+}
+
+// close is flushing the journal on disk and releasing it.
+func (s *diskStore) close() error {
+	if err := s.journal.Sync(); err != nil {
+		_ = s.journal.Close()
+		return err
+	}
+	return s.journal.Close()
+}
+
+func (s *diskStore) writeState() error {
+	data, err := json.Marshal(s.state)
+	if err != nil {
+		return err
+	}
+	tmpPath := filepath.Join(s.path, stateFileName+".tmp")
+	if writeErr := os.WriteFile(tmpPath, data, 0600); writeErr != nil {
+		return writeErr
+	}
+	return os.Rename(tmpPath, filepath.Join(s.path, stateFileName))
+}
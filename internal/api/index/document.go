@@ -0,0 +1,151 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package index
+
+import (
+	"encoding/json"
+
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/tidwall/gjson"
+)
+
+// documentVersion must be increased every time the way the documents are built changes,
+// so the documents persisted on disk are built again.
+const documentVersion = 2
+
+// Attributes are the values of a resource that can be used as filters in a query (e.g. `tag:kafka`).
+const (
+	tagAttribute        = "tag"
+	panelAttribute      = "panel"
+	datasourceAttribute = "datasource"
+	pluginAttribute     = "plugin"
+)
+
+// attributePaths are the paths of the values of each attribute, per kind. The tags are extracted for every kind.
+var attributePaths = map[v1.Kind]map[string][]string{
+	v1.KindDashboard: {
+		panelAttribute:      {"spec.panels.@values.#.spec.display.name"},
+		datasourceAttribute: {"spec.panels.@values.#.spec.queries.#.spec.plugin.spec.datasource.name"},
+		pluginAttribute: {
+			"spec.panels.@values.#.spec.plugin.kind",
+			"spec.panels.@values.#.spec.queries.#.spec.plugin.kind",
+			"spec.variables.#.spec.plugin.kind",
+		},
+	},
+	v1.KindDatasource: {
+		datasourceAttribute: {"metadata.name"},
+		pluginAttribute:     {"spec.plugin.kind"},
+	},
+	v1.KindGlobalDatasource: {
+		datasourceAttribute: {"metadata.name"},
+		pluginAttribute:     {"spec.plugin.kind"},
+	},
+	v1.KindVariable: {
+		datasourceAttribute: {"spec.spec.plugin.spec.datasource.name"},
+		pluginAttribute:     {"spec.spec.plugin.kind"},
+	},
+	v1.KindGlobalVariable: {
+		datasourceAttribute: {"spec.spec.plugin.spec.datasource.name"},
+		pluginAttribute:     {"spec.spec.plugin.kind"},
+	},
+}
+
+// document is what is kept from a resource once it has been indexed.
+// It is also the format used to persist the index on disk, so the resources don't need to be indexed again after a restart.
+type document struct {
+	Kind        v1.Kind         `json:"kind"`
+	Metadata    json.RawMessage `json:"metadata"`
+	DisplayName string          `json:"displayName,omitempty"`
+	Fields      []string        `json:"fields,omitempty"`
+	// Attributes are the values that can be used as filters in a query, per attribute.
+	Attributes map[string][]string `json:"attributes,omitempty"`
+}
+
+func newDocument(kind v1.Kind, raw json.RawMessage, indexedKeys []string) *document {
+	customRaw := rawDocument(raw)
+	var fields []string
+	for _, k := range indexedKeys {
+		fs := customRaw.getFields(k)
+		recursiveDeepExtractFields(fs, &fields)
+	}
+	return &document{
+		Kind:        kind,
+		Metadata:    json.RawMessage(customRaw.getRawMetadata()),
+		DisplayName: customRaw.getDisplayName(),
+		Fields:      fields,
+		Attributes:  extractAttributes(kind, customRaw),
+	}
+}
+
+func extractAttributes(kind v1.Kind, raw rawDocument) map[string][]string {
+	paths := map[string][]string{tagAttribute: {"metadata.tags"}}
+	for attribute, attributePaths := range attributePaths[kind] {
+		paths[attribute] = attributePaths
+	}
+	var attributes map[string][]string
+	for attribute, attributePaths := range paths {
+		var values []string
+		for _, path := range attributePaths {
+			recursiveDeepExtractFields([]gjson.Result{raw.getField(path)}, &values)
+		}
+		values = uniqueValues(values)
+		if len(values) == 0 {
+			continue
+		}
+		if attributes == nil {
+			attributes = make(map[string][]string)
+		}
+		attributes[attribute] = values
+	}
+	return attributes
+}
+
+// uniqueValues removes the duplicated and the empty values, keeping the order of the first occurrences.
+func uniqueValues(values []string) []string {
+	var result []string
+	seen := make(map[string]bool, len(values))
+	for _, v := range values {
+		if len(v) == 0 || seen[v] {
+			continue
+		}
+		seen[v] = true
+		result = append(result, v)
+	}
+	return result
+}
+
+// key identifies the document among the documents of the same kind.
+func (d *document) key() string {
+	return documentKey(gjson.GetBytes(d.Metadata, "project").String(), gjson.GetBytes(d.Metadata, "name").String())
+}
+
+func documentKey(project string, name string) string {
+	if len(project) == 0 {
+		return name
+	}
+	return project + "/" + name
+}
+
+type operationType string
+
+const (
+	addOperation    operationType = "add"
+	deleteOperation operationType = "delete"
+)
+
+// operation is a change of the index. The operations are written in the journal of the index persisted on disk.
+type operation struct {
+	Type     operationType `json:"type"`
+	Document *document     `json:"document"`
+}
@@ -19,6 +19,8 @@
 // You should do the opposite. First filter the amount of data based of the permission and then search through the rest.
 //
 // 2. The indexation is done by the API server. It means that the indexation is done in memory and not persisted in the database.
+// The index can optionally be persisted on disk (see disk.go), only to make the search available right after a restart.
+// The documents are loaded back in memory, and every resource is still read from the database to reconcile them.
 // If your third party library is using a database, makes sure it worth it.
 //
 // 3. The goal of this search engine is also to provide more information to display in the UI.
@@ -30,7 +32,11 @@ package index
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"reflect"
+	"slices"
+	"sort"
 	"sync"
 
 	"github.com/labstack/echo/v4"
@@ -38,6 +44,12 @@ import (
 	"github.com/perses/perses/internal/api/database/model"
 	apiInterface "github.com/perses/perses/internal/api/interface"
 	"github.com/perses/perses/internal/api/interface/v1/dashboard"
+	"github.com/perses/perses/internal/api/interface/v1/datasource"
+	"github.com/perses/perses/internal/api/interface/v1/folder"
+	"github.com/perses/perses/internal/api/interface/v1/globaldatasource"
+	"github.com/perses/perses/internal/api/interface/v1/globalvariable"
+	"github.com/perses/perses/internal/api/interface/v1/project"
+	"github.com/perses/perses/internal/api/interface/v1/variable"
 	"github.com/perses/perses/pkg/model/api"
 	"github.com/perses/perses/pkg/model/api/config"
 	v1 "github.com/perses/perses/pkg/model/api/v1"
@@ -59,6 +71,10 @@ func (r rawDocument) getFields(field string) []gjson.Result {
 func (r rawDocument) getDisplayName() string {
 	var displayName string
 	displayName = r.getField("spec.display.name").String()
+	if len(displayName) == 0 {
+		// Variables are storing their display name one level deeper.
+		displayName = r.getField("spec.spec.display.name").String()
+	}
 	if len(displayName) == 0 {
 		displayName = r.getField("metadata.name").String()
 	}
@@ -70,30 +86,101 @@ func (r rawDocument) getRawMetadata() string {
 }
 
 type index[T api.Metadata] struct {
+	kind     v1.Kind
 	metadata T
 	// displayName is the string that will be used for the search display.
 	displayName string
 	// fields is the list of string that has been collected when the index is created.
 	// It is used to know which fields are available for search.
 	fields []string
+	// attributes are the values that can be used as filters in a query, per attribute.
+	attributes map[string][]string
 	// matcher is the search engine that will be used to search through the fields.
 	matcher *matcher
 }
 
-func (idx *index[T]) match(text string) *SearchResult {
-	for _, field := range idx.fields {
-		result := idx.matcher.match(text, field)
-		if result != nil {
-			result.Metadata = idx.metadata
-			result.DisplayName = idx.displayName
-			return result
-		}
+func (idx *index[T]) candidate() *candidate {
+	var project string
+	if m, ok := any(idx.metadata).(*v1.ProjectMetadata); ok {
+		project = m.Project
+	} else if idx.kind == v1.KindProject {
+		// A project belongs to itself, so it is found when filtering by project.
+		project = idx.metadata.GetName()
+	}
+	return &candidate{
+		kind:       idx.kind,
+		name:       idx.metadata.GetName(),
+		project:    project,
+		fields:     idx.fields,
+		attributes: idx.attributes,
+		matcher:    idx.matcher,
 	}
-	return nil
 }
 
-func newProjectIndexer(indexedKeys []string, scope role.Scope, authz authorization.Authorization, matcher *matcher) *projectIndexer {
+func (idx *index[T]) match(q queryNode) *SearchResult {
+	c := idx.candidate()
+	result := q.match(c)
+	if result == nil {
+		return nil
+	}
+	idx.fill(result, c)
+	return result
+}
+
+func (idx *index[T]) toResult() *SearchResult {
+	result := &SearchResult{}
+	idx.fill(result, idx.candidate())
+	return result
+}
+
+func (idx *index[T]) fill(result *SearchResult, c *candidate) {
+	result.Kind = idx.kind
+	result.Metadata = idx.metadata
+	result.DisplayName = idx.displayName
+	result.project = c.project
+	result.attributes = idx.attributes
+}
+
+func newIndex[T api.Metadata](doc *document, m *matcher) (index[T], error) {
+	var metadata T
+	if err := json.Unmarshal(doc.Metadata, &metadata); err != nil {
+		return index[T]{}, err
+	}
+	return index[T]{
+		kind:        doc.Kind,
+		metadata:    metadata,
+		fields:      doc.Fields,
+		displayName: doc.DisplayName,
+		attributes:  doc.Attributes,
+		matcher:     m,
+	}, nil
+}
+
+func (idx *index[T]) toDocument() (*document, error) {
+	metadata, err := json.Marshal(idx.metadata)
+	if err != nil {
+		return nil, err
+	}
+	return &document{
+		Kind:        idx.kind,
+		Metadata:    metadata,
+		DisplayName: idx.displayName,
+		Fields:      idx.fields,
+		Attributes:  idx.attributes,
+	}, nil
+}
+
+// equal returns true when both indexes are holding the same values.
+func (idx *index[T]) equal(other index[T]) bool {
+	return idx.displayName == other.displayName &&
+		slices.Equal(idx.fields, other.fields) &&
+		reflect.DeepEqual(idx.attributes, other.attributes) &&
+		reflect.DeepEqual(idx.metadata, other.metadata)
+}
+
+func newProjectIndexer(kind v1.Kind, indexedKeys []string, scope role.Scope, authz authorization.Authorization, matcher *matcher) *projectIndexer {
 	return &projectIndexer{
+		kind:        kind,
 		scope:       scope,
 		authz:       authz,
 		indexedKeys: indexedKeys,
@@ -105,6 +192,8 @@ func newProjectIndexer(indexedKeys []string, scope role.Scope, authz authorizati
 // projectIndexer is the indexer that will be used to index and search through the resources that belongs to a project.
 // Examples of project resources are: Dashboard, Datasource, etc.
 type projectIndexer struct {
+	// kind is the kind of the resource that will be indexed.
+	kind v1.Kind
 	// scope is the scope of the resource that will be indexed.
 	scope role.Scope
 	// authz is the authorization service that will be used to check if the user has the permission to search through the resources.
@@ -131,61 +220,108 @@ func recursiveDeepExtractFields(fs []gjson.Result, fields *[]string) {
 		if f.IsArray() {
 			recursiveDeepExtractFields(f.Array(), fields)
 		} else if f.IsObject() {
-			for _, v := range f.Map() {
+			// ForEach is following the order of the document, so the fields are always extracted in the same order.
+			f.ForEach(func(_, v gjson.Result) bool {
 				recursiveDeepExtractFields([]gjson.Result{v}, fields)
-			}
+				return true
+			})
 		} else {
 			*fields = append(*fields, f.String())
 		}
 	}
 }
 
-// add is adding a new document to the index.
-// The row will be used to find the various field to be indexed depending on the configuration.
+// newDocument is extracting from the raw resource the various fields to be indexed depending on the configuration.
 // We are treating the raw JSON instead of the struct because then we can index anything; and we don't need to deal with the Go struct.
-func (c *projectIndexer) add(raw json.RawMessage) error {
-	customRaw := rawDocument(raw)
-	var fields []string
-	for _, k := range c.indexedKeys {
-		fs := customRaw.getFields(k)
-		recursiveDeepExtractFields(fs, &fields)
-	}
+func (c *projectIndexer) newDocument(raw json.RawMessage) *document {
+	return newDocument(c.kind, raw, c.indexedKeys)
+}
 
-	displayName := customRaw.getDisplayName()
-	rawMetadata := customRaw.getRawMetadata()
-	var m *v1.ProjectMetadata
-	if err := json.Unmarshal([]byte(rawMetadata), &m); err != nil {
-		return err
-	}
-	idx := index[*v1.ProjectMetadata]{
-		metadata:    m,
-		fields:      fields,
-		displayName: displayName,
-		matcher:     c.matcher,
+// addDocument is adding a new document to the index, or replacing the existing one.
+// It returns false if the document was already indexed with the same values.
+func (c *projectIndexer) addDocument(doc *document) (bool, error) {
+	idx, err := newIndex[*v1.ProjectMetadata](doc, c.matcher)
+	if err != nil {
+		return false, err
 	}
+	m := idx.metadata
 	c.mutex.Lock()
+	defer c.mutex.Unlock()
 	projectIdx := c.idx[m.Project]
 	if projectIdx == nil {
 		projectIdx = make(map[string]index[*v1.ProjectMetadata])
 		c.idx[m.Project] = projectIdx
 	}
+	if previous, ok := projectIdx[m.Name]; ok && previous.equal(idx) {
+		return false, nil
+	}
 	projectIdx[m.Name] = idx
-	c.mutex.Unlock()
-	return nil
+	return true, nil
 }
 
-func (c *projectIndexer) delete(metadata *v1.ProjectMetadata) {
+func (c *projectIndexer) deleteDocument(doc *document) (bool, error) {
+	var m *v1.ProjectMetadata
+	if err := json.Unmarshal(doc.Metadata, &m); err != nil {
+		return false, err
+	}
 	c.mutex.Lock()
-	projectIdx := c.idx[metadata.Project]
-	if projectIdx != nil {
-		delete(projectIdx, metadata.Name)
+	defer c.mutex.Unlock()
+	projectIdx := c.idx[m.Project]
+	_, exists := projectIdx[m.Name]
+	if exists {
+		delete(projectIdx, m.Name)
 	}
 	if len(projectIdx) == 0 {
-		delete(c.idx, metadata.Project)
+		delete(c.idx, m.Project)
 	}
+	return exists, nil
+}
+
+// deleteProject is removing every document that belongs to the given project.
+func (c *projectIndexer) deleteProject(project string) {
+	c.mutex.Lock()
+	delete(c.idx, project)
 	c.mutex.Unlock()
 }
 
+// prune is removing the documents whose key is not in the given set. It returns the removed documents.
+func (c *projectIndexer) prune(keys map[string]bool) []*document {
+	var removed []*document
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	for project, projectIdx := range c.idx {
+		for name, idx := range projectIdx {
+			if keys[documentKey(project, name)] {
+				continue
+			}
+			if doc, err := idx.toDocument(); err == nil {
+				removed = append(removed, doc)
+			}
+			delete(projectIdx, name)
+		}
+		if len(projectIdx) == 0 {
+			delete(c.idx, project)
+		}
+	}
+	return removed
+}
+
+func (c *projectIndexer) documents() ([]*document, error) {
+	var docs []*document
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	for _, projectIdx := range c.idx {
+		for _, idx := range projectIdx {
+			doc, err := idx.toDocument()
+			if err != nil {
+				return nil, err
+			}
+			docs = append(docs, doc)
+		}
+	}
+	return docs, nil
+}
+
 func (c *projectIndexer) collect(ctx echo.Context, project string, collector func(idx index[*v1.ProjectMetadata]) *SearchResult) ([]*SearchResult, error) {
 	var projectList []string
 	if len(project) != 0 {
@@ -245,115 +381,507 @@ func (c *projectIndexer) collect(ctx echo.Context, project string, collector fun
 
 func (c *projectIndexer) list(ctx echo.Context, project string) ([]*SearchResult, error) {
 	return c.collect(ctx, project, func(idx index[*v1.ProjectMetadata]) *SearchResult {
-		return &SearchResult{
-			Metadata:    idx.metadata,
-			DisplayName: idx.displayName,
-		}
+		return idx.toResult()
 	})
 }
 
-func (c *projectIndexer) search(ctx echo.Context, project string, text string) ([]*SearchResult, error) {
+func (c *projectIndexer) search(ctx echo.Context, project string, q queryNode) ([]*SearchResult, error) {
 	return c.collect(ctx, project, func(idx index[*v1.ProjectMetadata]) *SearchResult {
-		return idx.match(text)
+		return idx.match(q)
 	})
 }
 
+func newGlobalIndexer(kind v1.Kind, indexedKeys []string, scope role.Scope, authz authorization.Authorization, matcher *matcher) *globalIndexer {
+	return &globalIndexer{
+		kind:        kind,
+		scope:       scope,
+		authz:       authz,
+		indexedKeys: indexedKeys,
+		idx:         make(map[string]index[*v1.Metadata]),
+		matcher:     matcher,
+	}
+}
+
+// globalIndexer is the indexer that will be used to index and search through the resources that don't belong to a project.
+// Examples of global resources are: Project, GlobalDatasource, etc.
+type globalIndexer struct {
+	// kind is the kind of the resource that will be indexed.
+	kind v1.Kind
+	// scope is the scope of the resource that will be indexed.
+	scope role.Scope
+	// authz is the authorization service that will be used to check if the user has the permission to search through the resources.
+	authz authorization.Authorization
+	// indexedKeys is the list of keys that are indexed for the resource.
+	indexedKeys []string
+	// idx is the index used for the global resources. The key is the resource name.
+	idx map[string]index[*v1.Metadata]
+	// matcher is the search engine that will be used when creating a new index for a resource.
+	matcher *matcher
+	// mutex will protect the index.
+	mutex sync.RWMutex
+}
+
+func (c *globalIndexer) newDocument(raw json.RawMessage) *document {
+	return newDocument(c.kind, raw, c.indexedKeys)
+}
+
+func (c *globalIndexer) addDocument(doc *document) (bool, error) {
+	idx, err := newIndex[*v1.Metadata](doc, c.matcher)
+	if err != nil {
+		return false, err
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if previous, ok := c.idx[idx.metadata.Name]; ok && previous.equal(idx) {
+		return false, nil
+	}
+	c.idx[idx.metadata.Name] = idx
+	return true, nil
+}
+
+func (c *globalIndexer) deleteDocument(doc *document) (bool, error) {
+	var m *v1.Metadata
+	if err := json.Unmarshal(doc.Metadata, &m); err != nil {
+		return false, err
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	_, exists := c.idx[m.Name]
+	delete(c.idx, m.Name)
+	return exists, nil
+}
+
+func (c *globalIndexer) prune(keys map[string]bool) []*document {
+	var removed []*document
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	for name, idx := range c.idx {
+		if keys[documentKey("", name)] {
+			continue
+		}
+		if doc, err := idx.toDocument(); err == nil {
+			removed = append(removed, doc)
+		}
+		delete(c.idx, name)
+	}
+	return removed
+}
+
+func (c *globalIndexer) documents() ([]*document, error) {
+	var docs []*document
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	for _, idx := range c.idx {
+		doc, err := idx.toDocument()
+		if err != nil {
+			return nil, err
+		}
+		docs = append(docs, doc)
+	}
+	return docs, nil
+}
+
+func (c *globalIndexer) collect(ctx echo.Context, collector func(idx index[*v1.Metadata]) *SearchResult) ([]*SearchResult, error) {
+	// allowedNames is nil when the user can read every resource of the index.
+	var allowedNames map[string]bool
+	if c.authz.IsEnabled() {
+		if c.scope == role.ProjectScope {
+			// A project is readable as soon as the user has the permission to read it, so the list depends on the user.
+			projects, err := c.authz.GetUserProjects(ctx, role.ReadAction, c.scope)
+			if err != nil {
+				return nil, err
+			}
+			if len(projects) != 1 || projects[0] != v1.WildcardProject {
+				allowedNames = make(map[string]bool, len(projects))
+				for _, p := range projects {
+					allowedNames[p] = true
+				}
+			}
+		} else if ok := c.authz.HasPermission(ctx, role.ReadAction, v1.WildcardProject, c.scope); !ok {
+			return nil, apiInterface.HandleForbiddenError(fmt.Sprintf("missing '%s' global permission for '%s' kind", role.ReadAction, c.scope))
+		}
+	}
+	var results []*SearchResult
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	for name, idx := range c.idx {
+		if allowedNames != nil && !allowedNames[name] {
+			continue
+		}
+		if result := collector(idx); result != nil {
+			results = append(results, result)
+		}
+	}
+	if len(results) == 0 {
+		return make([]*SearchResult, 0), nil
+	}
+	return results, nil
+}
+
+// list is listing the global resources. The project is ignored as the resources don't belong to a project.
+func (c *globalIndexer) list(ctx echo.Context, _ string) ([]*SearchResult, error) {
+	return c.collect(ctx, func(idx index[*v1.Metadata]) *SearchResult {
+		return idx.toResult()
+	})
+}
+
+// search is searching through the global resources. The project is ignored as the resources don't belong to a project.
+func (c *globalIndexer) search(ctx echo.Context, _ string, q queryNode) ([]*SearchResult, error) {
+	return c.collect(ctx, func(idx index[*v1.Metadata]) *SearchResult {
+		return idx.match(q)
+	})
+}
+
+// indexer is the common behavior of the projectIndexer and the globalIndexer.
+type indexer interface {
+	newDocument(raw json.RawMessage) *document
+	// addDocument and deleteDocument return false when the index didn't change.
+	addDocument(doc *document) (bool, error)
+	deleteDocument(doc *document) (bool, error)
+	// prune is removing the documents whose key is not in the given set and returns them.
+	prune(keys map[string]bool) []*document
+	documents() ([]*document, error)
+	list(ctx echo.Context, project string) ([]*SearchResult, error)
+	search(ctx echo.Context, project string, q queryNode) ([]*SearchResult, error)
+}
+
+// indexedKinds is the list of kinds supported by the index, in the order they are returned by SearchAll when the score is equal.
+var indexedKinds = []v1.Kind{
+	v1.KindProject,
+	v1.KindDashboard,
+	v1.KindDatasource,
+	v1.KindVariable,
+	v1.KindFolder,
+	v1.KindGlobalDatasource,
+	v1.KindGlobalVariable,
+}
+
 type Client interface {
 	// List is listing all the documents in the index for the given kind and project.
 	// The project parameter can be empty, in that case, the list will be done through all the projects.
+	// It is ignored for the global kinds (Project, GlobalDatasource, GlobalVariable).
 	List(ctx echo.Context, kind v1.Kind, project string) ([]*SearchResult, error)
 	// Search is searching through the index for the given kind and project.
+	// The text can use the query language described in query.go, like `tag:kafka panel:"lag"`.
 	// The project parameter can be empty, in that case, the search will be done through all the projects.
+	// It is ignored for the global kinds (Project, GlobalDatasource, GlobalVariable).
 	Search(ctx echo.Context, kind v1.Kind, project string, txt string) ([]*SearchResult, error)
+	// SearchAll is searching through every kind the user is allowed to read, and returns the results sorted by score
+	// with the number of results per kind, project, tag and plugin.
+	// When the project is provided, only the resources belonging to this project are searched.
+	SearchAll(ctx echo.Context, project string, txt string) (*SearchResponse, error)
 	// Refresh is refreshing the index by reloading all the documents from the database.
 	Refresh() error
 	// Add is adding a document to the index. If it already exists, it will be updated. If it doesn't exist, it will be created.
 	Add(entity api.Entity) error
 	// Delete is deleting a document from the index.
+	// Deleting a project is also deleting every document that belongs to it.
 	Delete(kind v1.Kind, metadata api.Metadata)
+	// Close is releasing the files used to persist the index on disk, if any.
+	Close() error
 }
 
-func New(conf config.Search, authz authorization.Authorization, dao model.DAO) Client {
-	return &client{
-		dashboards: newProjectIndexer(conf.IndexKeys.Dashboard, role.DashboardScope, authz, &matcher{
-			caseSensitive: false,
-			excludedChars: conf.ExcludedChars,
-		}),
-		dao: dao,
+// New returns the client of the index. When the configuration asks for it, the index is persisted on disk,
+// and the documents persisted by a previous run are loaded back. They may be outdated, as the database can change
+// while the server is stopped, so Refresh must still be called to reconcile the index with the database.
+func New(conf config.Search, authz authorization.Authorization, dao model.DAO) (Client, error) {
+	m := &matcher{
+		caseSensitive: false,
+		excludedChars: conf.ExcludedChars,
+	}
+	keys := conf.IndexKeys
+	c := &client{
+		dashboards:        newProjectIndexer(v1.KindDashboard, keys.Dashboard, role.DashboardScope, authz, m),
+		datasources:       newProjectIndexer(v1.KindDatasource, keys.Datasource, role.DatasourceScope, authz, m),
+		variables:         newProjectIndexer(v1.KindVariable, keys.Variable, role.VariableScope, authz, m),
+		folders:           newProjectIndexer(v1.KindFolder, keys.Folder, role.FolderScope, authz, m),
+		projects:          newGlobalIndexer(v1.KindProject, keys.Project, role.ProjectScope, authz, m),
+		globalDatasources: newGlobalIndexer(v1.KindGlobalDatasource, keys.GlobalDatasource, role.GlobalDatasourceScope, authz, m),
+		globalVariables:   newGlobalIndexer(v1.KindGlobalVariable, keys.GlobalVariable, role.GlobalVariableScope, authz, m),
+		dao:               dao,
 	}
+	if conf.Disk == nil {
+		return c, nil
+	}
+	store, err := openDiskStore(conf.Disk.Path, keys)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open the search index stored in %q: %w", conf.Disk.Path, err)
+	}
+	c.store = store
+	if loadErr := store.load(c.apply); loadErr != nil {
+		return nil, fmt.Errorf("unable to load the search index stored in %q: %w", conf.Disk.Path, loadErr)
+	}
+	// Starting from a fresh journal, so it doesn't grow forever and a partial write from a previous run is dropped.
+	if compactErr := c.compact(); compactErr != nil {
+		return nil, compactErr
+	}
+	return c, nil
 }
 
 type client struct {
-	dashboards *projectIndexer
-	dao        model.DAO
+	dashboards        *projectIndexer
+	datasources       *projectIndexer
+	variables         *projectIndexer
+	folders           *projectIndexer
+	projects          *globalIndexer
+	globalDatasources *globalIndexer
+	globalVariables   *globalIndexer
+	dao               model.DAO
+	// store is persisting the index on disk. It is nil when the index is only kept in memory.
+	store *diskStore
+	// refreshedKeys is the set of documents seen during the refresh of a kind.
+	// It is used to know which documents have been deleted from the database.
+	refreshedKeys map[v1.Kind]map[string]bool
+	// mutex is serializing the changes of the index, so they are persisted in the same order they are applied.
+	mutex sync.Mutex
 }
 
-func (c *client) add(raw json.RawMessage) error {
-	kind := v1.Kind(gjson.GetBytes(raw, "kind").String())
+func (c *client) getIndexer(kind v1.Kind) (indexer, bool) {
 	switch kind {
 	case v1.KindDashboard:
-		return c.dashboards.add(raw)
+		return c.dashboards, true
+	case v1.KindDatasource:
+		return c.datasources, true
+	case v1.KindVariable:
+		return c.variables, true
+	case v1.KindFolder:
+		return c.folders, true
+	case v1.KindProject:
+		return c.projects, true
+	case v1.KindGlobalDatasource:
+		return c.globalDatasources, true
+	case v1.KindGlobalVariable:
+		return c.globalVariables, true
+	default:
+		return nil, false
+	}
+}
+
+// apply is applying the operation on the index in memory. It returns false if the index didn't change.
+func (c *client) apply(op *operation) (bool, error) {
+	idx, ok := c.getIndexer(op.Document.Kind)
+	if !ok {
+		return false, fmt.Errorf("kind %s is not supported by the index", op.Document.Kind)
+	}
+	switch op.Type {
+	case addOperation:
+		return idx.addDocument(op.Document)
+	case deleteOperation:
+		changed, err := idx.deleteDocument(op.Document)
+		if err != nil {
+			return false, err
+		}
+		if op.Document.Kind == v1.KindProject {
+			// The resources of the project are deleted with it, so they must not be found anymore.
+			name := gjson.GetBytes(op.Document.Metadata, "name").String()
+			for _, projectIdx := range []*projectIndexer{c.dashboards, c.datasources, c.variables, c.folders} {
+				projectIdx.deleteProject(name)
+			}
+		}
+		return changed, nil
 	default:
+		return false, fmt.Errorf("unknown operation %q", op.Type)
+	}
+}
+
+// update is applying the operation and persisting it when the index is stored on disk.
+func (c *client) update(op *operation) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if keys := c.refreshedKeys[op.Document.Kind]; keys != nil {
+		// The document has been changed during the refresh, it must not be pruned at the end of it.
+		keys[op.Document.key()] = true
+	}
+	changed, err := c.apply(op)
+	if err != nil || !changed || c.store == nil {
+		return err
+	}
+	if appendErr := c.store.append(op); appendErr != nil {
+		return appendErr
+	}
+	if c.store.shouldCompact() {
+		return c.compactWithoutLock()
+	}
+	return nil
+}
+
+func (c *client) compact() error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.compactWithoutLock()
+}
+
+func (c *client) compactWithoutLock() error {
+	var docs []*document
+	for _, kind := range indexedKinds {
+		idx, _ := c.getIndexer(kind)
+		kindDocs, err := idx.documents()
+		if err != nil {
+			return err
+		}
+		docs = append(docs, kindDocs...)
+	}
+	return c.store.compact(docs)
+}
+
+func (c *client) add(raw json.RawMessage) error {
+	kind := v1.Kind(gjson.GetBytes(raw, "kind").String())
+	idx, ok := c.getIndexer(kind)
+	if !ok {
 		logrus.Warnf("kind %s is not supported for indexing", kind)
 		return nil
 	}
+	return c.update(&operation{Type: addOperation, Document: idx.newDocument(raw)})
 }
 
 func (c *client) Add(entity api.Entity) error {
 	kind := v1.Kind(entity.GetKind())
-	switch kind {
-	case v1.KindDashboard:
-		raw, err := json.Marshal(entity)
-		if err != nil {
-			return err
-		}
-		return c.dashboards.add(raw)
-	default:
+	if _, ok := c.getIndexer(kind); !ok {
 		logrus.Warnf("kind %s is not supported for indexing", kind)
 		return nil
 	}
+	raw, err := json.Marshal(entity)
+	if err != nil {
+		return err
+	}
+	return c.add(raw)
 }
 
 func (c *client) Delete(kind v1.Kind, metadata api.Metadata) {
-	switch kind {
-	case v1.KindDashboard:
-		c.dashboards.delete(metadata.(*v1.ProjectMetadata))
-	default:
+	if _, ok := c.getIndexer(kind); !ok {
 		logrus.Warnf("kind %s is not supported for deleting", kind)
+		return
 	}
+	rawMetadata, err := json.Marshal(metadata)
+	if err != nil {
+		logrus.WithError(err).Errorf("unable to delete the %s %q from the index", kind, metadata.GetName())
+		return
+	}
+	if updateErr := c.update(&operation{Type: deleteOperation, Document: &document{Kind: kind, Metadata: rawMetadata}}); updateErr != nil {
+		logrus.WithError(updateErr).Errorf("unable to delete the %s %q from the index", kind, metadata.GetName())
+	}
+}
+
+func (c *client) Close() error {
+	if c.store == nil {
+		return nil
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.store.close()
 }
 
 func (c *client) Search(ctx echo.Context, kind v1.Kind, project string, txt string) ([]*SearchResult, error) {
-	switch kind {
-	case v1.KindDashboard:
-		return c.dashboards.search(ctx, project, txt)
-	default:
+	idx, ok := c.getIndexer(kind)
+	if !ok {
 		logrus.Warnf("kind %s is not supported for searching", kind)
 		return make([]*SearchResult, 0), nil
 	}
+	q, err := parseQuery(txt)
+	if err != nil {
+		return nil, apiInterface.HandleBadRequestError(err.Error())
+	}
+	return idx.search(ctx, project, q)
+}
+
+func (c *client) SearchAll(ctx echo.Context, project string, txt string) (*SearchResponse, error) {
+	q, parseErr := parseQuery(txt)
+	if parseErr != nil {
+		return nil, apiInterface.HandleBadRequestError(parseErr.Error())
+	}
+	results := make([]*SearchResult, 0)
+	for _, kind := range indexedKinds {
+		idx, _ := c.getIndexer(kind)
+		if _, isGlobal := idx.(*globalIndexer); isGlobal && len(project) > 0 {
+			continue
+		}
+		kindResults, err := idx.search(ctx, project, q)
+		if err != nil {
+			if errors.Is(err, apiInterface.ForbiddenError) {
+				// The user is not allowed to read this kind, it is simply not part of the search.
+				continue
+			}
+			return nil, err
+		}
+		results = append(results, kindResults...)
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Score > results[j].Score
+	})
+	return newSearchResponse(results), nil
 }
 
 func (c *client) List(ctx echo.Context, kind v1.Kind, project string) ([]*SearchResult, error) {
-	switch kind {
-	case v1.KindDashboard:
-		return c.dashboards.list(ctx, project)
-	default:
+	idx, ok := c.getIndexer(kind)
+	if !ok {
 		logrus.Warnf("kind %s is not supported for listing", kind)
 		return make([]*SearchResult, 0), nil
 	}
+	return idx.list(ctx, project)
 }
 
+// Refresh is streaming every resource from the database. Only the documents that changed are updated,
+// and the documents that are not in the database anymore are removed from the index.
 func (c *client) Refresh() error {
+	queries := map[v1.Kind]model.Query{
+		v1.KindProject:          &project.Query{},
+		v1.KindDashboard:        &dashboard.Query{},
+		v1.KindDatasource:       &datasource.Query{},
+		v1.KindVariable:         &variable.Query{},
+		v1.KindFolder:           &folder.Query{},
+		v1.KindGlobalDatasource: &globaldatasource.Query{},
+		v1.KindGlobalVariable:   &globalvariable.Query{},
+	}
+	for _, kind := range indexedKinds {
+		if err := c.refresh(kind, queries[kind]); err != nil {
+			return err
+		}
+	}
+	if c.store != nil {
+		return c.compact()
+	}
+	return nil
+}
+
+func (c *client) refresh(kind v1.Kind, q model.Query) error {
+	keys := make(map[string]bool)
+	c.mutex.Lock()
+	if c.refreshedKeys == nil {
+		c.refreshedKeys = make(map[v1.Kind]map[string]bool)
+	}
+	c.refreshedKeys[kind] = keys
+	c.mutex.Unlock()
+	defer func() {
+		c.mutex.Lock()
+		delete(c.refreshedKeys, kind)
+		c.mutex.Unlock()
+	}()
+
 	ch := make(chan json.RawMessage)
-	q := &dashboard.Query{}
-	var err error
+	errCh := make(chan error, 1)
 	go func() {
-		err = c.dao.StreamRaw(q, ch)
+		errCh <- c.dao.StreamRaw(q, ch)
 	}()
 	for raw := range ch {
 		if addErr := c.add(raw); addErr != nil {
 			logrus.WithError(addErr).Error("failed to add document to index")
 		}
 	}
-	return err
+	if err := <-errCh; err != nil {
+		// Nothing is pruned, as the list of the resources is incomplete.
+		return err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	idx, _ := c.getIndexer(kind)
+	for _, doc := range idx.prune(keys) {
+		if c.store == nil {
+			continue
+		}
+		if err := c.store.append(&operation{Type: deleteOperation, Document: doc}); err != nil {
+			return err
+		}
+	}
+	return nil
 }
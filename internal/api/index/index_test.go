@@ -15,9 +15,15 @@ package index
 
 import (
 	"encoding/json"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/perses/perses/internal/api/authorization"
+	"github.com/perses/perses/internal/api/database/model"
+	apiInterface "github.com/perses/perses/internal/api/interface"
+	"github.com/perses/perses/internal/api/interface/v1/dashboard"
+	"github.com/perses/perses/internal/api/interface/v1/project"
 	"github.com/perses/perses/pkg/model/api/config"
 	v1 "github.com/perses/perses/pkg/model/api/v1"
 	"github.com/stretchr/testify/assert"
@@ -29,7 +35,7 @@ import (
 // so tests can safely pass nil as the context when calling functions that require it.
 func newDisabledAuthz(t *testing.T) authorization.Authorization {
 	t.Helper()
-	authz, err := authorization.New(nil, nil, nil, nil, nil, config.Config{})
+	authz, err := authorization.New(nil, nil, nil, nil, nil, nil, nil, nil, config.Config{})
 	require.NoError(t, err)
 	require.False(t, authz.IsEnabled())
 	return authz
@@ -56,6 +62,7 @@ func TestClientAdd(t *testing.T) {
 			expectedProjectIdx: map[string]map[string]index[*v1.ProjectMetadata]{
 				"myproject": {
 					"mydashboard": {
+						kind: v1.KindDashboard,
 						metadata: &v1.ProjectMetadata{
 							Metadata: v1.Metadata{
 								Name: "mydashboard",
@@ -92,6 +99,7 @@ func TestClientAdd(t *testing.T) {
 			expectedProjectIdx: map[string]map[string]index[*v1.ProjectMetadata]{
 				"myproject": {
 					"mydashboard": {
+						kind: v1.KindDashboard,
 						metadata: &v1.ProjectMetadata{
 							Metadata: v1.Metadata{
 								Name: "mydashboard",
@@ -125,6 +133,7 @@ func TestClientAdd(t *testing.T) {
 			expectedProjectIdx: map[string]map[string]index[*v1.ProjectMetadata]{
 				"myproject": {
 					"mydashboard": {
+						kind: v1.KindDashboard,
 						metadata: &v1.ProjectMetadata{
 							Metadata: v1.Metadata{
 								Name: "mydashboard",
@@ -193,6 +202,7 @@ func TestClientAdd(t *testing.T) {
 			expectedProjectIdx: map[string]map[string]index[*v1.ProjectMetadata]{
 				"myproject": {
 					"mydashboard": {
+						kind: v1.KindDashboard,
 						metadata: &v1.ProjectMetadata{
 							Metadata: v1.Metadata{
 								Name: "mydashboard",
@@ -206,6 +216,10 @@ func TestClientAdd(t *testing.T) {
 							"simple line chart",
 							"another line chart",
 						},
+						attributes: map[string][]string{
+							panelAttribute:  {"simple line chart", "another line chart"},
+							pluginAttribute: {"TimeSeriesChart"},
+						},
 						matcher: &matcher{},
 					},
 				},
@@ -214,7 +228,7 @@ func TestClientAdd(t *testing.T) {
 	}
 	for _, tc := range testsCases {
 		t.Run(tc.title, func(t *testing.T) {
-			c := New(config.Search{IndexKeys: config.IndexKeys{Dashboard: tc.indexedKeys}}, newDisabledAuthz(t), nil).(*client)
+			c := newClient(t, config.Search{IndexKeys: config.IndexKeys{Dashboard: tc.indexedKeys}})
 			err := c.add(tc.raw)
 			require.NoError(t, err)
 			assert.Equal(t, tc.expectedProjectIdx, c.dashboards.idx)
@@ -223,7 +237,7 @@ func TestClientAdd(t *testing.T) {
 }
 
 func Test_client_add_multipleProjectsAreIndexedSeparately(t *testing.T) {
-	c := New(config.Search{IndexKeys: config.IndexKeys{Dashboard: []string{"metadata.name"}}}, newDisabledAuthz(t), nil).(*client)
+	c := newClient(t, config.Search{IndexKeys: config.IndexKeys{Dashboard: []string{"metadata.name"}}})
 
 	rawA := json.RawMessage(`{
 		"kind": "Dashboard",
@@ -279,13 +293,13 @@ func TestClientSearch_authorizationDisabled(t *testing.T) {
 		},
 		{
 			title:         "search with unsupported kind returns empty slice",
-			kind:          v1.KindProject,
+			kind:          v1.KindSecret,
 			project:       "",
 			text:          "node",
 			expectedNames: nil,
 		},
 	}
-	c := New(config.Search{IndexKeys: config.IndexKeys{Dashboard: []string{"metadata.name"}}}, newDisabledAuthz(t), nil).(*client)
+	c := newClient(t, config.Search{IndexKeys: config.IndexKeys{Dashboard: []string{"metadata.name"}}})
 
 	docs := []json.RawMessage{
 		json.RawMessage(`{"kind": "Dashboard", "metadata": {"name": "node-exporter", "project": "project-a"}, "spec": {}}`),
@@ -308,3 +322,384 @@ func TestClientSearch_authorizationDisabled(t *testing.T) {
 		})
 	}
 }
+
+func newClient(t *testing.T, conf config.Search) *client {
+	t.Helper()
+	c, err := New(conf, newDisabledAuthz(t), nil)
+	require.NoError(t, err)
+	return c.(*client)
+}
+
+func newTestClient(t *testing.T) *client {
+	t.Helper()
+	conf := config.Search{}
+	require.NoError(t, conf.Verify())
+	return newClient(t, conf)
+}
+
+func TestClientAdd_globalResource(t *testing.T) {
+	c := newTestClient(t)
+	raw := json.RawMessage(`{
+		"kind": "GlobalVariable",
+		"metadata": {"name": "instance"},
+		"spec": {
+			"kind": "ListVariable",
+			"spec": {
+				"display": {"name": "Instance"},
+				"plugin": {
+					"kind": "PrometheusLabelValuesVariable",
+					"spec": {"labelName": "instance", "matchers": ["node_cpu_seconds_total"]}
+				}
+			}
+		}
+	}`)
+	require.NoError(t, c.add(raw))
+	require.Contains(t, c.globalVariables.idx, "instance")
+	idx := c.globalVariables.idx["instance"]
+	assert.Equal(t, v1.KindGlobalVariable, idx.kind)
+	assert.Equal(t, &v1.Metadata{Name: "instance"}, idx.metadata)
+	assert.Equal(t, "Instance", idx.displayName)
+	assert.Equal(t, []string{"instance", "Instance", "instance", "node_cpu_seconds_total"}, idx.fields)
+}
+
+func TestClientSearchAll(t *testing.T) {
+	c := newTestClient(t)
+	docs := []json.RawMessage{
+		json.RawMessage(`{"kind": "Project", "metadata": {"name": "infra"}, "spec": {"display": {"name": "Infrastructure"}}}`),
+		json.RawMessage(`{
+			"kind": "Dashboard",
+			"metadata": {"name": "hosts", "project": "infra"},
+			"spec": {
+				"panels": {
+					"cpu": {
+						"kind": "Panel",
+						"spec": {
+							"display": {"name": "CPU", "description": "CPU usage per mode"},
+							"queries": [
+								{"kind": "TimeSeriesQuery", "spec": {"plugin": {"kind": "PrometheusTimeSeriesQuery", "spec": {"query": "rate(node_cpu_seconds_total[5m])"}}}}
+							]
+						}
+					}
+				}
+			}
+		}`),
+		json.RawMessage(`{"kind": "Dashboard", "metadata": {"name": "network", "project": "infra"}, "spec": {}}`),
+		json.RawMessage(`{
+			"kind": "Variable",
+			"metadata": {"name": "cpu", "project": "apps"},
+			"spec": {
+				"kind": "ListVariable",
+				"spec": {"plugin": {"kind": "PrometheusPromQLVariable", "spec": {"expr": "node_cpu_seconds_total"}}}
+			}
+		}`),
+		json.RawMessage(`{"kind": "GlobalDatasource", "metadata": {"name": "prometheus"}, "spec": {"plugin": {"kind": "PrometheusDatasource", "spec": {"directUrl": "http://localhost:9090"}}}}`),
+	}
+	for _, d := range docs {
+		require.NoError(t, c.add(d))
+	}
+
+	testsCases := []struct {
+		title         string
+		project       string
+		text          string
+		expectedKinds []v1.Kind
+		expectedNames []string
+	}{
+		{
+			title:         "query expression matches dashboards and variables",
+			text:          "node_cpu_seconds",
+			expectedKinds: []v1.Kind{v1.KindDashboard, v1.KindVariable},
+			expectedNames: []string{"hosts", "cpu"},
+		},
+		{
+			title:         "panel description",
+			text:          "usage per mode",
+			expectedKinds: []v1.Kind{v1.KindDashboard},
+			expectedNames: []string{"hosts"},
+		},
+		{
+			title:         "filtered by project",
+			project:       "infra",
+			text:          "node_cpu_seconds",
+			expectedKinds: []v1.Kind{v1.KindDashboard},
+			expectedNames: []string{"hosts"},
+		},
+		{
+			title:         "global resources",
+			text:          "localhost:9090",
+			expectedKinds: []v1.Kind{v1.KindGlobalDatasource},
+			expectedNames: []string{"prometheus"},
+		},
+		{
+			title:         "project display name",
+			text:          "Infrastructure",
+			expectedKinds: []v1.Kind{v1.KindProject},
+			expectedNames: []string{"infra"},
+		},
+	}
+	for _, tc := range testsCases {
+		t.Run(tc.title, func(t *testing.T) {
+			response, err := c.SearchAll(nil, tc.project, tc.text)
+			require.NoError(t, err)
+			var kinds []v1.Kind
+			var names []string
+			for _, r := range response.Results {
+				kinds = append(kinds, r.Kind)
+				names = append(names, r.Metadata.GetName())
+			}
+			assert.ElementsMatch(t, tc.expectedKinds, kinds)
+			assert.ElementsMatch(t, tc.expectedNames, names)
+		})
+	}
+}
+
+func TestClientDelete_projectRemovesItsResources(t *testing.T) {
+	c := newTestClient(t)
+	docs := []json.RawMessage{
+		json.RawMessage(`{"kind": "Project", "metadata": {"name": "infra"}, "spec": {}}`),
+		json.RawMessage(`{"kind": "Dashboard", "metadata": {"name": "hosts", "project": "infra"}, "spec": {}}`),
+		json.RawMessage(`{"kind": "Folder", "metadata": {"name": "hosts", "project": "infra"}, "spec": {}}`),
+		json.RawMessage(`{"kind": "Dashboard", "metadata": {"name": "hosts", "project": "apps"}, "spec": {}}`),
+	}
+	for _, d := range docs {
+		require.NoError(t, c.add(d))
+	}
+	c.Delete(v1.KindProject, &v1.Metadata{Name: "infra"})
+
+	assert.Empty(t, c.projects.idx)
+	assert.Empty(t, c.folders.idx)
+	assert.NotContains(t, c.dashboards.idx, "infra")
+	assert.Contains(t, c.dashboards.idx, "apps")
+}
+
+// streamDAO is a DAO only able to stream the documents it contains.
+type streamDAO struct {
+	model.DAO
+	docs map[v1.Kind][]json.RawMessage
+}
+
+func (d *streamDAO) StreamRaw(query model.Query, ch chan<- json.RawMessage) error {
+	defer close(ch)
+	var kind v1.Kind
+	switch query.(type) {
+	case *project.Query:
+		kind = v1.KindProject
+	case *dashboard.Query:
+		kind = v1.KindDashboard
+	}
+	for _, raw := range d.docs[kind] {
+		ch <- raw
+	}
+	return nil
+}
+
+func TestClientRefresh_removesDeletedDocuments(t *testing.T) {
+	conf := config.Search{}
+	require.NoError(t, conf.Verify())
+	dao := &streamDAO{docs: map[v1.Kind][]json.RawMessage{
+		v1.KindDashboard: {
+			json.RawMessage(`{"kind": "Dashboard", "metadata": {"name": "hosts", "project": "infra"}, "spec": {}}`),
+		},
+	}}
+	c, err := New(conf, newDisabledAuthz(t), dao)
+	require.NoError(t, err)
+	idx := c.(*client)
+	require.NoError(t, idx.add(json.RawMessage(`{"kind": "Dashboard", "metadata": {"name": "removed", "project": "infra"}, "spec": {}}`)))
+
+	require.NoError(t, c.Refresh())
+	assert.Contains(t, idx.dashboards.idx["infra"], "hosts")
+	assert.NotContains(t, idx.dashboards.idx["infra"], "removed")
+}
+
+func TestPersistentClient(t *testing.T) {
+	conf := config.Search{Disk: &config.SearchDisk{Path: t.TempDir()}}
+	require.NoError(t, conf.Verify())
+	authz := newDisabledAuthz(t)
+
+	c, err := New(conf, authz, nil)
+	require.NoError(t, err)
+	persisted := c.(*client)
+	require.NoError(t, persisted.add(json.RawMessage(`{"kind": "Project", "metadata": {"name": "infra"}, "spec": {}}`)))
+	require.NoError(t, persisted.add(json.RawMessage(`{"kind": "Project", "metadata": {"name": "apps"}, "spec": {}}`)))
+	require.NoError(t, persisted.add(json.RawMessage(`{"kind": "Dashboard", "metadata": {"name": "hosts", "project": "infra"}, "spec": {"display": {"name": "Hosts"}}}`)))
+	require.NoError(t, persisted.add(json.RawMessage(`{"kind": "Dashboard", "metadata": {"name": "api", "project": "apps"}, "spec": {}}`)))
+	persisted.Delete(v1.KindProject, &v1.Metadata{Name: "apps"})
+	require.NoError(t, c.Close())
+
+	// Opening the index again is loading what has been persisted.
+	c, err = New(conf, authz, nil)
+	require.NoError(t, err)
+	reloaded := c.(*client)
+	assert.Contains(t, reloaded.projects.idx, "infra")
+	assert.NotContains(t, reloaded.projects.idx, "apps")
+	assert.NotContains(t, reloaded.dashboards.idx, "apps")
+	require.Contains(t, reloaded.dashboards.idx["infra"], "hosts")
+	assert.Equal(t, "Hosts", reloaded.dashboards.idx["infra"]["hosts"].displayName)
+	assert.Equal(t, 0, reloaded.store.journalSize)
+	assert.Equal(t, 2, reloaded.store.snapshotSize)
+	require.NoError(t, c.Close())
+
+	// Changing the indexed keys is dropping the documents persisted, as they are outdated.
+	conf.IndexKeys.Dashboard = []string{"metadata.name"}
+	c, err = New(conf, authz, nil)
+	require.NoError(t, err)
+	assert.Empty(t, c.(*client).dashboards.idx)
+	require.NoError(t, c.Close())
+}
+
+func TestPersistentClient_reconcileAfterRestart(t *testing.T) {
+	conf := config.Search{Disk: &config.SearchDisk{Path: t.TempDir()}}
+	require.NoError(t, conf.Verify())
+	c, err := New(conf, newDisabledAuthz(t), nil)
+	require.NoError(t, err)
+	require.NoError(t, c.(*client).add(json.RawMessage(`{"kind": "Dashboard", "metadata": {"name": "hosts", "project": "infra"}, "spec": {}}`)))
+	require.NoError(t, c.(*client).add(json.RawMessage(`{"kind": "Dashboard", "metadata": {"name": "removed", "project": "infra"}, "spec": {}}`)))
+	require.NoError(t, c.Close())
+
+	// The dashboard "removed" has been deleted from the database while the server was stopped.
+	dao := &streamDAO{docs: map[v1.Kind][]json.RawMessage{
+		v1.KindDashboard: {
+			json.RawMessage(`{"kind": "Dashboard", "metadata": {"name": "hosts", "project": "infra"}, "spec": {}}`),
+		},
+	}}
+	c, err = New(conf, newDisabledAuthz(t), dao)
+	require.NoError(t, err)
+	reloaded := c.(*client)
+	// The persisted documents are searchable before the reconciliation.
+	assert.Contains(t, reloaded.dashboards.idx["infra"], "removed")
+	require.NoError(t, c.Refresh())
+	assert.Contains(t, reloaded.dashboards.idx["infra"], "hosts")
+	assert.NotContains(t, reloaded.dashboards.idx["infra"], "removed")
+	require.NoError(t, c.Close())
+
+	// The deletion found by the reconciliation is persisted as well.
+	c, err = New(conf, newDisabledAuthz(t), nil)
+	require.NoError(t, err)
+	assert.NotContains(t, c.(*client).dashboards.idx["infra"], "removed")
+	require.NoError(t, c.Close())
+}
+
+func TestPersistentClient_partialJournal(t *testing.T) {
+	conf := config.Search{Disk: &config.SearchDisk{Path: t.TempDir()}}
+	require.NoError(t, conf.Verify())
+	c, err := New(conf, newDisabledAuthz(t), nil)
+	require.NoError(t, err)
+	require.NoError(t, c.(*client).add(json.RawMessage(`{"kind": "Project", "metadata": {"name": "infra"}, "spec": {}}`)))
+	require.NoError(t, c.Close())
+
+	// Simulating a server stopped while writing an operation.
+	journal, err := os.OpenFile(filepath.Join(conf.Disk.Path, journalFileName), os.O_APPEND|os.O_WRONLY, 0600)
+	require.NoError(t, err)
+	_, err = journal.WriteString(`{"type": "add", "document": {"kind": "Proj`)
+	require.NoError(t, err)
+	require.NoError(t, journal.Close())
+
+	c, err = New(conf, newDisabledAuthz(t), nil)
+	require.NoError(t, err)
+	assert.Contains(t, c.(*client).projects.idx, "infra")
+	require.NoError(t, c.Close())
+}
+
+func TestClientSearchAll_queryLanguage(t *testing.T) {
+	c := newTestClient(t)
+	docs := []json.RawMessage{
+		json.RawMessage(`{"kind": "Project", "metadata": {"name": "infra"}, "spec": {}}`),
+		json.RawMessage(`{
+			"kind": "Dashboard",
+			"metadata": {"name": "kafka-overview", "project": "infra", "tags": ["kafka", "messaging"]},
+			"spec": {
+				"panels": {
+					"lag": {
+						"kind": "Panel",
+						"spec": {
+							"display": {"name": "Consumer lag"},
+							"plugin": {"kind": "TimeSeriesChart", "spec": {}},
+							"queries": [
+								{"kind": "TimeSeriesQuery", "spec": {"plugin": {"kind": "PrometheusTimeSeriesQuery", "spec": {"datasource": {"kind": "PrometheusDatasource", "name": "prom-eu"}, "query": "kafka_consumergroup_lag"}}}}
+							]
+						}
+					}
+				}
+			}
+		}`),
+		json.RawMessage(`{
+			"kind": "Dashboard",
+			"metadata": {"name": "zookeeper", "project": "infra", "tags": ["zookeeper"]},
+			"spec": {"panels": {"up": {"kind": "Panel", "spec": {"display": {"name": "Up"}, "plugin": {"kind": "StatChart", "spec": {}}}}}}
+		}`),
+		json.RawMessage(`{"kind": "Dashboard", "metadata": {"name": "kafka-sandbox", "project": "sandbox", "tags": ["kafka"]}, "spec": {}}`),
+		json.RawMessage(`{"kind": "Datasource", "metadata": {"name": "prom-eu", "project": "infra"}, "spec": {"plugin": {"kind": "PrometheusDatasource", "spec": {}}}}`),
+	}
+	for _, d := range docs {
+		require.NoError(t, c.add(d))
+	}
+
+	testsCases := []struct {
+		title         string
+		text          string
+		expectedNames []string
+	}{
+		{
+			title:         "filters",
+			text:          `project:infra tag:kafka panel:"lag" datasource:prom-eu`,
+			expectedNames: []string{"kafka-overview"},
+		},
+		{
+			title:         "datasource filter matches the datasource itself",
+			text:          `kind:datasource datasource:PROM-EU`,
+			expectedNames: []string{"prom-eu"},
+		},
+		{
+			title:         "project filter matches the project itself",
+			text:          `project:infra kind:projects`,
+			expectedNames: []string{"infra"},
+		},
+		{
+			title:         "or and negation",
+			text:          `(tag:kafka OR tag:zookeeper) -project:sandbox`,
+			expectedNames: []string{"kafka-overview", "zookeeper"},
+		},
+		{
+			title:         "not operator",
+			text:          `tag:kafka NOT project:infra`,
+			expectedNames: []string{"kafka-sandbox"},
+		},
+		{
+			title:         "plugin filter with free text",
+			text:          `plugin:StatChart zookeeper`,
+			expectedNames: []string{"zookeeper"},
+		},
+		{
+			title:         "free text and filter",
+			text:          `kafka project:sandbox`,
+			expectedNames: []string{"kafka-sandbox"},
+		},
+	}
+	for _, tc := range testsCases {
+		t.Run(tc.title, func(t *testing.T) {
+			response, err := c.SearchAll(nil, "", tc.text)
+			require.NoError(t, err)
+			var names []string
+			for _, r := range response.Results {
+				names = append(names, r.Metadata.GetName())
+			}
+			assert.ElementsMatch(t, tc.expectedNames, names)
+		})
+	}
+
+	t.Run("facets", func(t *testing.T) {
+		response, err := c.SearchAll(nil, "", "tag:kafka OR tag:zookeeper")
+		require.NoError(t, err)
+		assert.Equal(t, map[Facet]map[string]int{
+			KindFacet:    {"Dashboard": 3},
+			ProjectFacet: {"infra": 2, "sandbox": 1},
+			TagFacet:     {"kafka": 2, "messaging": 1, "zookeeper": 1},
+			PluginFacet:  {"TimeSeriesChart": 1, "PrometheusTimeSeriesQuery": 1, "StatChart": 1},
+		}, response.Facets)
+	})
+
+	t.Run("invalid query", func(t *testing.T) {
+		_, err := c.SearchAll(nil, "", "(tag:kafka")
+		assert.ErrorIs(t, err, apiInterface.BadRequestError)
+	})
+}
@@ -19,6 +19,7 @@ import (
 	"strings"
 
 	"github.com/perses/perses/pkg/model/api"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
 )
 
 // MatchingInterval represents the start and the end position of the continuous characters that is matching the pattern.
@@ -39,6 +40,8 @@ func (m MatchingInterval) Size() uint64 {
 // the original string that matches the query, and the list of intervals that gives you the position of the characters that match the query in the original string.
 // Note for developers: this struct is not in the pkg/model/api/v1/search package to avoid generating them with cuelang and facing issues with cuelang when generating the package pkg/model/api.
 type SearchResult struct {
+	// Kind is the kind of the resource that matches the query.
+	Kind v1.Kind `json:"kind" yaml:"kind"`
 	// Metadata is the metadata of the resource that matches the query.
 	Metadata api.Metadata `json:"metadata" yaml:"metadata"`
 	// DisplayName is the string that will be used for the search display.
@@ -53,6 +56,48 @@ type SearchResult struct {
 	// When there is a perfect match, then the number is infinite.
 	// It can be equal to 0 if the query is empty.
 	Score uint64 `json:"score,omitempty"`
+	// project and attributes are used to compute the facets.
+	project    string
+	attributes map[string][]string
+}
+
+// Facet is a dimension used to count the results of a search.
+type Facet string
+
+const (
+	KindFacet    Facet = "kind"
+	ProjectFacet Facet = "project"
+	TagFacet     Facet = "tag"
+	PluginFacet  Facet = "plugin"
+)
+
+// SearchResponse is the response of a search through every kind.
+type SearchResponse struct {
+	Results []*SearchResult `json:"results"`
+	// Facets is the number of results per value, for each facet.
+	Facets map[Facet]map[string]int `json:"facets"`
+}
+
+func newSearchResponse(results []*SearchResult) *SearchResponse {
+	facets := map[Facet]map[string]int{
+		KindFacet:    {},
+		ProjectFacet: {},
+		TagFacet:     {},
+		PluginFacet:  {},
+	}
+	for _, result := range results {
+		facets[KindFacet][string(result.Kind)]++
+		if len(result.project) > 0 {
+			facets[ProjectFacet][result.project]++
+		}
+		for _, tag := range result.attributes[tagAttribute] {
+			facets[TagFacet][tag]++
+		}
+		for _, plugin := range result.attributes[pluginAttribute] {
+			facets[PluginFacet][plugin]++
+		}
+	}
+	return &SearchResponse{Results: results, Facets: facets}
 }
 
 type Query struct {
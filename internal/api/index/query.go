@@ -0,0 +1,399 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package index
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+)
+
+// The query language is a list of terms, implicitly joined with AND:
+//
+//	project:infra tag:kafka panel:"consumer lag" datasource:prom-eu
+//
+// A term is either a free text, matched with the fuzzy matcher against the indexed fields,
+// or a filter `field:value` on one of the fields below. The value can be quoted when it contains spaces.
+// Terms can be combined with AND, OR, NOT (or a leading `-`) and grouped with parentheses:
+//
+//	kind:dashboard (tag:kafka OR tag:zookeeper) -project:sandbox
+//
+// A query without any filter or operator is matched as a whole, like a single free text.
+const (
+	kindFilter    = "kind"
+	nameFilter    = "name"
+	projectFilter = "project"
+)
+
+// filters are the fields that can be used as filters.
+var filters = []string{kindFilter, nameFilter, projectFilter, tagAttribute, panelAttribute, datasourceAttribute, pluginAttribute}
+
+// candidate is a document being matched against a query.
+type candidate struct {
+	kind       v1.Kind
+	name       string
+	project    string
+	fields     []string
+	attributes map[string][]string
+	matcher    *matcher
+}
+
+// queryNode is a node of a parsed query.
+// match returns nil if the candidate doesn't match; otherwise a result, with a score when a free text matched.
+type queryNode interface {
+	match(c *candidate) *SearchResult
+}
+
+type textNode struct {
+	text string
+}
+
+func (n *textNode) match(c *candidate) *SearchResult {
+	for _, field := range c.fields {
+		if result := c.matcher.match(n.text, field); result != nil {
+			return result
+		}
+	}
+	return nil
+}
+
+type filterNode struct {
+	field string
+	value string
+}
+
+func (n *filterNode) match(c *candidate) *SearchResult {
+	if n.matchValue(c) {
+		return &SearchResult{}
+	}
+	return nil
+}
+
+func (n *filterNode) matchValue(c *candidate) bool {
+	switch n.field {
+	case kindFilter:
+		return strings.EqualFold(string(c.kind), n.value) || strings.EqualFold(v1.PluralKindMap[c.kind], n.value)
+	case projectFilter:
+		return strings.EqualFold(c.project, n.value)
+	case nameFilter:
+		return containsFold(c.name, n.value)
+	case panelAttribute:
+		return slices.ContainsFunc(c.attributes[n.field], func(v string) bool { return containsFold(v, n.value) })
+	default:
+		return slices.ContainsFunc(c.attributes[n.field], func(v string) bool { return strings.EqualFold(v, n.value) })
+	}
+}
+
+func containsFold(s string, substr string) bool {
+	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+}
+
+type notNode struct {
+	node queryNode
+}
+
+func (n *notNode) match(c *candidate) *SearchResult {
+	if n.node.match(c) != nil {
+		return nil
+	}
+	return &SearchResult{}
+}
+
+type andNode struct {
+	nodes []queryNode
+}
+
+func (n *andNode) match(c *candidate) *SearchResult {
+	result := &SearchResult{}
+	for _, node := range n.nodes {
+		r := node.match(c)
+		if r == nil {
+			return nil
+		}
+		mergeResult(result, r)
+	}
+	return result
+}
+
+type orNode struct {
+	nodes []queryNode
+}
+
+func (n *orNode) match(c *candidate) *SearchResult {
+	var result *SearchResult
+	for _, node := range n.nodes {
+		r := node.match(c)
+		if r == nil {
+			continue
+		}
+		if result == nil {
+			result = &SearchResult{}
+		}
+		mergeResult(result, r)
+	}
+	return result
+}
+
+// mergeResult is adding the score of r to result. The matching string is the first one found.
+func mergeResult(result *SearchResult, r *SearchResult) {
+	if result.Score > math.MaxUint64-r.Score {
+		// A perfect match already has the maximum score.
+		result.Score = math.MaxUint64
+	} else {
+		result.Score += r.Score
+	}
+	if len(result.Original) == 0 {
+		result.Original = r.Original
+		result.Intervals = r.Intervals
+	}
+}
+
+type tokenType int
+
+const (
+	wordToken tokenType = iota
+	// quotedToken is a value between double quotes.
+	quotedToken
+	openToken
+	closeToken
+	// negationToken is the `-` prefix of a term.
+	negationToken
+)
+
+type token struct {
+	kind  tokenType
+	value string
+}
+
+func tokenize(query string) ([]token, error) {
+	var tokens []token
+	runes := []rune(query)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case r == ' ' || r == '\t' || r == '\n':
+			i++
+		case r == '(':
+			tokens = append(tokens, token{kind: openToken})
+			i++
+		case r == ')':
+			tokens = append(tokens, token{kind: closeToken})
+			i++
+		case r == '-' && i+1 < len(runes) && runes[i+1] != ' ' && (i == 0 || runes[i-1] == ' ' || runes[i-1] == '('):
+			tokens = append(tokens, token{kind: negationToken})
+			i++
+		case r == '"':
+			value, next, err := readQuoted(runes, i)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, token{kind: quotedToken, value: value})
+			i = next
+		default:
+			start := i
+			for i < len(runes) && !strings.ContainsRune(" \t\n()\"", runes[i]) {
+				i++
+			}
+			word := string(runes[start:i])
+			// A filter value can be quoted: tag:"my tag"
+			if strings.HasSuffix(word, ":") && i < len(runes) && runes[i] == '"' {
+				value, next, err := readQuoted(runes, i)
+				if err != nil {
+					return nil, err
+				}
+				tokens = append(tokens, token{kind: wordToken, value: word + value})
+				i = next
+				continue
+			}
+			tokens = append(tokens, token{kind: wordToken, value: word})
+		}
+	}
+	return tokens, nil
+}
+
+// readQuoted reads the value between the double quotes starting at the index start.
+// It returns the value and the index following the closing quote.
+func readQuoted(runes []rune, start int) (string, int, error) {
+	var sb strings.Builder
+	for i := start + 1; i < len(runes); i++ {
+		if runes[i] == '\\' && i+1 < len(runes) && runes[i+1] == '"' {
+			sb.WriteRune('"')
+			i++
+			continue
+		}
+		if runes[i] == '"' {
+			return sb.String(), i + 1, nil
+		}
+		sb.WriteRune(runes[i])
+	}
+	return "", 0, fmt.Errorf("missing closing quote in the query")
+}
+
+type queryParser struct {
+	tokens []token
+	pos    int
+	// isStructured is true when the query uses a filter or an operator.
+	isStructured bool
+}
+
+// parseQuery parses the query. A query without any filter or operator is a single free text.
+func parseQuery(query string) (queryNode, error) {
+	tokens, err := tokenize(query)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("the query is empty")
+	}
+	p := &queryParser{tokens: tokens}
+	node, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("unexpected ')' in the query")
+	}
+	if !p.isStructured {
+		return &textNode{text: strings.TrimSpace(query)}, nil
+	}
+	return node, nil
+}
+
+func (p *queryParser) peek() *token {
+	if p.pos >= len(p.tokens) {
+		return nil
+	}
+	return &p.tokens[p.pos]
+}
+
+func (p *queryParser) isOperator(operator string) bool {
+	t := p.peek()
+	return t != nil && t.kind == wordToken && t.value == operator
+}
+
+func (p *queryParser) parseOr() (queryNode, error) {
+	node, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	nodes := []queryNode{node}
+	for p.isOperator("OR") {
+		p.isStructured = true
+		p.pos++
+		next, nextErr := p.parseAnd()
+		if nextErr != nil {
+			return nil, nextErr
+		}
+		nodes = append(nodes, next)
+	}
+	if len(nodes) == 1 {
+		return node, nil
+	}
+	return &orNode{nodes: nodes}, nil
+}
+
+func (p *queryParser) parseAnd() (queryNode, error) {
+	var nodes []queryNode
+	for {
+		t := p.peek()
+		if t == nil || t.kind == closeToken || p.isOperator("OR") {
+			break
+		}
+		if p.isOperator("AND") {
+			p.isStructured = true
+			p.pos++
+			continue
+		}
+		node, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, node)
+	}
+	if len(nodes) == 0 {
+		return nil, fmt.Errorf("missing a term in the query")
+	}
+	if len(nodes) == 1 {
+		return nodes[0], nil
+	}
+	return &andNode{nodes: nodes}, nil
+}
+
+func (p *queryParser) parseUnary() (queryNode, error) {
+	t := p.peek()
+	if t.kind == negationToken || (t.kind == wordToken && t.value == "NOT") {
+		p.isStructured = true
+		p.pos++
+		if p.peek() == nil {
+			return nil, fmt.Errorf("missing a term after the negation in the query")
+		}
+		node, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &notNode{node: node}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *queryParser) parsePrimary() (queryNode, error) {
+	t := p.peek()
+	p.pos++
+	switch t.kind {
+	case openToken:
+		p.isStructured = true
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.peek(); closing == nil || closing.kind != closeToken {
+			return nil, fmt.Errorf("missing ')' in the query")
+		}
+		p.pos++
+		return node, nil
+	case closeToken:
+		return nil, fmt.Errorf("unexpected ')' in the query")
+	case quotedToken:
+		p.isStructured = true
+		return &textNode{text: t.value}, nil
+	case wordToken:
+		if field, value, isFilter := parseFilter(t.value); isFilter {
+			p.isStructured = true
+			if len(value) == 0 {
+				return nil, fmt.Errorf("missing a value for the filter %q", field)
+			}
+			return &filterNode{field: field, value: value}, nil
+		}
+		return &textNode{text: t.value}, nil
+	default:
+		return nil, fmt.Errorf("unexpected term in the query")
+	}
+}
+
+// parseFilter returns the field and the value when the term is a filter on a known field.
+// Any other term containing ':' (e.g. localhost:9090) is a free text.
+func parseFilter(term string) (string, string, bool) {
+	field, value, found := strings.Cut(term, ":")
+	if !found {
+		return "", "", false
+	}
+	field = strings.ToLower(field)
+	if !slices.Contains(filters, field) {
+		return "", "", false
+	}
+	return field, value, true
+}
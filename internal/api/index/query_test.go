@@ -0,0 +1,122 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package index
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseQuery(t *testing.T) {
+	testSuite := []struct {
+		title    string
+		query    string
+		expected queryNode
+	}{
+		{
+			title:    "plain text is matched as a whole",
+			query:    " node exporter ",
+			expected: &textNode{text: "node exporter"},
+		},
+		{
+			title:    "colon in a free text",
+			query:    "localhost:9090",
+			expected: &textNode{text: "localhost:9090"},
+		},
+		{
+			title: "filters are joined with AND",
+			query: `project:infra tag:kafka panel:"consumer lag"`,
+			expected: &andNode{nodes: []queryNode{
+				&filterNode{field: projectFilter, value: "infra"},
+				&filterNode{field: tagAttribute, value: "kafka"},
+				&filterNode{field: panelAttribute, value: "consumer lag"},
+			}},
+		},
+		{
+			title: "operators and parentheses",
+			query: `kind:dashboard (tag:kafka OR TAG:zookeeper) -project:sandbox AND NOT "node"`,
+			expected: &andNode{nodes: []queryNode{
+				&filterNode{field: kindFilter, value: "dashboard"},
+				&orNode{nodes: []queryNode{
+					&filterNode{field: tagAttribute, value: "kafka"},
+					&filterNode{field: tagAttribute, value: "zookeeper"},
+				}},
+				&notNode{node: &filterNode{field: projectFilter, value: "sandbox"}},
+				&notNode{node: &textNode{text: "node"}},
+			}},
+		},
+		{
+			title: "dash inside a word",
+			query: "tag:kafka prom-eu",
+			expected: &andNode{nodes: []queryNode{
+				&filterNode{field: tagAttribute, value: "kafka"},
+				&textNode{text: "prom-eu"},
+			}},
+		},
+	}
+	for _, test := range testSuite {
+		t.Run(test.title, func(t *testing.T) {
+			node, err := parseQuery(test.query)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, node)
+		})
+	}
+}
+
+func TestParseQueryError(t *testing.T) {
+	testSuite := []struct {
+		title string
+		query string
+		err   string
+	}{
+		{
+			title: "empty query",
+			query: "  ",
+			err:   "the query is empty",
+		},
+		{
+			title: "missing closing parenthesis",
+			query: "(tag:kafka",
+			err:   "missing ')' in the query",
+		},
+		{
+			title: "unexpected closing parenthesis",
+			query: "tag:kafka)",
+			err:   "unexpected ')' in the query",
+		},
+		{
+			title: "missing closing quote",
+			query: `panel:"lag`,
+			err:   "missing closing quote in the query",
+		},
+		{
+			title: "missing filter value",
+			query: "tag: kafka",
+			err:   `missing a value for the filter "tag"`,
+		},
+		{
+			title: "dangling operator",
+			query: "tag:kafka OR",
+			err:   "missing a term in the query",
+		},
+	}
+	for _, test := range testSuite {
+		t.Run(test.title, func(t *testing.T) {
+			_, err := parseQuery(test.query)
+			assert.EqualError(t, err, test.err)
+		})
+	}
+}
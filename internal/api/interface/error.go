@@ -98,6 +98,10 @@ func HandleNotFoundError(msg string) error {
 	return handleErrorMsg(msg, NotFoundError)
 }
 
+func HandleConflictError(msg string) error {
+	return handleErrorMsg(msg, ConflictError)
+}
+
 func HandleBadRequestError(msg string) error {
 	return handleErrorMsg(msg, BadRequestError)
 }
@@ -0,0 +1,71 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package globaldashboardtemplate
+
+import (
+	"encoding/json"
+
+	"github.com/labstack/echo/v4"
+	databaseModel "github.com/perses/perses/internal/api/database/model"
+	apiInterface "github.com/perses/perses/internal/api/interface"
+	"github.com/perses/perses/pkg/model/api"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+)
+
+type Query struct {
+	databaseModel.Query
+	// NamePrefix is a prefix of the GlobalDashboardTemplate.metadata.name that is used to filter the list of the GlobalDashboardTemplate.
+	// NamePrefix can be empty in case you want to return the full list of GlobalDashboardTemplate available.
+	NamePrefix   string `query:"name"`
+	MetadataOnly bool   `query:"metadata_only"`
+}
+
+func (q *Query) GetMetadataOnlyQueryParam() bool {
+	return q.MetadataOnly
+}
+
+func (q *Query) IsRawQueryAllowed() bool {
+	return true
+}
+
+func (q *Query) IsRawMetadataQueryAllowed() bool {
+	return true
+}
+
+func (q *Query) GetProjectQueryParam() string {
+	return ""
+}
+
+func (q *Query) SetProjectQueryParam(_ string) {
+}
+
+type DAO interface {
+	Create(entity *v1.GlobalDashboardTemplate) error
+	Update(entity *v1.GlobalDashboardTemplate) error
+	Delete(name string) error
+	Get(name string) (*v1.GlobalDashboardTemplate, error)
+	List(q *Query) ([]*v1.GlobalDashboardTemplate, error)
+	RawList(q *Query) ([]json.RawMessage, error)
+	MetadataList(q *Query) ([]api.Entity, error)
+	RawMetadataList(q *Query) ([]json.RawMessage, error)
+}
+
+type Service interface {
+	apiInterface.Service[*v1.GlobalDashboardTemplate, *v1.GlobalDashboardTemplate, *Query]
+	// Instantiate renders the template and creates, or updates, the dashboard requested.
+	Instantiate(ctx echo.Context, parameters apiInterface.Parameters, request *v1.DashboardTemplateInstantiation) (*v1.Dashboard, error)
+	// Render renders again the dashboards instantiated from the template.
+	// Unless force is true, only the dashboards rendered from a previous version of the template are updated.
+	Render(ctx echo.Context, parameters apiInterface.Parameters, force bool) ([]v1.DashboardTemplateRenderResult, error)
+}
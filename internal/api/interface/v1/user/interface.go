@@ -57,8 +57,17 @@ type DAO interface {
 	List(q *Query) ([]*v1.User, error)
 	MetadataList(q *Query) ([]api.Entity, error)
 	RawMetadataList(q *Query) ([]json.RawMessage, error)
+	// AddRecentlyViewedDashboard stores the view of the dashboard, replacing the previous view of the same dashboard by the user.
+	AddRecentlyViewedDashboard(username string, recent v1.RecentDashboard) error
+	ListRecentlyViewedDashboards(username string, limit int) ([]v1.RecentDashboard, error)
+	DeleteRecentlyViewedDashboards(username string) error
 }
 
 type Service interface {
 	apiInterface.Service[*v1.User, *v1.PublicUser, *Query]
+	GetPreferences(username string) (*v1.UserPreferences, error)
+	// UpdatePreferences replaces the preferences of the user.
+	// The recently viewed dashboards are kept when they are not provided, as they are filled by the server with each view.
+	UpdatePreferences(username string, preferences *v1.UserPreferences) (*v1.UserPreferences, error)
+	AddRecentlyViewedDashboard(username string, dashboard v1.DashboardReference) error
 }
@@ -13,8 +13,48 @@
 
 package view
 
-import v1 "github.com/perses/perses/pkg/model/api/v1"
+import (
+	databaseModel "github.com/perses/perses/internal/api/database/model"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+)
+
+// UsageQuery is the query used to build the dashboard usage reports of a project.
+type UsageQuery struct {
+	Project string `param:"project"`
+	// Days is the number of days, up to today included, the report is built on.
+	Days int `query:"days"`
+	// Limit is the maximum number of dashboards returned.
+	Limit int `query:"limit"`
+}
+
+// DashboardUsage is the usage of a dashboard over the days of the report.
+type DashboardUsage struct {
+	Project   string `json:"project" yaml:"project"`
+	Dashboard string `json:"dashboard" yaml:"dashboard"`
+	Views     int64  `json:"views" yaml:"views"`
+	// Viewers is the number of distinct users who viewed the dashboard.
+	Viewers      int   `json:"viewers" yaml:"viewers"`
+	RenderErrors int64 `json:"render_errors" yaml:"render_errors"`
+	// AverageRenderTimeSecs is the average of the render times reported by the views.
+	AverageRenderTimeSecs float64 `json:"average_render_time" yaml:"average_render_time"`
+	// LastViewDay is the last day the dashboard was viewed, among the days still kept by the retention.
+	// It is empty if the dashboard was never viewed.
+	LastViewDay string `json:"last_view_day,omitempty" yaml:"last_view_day,omitempty"`
+}
+
+type DAO interface {
+	Add(usage *databaseModel.DashboardUsage) error
+	List(project string, from string) ([]*databaseModel.DashboardUsage, error)
+	DeleteBefore(day string) error
+}
 
 type Service interface {
-	View(*v1.View) error
+	// View records the view of a dashboard by the user.
+	View(view *v1.View, user string) error
+	// MostViewed returns the dashboards of the project with the highest number of views.
+	MostViewed(q *UsageQuery) ([]*DashboardUsage, error)
+	// NeverViewed returns the dashboards of the project that haven't been viewed.
+	NeverViewed(q *UsageQuery) ([]*DashboardUsage, error)
+	// Slowest returns the dashboards of the project with the highest average render time.
+	Slowest(q *UsageQuery) ([]*DashboardUsage, error)
 }
@@ -0,0 +1,414 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package lifecycle applies the lifecycle policies to the stale dashboards, meaning the dashboards nobody viewed or updated for a while.
+//
+// A stale dashboard is first marked with the tag of the policy, and moved to the archive folder if there is one.
+// If the dashboard is viewed again, the tag is removed and the dashboard is put back in its folder.
+// Otherwise, the dashboard is deleted once the grace period is over.
+//
+// The tag is added with an update of the dashboard, so the metadata.updatedAt of a marked dashboard tells when the grace period started.
+//
+// The dashboards managed by the provisioning are left untouched, as the provisioning would restore them at its next execution.
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/perses/common/set"
+	databaseModel "github.com/perses/perses/internal/api/database/model"
+	apiInterface "github.com/perses/perses/internal/api/interface"
+	"github.com/perses/perses/internal/api/interface/v1/dashboard"
+	"github.com/perses/perses/internal/api/interface/v1/folder"
+	"github.com/perses/perses/internal/api/interface/v1/project"
+	"github.com/perses/perses/internal/api/interface/v1/view"
+	"github.com/perses/perses/internal/api/provisioning/managed"
+	"github.com/perses/perses/pkg/model/api/config"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/sirupsen/logrus"
+)
+
+type Action string
+
+const (
+	// MarkAction tags the dashboard and moves it to the archive folder.
+	MarkAction Action = "mark"
+	// RestoreAction removes the tag of a dashboard viewed again and takes it out of the archive folder.
+	RestoreAction Action = "restore"
+	// DeleteAction deletes the dashboard once the grace period is over.
+	DeleteAction Action = "delete"
+)
+
+// Step is an action a policy takes on a dashboard.
+type Step struct {
+	Project   string `json:"project"`
+	Dashboard string `json:"dashboard"`
+	Action    Action `json:"action"`
+	// LastActivity is the last time the dashboard was viewed or updated.
+	LastActivity time.Time `json:"last_activity"`
+}
+
+// Lifecycle computes and applies the steps of the lifecycle policies.
+// It is also the task applying the policies of every project at regular interval.
+type Lifecycle struct {
+	conf             *config.DashboardLifecycle
+	dashboardService dashboard.Service
+	folderService    folder.Service
+	projectService   project.Service
+	usageDAO         view.DAO
+	now              func() time.Time
+}
+
+func New(conf *config.DashboardLifecycle, dashboardService dashboard.Service, folderService folder.Service, projectService project.Service, usageDAO view.DAO) *Lifecycle {
+	return &Lifecycle{
+		conf:             conf,
+		dashboardService: dashboardService,
+		folderService:    folderService,
+		projectService:   projectService,
+		usageDAO:         usageDAO,
+		now:              time.Now,
+	}
+}
+
+func (l *Lifecycle) String() string {
+	return "dashboard lifecycle"
+}
+
+func (l *Lifecycle) Execute(_ context.Context, _ context.CancelFunc) error {
+	projects, err := l.projectService.List(&project.Query{})
+	if err != nil {
+		return err
+	}
+	// A project failing doesn't prevent the policies of the other projects to be applied.
+	var errs []error
+	for _, p := range projects {
+		policy := l.conf.GetPolicy(p.Metadata.Name)
+		if policy == nil {
+			continue
+		}
+		dashboards, listErr := l.dashboardService.List(&dashboard.Query{Project: p.Metadata.Name})
+		if listErr != nil {
+			logrus.WithError(listErr).WithField("project", p.Metadata.Name).Error("unable to list the dashboards to apply the lifecycle policy")
+			errs = append(errs, fmt.Errorf("project %q: %w", p.Metadata.Name, listErr))
+			continue
+		}
+		steps, planErr := l.plan(p.Metadata.Name, policy, dashboards)
+		if planErr != nil {
+			logrus.WithError(planErr).WithField("project", p.Metadata.Name).Error("unable to plan the lifecycle policy")
+			errs = append(errs, fmt.Errorf("project %q: %w", p.Metadata.Name, planErr))
+			continue
+		}
+		l.apply(p.Metadata.Name, policy, dashboards, steps)
+	}
+	return errors.Join(errs...)
+}
+
+// Plan returns the steps the policy of the project would take, without applying them.
+func (l *Lifecycle) Plan(projectName string) ([]*Step, error) {
+	policy := l.conf.GetPolicy(projectName)
+	if policy == nil {
+		return []*Step{}, nil
+	}
+	dashboards, err := l.dashboardService.List(&dashboard.Query{Project: projectName})
+	if err != nil {
+		return nil, err
+	}
+	return l.plan(projectName, policy, dashboards)
+}
+
+func (l *Lifecycle) plan(projectName string, policy *config.DashboardLifecyclePolicy, dashboards []*v1.Dashboard) ([]*Step, error) {
+	lastViews, err := l.lastViews(projectName)
+	if err != nil {
+		return nil, err
+	}
+	now := l.now()
+	steps := []*Step{}
+	for _, dash := range dashboards {
+		if managed.IsManaged(&dash.Metadata) {
+			continue
+		}
+		metadata := dash.Metadata
+		lastView, isViewed := lastViews[metadata.Name]
+		if metadata.Tags.Contains(policy.Tag) {
+			if isViewed && lastView.After(metadata.UpdatedAt) {
+				steps = append(steps, &Step{Project: projectName, Dashboard: metadata.Name, Action: RestoreAction, LastActivity: lastView})
+			} else if policy.DeleteAfter > 0 && now.Sub(metadata.UpdatedAt) >= time.Duration(policy.DeleteAfter) {
+				steps = append(steps, &Step{Project: projectName, Dashboard: metadata.Name, Action: DeleteAction, LastActivity: metadata.UpdatedAt})
+			}
+			continue
+		}
+		lastActivity := metadata.UpdatedAt
+		if isViewed && lastView.After(lastActivity) {
+			lastActivity = lastView
+		}
+		if now.Sub(lastActivity) >= time.Duration(policy.UnusedFor) {
+			steps = append(steps, &Step{Project: projectName, Dashboard: metadata.Name, Action: MarkAction, LastActivity: lastActivity})
+		}
+	}
+	sort.Slice(steps, func(i, j int) bool {
+		return steps[i].Dashboard < steps[j].Dashboard
+	})
+	return steps, nil
+}
+
+// lastViews returns, for each dashboard viewed during the retention of the usage, the end of the last day it was viewed.
+// The views are only stored per day, so a dashboard viewed during the day it was marked counts as viewed again.
+func (l *Lifecycle) lastViews(projectName string) (map[string]time.Time, error) {
+	result := make(map[string]time.Time)
+	usages, err := l.usageDAO.List(projectName, "")
+	if err != nil {
+		return nil, err
+	}
+	for _, usage := range usages {
+		if usage.Views == 0 {
+			continue
+		}
+		day, parseErr := time.Parse(databaseModel.DayFormat, usage.Day)
+		if parseErr != nil {
+			return nil, fmt.Errorf("invalid day %q in the usage of the dashboard %q: %w", usage.Day, usage.Dashboard, parseErr)
+		}
+		endOfDay := day.Add(24 * time.Hour)
+		if endOfDay.After(result[usage.Dashboard]) {
+			result[usage.Dashboard] = endOfDay
+		}
+	}
+	return result, nil
+}
+
+// apply runs the steps of the project. A failing step is logged and doesn't prevent the other ones to run.
+func (l *Lifecycle) apply(projectName string, policy *config.DashboardLifecyclePolicy, dashboards []*v1.Dashboard, steps []*Step) {
+	dashboardsByName := make(map[string]*v1.Dashboard, len(dashboards))
+	for _, dash := range dashboards {
+		dashboardsByName[dash.Metadata.Name] = dash
+	}
+	var marked []string
+	removed := set.New[string]()
+	restored := set.New[string]()
+	for _, step := range steps {
+		logger := logrus.WithFields(logrus.Fields{"project": projectName, "dashboard": step.Dashboard, "action": step.Action})
+		params := apiInterface.Parameters{Project: projectName, Name: step.Dashboard}
+		dash := dashboardsByName[step.Dashboard]
+		var err error
+		switch step.Action {
+		case MarkAction:
+			if dash.Metadata.Tags == nil {
+				dash.Metadata.Tags = set.New[string]()
+			}
+			dash.Metadata.Tags.Add(policy.Tag)
+			if _, err = l.dashboardService.Update(nil, dash, params); err == nil {
+				marked = append(marked, step.Dashboard)
+			}
+		case RestoreAction:
+			dash.Metadata.Tags.Remove(policy.Tag)
+			if _, err = l.dashboardService.Update(nil, dash, params); err == nil {
+				restored.Add(step.Dashboard)
+			}
+		case DeleteAction:
+			if err = l.dashboardService.Delete(nil, params); err == nil {
+				removed.Add(step.Dashboard)
+			}
+		}
+		if err != nil {
+			logger.WithError(err).Error("unable to apply the lifecycle policy")
+			continue
+		}
+		logger.Info("lifecycle policy applied")
+	}
+	if err := l.updateFolders(projectName, policy.ArchiveFolder, marked, removed, restored); err != nil {
+		logrus.WithError(err).WithField("project", projectName).Error("unable to update the folders with the stale dashboards")
+	}
+}
+
+// updateFolders removes the deleted dashboards from every folder of the project, and moves the marked and the restored dashboards in and out of the archive folder.
+//
+// A marked dashboard is put in the archive folder under the same path as in its folder, for example archive > team-a > kafka
+// for a dashboard in the sub-folder kafka of the folder team-a. This is what tells where a restored dashboard goes back.
+// A dashboard can only be referenced once in a folder, so when it is in several folders, it is only moved out of the first one
+// by name and stays in the other ones.
+func (l *Lifecycle) updateFolders(projectName string, archiveFolder string, marked []string, removed set.Set[string], restored set.Set[string]) error {
+	if len(archiveFolder) == 0 {
+		// The marked dashboards stay in their folder, only the deleted ones must be removed.
+		marked = nil
+		restored = nil
+	}
+	if len(marked) == 0 && len(removed) == 0 && len(restored) == 0 {
+		return nil
+	}
+	folders, err := l.folderService.List(&folder.Query{Project: projectName})
+	if err != nil {
+		return err
+	}
+	sort.Slice(folders, func(i, j int) bool {
+		return folders[i].Metadata.Name < folders[j].Metadata.Name
+	})
+	foldersByName := make(map[string]*v1.Folder, len(folders))
+	for _, f := range folders {
+		foldersByName[f.Metadata.Name] = f
+	}
+	updated := set.New[string]()
+	created := set.New[string]()
+
+	archive := foldersByName[archiveFolder]
+	if archive == nil && len(marked) > 0 {
+		archive = newFolder(projectName, archiveFolder)
+		foldersByName[archiveFolder] = archive
+		created.Add(archiveFolder)
+	}
+	// The path of the restored dashboards in the archive folder is their origin.
+	origins := make(map[string][]string)
+	if archive != nil {
+		for name := range restored {
+			if path, ok := findDashboard(archive.Spec.Items, name); ok && len(path) > 0 {
+				origins[name] = path
+			}
+		}
+		var isUpdated bool
+		// The marked dashboards are removed from the archive folder too, in case someone already put them there.
+		archive.Spec.Items, isUpdated = removeDashboards(archive.Spec.Items, func(name string) bool {
+			return removed.Contains(name) || restored.Contains(name) || slices.Contains(marked, name)
+		})
+		if isUpdated {
+			archive.Spec.Items = removeEmptyFolders(archive.Spec.Items)
+			updated.Add(archiveFolder)
+		}
+	}
+	for _, f := range folders {
+		if f.Metadata.Name == archiveFolder {
+			continue
+		}
+		var isUpdated bool
+		if f.Spec.Items, isUpdated = removeDashboards(f.Spec.Items, removed.Contains); isUpdated {
+			updated.Add(f.Metadata.Name)
+		}
+	}
+	for _, name := range marked {
+		var origin []string
+		for _, f := range folders {
+			if f.Metadata.Name == archiveFolder {
+				continue
+			}
+			if path, ok := findDashboard(f.Spec.Items, name); ok {
+				origin = append([]string{f.Metadata.Name}, path...)
+				f.Spec.Items, _ = removeDashboards(f.Spec.Items, func(n string) bool { return n == name })
+				updated.Add(f.Metadata.Name)
+				break
+			}
+		}
+		archive.Spec.Items = addDashboard(archive.Spec.Items, origin, name)
+		updated.Add(archiveFolder)
+	}
+	for _, name := range slices.Sorted(maps.Keys(origins)) {
+		origin := origins[name]
+		f := foldersByName[origin[0]]
+		if f == nil {
+			// The folder was deleted while the dashboard was in the archive.
+			f = newFolder(projectName, origin[0])
+			foldersByName[origin[0]] = f
+			created.Add(origin[0])
+		} else if _, ok := findDashboard(f.Spec.Items, name); ok {
+			// Someone already put the dashboard back.
+			continue
+		}
+		f.Spec.Items = addDashboard(f.Spec.Items, origin[1:], name)
+		updated.Add(origin[0])
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(updated)) {
+		f := foldersByName[name]
+		if created.Contains(name) {
+			_, err = l.folderService.Create(nil, f)
+		} else {
+			_, err = l.folderService.Update(nil, f, apiInterface.Parameters{Project: projectName, Name: name})
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func newFolder(projectName string, name string) *v1.Folder {
+	return &v1.Folder{
+		Kind:     v1.KindFolder,
+		Metadata: *v1.NewProjectMetadata(projectName, name),
+	}
+}
+
+// findDashboard returns the names of the sub-folders leading to the dashboard, and false when the dashboard isn't referenced.
+func findDashboard(items []v1.FolderItem, dashboard string) ([]string, bool) {
+	for _, item := range items {
+		if item.Kind == v1.KindDashboard && item.Name == dashboard {
+			return []string{}, true
+		}
+		if item.Kind == v1.KindFolder {
+			if path, ok := findDashboard(item.Items, dashboard); ok {
+				return append([]string{item.Name}, path...), true
+			}
+		}
+	}
+	return nil, false
+}
+
+// addDashboard adds the dashboard under the path of sub-folders, creating the missing ones.
+func addDashboard(items []v1.FolderItem, path []string, dashboard string) []v1.FolderItem {
+	if len(path) == 0 {
+		return append(items, v1.FolderItem{Kind: v1.KindDashboard, Name: dashboard})
+	}
+	for i := range items {
+		if items[i].Kind == v1.KindFolder && items[i].Name == path[0] {
+			items[i].Items = addDashboard(items[i].Items, path[1:], dashboard)
+			return items
+		}
+	}
+	return append(items, v1.FolderItem{Kind: v1.KindFolder, Name: path[0], Items: addDashboard(nil, path[1:], dashboard)})
+}
+
+// removeEmptyFolders removes the sub-folders left without any item, so the archive folder doesn't keep the paths of the restored dashboards.
+func removeEmptyFolders(items []v1.FolderItem) []v1.FolderItem {
+	result := make([]v1.FolderItem, 0, len(items))
+	for _, item := range items {
+		if item.Kind == v1.KindFolder {
+			item.Items = removeEmptyFolders(item.Items)
+			if len(item.Items) == 0 {
+				continue
+			}
+		}
+		result = append(result, item)
+	}
+	return result
+}
+
+// removeDashboards removes the references to the dashboards matching the function, including in the sub-folders.
+func removeDashboards(items []v1.FolderItem, matches func(name string) bool) ([]v1.FolderItem, bool) {
+	var isUpdated bool
+	result := make([]v1.FolderItem, 0, len(items))
+	for _, item := range items {
+		if item.Kind == v1.KindDashboard && matches(item.Name) {
+			isUpdated = true
+			continue
+		}
+		if item.Kind == v1.KindFolder {
+			var isSubUpdated bool
+			item.Items, isSubUpdated = removeDashboards(item.Items, matches)
+			isUpdated = isUpdated || isSubUpdated
+		}
+		result = append(result, item)
+	}
+	return result, isUpdated
+}
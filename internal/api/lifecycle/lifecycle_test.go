@@ -0,0 +1,307 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lifecycle
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/perses/common/set"
+	databaseModel "github.com/perses/perses/internal/api/database/model"
+	apiInterface "github.com/perses/perses/internal/api/interface"
+	"github.com/perses/perses/internal/api/interface/v1/dashboard"
+	"github.com/perses/perses/internal/api/interface/v1/folder"
+	"github.com/perses/perses/internal/api/interface/v1/project"
+	"github.com/perses/perses/internal/api/interface/v1/view"
+	"github.com/perses/perses/internal/api/provisioning/managed"
+	"github.com/perses/perses/pkg/model/api/config"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/perses/spec/go/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var now = time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+const day = 24 * time.Hour
+
+type mockDashboardService struct {
+	dashboard.Service
+	dashboards map[string]*v1.Dashboard
+	// failingProject is the project for which the dashboards can't be listed.
+	failingProject string
+}
+
+func (s *mockDashboardService) List(query *dashboard.Query) ([]*v1.Dashboard, error) {
+	if len(s.failingProject) > 0 && query.Project == s.failingProject {
+		return nil, errors.New("database unavailable")
+	}
+	var result []*v1.Dashboard
+	for _, dash := range s.dashboards {
+		result = append(result, dash)
+	}
+	return result, nil
+}
+
+func (s *mockDashboardService) Update(_ echo.Context, entity *v1.Dashboard, _ apiInterface.Parameters) (*v1.Dashboard, error) {
+	entity.Metadata.UpdatedAt = now
+	s.dashboards[entity.Metadata.Name] = entity
+	return entity, nil
+}
+
+func (s *mockDashboardService) Delete(_ echo.Context, parameters apiInterface.Parameters) error {
+	delete(s.dashboards, parameters.Name)
+	return nil
+}
+
+type mockFolderService struct {
+	folder.Service
+	folders map[string]*v1.Folder
+}
+
+func (s *mockFolderService) List(_ *folder.Query) ([]*v1.Folder, error) {
+	var result []*v1.Folder
+	for _, f := range s.folders {
+		result = append(result, f)
+	}
+	return result, nil
+}
+
+func (s *mockFolderService) Create(_ echo.Context, entity *v1.Folder) (*v1.Folder, error) {
+	s.folders[entity.Metadata.Name] = entity
+	return entity, nil
+}
+
+func (s *mockFolderService) Update(_ echo.Context, entity *v1.Folder, _ apiInterface.Parameters) (*v1.Folder, error) {
+	s.folders[entity.Metadata.Name] = entity
+	return entity, nil
+}
+
+type mockProjectService struct {
+	project.Service
+	projects []string
+}
+
+func (s *mockProjectService) List(_ *project.Query) ([]*v1.Project, error) {
+	if len(s.projects) == 0 {
+		return []*v1.Project{{Kind: v1.KindProject, Metadata: v1.Metadata{Name: "perses"}}}, nil
+	}
+	var result []*v1.Project
+	for _, name := range s.projects {
+		result = append(result, &v1.Project{Kind: v1.KindProject, Metadata: v1.Metadata{Name: name}})
+	}
+	return result, nil
+}
+
+type mockUsageDAO struct {
+	view.DAO
+	usage []*databaseModel.DashboardUsage
+}
+
+func (d *mockUsageDAO) List(_ string, _ string) ([]*databaseModel.DashboardUsage, error) {
+	return d.usage, nil
+}
+
+func newDashboard(name string, updatedAt time.Time, tags ...string) *v1.Dashboard {
+	dash := &v1.Dashboard{
+		Kind:     v1.KindDashboard,
+		Metadata: *v1.NewProjectMetadata("perses", name),
+	}
+	dash.Metadata.UpdatedAt = updatedAt
+	if len(tags) > 0 {
+		dash.Metadata.Tags = set.New(tags...)
+	}
+	return dash
+}
+
+func newUsage(dashboard string, viewedAt time.Time) *databaseModel.DashboardUsage {
+	return &databaseModel.DashboardUsage{
+		Project:   "perses",
+		Dashboard: dashboard,
+		User:      "alice",
+		Day:       viewedAt.Format(databaseModel.DayFormat),
+		Views:     1,
+	}
+}
+
+func newLifecycle(dashboards []*v1.Dashboard, folders []*v1.Folder, usage []*databaseModel.DashboardUsage) (*Lifecycle, *mockDashboardService, *mockFolderService) {
+	dashboardService := &mockDashboardService{dashboards: make(map[string]*v1.Dashboard)}
+	for _, dash := range dashboards {
+		dashboardService.dashboards[dash.Metadata.Name] = dash
+	}
+	folderService := &mockFolderService{folders: make(map[string]*v1.Folder)}
+	for _, f := range folders {
+		folderService.folders[f.Metadata.Name] = f
+	}
+	conf := &config.DashboardLifecycle{
+		Policies: []*config.DashboardLifecyclePolicy{
+			{
+				Projects:      []string{"perses"},
+				UnusedFor:     common.Duration(30 * day),
+				Tag:           "stale",
+				ArchiveFolder: "archive",
+				DeleteAfter:   common.Duration(7 * day),
+			},
+		},
+	}
+	l := New(conf, dashboardService, folderService, &mockProjectService{}, &mockUsageDAO{usage: usage})
+	l.now = func() time.Time { return now }
+	return l, dashboardService, folderService
+}
+
+func TestPlan(t *testing.T) {
+	l, _, _ := newLifecycle(
+		[]*v1.Dashboard{
+			newDashboard("recently-updated", now.Add(-2*day)),
+			newDashboard("recently-viewed", now.Add(-60*day)),
+			newDashboard("unused", now.Add(-60*day)),
+			newDashboard("viewed-again", now.Add(-3*day), "stale"),
+			newDashboard("in-grace-period", now.Add(-3*day), "stale"),
+			newDashboard("grace-period-over", now.Add(-10*day), "stale"),
+			// The provisioning would restore the dashboards it manages, so they are left out of the policy.
+			newDashboard("provisioned", now.Add(-60*day), managed.FileTag),
+			newDashboard("provisioned-from-git", now.Add(-10*day), "stale", managed.GitTagPrefix+"dashboards"),
+		},
+		nil,
+		[]*databaseModel.DashboardUsage{
+			newUsage("recently-viewed", now.Add(-5*day)),
+			newUsage("unused", now.Add(-45*day)),
+			newUsage("viewed-again", now.Add(-1*day)),
+			newUsage("grace-period-over", now.Add(-20*day)),
+		},
+	)
+	steps, err := l.Plan("perses")
+	require.NoError(t, err)
+	assert.Equal(t, []*Step{
+		{Project: "perses", Dashboard: "grace-period-over", Action: DeleteAction, LastActivity: now.Add(-10 * day)},
+		{Project: "perses", Dashboard: "unused", Action: MarkAction, LastActivity: time.Date(2026, 9, 4, 0, 0, 0, 0, time.UTC)},
+		{Project: "perses", Dashboard: "viewed-again", Action: RestoreAction, LastActivity: time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)},
+	}, steps)
+
+	steps, err = l.Plan("another-project")
+	require.NoError(t, err)
+	assert.Empty(t, steps)
+}
+
+func TestExecute(t *testing.T) {
+	l, dashboardService, folderService := newLifecycle(
+		[]*v1.Dashboard{
+			newDashboard("unused", now.Add(-60*day)),
+			newDashboard("viewed-again", now.Add(-3*day), "stale", "team-a"),
+			newDashboard("grace-period-over", now.Add(-10*day), "stale"),
+		},
+		[]*v1.Folder{
+			{
+				Kind:     v1.KindFolder,
+				Metadata: *v1.NewProjectMetadata("perses", "team-a"),
+				Spec: v1.FolderSpec{Items: []v1.FolderItem{
+					{Kind: v1.KindFolder, Name: "kafka", Items: []v1.FolderItem{{Kind: v1.KindDashboard, Name: "unused"}}},
+					{Kind: v1.KindDashboard, Name: "grace-period-over"},
+				}},
+			},
+			{
+				Kind:     v1.KindFolder,
+				Metadata: *v1.NewProjectMetadata("perses", "team-b"),
+				Spec:     v1.FolderSpec{Items: []v1.FolderItem{{Kind: v1.KindDashboard, Name: "unused"}}},
+			},
+			{
+				Kind:     v1.KindFolder,
+				Metadata: *v1.NewProjectMetadata("perses", "archive"),
+				Spec:     v1.FolderSpec{Items: []v1.FolderItem{{Kind: v1.KindDashboard, Name: "viewed-again"}}},
+			},
+		},
+		[]*databaseModel.DashboardUsage{newUsage("viewed-again", now)},
+	)
+	require.NoError(t, l.Execute(nil, nil))
+
+	assert.NotContains(t, dashboardService.dashboards, "grace-period-over")
+	assert.True(t, dashboardService.dashboards["unused"].Metadata.Tags.Contains("stale"))
+	assert.Equal(t, set.New("team-a"), dashboardService.dashboards["viewed-again"].Metadata.Tags)
+
+	assert.Equal(t, []v1.FolderItem{{Kind: v1.KindFolder, Name: "kafka", Items: []v1.FolderItem{}}}, folderService.folders["team-a"].Spec.Items)
+	// The dashboard is only moved out of the first folder, and the archive folder keeps its path.
+	assert.Equal(t, []v1.FolderItem{{Kind: v1.KindDashboard, Name: "unused"}}, folderService.folders["team-b"].Spec.Items)
+	assert.Equal(t, []v1.FolderItem{
+		{Kind: v1.KindFolder, Name: "team-a", Items: []v1.FolderItem{
+			{Kind: v1.KindFolder, Name: "kafka", Items: []v1.FolderItem{{Kind: v1.KindDashboard, Name: "unused"}}},
+		}},
+	}, folderService.folders["archive"].Spec.Items)
+}
+
+func TestExecuteRestoresInOriginalFolder(t *testing.T) {
+	l, dashboardService, folderService := newLifecycle(
+		[]*v1.Dashboard{
+			newDashboard("kafka-lag", now.Add(-3*day), "stale"),
+			newDashboard("redis", now.Add(-3*day), "stale"),
+			newDashboard("in-grace-period", now.Add(-3*day), "stale"),
+		},
+		[]*v1.Folder{
+			{
+				Kind:     v1.KindFolder,
+				Metadata: *v1.NewProjectMetadata("perses", "team-a"),
+				Spec:     v1.FolderSpec{Items: []v1.FolderItem{{Kind: v1.KindDashboard, Name: "overview"}}},
+			},
+			{
+				Kind:     v1.KindFolder,
+				Metadata: *v1.NewProjectMetadata("perses", "archive"),
+				Spec: v1.FolderSpec{Items: []v1.FolderItem{
+					{Kind: v1.KindFolder, Name: "team-a", Items: []v1.FolderItem{
+						{Kind: v1.KindFolder, Name: "kafka", Items: []v1.FolderItem{{Kind: v1.KindDashboard, Name: "kafka-lag"}}},
+					}},
+					{Kind: v1.KindFolder, Name: "team-b", Items: []v1.FolderItem{{Kind: v1.KindDashboard, Name: "redis"}}},
+					{Kind: v1.KindDashboard, Name: "in-grace-period"},
+				}},
+			},
+		},
+		[]*databaseModel.DashboardUsage{newUsage("kafka-lag", now), newUsage("redis", now)},
+	)
+	require.NoError(t, l.Execute(nil, nil))
+
+	assert.False(t, dashboardService.dashboards["kafka-lag"].Metadata.Tags.Contains("stale"))
+	assert.False(t, dashboardService.dashboards["redis"].Metadata.Tags.Contains("stale"))
+
+	assert.Equal(t, []v1.FolderItem{
+		{Kind: v1.KindDashboard, Name: "overview"},
+		{Kind: v1.KindFolder, Name: "kafka", Items: []v1.FolderItem{{Kind: v1.KindDashboard, Name: "kafka-lag"}}},
+	}, folderService.folders["team-a"].Spec.Items)
+	// The folder team-b was deleted while its dashboard was in the archive, so it is created again.
+	require.Contains(t, folderService.folders, "team-b")
+	assert.Equal(t, []v1.FolderItem{{Kind: v1.KindDashboard, Name: "redis"}}, folderService.folders["team-b"].Spec.Items)
+	assert.Equal(t, []v1.FolderItem{{Kind: v1.KindDashboard, Name: "in-grace-period"}}, folderService.folders["archive"].Spec.Items)
+}
+
+func TestExecuteCreatesArchiveFolder(t *testing.T) {
+	l, _, folderService := newLifecycle([]*v1.Dashboard{newDashboard("unused", now.Add(-60*day))}, nil, nil)
+	require.NoError(t, l.Execute(nil, nil))
+	require.Contains(t, folderService.folders, "archive")
+	assert.Equal(t, []v1.FolderItem{{Kind: v1.KindDashboard, Name: "unused"}}, folderService.folders["archive"].Spec.Items)
+}
+
+func TestExecuteContinuesAfterProjectFailure(t *testing.T) {
+	l, dashboardService, _ := newLifecycle(
+		[]*v1.Dashboard{newDashboard("unused", now.Add(-60*day))},
+		nil,
+		nil,
+	)
+	l.conf.Policies[0].Projects = nil
+	l.projectService = &mockProjectService{projects: []string{"broken", "perses"}}
+	dashboardService.failingProject = "broken"
+
+	err := l.Execute(nil, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `project "broken"`)
+	assert.True(t, dashboardService.dashboards["unused"].Metadata.Tags.Contains("stale"))
+}
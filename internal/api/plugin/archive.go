@@ -15,40 +15,150 @@ package plugin
 
 import (
 	"context"
+	"crypto/ed25519"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 
 	"github.com/mholt/archives"
 	"github.com/perses/perses/internal/api/archive"
+	"github.com/perses/perses/internal/api/plugin/registry"
+	"github.com/perses/perses/pkg/model/api/config"
 	"github.com/sirupsen/logrus"
 )
 
 type arch struct {
 	folders      []string
 	targetFolder string
+	// publicKeyPaths is the list of the public keys used to verify the signature of the archives.
+	publicKeyPaths []string
+	// registry is the remote registry from which the pinned modules are pulled before extracting the archives. It is optional.
+	registry *config.PluginRegistry
+	// signatures contains the result of the signature verification for each archive extracted, indexed by the name of the folder where the archive has been extracted.
+	// A nil value means the signature has been successfully verified.
+	signatures map[string]error
+	mutex      sync.RWMutex
+}
+
+// signatureStatus returns nil if the plugin stored in the given folder has been extracted from an archive with a valid signature.
+func (a *arch) signatureStatus(pluginFolder string) error {
+	a.mutex.RLock()
+	defer a.mutex.RUnlock()
+	status, ok := a.signatures[pluginFolder]
+	if !ok {
+		// The folder doesn't come from an archive, or the archive has not been verified.
+		return archive.ErrNotSigned
+	}
+	return status
 }
 
 func (a *arch) unzipAll() error {
+	keys, err := archive.ReadPublicKeys(a.publicKeyPaths)
+	if err != nil {
+		return fmt.Errorf("unable to read the public keys used to verify the plugin archives: %w", err)
+	}
+	archivePaths, err := a.listArchives()
+	if err != nil {
+		return err
+	}
+	signatures := make(map[string]error)
+	for _, archivePath := range archivePaths {
+		folder, fileName := filepath.Split(archivePath)
+		archiveName := archive.ExtractArchiveName(fileName)
+		if len(keys) > 0 {
+			sigErr := verifyArchive(archivePath, keys)
+			signatures[archiveName] = sigErr
+			if errors.Is(sigErr, archive.ErrNotSigned) {
+				logrus.Warnf("plugin archive %q is not signed", fileName)
+			} else if sigErr != nil {
+				// We don't extract an archive that has been tampered with or signed by an untrusted key.
+				// A previous extraction of the archive must not be loaded either.
+				logrus.WithError(sigErr).Errorf("plugin archive %q is skipped as its signature cannot be verified", fileName)
+				if removeErr := a.removeExtractedArchive(archiveName); removeErr != nil {
+					logrus.WithError(removeErr).Errorf("unable to remove the previous extraction of the plugin archive %q", fileName)
+				}
+				continue
+			}
+		}
+		if unzipErr := a.unzip(folder, fileName); unzipErr != nil {
+			return fmt.Errorf("unable to unzip plugin archive %q: %w", fileName, unzipErr)
+		}
+	}
+	a.mutex.Lock()
+	a.signatures = signatures
+	a.mutex.Unlock()
+	return nil
+}
+
+// verifyArchive checks the signature of the archive against the name and the version of the plugin module it contains.
+func verifyArchive(archivePath string, keys []ed25519.PublicKey) error {
+	manifest, err := ReadManifestFromArchive(archivePath)
+	if err != nil {
+		if _, statErr := os.Stat(archive.SignatureFilePath(archivePath)); errors.Is(statErr, os.ErrNotExist) {
+			return archive.ErrNotSigned
+		}
+		return fmt.Errorf("%w: unable to read the manifest of the archive: %s", archive.ErrInvalidSignature, err)
+	}
+	return archive.Verify(archivePath, manifest.Name, manifest.Metadata.BuildInfo.Version, keys)
+}
+
+// removeExtractedArchive removes the folder where the archive has been extracted, if it exists.
+func (a *arch) removeExtractedArchive(archiveName string) error {
+	if len(archiveName) == 0 || strings.Contains(archiveName, "..") {
+		return fmt.Errorf("archive name %q contains invalid characters", archiveName)
+	}
+	return os.RemoveAll(filepath.Join(a.targetFolder, archiveName))
+}
+
+// listArchives returns the path of every archive found in the archive folders, followed by the archives of the modules pulled from the registry.
+func (a *arch) listArchives() ([]string, error) {
+	var result []string
 	for _, folder := range a.folders {
 		files, err := os.ReadDir(folder)
 		if err != nil {
-			return fmt.Errorf("unable to read directory %s: %w", folder, err)
+			return nil, fmt.Errorf("unable to read directory %s: %w", folder, err)
 		}
 		for _, file := range files {
 			if file.IsDir() {
 				// we are only looking for archive, so we can skip any sub-folder
 				continue
 			}
-
-			if unzipErr := a.unzip(folder, file.Name()); unzipErr != nil {
-				return fmt.Errorf("unable to unzip plugin archive %q: %w", file.Name(), unzipErr)
+			if !archive.IsArchiveFile(file.Name()) {
+				logrus.Debugf("skipping unarchive file %s", file.Name())
+				continue
 			}
+			result = append(result, filepath.Join(folder, file.Name()))
 		}
 	}
-	return nil
+	return append(result, a.pullFromRegistry()...), nil
+}
+
+// pullFromRegistry pulls the modules pinned in the configuration and returns the path of their archive in the cache.
+// A module that cannot be pulled is not blocking: the error is logged, and the other modules are still installed.
+func (a *arch) pullFromRegistry() []string {
+	if a.registry == nil || len(a.registry.Modules) == 0 {
+		return nil
+	}
+	client, err := registry.New(a.registry.URL.String(), a.registry.CachePath, a.registry.Offline, int64(a.registry.MaxArchiveSize)*1024*1024)
+	if err != nil {
+		logrus.WithError(err).Error("unable to create the plugin registry client")
+		return nil
+	}
+	var result []string
+	for _, m := range a.registry.Modules {
+		archivePath, pullErr := client.Pull(m.Name, m.Version)
+		if pullErr != nil {
+			logrus.WithError(pullErr).Errorf("unable to pull the plugin module %q from the registry", m.Name)
+			continue
+		}
+		logrus.Infof("plugin module %q pulled from the registry", m.Name)
+		result = append(result, archivePath)
+	}
+	return result
 }
 
 func (a *arch) unzip(folder string, archiveFileName string) error {
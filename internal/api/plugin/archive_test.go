@@ -0,0 +1,113 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mholt/archives"
+	"github.com/perses/perses/internal/api/archive"
+	"github.com/perses/perses/pkg/model/api/config"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// buildTestArchive builds the archive of a plugin module without any plugin, so it doesn't require any schema.
+func buildTestArchive(t *testing.T, archiveFolder string, name string, version string) string {
+	sourceFolder := t.TempDir()
+	manifest := fmt.Sprintf(`{"id":%q,"name":%q,"metaData":{"buildInfo":{"buildVersion":%q,"buildName":%q}}}`, name, name, version, name)
+	require.NoError(t, os.WriteFile(filepath.Join(sourceFolder, ManifestFileName), []byte(manifest), 0600))
+	npmPackage := fmt.Sprintf(`{"name":%q,"version":%q,"perses":{"plugins":[]}}`, name, version)
+	require.NoError(t, os.WriteFile(filepath.Join(sourceFolder, PackageJSONFile), []byte(npmPackage), 0600))
+	files, err := archives.FilesFromDisk(context.Background(), nil, map[string]string{
+		filepath.Join(sourceFolder, ManifestFileName): ManifestFileName,
+		filepath.Join(sourceFolder, PackageJSONFile):  PackageJSONFile,
+	})
+	require.NoError(t, err)
+	archiveName := filepath.Join(archiveFolder, fmt.Sprintf("%s-%s", name, version))
+	require.NoError(t, archive.Build(archiveName, archive.TARgz, files))
+	return fmt.Sprintf("%s.%s", archiveName, archive.TARgz)
+}
+
+func writeTestPublicKey(t *testing.T, folder string, key ed25519.PublicKey) string {
+	data, err := x509.MarshalPKIXPublicKey(key)
+	require.NoError(t, err)
+	keyPath := filepath.Join(folder, "perses-plugin.pub")
+	require.NoError(t, os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: data}), 0600))
+	return keyPath
+}
+
+func TestLoadRequireSigned(t *testing.T) {
+	archiveFolder := t.TempDir()
+	pluginFolder := t.TempDir()
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	_, untrustedPriv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	signed := buildTestArchive(t, archiveFolder, "Signed", "1.0.0")
+	_, err = archive.Sign(signed, "Signed", "1.0.0", priv)
+	require.NoError(t, err)
+
+	buildTestArchive(t, archiveFolder, "Unsigned", "1.0.0")
+
+	untrusted := buildTestArchive(t, archiveFolder, "Untrusted", "1.0.0")
+	_, err = archive.Sign(untrusted, "Untrusted", "1.0.0", untrustedPriv)
+	require.NoError(t, err)
+
+	// The archive is modified after being signed, while a previous extraction of the archive is still on disk.
+	tampered := buildTestArchive(t, archiveFolder, "Tampered", "1.0.0")
+	_, err = archive.Sign(tampered, "Tampered", "1.0.0", priv)
+	require.NoError(t, err)
+	pluginService := New(config.Plugin{Path: pluginFolder, ArchivePaths: []string{archiveFolder}})
+	require.NoError(t, pluginService.UnzipArchives())
+	require.DirExists(t, filepath.Join(pluginFolder, "Tampered-1.0.0"))
+	archiveFile, err := os.OpenFile(tampered, os.O_APPEND|os.O_WRONLY, 0600)
+	require.NoError(t, err)
+	_, err = archiveFile.WriteString("tampered")
+	require.NoError(t, err)
+	require.NoError(t, archiveFile.Close())
+
+	pluginService = New(config.Plugin{
+		Path:          pluginFolder,
+		ArchivePaths:  []string{archiveFolder},
+		PublicKeys:    []string{writeTestPublicKey(t, t.TempDir(), pub)},
+		RequireSigned: true,
+	})
+	require.NoError(t, pluginService.UnzipArchives())
+	require.NoError(t, pluginService.Load())
+
+	assert.NoDirExists(t, filepath.Join(pluginFolder, "Untrusted-1.0.0"))
+	assert.NoDirExists(t, filepath.Join(pluginFolder, "Tampered-1.0.0"))
+
+	data, err := pluginService.List()
+	require.NoError(t, err)
+	var modules []v1.PluginModule
+	require.NoError(t, json.Unmarshal(data, &modules))
+	status := make(map[string]bool)
+	for _, m := range modules {
+		status[m.Metadata.Name] = m.Status.IsLoaded
+	}
+	assert.Equal(t, map[string]bool{"Signed": true, "Unsigned": false}, status)
+}
@@ -0,0 +1,249 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package backend runs the server-side part of the plugin modules.
+//
+// A plugin module can ship a WASM module compiled for WASI (preview 1), that is used to transform the response of a
+// datasource before it is returned to the browser (downsampling, unit conversion, etc.).
+// The contract with the WASM module is the one of a command line program:
+//   - the response of the datasource is written on the standard input,
+//   - the parameters of the transformation are passed as arguments, formatted as `key=value`,
+//   - the transformed response must be written on the standard output,
+//   - a non-zero exit code means the transformation failed, and the standard error is used as the error message.
+//
+// Each call runs in a fresh instance of the module, without any access to the file system, the network, the clock or
+// the environment variables of the server. The memory and the duration of each call are limited.
+package backend
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/perses/perses/pkg/model/api/config"
+	"github.com/sirupsen/logrus"
+	"github.com/tetratelabs/wazero"
+	"github.com/tetratelabs/wazero/imports/wasi_snapshot_preview1"
+	"github.com/tetratelabs/wazero/sys"
+)
+
+// pagesPerMiB is the number of WASM memory pages (64KiB each) in one MiB.
+const pagesPerMiB = 16
+
+// maxStderrSize is the maximum number of bytes of the standard error kept to build the error message.
+const maxStderrSize = 4 * 1024
+
+var (
+	// ErrNotFound is returned when no backend is loaded for the requested module.
+	ErrNotFound = errors.New("no backend loaded for this plugin module")
+	// ErrOutputTooLarge is returned when the output of the backend exceeds the configured limit.
+	ErrOutputTooLarge = errors.New("the output of the plugin backend exceeds the size limit")
+)
+
+// Runtime compiles and executes the WASM backends of the plugin modules.
+type Runtime interface {
+	// IsEnabled returns true if the execution of the plugin backends is enabled.
+	IsEnabled() bool
+	// Load compiles the WASM module stored in wasmPath and registers it as the backend of the given plugin module.
+	// A backend already registered for the same module is replaced.
+	Load(moduleName string, wasmPath string) error
+	// Unload removes the backend of the given plugin module.
+	Unload(moduleName string)
+	// Has returns true if a backend is registered for the given plugin module.
+	Has(moduleName string) bool
+	// MaxInputSize returns the maximum size, in bytes, of the input that can be given to Transform.
+	MaxInputSize() int
+	// Transform executes the backend of the given plugin module with the input and the parameters provided.
+	Transform(ctx context.Context, moduleName string, input []byte, params map[string]string) ([]byte, error)
+}
+
+// New returns a Runtime configured according to the configuration.
+// If the plugin backends are disabled, the Runtime returned refuses to load any module.
+func New(cfg config.PluginBackend) Runtime {
+	if !cfg.Enable {
+		return &disabledRuntime{}
+	}
+	runtimeConfig := wazero.NewRuntimeConfig().
+		WithMemoryLimitPages(uint32(cfg.MemoryLimit) * pagesPerMiB).
+		// Stop the execution of the module as soon as the timeout is reached, even if it is stuck in a loop.
+		WithCloseOnContextDone(true)
+	ctx := context.Background()
+	r := wazero.NewRuntimeWithConfig(ctx, runtimeConfig)
+	wasi_snapshot_preview1.MustInstantiate(ctx, r)
+	return &wasmRuntime{
+		runtime:       r,
+		timeout:       time.Duration(cfg.Timeout),
+		maxOutputSize: cfg.MaxOutputSize * 1024 * 1024,
+		maxInputSize:  cfg.MaxInputSize * 1024 * 1024,
+		modules:       make(map[string]wazero.CompiledModule),
+	}
+}
+
+type wasmRuntime struct {
+	runtime       wazero.Runtime
+	timeout       time.Duration
+	maxOutputSize int
+	maxInputSize  int
+	// modules contains the compiled backends, indexed by the lower-cased name of the plugin module.
+	modules map[string]wazero.CompiledModule
+	mutex   sync.RWMutex
+}
+
+func (w *wasmRuntime) IsEnabled() bool {
+	return true
+}
+
+func (w *wasmRuntime) Load(moduleName string, wasmPath string) error {
+	data, err := os.ReadFile(wasmPath) //nolint: gosec
+	if err != nil {
+		return fmt.Errorf("unable to read the backend of the plugin module %q: %w", moduleName, err)
+	}
+	compiled, err := w.runtime.CompileModule(context.Background(), data)
+	if err != nil {
+		return fmt.Errorf("unable to compile the backend of the plugin module %q: %w", moduleName, err)
+	}
+	w.mutex.Lock()
+	previous, exist := w.modules[strings.ToLower(moduleName)]
+	w.modules[strings.ToLower(moduleName)] = compiled
+	w.mutex.Unlock()
+	if exist {
+		if closeErr := previous.Close(context.Background()); closeErr != nil {
+			logrus.WithError(closeErr).Warnf("unable to release the previous backend of the plugin module %q", moduleName)
+		}
+	}
+	return nil
+}
+
+func (w *wasmRuntime) Unload(moduleName string) {
+	w.mutex.Lock()
+	compiled, exist := w.modules[strings.ToLower(moduleName)]
+	delete(w.modules, strings.ToLower(moduleName))
+	w.mutex.Unlock()
+	if exist {
+		if closeErr := compiled.Close(context.Background()); closeErr != nil {
+			logrus.WithError(closeErr).Warnf("unable to release the backend of the plugin module %q", moduleName)
+		}
+	}
+}
+
+func (w *wasmRuntime) Has(moduleName string) bool {
+	w.mutex.RLock()
+	defer w.mutex.RUnlock()
+	_, exist := w.modules[strings.ToLower(moduleName)]
+	return exist
+}
+
+func (w *wasmRuntime) MaxInputSize() int {
+	return w.maxInputSize
+}
+
+func (w *wasmRuntime) Transform(ctx context.Context, moduleName string, input []byte, params map[string]string) ([]byte, error) {
+	w.mutex.RLock()
+	compiled, exist := w.modules[strings.ToLower(moduleName)]
+	w.mutex.RUnlock()
+	if !exist {
+		return nil, ErrNotFound
+	}
+	ctx, cancel := context.WithTimeout(ctx, w.timeout)
+	defer cancel()
+
+	stdout := &limitedBuffer{limit: w.maxOutputSize}
+	stderr := &limitedBuffer{limit: maxStderrSize, truncate: true}
+	args := []string{moduleName}
+	for _, k := range slices.Sorted(maps.Keys(params)) {
+		args = append(args, fmt.Sprintf("%s=%s", k, params[k]))
+	}
+	moduleConfig := wazero.NewModuleConfig().
+		// An empty name allows running several instances of the same module at the same time.
+		WithName("").
+		WithArgs(args...).
+		WithStdin(bytes.NewReader(input)).
+		WithStdout(stdout).
+		WithStderr(stderr)
+
+	mod, err := w.runtime.InstantiateModule(ctx, compiled, moduleConfig)
+	if mod != nil {
+		defer mod.Close(context.Background()) //nolint: errcheck
+	}
+	if stdout.exceeded {
+		return nil, ErrOutputTooLarge
+	}
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("the backend of the plugin module %q has been stopped: %w", moduleName, ctx.Err())
+		}
+		var exitErr *sys.ExitError
+		if errors.As(err, &exitErr) && stderr.Len() > 0 {
+			return nil, fmt.Errorf("the backend of the plugin module %q failed with the exit code %d: %s", moduleName, exitErr.ExitCode(), strings.TrimSpace(stderr.String()))
+		}
+		return nil, fmt.Errorf("the backend of the plugin module %q failed: %w", moduleName, err)
+	}
+	return stdout.Bytes(), nil
+}
+
+// limitedBuffer is a buffer that stops accepting data once the limit is reached.
+// When truncate is false, writing beyond the limit returns an error, which stops the WASM module.
+type limitedBuffer struct {
+	bytes.Buffer
+	limit    int
+	truncate bool
+	exceeded bool
+}
+
+func (l *limitedBuffer) Write(p []byte) (int, error) {
+	remaining := l.limit - l.Len()
+	if len(p) <= remaining {
+		return l.Buffer.Write(p)
+	}
+	if l.truncate {
+		if remaining > 0 {
+			l.Buffer.Write(p[:remaining]) //nolint: errcheck
+		}
+		// Pretend everything has been written so the module is not disturbed by the truncation.
+		return len(p), nil
+	}
+	l.exceeded = true
+	return 0, io.ErrShortWrite
+}
+
+type disabledRuntime struct{}
+
+func (d *disabledRuntime) IsEnabled() bool {
+	return false
+}
+
+func (d *disabledRuntime) Load(moduleName string, _ string) error {
+	return fmt.Errorf("the plugin module %q has a backend, but the plugin backends are disabled", moduleName)
+}
+
+func (d *disabledRuntime) Unload(_ string) {}
+
+func (d *disabledRuntime) Has(_ string) bool {
+	return false
+}
+
+func (d *disabledRuntime) MaxInputSize() int {
+	return 0
+}
+
+func (d *disabledRuntime) Transform(_ context.Context, _ string, _ []byte, _ map[string]string) ([]byte, error) {
+	return nil, ErrNotFound
+}
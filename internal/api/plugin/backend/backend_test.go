@@ -0,0 +1,83 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backend
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/perses/perses/pkg/model/api/config"
+	"github.com/perses/spec/go/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// The WASM modules used in these tests are described in testdata/README.md.
+func newTestRuntime(t *testing.T, memoryLimit int) Runtime {
+	r := New(config.PluginBackend{
+		Enable:        true,
+		Timeout:       common.Duration(500 * time.Millisecond),
+		MemoryLimit:   memoryLimit,
+		MaxOutputSize: 1,
+	})
+	for _, name := range []string{"echo", "loop", "fail", "grow"} {
+		require.NoError(t, r.Load(name, filepath.Join("testdata", name+".wasm")))
+	}
+	return r
+}
+
+func TestDisabledRuntime(t *testing.T) {
+	r := New(config.PluginBackend{})
+	assert.False(t, r.IsEnabled())
+	assert.Error(t, r.Load("echo", filepath.Join("testdata", "echo.wasm")))
+	_, err := r.Transform(context.Background(), "echo", []byte("data"), nil)
+	assert.True(t, errors.Is(err, ErrNotFound))
+}
+
+func TestTransform(t *testing.T) {
+	r := newTestRuntime(t, 1)
+	assert.True(t, r.Has("Echo"))
+
+	input := `{"status":"success","data":{"resultType":"matrix","result":[]}}`
+	output, err := r.Transform(context.Background(), "echo", []byte(input), map[string]string{"maxPoints": "500"})
+	require.NoError(t, err)
+	assert.Equal(t, input, string(output))
+
+	_, err = r.Transform(context.Background(), "unknown", []byte(input), nil)
+	assert.True(t, errors.Is(err, ErrNotFound))
+
+	r.Unload("echo")
+	assert.False(t, r.Has("echo"))
+}
+
+func TestTransformLimits(t *testing.T) {
+	r := newTestRuntime(t, 1)
+
+	_, err := r.Transform(context.Background(), "loop", nil, nil)
+	assert.ErrorContains(t, err, "has been stopped")
+
+	_, err = r.Transform(context.Background(), "fail", nil, nil)
+	assert.EqualError(t, err, `the backend of the plugin module "fail" failed with the exit code 3: boom`)
+
+	// The module tries to use 64MiB of memory while it is limited to 1MiB.
+	_, err = r.Transform(context.Background(), "grow", nil, nil)
+	assert.ErrorContains(t, err, "unreachable")
+
+	_, err = r.Transform(context.Background(), "echo", []byte(strings.Repeat("a", 2*1024*1024)), nil)
+	assert.True(t, errors.Is(err, ErrOutputTooLarge))
+}
@@ -0,0 +1,165 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package constraint provides a minimal semver range implementation used to express the compatibility of a plugin module
+// with Perses and with other plugin modules.
+//
+// A range is a list of comparator sets separated by `||`. A comparator set is a list of comparators separated by spaces
+// that must all be satisfied. Supported operators are `=`, `>`, `>=`, `<`, `<=`, `^` and `~`.
+// Example: `>=0.52.0 <0.60.0 || ^1.0.0`
+package constraint
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/mod/semver"
+)
+
+type operator string
+
+const (
+	equal        operator = "="
+	greater      operator = ">"
+	greaterEqual operator = ">="
+	less         operator = "<"
+	lessEqual    operator = "<="
+	caret        operator = "^"
+	tilde        operator = "~"
+)
+
+// operators is ordered so that the longest prefix is matched first.
+var operators = []operator{greaterEqual, lessEqual, greater, less, equal, caret, tilde}
+
+type comparator struct {
+	op      operator
+	version string
+}
+
+func (c comparator) check(version string) bool {
+	cmp := semver.Compare(version, c.version)
+	switch c.op {
+	case equal:
+		return cmp == 0
+	case greater:
+		return cmp > 0
+	case greaterEqual:
+		return cmp >= 0
+	case less:
+		return cmp < 0
+	case lessEqual:
+		return cmp <= 0
+	case caret:
+		// ^1.2.3 := >=1.2.3 <2.0.0, ^0.2.3 := >=0.2.3 <0.3.0
+		if cmp < 0 {
+			return false
+		}
+		if semver.Major(c.version) != "v0" {
+			return semver.Major(version) == semver.Major(c.version)
+		}
+		return semver.MajorMinor(version) == semver.MajorMinor(c.version)
+	case tilde:
+		// ~1.2.3 := >=1.2.3 <1.3.0
+		return cmp >= 0 && semver.MajorMinor(version) == semver.MajorMinor(c.version)
+	}
+	return false
+}
+
+// Range is a parsed semver range.
+type Range struct {
+	raw  string
+	sets [][]comparator
+}
+
+// Parse parses the given range. An empty range matches every version.
+func Parse(raw string) (*Range, error) {
+	r := &Range{raw: strings.TrimSpace(raw)}
+	if len(r.raw) == 0 || r.raw == "*" {
+		return r, nil
+	}
+	for _, rawSet := range strings.Split(r.raw, "||") {
+		var set []comparator
+		for _, rawComparator := range strings.Fields(rawSet) {
+			c, err := parseComparator(rawComparator)
+			if err != nil {
+				return nil, fmt.Errorf("invalid version range %q: %w", raw, err)
+			}
+			set = append(set, c)
+		}
+		if len(set) == 0 {
+			return nil, fmt.Errorf("invalid version range %q: empty comparator set", raw)
+		}
+		r.sets = append(r.sets, set)
+	}
+	return r, nil
+}
+
+func parseComparator(raw string) (comparator, error) {
+	op := equal
+	for _, o := range operators {
+		if strings.HasPrefix(raw, string(o)) {
+			op = o
+			raw = strings.TrimPrefix(raw, string(o))
+			break
+		}
+	}
+	version := Canonical(raw)
+	if !semver.IsValid(version) {
+		return comparator{}, fmt.Errorf("%q is not a valid semver version", raw)
+	}
+	return comparator{op: op, version: version}, nil
+}
+
+// Check returns true if the version satisfies the range.
+// The version can be provided with or without the `v` prefix.
+func (r *Range) Check(version string) bool {
+	if len(r.sets) == 0 {
+		return true
+	}
+	version = Canonical(version)
+	if !semver.IsValid(version) {
+		return false
+	}
+	for _, set := range r.sets {
+		satisfied := true
+		for _, c := range set {
+			if !c.check(version) {
+				satisfied = false
+				break
+			}
+		}
+		if satisfied {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Range) String() string {
+	return r.raw
+}
+
+// Canonical adds the `v` prefix expected by golang.org/x/mod/semver if it is missing.
+func Canonical(version string) string {
+	if len(version) > 0 && !strings.HasPrefix(version, "v") {
+		return "v" + version
+	}
+	return version
+}
+
+// Release returns the canonical version without its pre-release and build suffixes.
+// For example, `0.50.0-rc.1` becomes `v0.50.0`.
+func Release(version string) string {
+	version = semver.Canonical(Canonical(version))
+	return strings.TrimSuffix(version, semver.Prerelease(version))
+}
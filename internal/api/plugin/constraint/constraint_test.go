@@ -0,0 +1,64 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package constraint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRangeCheck(t *testing.T) {
+	testSuite := []struct {
+		title    string
+		rng      string
+		version  string
+		expected bool
+	}{
+		{title: "empty range", rng: "", version: "0.1.0", expected: true},
+		{title: "wildcard", rng: "*", version: "v3.0.0", expected: true},
+		{title: "exact version", rng: "0.52.0", version: "v0.52.0", expected: true},
+		{title: "exact version mismatch", rng: "=0.52.0", version: "0.52.1", expected: false},
+		{title: "lower bound", rng: ">=0.52.0", version: "0.53.0", expected: true},
+		{title: "lower bound not satisfied", rng: ">=0.52.0", version: "0.51.9", expected: false},
+		{title: "bounded range", rng: ">=0.52.0 <0.60.0", version: "0.60.0", expected: false},
+		{title: "or", rng: "<0.40.0 || >=0.52.0", version: "0.53.0", expected: true},
+		{title: "caret major", rng: "^1.2.0", version: "1.9.0", expected: true},
+		{title: "caret major mismatch", rng: "^1.2.0", version: "2.0.0", expected: false},
+		{title: "caret zero major", rng: "^0.5.0", version: "0.6.0", expected: false},
+		{title: "tilde", rng: "~0.5.1", version: "0.5.9", expected: true},
+		{title: "tilde mismatch", rng: "~0.5.1", version: "0.6.0", expected: false},
+		{title: "invalid version", rng: ">=0.5.0", version: "dev", expected: false},
+	}
+	for _, test := range testSuite {
+		t.Run(test.title, func(t *testing.T) {
+			r, err := Parse(test.rng)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, r.Check(test.version))
+		})
+	}
+}
+
+func TestParseError(t *testing.T) {
+	for _, rng := range []string{">=foo", "0.1.0 ||", "=>0.1.0"} {
+		_, err := Parse(rng)
+		assert.Error(t, err, rng)
+	}
+}
+
+func TestRelease(t *testing.T) {
+	assert.Equal(t, "v0.50.0", Release("0.50.0-rc.1"))
+	assert.Equal(t, "v0.50.0", Release("v0.50.0+build.1"))
+	assert.Equal(t, "v0.50.0", Release("0.50.0"))
+}
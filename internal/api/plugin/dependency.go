@@ -0,0 +1,140 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/perses/perses/internal/api/plugin/constraint"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/perses/spec/go/module"
+	"github.com/perses/spec/go/plugin"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/mod/semver"
+)
+
+// checkPersesVersion verifies the current Perses version satisfies the range required by the module.
+// When the Perses version is unknown (e.g. binary built without the version flag) or is not a semver version, the check is skipped.
+func checkPersesVersion(persesVersion string, requirements v1.ModuleRequirements) error {
+	if len(requirements.PersesVersion) == 0 {
+		return nil
+	}
+	r, err := constraint.Parse(requirements.PersesVersion)
+	if err != nil {
+		return err
+	}
+	if len(persesVersion) == 0 || !semver.IsValid(constraint.Canonical(persesVersion)) {
+		logrus.Debugf("Perses version %q is unknown, skipping the compatibility check with the range %q", persesVersion, r)
+		return nil
+	}
+	// A pre-release of Perses (e.g. 0.50.0-rc.1) already ships the features of the release it precedes,
+	// so it is checked as this release. Otherwise, it would not satisfy a range like ">=0.50.0".
+	if !r.Check(constraint.Release(persesVersion)) {
+		return fmt.Errorf("module requires Perses %q, current version is %q", r, persesVersion)
+	}
+	return nil
+}
+
+func registryOrDefault(registry string) string {
+	if registry == "" {
+		return plugin.DefaultRegistry
+	}
+	return registry
+}
+
+func isSameModule(metadata module.Metadata, name, registry string) bool {
+	return strings.EqualFold(metadata.Name, name) && registryOrDefault(metadata.Registry) == registryOrDefault(registry)
+}
+
+// findMissingDependency returns an error describing the first dependency of the module that is not satisfied by the loaded modules.
+func findMissingDependency(pluginModule *v1.PluginModule, modules []*v1.PluginModule) error {
+	for _, dep := range pluginModule.Spec.Dependencies {
+		r, err := constraint.Parse(dep.Version)
+		if err != nil {
+			return fmt.Errorf("dependency %q: %w", dep.Name, err)
+		}
+		found := false
+		for _, m := range modules {
+			if m.Status == nil || !m.Status.IsLoaded {
+				continue
+			}
+			if isSameModule(m.Metadata, dep.Name, dep.Registry) && r.Check(m.Metadata.Version) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			if len(dep.Version) == 0 {
+				return fmt.Errorf("missing dependency: module %q is required", dep.Name)
+			}
+			return fmt.Errorf("missing dependency: module %q with a version matching %q is required", dep.Name, r)
+		}
+	}
+	return nil
+}
+
+// resolveDependencies marks as not loaded every module whose dependencies are not satisfied by the other loaded modules.
+// As refusing a module can break the modules depending on it, the resolution is repeated until nothing changes anymore.
+func resolveDependencies(modules []*v1.PluginModule) {
+	for changed := true; changed; {
+		changed = false
+		for _, m := range modules {
+			if m.Status == nil || !m.Status.IsLoaded {
+				continue
+			}
+			if err := findMissingDependency(m, modules); err != nil {
+				logrus.WithError(err).Errorf("plugin module %q is not loaded", m.Metadata.Name)
+				m.Status.IsLoaded = false
+				m.Status.Error = err.Error()
+				changed = true
+			}
+		}
+	}
+}
+
+// detectConflicts sets, for each loaded module, the list of plugins that are also registered by another loaded module.
+// Different versions of the same module are not considered as conflicting, neither are modules coming from different registries.
+func detectConflicts(modules []*v1.PluginModule) {
+	for _, m := range modules {
+		m.Conflicts = nil
+		if m.Status == nil || !m.Status.IsLoaded {
+			continue
+		}
+		for _, plg := range m.Spec.Plugins {
+			var others []module.Metadata
+			for _, other := range modules {
+				if other == m || other.Status == nil || !other.Status.IsLoaded ||
+					isSameModule(other.Metadata, m.Metadata.Name, m.Metadata.Registry) ||
+					registryOrDefault(other.Metadata.Registry) != registryOrDefault(m.Metadata.Registry) {
+					continue
+				}
+				for _, otherPlg := range other.Spec.Plugins {
+					if otherPlg.Kind == plg.Kind && otherPlg.Spec.Name == plg.Spec.Name {
+						others = append(others, other.Metadata)
+						break
+					}
+				}
+			}
+			if len(others) > 0 {
+				logrus.Warnf("plugin %s %q of the module %q is also registered by other modules", plg.Kind, plg.Spec.Name, m.Metadata.Name)
+				m.Conflicts = append(m.Conflicts, v1.PluginConflict{
+					Kind:    string(plg.Kind),
+					Name:    plg.Spec.Name,
+					Modules: others,
+				})
+			}
+		}
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"testing"
+
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/perses/spec/go/module"
+	"github.com/perses/spec/go/plugin"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestModule(name, version string, requirements v1.ModuleRequirements, plugins ...module.Plugin) *v1.PluginModule {
+	return &v1.PluginModule{
+		Kind:     v1.PluginModuleKind,
+		Metadata: module.Metadata{Name: name, Version: version},
+		Spec: v1.ModuleSpec{
+			Plugins:            plugins,
+			ModuleRequirements: requirements,
+		},
+		Status: &module.Status{IsLoaded: true},
+	}
+}
+
+func TestCheckPersesVersion(t *testing.T) {
+	assert.NoError(t, checkPersesVersion("0.54.0", v1.ModuleRequirements{}))
+	assert.NoError(t, checkPersesVersion("0.54.0", v1.ModuleRequirements{PersesVersion: ">=0.52.0"}))
+	assert.NoError(t, checkPersesVersion("", v1.ModuleRequirements{PersesVersion: ">=0.60.0"}))
+	assert.Error(t, checkPersesVersion("0.54.0", v1.ModuleRequirements{PersesVersion: ">=0.60.0"}))
+	assert.Error(t, checkPersesVersion("0.54.0", v1.ModuleRequirements{PersesVersion: "not a range"}))
+	// A pre-release is checked as the release it precedes.
+	assert.NoError(t, checkPersesVersion("0.50.0-rc.1", v1.ModuleRequirements{PersesVersion: ">=0.50.0"}))
+	assert.NoError(t, checkPersesVersion("0.50.0-rc.1", v1.ModuleRequirements{PersesVersion: "^0.50.0"}))
+	assert.Error(t, checkPersesVersion("0.50.0-rc.1", v1.ModuleRequirements{PersesVersion: ">=0.51.0"}))
+	// A version that doesn't follow semver is considered as unknown.
+	assert.NoError(t, checkPersesVersion("main", v1.ModuleRequirements{PersesVersion: ">=0.60.0"}))
+}
+
+func TestResolveDependencies(t *testing.T) {
+	datasource := newTestModule("Prometheus", "0.5.0", v1.ModuleRequirements{})
+	query := newTestModule("PromQuery", "1.0.0", v1.ModuleRequirements{
+		Dependencies: []v1.ModuleDependency{{Name: "prometheus", Version: ">=0.5.0"}},
+	})
+	tooRecent := newTestModule("Traces", "1.0.0", v1.ModuleRequirements{
+		Dependencies: []v1.ModuleDependency{{Name: "Prometheus", Version: ">=1.0.0"}},
+	})
+	// transitive depends on a module that is refused, so it must be refused too.
+	transitive := newTestModule("TraceTable", "1.0.0", v1.ModuleRequirements{
+		Dependencies: []v1.ModuleDependency{{Name: "Traces"}},
+	})
+	modules := []*v1.PluginModule{transitive, datasource, query, tooRecent}
+	resolveDependencies(modules)
+
+	assert.True(t, datasource.Status.IsLoaded)
+	assert.True(t, query.Status.IsLoaded)
+	assert.False(t, tooRecent.Status.IsLoaded)
+	assert.Equal(t, `missing dependency: module "Prometheus" with a version matching ">=1.0.0" is required`, tooRecent.Status.Error)
+	assert.False(t, transitive.Status.IsLoaded)
+	assert.Equal(t, `missing dependency: module "Traces" is required`, transitive.Status.Error)
+}
+
+func TestDetectConflicts(t *testing.T) {
+	barChart := module.Plugin{Kind: plugin.KindPanel, Spec: module.PluginSpec{Name: "BarChart"}}
+	table := module.Plugin{Kind: plugin.KindPanel, Spec: module.PluginSpec{Name: "Table"}}
+	first := newTestModule("charts", "1.0.0", v1.ModuleRequirements{}, barChart, table)
+	firstNewVersion := newTestModule("charts", "1.1.0", v1.ModuleRequirements{}, barChart, table)
+	second := newTestModule("BarChart", "0.9.0", v1.ModuleRequirements{}, barChart)
+	modules := []*v1.PluginModule{first, firstNewVersion, second}
+	detectConflicts(modules)
+
+	assert.Equal(t, []v1.PluginConflict{{Kind: "Panel", Name: "BarChart", Modules: []module.Metadata{second.Metadata}}}, first.Conflicts)
+	assert.Equal(t, []v1.PluginConflict{{Kind: "Panel", Name: "BarChart", Modules: []module.Metadata{first.Metadata, firstNewVersion.Metadata}}}, second.Conflicts)
+}
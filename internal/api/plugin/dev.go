@@ -17,8 +17,11 @@ import (
 	"fmt"
 
 	apiinterface "github.com/perses/perses/internal/api/interface"
+	"github.com/perses/perses/internal/api/plugin/tree"
 	v1 "github.com/perses/perses/pkg/model/api/v1"
 	"github.com/perses/spec/go/module"
+	"github.com/perses/spec/go/plugin"
+	"github.com/prometheus/common/version"
 	"github.com/sirupsen/logrus"
 )
 
@@ -43,14 +46,21 @@ func (p *pluginFile) LoadDevPlugin(plugins []v1.PluginInDevelopment) error {
 				Registry: plg.Registry,
 			},
 			Spec: v1.ModuleSpec{
-				SchemasPath: npmPackageData.Perses.SchemasPath,
-				Plugins:     npmPackageData.Perses.Plugins,
+				SchemasPath:        npmPackageData.Perses.SchemasPath,
+				Plugins:            npmPackageData.Perses.Plugins,
+				ModuleRequirements: npmPackageData.Requirements,
 			},
 			Status: &module.Status{
 				IsLoaded: true,
 				InDev:    true,
 			},
 		}
+		if compatibilityErr := checkPersesVersion(version.Version, pluginModule.Spec.ModuleRequirements); compatibilityErr != nil {
+			return apiinterface.HandleBadRequestError(fmt.Sprintf("incompatible plugin module: %s", compatibilityErr))
+		}
+		if depErr := findMissingDependency(&pluginModule, p.loadedModules()); depErr != nil {
+			return apiinterface.HandleBadRequestError(fmt.Sprintf("unable to resolve the dependencies: %s", depErr))
+		}
 		pluginLoaded := &Loaded{
 			DevEnvironment: &v1.PluginInDevelopment{
 				Name:          plg.Name,
@@ -76,6 +86,23 @@ func (p *pluginFile) LoadDevPlugin(plugins []v1.PluginInDevelopment) error {
 	return p.storeLoadedList()
 }
 
+// loadedModules returns the modules currently loaded, including the modules in development.
+func (p *pluginFile) loadedModules() []*v1.PluginModule {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+	var modules []*v1.PluginModule
+	for _, versions := range tree.Merge(p.loaded, p.devLoaded) {
+		for moduleVersion, loaded := range versions {
+			if moduleVersion == plugin.LatestVersion {
+				// we skip the latest version pointer as it's not a real version
+				continue
+			}
+			modules = append(modules, &loaded.Module)
+		}
+	}
+	return modules
+}
+
 func (p *pluginFile) RefreshDevPlugin(metadata module.Metadata) error {
 	p.mutex.RLock()
 	defer p.mutex.RUnlock()
@@ -19,6 +19,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/perses/perses/internal/api/plugin/tree"
 	v1 "github.com/perses/perses/pkg/model/api/v1"
 	"github.com/perses/spec/go/common"
 	"github.com/stretchr/testify/assert"
@@ -57,3 +58,38 @@ func TestLoadDevPluginReportsPackageError(t *testing.T) {
 	assert.Contains(t, err.Error(), "failed to load plugin package")
 	assert.NotContains(t, err.Error(), "%!s(<nil>)")
 }
+
+func TestLoadDevPluginChecksDependencies(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, ManifestFileName):
+			_, _ = w.Write([]byte(`{"id":"foo","name":"foo","metaData":{"buildInfo":{"buildVersion":"0.1.0"}}}`))
+		case strings.HasSuffix(r.URL.Path, PackageJSONFile):
+			_, _ = w.Write([]byte(`{"version":"0.1.0","perses":{"plugins":[],"dependencies":[{"name":"Prometheus","version":">=0.5.0"}]}}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	p := &pluginFile{path: t.TempDir(), devLoaded: make(tree.Tree[*Loaded])}
+	err := p.LoadDevPlugin([]v1.PluginInDevelopment{
+		{
+			Name:          "foo",
+			URL:           common.MustParseURL(server.URL),
+			DisableSchema: true,
+		},
+	})
+	assert.ErrorContains(t, err, "unable to resolve the dependencies")
+
+	prometheus := newTestModule("Prometheus", "0.5.0", v1.ModuleRequirements{})
+	p.loaded = make(tree.Tree[*Loaded])
+	p.loaded.Add(prometheus.Metadata.Name, prometheus.Metadata, &Loaded{Module: *prometheus})
+	assert.NoError(t, p.LoadDevPlugin([]v1.PluginInDevelopment{
+		{
+			Name:          "foo",
+			URL:           common.MustParseURL(server.URL),
+			DisableSchema: true,
+		},
+	}))
+}
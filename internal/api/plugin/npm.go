@@ -21,6 +21,8 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/perses/perses/internal/api/archive"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
 	"github.com/perses/spec/go/common"
 	"github.com/perses/spec/go/module"
 )
@@ -36,6 +38,34 @@ type NPMPackage struct {
 	Scripts    map[string]string `json:"scripts"`
 	Workspaces []string          `json:"workspaces"`
 	Perses     module.Module     `json:"perses"`
+	// Requirements is read from the `perses` section as well.
+	// It contains the Perses version and the other modules required by the module.
+	Requirements v1.ModuleRequirements `json:"-"`
+	// Backend is read from the `perses` section as well. It describes the server-side part of the module, if any.
+	Backend *v1.ModuleBackend `json:"-"`
+}
+
+func (n *NPMPackage) UnmarshalJSON(data []byte) error {
+	var tmp NPMPackage
+	type plain NPMPackage
+	if err := json.Unmarshal(data, (*plain)(&tmp)); err != nil {
+		return err
+	}
+	// The requirements and the backend are part of the `perses` section, but they are not part of the module definition shared with the frontend.
+	// So we need to decode this section a second time to get them.
+	var extra struct {
+		Perses struct {
+			v1.ModuleRequirements
+			Backend *v1.ModuleBackend `json:"backend"`
+		} `json:"perses"`
+	}
+	if err := json.Unmarshal(data, &extra); err != nil {
+		return err
+	}
+	tmp.Requirements = extra.Perses.ModuleRequirements
+	tmp.Backend = extra.Perses.Backend
+	*n = tmp
+	return nil
 }
 
 type BuildInfo struct {
@@ -78,6 +108,19 @@ func ReadManifest(pluginPath string) (*NPMManifest, error) {
 	return manifestData, readFile(manifestFilePath, manifestData)
 }
 
+// ReadManifestFromArchive reads the manifest of the plugin module contained in the archive, without extracting it.
+func ReadManifestFromArchive(archivePath string) (*NPMManifest, error) {
+	data, err := archive.ReadFile(archivePath, ManifestFileName)
+	if err != nil {
+		return nil, err
+	}
+	manifestData := &NPMManifest{}
+	if unmarshalErr := json.Unmarshal(data, manifestData); unmarshalErr != nil {
+		return nil, fmt.Errorf("failed to unmarshal the manifest of the archive %q: %w", archivePath, unmarshalErr)
+	}
+	return manifestData, nil
+}
+
 func ReadManifestFromNetwork(url *common.URL, pluginName string) (*NPMManifest, error) {
 	manifestData := &NPMManifest{}
 	return manifestData, readFileFromNetwork(url, pluginName, ManifestFileName, manifestData)
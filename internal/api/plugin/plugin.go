@@ -22,6 +22,7 @@ import (
 	"strings"
 	"sync"
 
+	"github.com/perses/perses/internal/api/plugin/backend"
 	"github.com/perses/perses/internal/api/plugin/migrate"
 	"github.com/perses/perses/internal/api/plugin/schema"
 	"github.com/perses/perses/internal/api/plugin/tree"
@@ -31,6 +32,7 @@ import (
 	v1 "github.com/perses/perses/pkg/model/api/v1"
 	"github.com/perses/spec/go/module"
 	"github.com/perses/spec/go/plugin"
+	"github.com/prometheus/common/version"
 	"github.com/sirupsen/logrus"
 	"golang.org/x/mod/semver"
 )
@@ -52,10 +54,16 @@ type Plugin interface {
 	RefreshDevPlugin(metadata module.Metadata) error
 	UnLoadDevPlugin(metadata module.Metadata) error
 	List() ([]byte, error)
+	// ListForProject returns the list of the plugin modules, restricted by the plugin policy of a project.
+	ListForProject(policy *v1.ProjectPluginPolicy) ([]v1.PluginModule, error)
+	// Policy returns the schema.Policy enforcing the plugin policy of a project. It returns nil if the policy is empty.
+	Policy(policy *v1.ProjectPluginPolicy) schema.Policy
 	UnzipArchives() error
 	GetLoadedPlugin(name, version, registry string) (*Loaded, bool)
 	Schema() schema.Schema
 	Migration() migrate.Migration
+	// Backend returns the runtime executing the server-side part of the plugin modules.
+	Backend() backend.Runtime
 }
 
 // StrictLoad is a helper function that loads the plugin from the default path.
@@ -82,15 +90,19 @@ func New(cfg config.Plugin) Plugin {
 	return &pluginFile{
 		path: cfg.Path,
 		archibal: &arch{
-			folders:      cfg.ArchivePaths,
-			targetFolder: cfg.Path,
+			folders:        cfg.ArchivePaths,
+			targetFolder:   cfg.Path,
+			publicKeyPaths: cfg.PublicKeys,
+			registry:       cfg.Registry,
 		},
-		enabled:   cfg.Enabled,
-		disabled:  cfg.Disabled,
-		sch:       schema.New(),
-		mig:       migrate.New(),
-		loaded:    make(tree.Tree[*Loaded]),
-		devLoaded: make(tree.Tree[*Loaded]),
+		enabled:       cfg.Enabled,
+		disabled:      cfg.Disabled,
+		requireSigned: cfg.RequireSigned,
+		sch:           schema.New(),
+		mig:           migrate.New(),
+		backend:       backend.New(cfg.Backend),
+		loaded:        make(tree.Tree[*Loaded]),
+		devLoaded:     make(tree.Tree[*Loaded]),
 	}
 }
 
@@ -105,6 +117,8 @@ type pluginFile struct {
 	enabled []string
 	// disabled is the list of plugin or module that will be dropped when loading them from the file system. If empty, all plugins/modules will be loaded.
 	disabled []string
+	// requireSigned, when true, marks as not loaded every module that doesn't come from an archive signed with a trusted key.
+	requireSigned bool
 	// archibal is the archive service used only to extract the plugin files from the archive.
 	archibal *arch
 	// sch is the service used to load and provide the schema of the plugin.
@@ -113,6 +127,9 @@ type pluginFile struct {
 	// mig is the service used to load and provide the migration schema of the plugin.
 	// This service is used when migrating the plugin from Grafana to Perses.
 	mig migrate.Migration
+	// backend is the runtime executing the WASM modules shipped by the plugin modules.
+	// It is used by the proxy to transform the response of the datasources.
+	backend backend.Runtime
 	// mutex will protect the loaded map.
 	mutex sync.RWMutex
 }
@@ -150,6 +167,10 @@ func (p *pluginFile) Migration() migrate.Migration {
 	return p.mig
 }
 
+func (p *pluginFile) Backend() backend.Runtime {
+	return p.backend
+}
+
 func (p *pluginFile) UnzipArchives() error {
 	return p.archibal.unzipAll()
 }
@@ -159,6 +180,7 @@ func (p *pluginFile) Load() error {
 	if err != nil {
 		return err
 	}
+	var candidates []*Loaded
 	for _, f := range files {
 		if !f.IsDir() {
 			// we are only interested in the plugin folder, so any files at the root of the plugin folder can be skipped
@@ -170,18 +192,105 @@ func (p *pluginFile) Load() error {
 			// the plugin is not valid, we can skip it
 			continue
 		}
-		pluginLoaded := &Loaded{
+		candidates = append(candidates, &Loaded{
 			DevEnvironment: nil,
 			Module:         *pluginModule,
 			LocalPath:      pluginPath,
-		}
+		})
+	}
+	modules := make([]*v1.PluginModule, 0, len(candidates))
+	for _, candidate := range candidates {
+		modules = append(modules, &candidate.Module)
+	}
+	// Once every module has been read, we can check if their dependencies are satisfied.
+	resolveDependencies(modules)
+	p.loadSchemas(candidates)
+	for _, candidate := range candidates {
+		p.loadBackend(candidate)
+	}
+	detectConflicts(modules)
+	for _, candidate := range candidates {
 		p.mutex.Lock()
-		p.loaded.Add(pluginModule.Metadata.Name, pluginModule.Metadata, pluginLoaded)
+		p.loaded.Add(candidate.Module.Metadata.Name, candidate.Module.Metadata, candidate)
 		p.mutex.Unlock()
 	}
 	return p.storeLoadedList()
 }
 
+// loadSchemas loads the schemas of the modules, making sure the schemas of a module are loaded after the ones of its dependencies.
+// A module is refused if the schemas of one of its dependencies cannot be loaded.
+func (p *pluginFile) loadSchemas(candidates []*Loaded) {
+	var ready []*v1.PluginModule
+	pending := slices.Clone(candidates)
+	for progress := true; progress; {
+		progress = false
+		var stillPending []*Loaded
+		for _, candidate := range pending {
+			if !candidate.Module.Status.IsLoaded {
+				continue
+			}
+			if findMissingDependency(&candidate.Module, ready) != nil {
+				// The dependencies are not loaded yet, let's try again at the next iteration.
+				stillPending = append(stillPending, candidate)
+				continue
+			}
+			p.loadSinglePluginSchemas(candidate)
+			ready = append(ready, &candidate.Module)
+			progress = true
+		}
+		pending = stillPending
+	}
+	// Remaining modules are depending on a module that has been refused or on a circular dependency.
+	for _, candidate := range pending {
+		depErr := findMissingDependency(&candidate.Module, ready)
+		candidate.Module.Status.IsLoaded = false
+		candidate.Module.Status.Error = fmt.Sprintf("unable to resolve the dependencies: %s", depErr)
+		logrus.WithError(depErr).Errorf("plugin module %q is not loaded", candidate.Module.Metadata.Name)
+	}
+}
+
+func (p *pluginFile) loadSinglePluginSchemas(candidate *Loaded) {
+	pluginModule := candidate.Module
+	pluginStatus := pluginModule.Status
+	if !IsSchemaRequired(pluginModule.Spec) {
+		return
+	}
+	if pluginSchemaLoadErr := p.sch.Load(candidate.LocalPath, pluginModule); pluginSchemaLoadErr != nil {
+		pluginStatus.IsLoaded = false
+		pluginStatus.Error = "unable to load plugin schema"
+		logrus.WithError(pluginSchemaLoadErr).Error(pluginStatus.Error)
+		return
+	}
+	if pluginMigrateLoadErr := p.mig.Load(candidate.LocalPath, pluginModule); pluginMigrateLoadErr != nil {
+		pluginStatus.IsLoaded = false
+		pluginStatus.Error = "unable to load plugin migration"
+		logrus.WithError(pluginMigrateLoadErr).Error(pluginStatus.Error)
+	}
+}
+
+// loadBackend compiles the WASM module shipped by the plugin module, if any.
+func (p *pluginFile) loadBackend(candidate *Loaded) {
+	pluginModule := candidate.Module
+	if !pluginModule.Status.IsLoaded || pluginModule.Spec.Backend == nil || len(pluginModule.Spec.Backend.Wasm) == 0 {
+		return
+	}
+	if !p.backend.IsEnabled() {
+		logrus.Warnf("plugin module %q has a backend, but it is ignored as the plugin backends are disabled", pluginModule.Metadata.Name)
+		return
+	}
+	if strings.Contains(pluginModule.Spec.Backend.Wasm, "..") {
+		pluginModule.Status.IsLoaded = false
+		pluginModule.Status.Error = "invalid backend path"
+		logrus.Errorf("the backend path %q of the plugin module %q contains invalid characters", pluginModule.Spec.Backend.Wasm, pluginModule.Metadata.Name)
+		return
+	}
+	if err := p.backend.Load(pluginModule.Metadata.Name, filepath.Join(candidate.LocalPath, pluginModule.Spec.Backend.Wasm)); err != nil {
+		pluginModule.Status.IsLoaded = false
+		pluginModule.Status.Error = "unable to load plugin backend"
+		logrus.WithError(err).Error(pluginModule.Status.Error)
+	}
+}
+
 func (p *pluginFile) loadSinglePlugin(file os.DirEntry, pluginPath string) *v1.PluginModule {
 	if validErr := IsRequiredFileExists(pluginPath, pluginPath, pluginPath); validErr != nil {
 		logrus.WithError(validErr).Errorf("folder %q is not a valid plugin and is skipped. Missing mandatory files", file.Name())
@@ -199,9 +308,9 @@ func (p *pluginFile) loadSinglePlugin(file os.DirEntry, pluginPath string) *v1.P
 		InDev:    false,
 	}
 
-	version := manifest.Metadata.BuildInfo.Version
-	if !strings.HasPrefix(version, "v") {
-		version = fmt.Sprintf("v%s", version)
+	moduleVersion := manifest.Metadata.BuildInfo.Version
+	if !strings.HasPrefix(moduleVersion, "v") {
+		moduleVersion = fmt.Sprintf("v%s", moduleVersion)
 	}
 	pluginModule := &v1.PluginModule{
 		Kind: v1.PluginModuleKind,
@@ -212,8 +321,17 @@ func (p *pluginFile) loadSinglePlugin(file os.DirEntry, pluginPath string) *v1.P
 		Status: pluginStatus,
 	}
 
-	if !semver.IsValid(version) {
-		logrus.Errorf("plugin %q does not follow the semver convention for its version %q", manifest.Name, version)
+	if p.requireSigned {
+		if sigErr := p.archibal.signatureStatus(file.Name()); sigErr != nil {
+			logrus.WithError(sigErr).Errorf("plugin %q is not loaded as signed plugins are required", manifest.Name)
+			pluginStatus.IsLoaded = false
+			pluginStatus.Error = fmt.Sprintf("signed plugins are required, but the signature of the plugin cannot be verified: %s", sigErr)
+			return pluginModule
+		}
+	}
+
+	if !semver.IsValid(moduleVersion) {
+		logrus.Errorf("plugin %q does not follow the semver convention for its version %q", manifest.Name, moduleVersion)
 		pluginStatus.IsLoaded = false
 		pluginStatus.Error = "invalid plugin version, must follow semver convention"
 		return pluginModule
@@ -227,8 +345,10 @@ func (p *pluginFile) loadSinglePlugin(file os.DirEntry, pluginPath string) *v1.P
 		return pluginModule
 	}
 	pluginModule.Spec = v1.ModuleSpec{
-		SchemasPath: npmPackageData.Perses.SchemasPath,
-		Plugins:     npmPackageData.Perses.Plugins,
+		SchemasPath:        npmPackageData.Perses.SchemasPath,
+		Plugins:            npmPackageData.Perses.Plugins,
+		ModuleRequirements: npmPackageData.Requirements,
+		Backend:            npmPackageData.Backend,
 	}
 
 	if p.filter(pluginModule) {
@@ -236,19 +356,11 @@ func (p *pluginFile) loadSinglePlugin(file os.DirEntry, pluginPath string) *v1.P
 		return nil
 	}
 
-	if IsSchemaRequired(pluginModule.Spec) {
-		if pluginSchemaLoadErr := p.sch.Load(pluginPath, *pluginModule); pluginSchemaLoadErr != nil {
-			pluginStatus.IsLoaded = false
-			pluginStatus.Error = "unable to load plugin schema"
-			logrus.WithError(pluginSchemaLoadErr).Error(pluginStatus.Error)
-			return pluginModule
-		}
-		if pluginMigrateLoadErr := p.mig.Load(pluginPath, *pluginModule); pluginMigrateLoadErr != nil {
-			pluginStatus.IsLoaded = false
-			pluginStatus.Error = "unable to load plugin migration"
-			logrus.WithError(pluginMigrateLoadErr).Error(pluginStatus.Error)
-			return pluginModule
-		}
+	if compatibilityErr := checkPersesVersion(version.Version, pluginModule.Spec.ModuleRequirements); compatibilityErr != nil {
+		pluginStatus.IsLoaded = false
+		pluginStatus.Error = fmt.Sprintf("incompatible plugin module: %s", compatibilityErr)
+		logrus.WithError(compatibilityErr).Errorf("plugin module %q is not loaded", manifest.Name)
+		return pluginModule
 	}
 	return pluginModule
 }
@@ -280,14 +392,21 @@ func (p *pluginFile) storeLoadedList() error {
 // filter is filtering the module and/or the plugins based on the configuration.
 // The boolean returned is true if the complete module is filtered, false if only some plugins are filtered or if no filtering is applied.
 func (p *pluginFile) filter(pluginModule *v1.PluginModule) bool {
-	if len(p.enabled) > 0 {
+	return filterModule(pluginModule, p.enabled, p.disabled)
+}
+
+// filterModule is filtering the plugins of the module based on the given lists of enabled and disabled plugins / modules.
+// Names in these lists are expected to be lowercase.
+// The boolean returned is true if the complete module is filtered, false if only some plugins are filtered or if no filtering is applied.
+func filterModule(pluginModule *v1.PluginModule, enabled []string, disabled []string) bool {
+	if len(enabled) > 0 {
 		// if the module or the plugin is in the activated list, we keep it, otherwise we filter it out
-		if slices.Contains(p.enabled, strings.ToLower(pluginModule.Metadata.Name)) {
+		if slices.Contains(enabled, strings.ToLower(pluginModule.Metadata.Name)) {
 			return false
 		}
 		var newSpec []module.Plugin
 		for _, plg := range pluginModule.Spec.Plugins {
-			if slices.Contains(p.enabled, strings.ToLower(plg.Spec.Name)) {
+			if slices.Contains(enabled, strings.ToLower(plg.Spec.Name)) {
 				newSpec = append(newSpec, plg)
 			}
 		}
@@ -295,14 +414,14 @@ func (p *pluginFile) filter(pluginModule *v1.PluginModule) bool {
 		// if the length of the new spec is 0, it means that all the plugins of the module are filtered out, so we can filter out the complete module
 		return len(newSpec) == 0
 	}
-	if len(p.disabled) > 0 {
+	if len(disabled) > 0 {
 		// we can then check if the module or plugins are dropped. Logic is the opposite of the activation, if the module or the plugin is in the deactivated list, we filter it out, otherwise we keep it.
-		if slices.Contains(p.disabled, strings.ToLower(pluginModule.Metadata.Name)) {
+		if slices.Contains(disabled, strings.ToLower(pluginModule.Metadata.Name)) {
 			return true
 		}
 		var newSpec []module.Plugin
 		for _, plg := range pluginModule.Spec.Plugins {
-			if !slices.Contains(p.disabled, strings.ToLower(plg.Spec.Name)) {
+			if !slices.Contains(disabled, strings.ToLower(plg.Spec.Name)) {
 				newSpec = append(newSpec, plg)
 			}
 		}
@@ -347,3 +347,59 @@ func TestFilter(t *testing.T) {
 		})
 	}
 }
+
+func TestIsPluginAllowed(t *testing.T) {
+	tests := []struct {
+		title       string
+		pluginKind  string
+		moduleNames []string
+		enabled     []string
+		disabled    []string
+		expected    bool
+	}{
+		{
+			title:      "no policy",
+			pluginKind: "TimeSeriesChart",
+			expected:   true,
+		},
+		{
+			title:      "plugin enabled",
+			pluginKind: "TimeSeriesChart",
+			enabled:    []string{"timeserieschart"},
+			expected:   true,
+		},
+		{
+			title:       "module enabled",
+			pluginKind:  "PrometheusTimeSeriesQuery",
+			moduleNames: []string{"Prometheus"},
+			enabled:     []string{"prometheus"},
+			expected:    true,
+		},
+		{
+			title:       "not enabled",
+			pluginKind:  "TempoTraceQuery",
+			moduleNames: []string{"Tempo"},
+			enabled:     []string{"prometheus"},
+			expected:    false,
+		},
+		{
+			title:       "module disabled",
+			pluginKind:  "TempoTraceQuery",
+			moduleNames: []string{"Tempo"},
+			disabled:    []string{"tempo"},
+			expected:    false,
+		},
+		{
+			title:       "other plugin disabled",
+			pluginKind:  "PrometheusTimeSeriesQuery",
+			moduleNames: []string{"Prometheus"},
+			disabled:    []string{"tempo"},
+			expected:    true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.title, func(t *testing.T) {
+			assert.Equal(t, test.expected, isPluginAllowed(test.pluginKind, test.moduleNames, test.enabled, test.disabled))
+		})
+	}
+}
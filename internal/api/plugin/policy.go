@@ -0,0 +1,125 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/perses/perses/internal/api/interface/v1/project"
+	"github.com/perses/perses/internal/api/plugin/schema"
+	"github.com/perses/perses/internal/api/plugin/tree"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+)
+
+func toLower(list []string) []string {
+	result := make([]string, 0, len(list))
+	for _, s := range list {
+		result = append(result, strings.ToLower(s))
+	}
+	return result
+}
+
+// isPluginAllowed checks the plugin against the lists of enabled and disabled names.
+// A plugin is matched either by its own name or by the name of one of the modules providing it.
+func isPluginAllowed(pluginKind string, moduleNames []string, enabled []string, disabled []string) bool {
+	names := append([]string{strings.ToLower(pluginKind)}, toLower(moduleNames)...)
+	if len(enabled) > 0 {
+		for _, name := range names {
+			if slices.Contains(enabled, name) {
+				return true
+			}
+		}
+		return false
+	}
+	for _, name := range names {
+		if slices.Contains(disabled, name) {
+			return false
+		}
+	}
+	return true
+}
+
+func (p *pluginFile) ListForProject(policy *v1.ProjectPluginPolicy) ([]v1.PluginModule, error) {
+	data, err := p.List()
+	if err != nil {
+		return nil, err
+	}
+	var modules []v1.PluginModule
+	if unmarshalErr := json.Unmarshal(data, &modules); unmarshalErr != nil {
+		return nil, unmarshalErr
+	}
+	if policy.IsEmpty() {
+		return modules, nil
+	}
+	enabled := toLower(policy.Enabled)
+	disabled := toLower(policy.Disabled)
+	result := make([]v1.PluginModule, 0, len(modules))
+	for i := range modules {
+		if !filterModule(&modules[i], enabled, disabled) {
+			result = append(result, modules[i])
+		}
+	}
+	return result, nil
+}
+
+func (p *pluginFile) Policy(policy *v1.ProjectPluginPolicy) schema.Policy {
+	if policy.IsEmpty() {
+		return nil
+	}
+	enabled := toLower(policy.Enabled)
+	disabled := toLower(policy.Disabled)
+	return func(pluginKind string) error {
+		if isPluginAllowed(pluginKind, p.modulesProviding(pluginKind), enabled, disabled) {
+			return nil
+		}
+		return fmt.Errorf("plugin %q is not allowed in this project", pluginKind)
+	}
+}
+
+// modulesProviding returns the name of the loaded modules providing a plugin with the given name.
+func (p *pluginFile) modulesProviding(pluginKind string) []string {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+	var result []string
+	for _, versions := range tree.Merge(p.loaded, p.devLoaded) {
+		for _, loaded := range versions {
+			if slices.Contains(result, loaded.Module.Metadata.Name) {
+				continue
+			}
+			for _, plg := range loaded.Module.Spec.Plugins {
+				if plg.Spec.Name == pluginKind {
+					result = append(result, loaded.Module.Metadata.Name)
+					break
+				}
+			}
+		}
+	}
+	return result
+}
+
+// ProjectSchema returns the schema to use to validate the resources of the given project.
+// When the project defines a plugin policy, the schema returned refuses any plugin not allowed by this policy.
+func ProjectSchema(svc Plugin, projectDAO project.DAO, projectName string) (schema.Schema, error) {
+	if len(projectName) == 0 {
+		return svc.Schema(), nil
+	}
+	p, err := projectDAO.Get(projectName)
+	if err != nil {
+		return nil, err
+	}
+	return schema.WithPolicy(svc.Schema(), svc.Policy(p.Spec.Plugins)), nil
+}
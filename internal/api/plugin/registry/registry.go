@@ -0,0 +1,318 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package registry provides a client for a remote plugin registry.
+//
+// A registry is an HTTP server exposing an index (`<url>/index.json`) that lists the available plugin modules, their
+// versions and the URL of the archive of each version. The archives pulled from the registry are kept in a local cache
+// so they can be used again without any network access.
+package registry
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/perses/perses/internal/api/archive"
+	"github.com/perses/perses/internal/api/plugin/constraint"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/mod/semver"
+)
+
+const (
+	// IndexFileName is the name of the index file served by the registry, and stored in the cache.
+	IndexFileName  = "index.json"
+	defaultTimeout = 30 * time.Second
+	// DefaultMaxArchiveSize is the maximum size, in bytes, of a file downloaded from the registry when no limit is provided.
+	DefaultMaxArchiveSize int64 = 100 * 1024 * 1024
+)
+
+// ErrModuleNotFound is returned when no version of the module matching the request is available in the registry.
+var ErrModuleNotFound = errors.New("module not found in the registry")
+
+// Index is the list of the plugin modules available in a registry.
+type Index struct {
+	Modules []Module `json:"modules"`
+}
+
+// Module is a plugin module available in a registry.
+type Module struct {
+	Name        string    `json:"name"`
+	Description string    `json:"description,omitempty"`
+	Versions    []Version `json:"versions"`
+}
+
+// LatestVersion returns the highest version of the module, or an empty string if the module has no version.
+func (m Module) LatestVersion() string {
+	latest := ""
+	for _, v := range m.Versions {
+		if len(latest) == 0 || semver.Compare(constraint.Canonical(v.Version), constraint.Canonical(latest)) > 0 {
+			latest = v.Version
+		}
+	}
+	return latest
+}
+
+// Version is a version of a plugin module available in a registry.
+type Version struct {
+	Version string `json:"version"`
+	// URL is the URL of the archive. It can be absolute or relative to the URL of the registry.
+	URL string `json:"url"`
+	// Checksum is the sha256 checksum of the archive, formatted as `sha256:<hex>`. It is verified after the download.
+	// An archive without checksum cannot be pulled.
+	Checksum string `json:"checksum,omitempty"`
+	// SignatureURL is the URL of the detached signature of the archive. It can be absolute or relative to the URL of the registry.
+	SignatureURL string `json:"signatureUrl,omitempty"`
+}
+
+// Client allows to search and pull plugin modules from a registry.
+type Client struct {
+	url            *url.URL
+	cachePath      string
+	offline        bool
+	maxArchiveSize int64
+	httpClient     *http.Client
+}
+
+// New creates a client for the registry available at the given URL.
+// The index and the archives are cached in cachePath. When offline is true, the client never reaches the registry and only uses the cache.
+// maxArchiveSize is the maximum size, in bytes, of a file downloaded from the registry. When it is not positive, DefaultMaxArchiveSize is used.
+func New(registryURL string, cachePath string, offline bool, maxArchiveSize int64) (*Client, error) {
+	u, err := url.Parse(registryURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid registry URL %q: %w", registryURL, err)
+	}
+	if maxArchiveSize <= 0 {
+		maxArchiveSize = DefaultMaxArchiveSize
+	}
+	return &Client{
+		url:            u,
+		cachePath:      cachePath,
+		offline:        offline,
+		maxArchiveSize: maxArchiveSize,
+		httpClient:     &http.Client{Timeout: defaultTimeout},
+	}, nil
+}
+
+// Index returns the index of the registry.
+// If the registry cannot be reached, the index stored in the cache during the previous successful call is returned instead.
+func (c *Client) Index() (*Index, error) {
+	cachedIndexPath := filepath.Join(c.cachePath, IndexFileName)
+	if !c.offline {
+		data, err := c.download(c.resolve(IndexFileName))
+		if err == nil {
+			index := &Index{}
+			if unmarshalErr := json.Unmarshal(data, index); unmarshalErr != nil {
+				return nil, fmt.Errorf("unable to decode the index of the registry: %w", unmarshalErr)
+			}
+			if writeErr := c.writeCache(IndexFileName, data); writeErr != nil {
+				logrus.WithError(writeErr).Warn("unable to cache the index of the plugin registry")
+			}
+			return index, nil
+		}
+		logrus.WithError(err).Warnf("unable to reach the plugin registry %q, using the cached index", c.url)
+	}
+	data, err := os.ReadFile(cachedIndexPath) //nolint: gosec
+	if err != nil {
+		return nil, fmt.Errorf("the registry cannot be reached and no index is available in the cache: %w", err)
+	}
+	index := &Index{}
+	if unmarshalErr := json.Unmarshal(data, index); unmarshalErr != nil {
+		return nil, fmt.Errorf("unable to decode the cached index of the registry: %w", unmarshalErr)
+	}
+	return index, nil
+}
+
+// Search returns the modules, sorted by name, whose name or description contains the given term (case-insensitive).
+// An empty term returns every module.
+func (c *Client) Search(term string) ([]Module, error) {
+	index, err := c.Index()
+	if err != nil {
+		return nil, err
+	}
+	term = strings.ToLower(term)
+	var result []Module
+	for _, m := range index.Modules {
+		if strings.Contains(strings.ToLower(m.Name), term) || strings.Contains(strings.ToLower(m.Description), term) {
+			result = append(result, m)
+		}
+	}
+	slices.SortFunc(result, func(a, b Module) int {
+		return strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+	})
+	return result, nil
+}
+
+// Resolve returns the highest version of the module that matches the version range.
+// An empty range matches every version.
+func (c *Client) Resolve(name string, versionRange string) (*Version, error) {
+	r, err := constraint.Parse(versionRange)
+	if err != nil {
+		return nil, err
+	}
+	index, err := c.Index()
+	if err != nil {
+		return nil, err
+	}
+	var result *Version
+	for _, m := range index.Modules {
+		if !strings.EqualFold(m.Name, name) {
+			continue
+		}
+		for i, v := range m.Versions {
+			if !r.Check(v.Version) {
+				continue
+			}
+			if result == nil || semver.Compare(constraint.Canonical(v.Version), constraint.Canonical(result.Version)) > 0 {
+				result = &m.Versions[i]
+			}
+		}
+	}
+	if result == nil {
+		if len(versionRange) == 0 {
+			return nil, fmt.Errorf("%w: %q", ErrModuleNotFound, name)
+		}
+		return nil, fmt.Errorf("%w: %q with a version matching %q", ErrModuleNotFound, name, versionRange)
+	}
+	return result, nil
+}
+
+// Pull downloads the archive of the highest version of the module that matches the version range and returns its path in the cache.
+// An archive already present in the cache is not downloaded again.
+// The registry must provide the checksum of the archive, otherwise the archive is refused.
+func (c *Client) Pull(name string, versionRange string) (string, error) {
+	v, err := c.Resolve(name, versionRange)
+	if err != nil {
+		return "", err
+	}
+	if len(v.Checksum) == 0 {
+		return "", fmt.Errorf("the registry doesn't provide the checksum of the module %q in version %q, so its archive cannot be verified", name, v.Version)
+	}
+	format, err := archiveFormat(v.URL)
+	if err != nil {
+		return "", err
+	}
+	archiveFileName := fmt.Sprintf("%s-%s.%s", name, strings.TrimPrefix(v.Version, "v"), format)
+	if strings.ContainsAny(archiveFileName, `/\`) || strings.Contains(archiveFileName, "..") {
+		return "", fmt.Errorf("module %q with the version %q produces an invalid archive name", name, v.Version)
+	}
+	archivePath := filepath.Join(c.cachePath, archiveFileName)
+	if _, statErr := os.Stat(archivePath); statErr == nil {
+		if checkErr := checkChecksum(archivePath, v.Checksum); checkErr == nil {
+			logrus.Debugf("plugin archive %q found in the cache", archiveFileName)
+			return archivePath, nil
+		}
+		logrus.Warnf("cached plugin archive %q doesn't match the checksum of the registry, downloading it again", archiveFileName)
+	}
+	if c.offline {
+		return "", fmt.Errorf("plugin archive %q is not available in the cache and the registry cannot be used in offline mode", archiveFileName)
+	}
+	data, err := c.download(c.resolve(v.URL))
+	if err != nil {
+		return "", fmt.Errorf("unable to download the module %q: %w", name, err)
+	}
+	if writeErr := c.writeCache(archiveFileName, data); writeErr != nil {
+		return "", writeErr
+	}
+	if checkErr := checkChecksum(archivePath, v.Checksum); checkErr != nil {
+		_ = os.Remove(archivePath)
+		return "", checkErr
+	}
+	if len(v.SignatureURL) > 0 {
+		sig, sigErr := c.download(c.resolve(v.SignatureURL))
+		if sigErr != nil {
+			return "", fmt.Errorf("unable to download the signature of the module %q: %w", name, sigErr)
+		}
+		if writeErr := c.writeCache(filepath.Base(archive.SignatureFilePath(archivePath)), sig); writeErr != nil {
+			return "", writeErr
+		}
+	}
+	return archivePath, nil
+}
+
+// resolve returns the absolute URL of the given reference. A relative reference is resolved against the URL of the registry.
+func (c *Client) resolve(ref string) string {
+	u, err := url.Parse(ref)
+	if err == nil && u.IsAbs() {
+		return ref
+	}
+	base := *c.url
+	base.Path = path.Join(base.Path, ref)
+	return base.String()
+}
+
+func (c *Client) download(rawURL string) ([]byte, error) {
+	resp, err := c.httpClient.Get(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close() //nolint: errcheck
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d when getting %q", resp.StatusCode, rawURL)
+	}
+	if resp.ContentLength > c.maxArchiveSize {
+		return nil, fmt.Errorf("%q is too large: %d bytes, the limit is %d bytes", rawURL, resp.ContentLength, c.maxArchiveSize)
+	}
+	// The content length can be missing or wrong, so the body is read up to the limit, plus one byte to detect it is exceeded.
+	data, err := io.ReadAll(io.LimitReader(resp.Body, c.maxArchiveSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > c.maxArchiveSize {
+		return nil, fmt.Errorf("%q is too large, the limit is %d bytes", rawURL, c.maxArchiveSize)
+	}
+	return data, nil
+}
+
+func (c *Client) writeCache(fileName string, data []byte) error {
+	if err := os.MkdirAll(c.cachePath, 0750); err != nil {
+		return fmt.Errorf("unable to create the cache directory %q: %w", c.cachePath, err)
+	}
+	if err := os.WriteFile(filepath.Join(c.cachePath, fileName), data, 0600); err != nil {
+		return fmt.Errorf("unable to write %q in the cache: %w", fileName, err)
+	}
+	return nil
+}
+
+func archiveFormat(archiveURL string) (archive.Format, error) {
+	u, err := url.Parse(archiveURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid archive URL %q: %w", archiveURL, err)
+	}
+	for _, format := range []archive.Format{archive.TARgz, archive.TAR, archive.ZIP} {
+		if strings.HasSuffix(u.Path, "."+string(format)) {
+			return format, nil
+		}
+	}
+	return "", fmt.Errorf("archive URL %q doesn't point to a supported archive format", archiveURL)
+}
+
+func checkChecksum(archivePath string, expected string) error {
+	checksum, err := archive.Checksum(archivePath)
+	if err != nil {
+		return err
+	}
+	if !strings.EqualFold(checksum, expected) {
+		return fmt.Errorf("checksum mismatch for the archive %q: expected %q, got %q", filepath.Base(archivePath), expected, checksum)
+	}
+	return nil
+}
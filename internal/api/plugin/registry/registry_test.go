@@ -0,0 +1,164 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var archiveContent = []byte("fake archive content")
+
+func checksum(data []byte) string {
+	h := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(h[:])
+}
+
+// newTestRegistry starts a local registry serving an index with two versions of the Prometheus module.
+func newTestRegistry(t *testing.T) *httptest.Server {
+	index := Index{
+		Modules: []Module{
+			{
+				Name:        "Prometheus",
+				Description: "Prometheus datasource, queries and variables",
+				Versions: []Version{
+					{Version: "0.5.0", URL: "archives/Prometheus-0.5.0.tar.gz", Checksum: checksum(archiveContent)},
+					{Version: "0.6.0", URL: "archives/Prometheus-0.6.0.tar.gz", Checksum: checksum(archiveContent)},
+				},
+			},
+			{
+				Name:     "BarChart",
+				Versions: []Version{{Version: "1.0.0", URL: "archives/BarChart-1.0.0.zip", Checksum: "sha256:0000"}},
+			},
+			{
+				Name:     "Unverified",
+				Versions: []Version{{Version: "1.0.0", URL: "archives/Unverified-1.0.0.zip"}},
+			},
+		},
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/registry/index.json", func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(index)
+	})
+	mux.HandleFunc("/registry/archives/", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write(archiveContent)
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestClient_Search(t *testing.T) {
+	server := newTestRegistry(t)
+	c, err := New(server.URL+"/registry", t.TempDir(), false, 0)
+	require.NoError(t, err)
+
+	modules, err := c.Search("")
+	require.NoError(t, err)
+	require.Len(t, modules, 3)
+	assert.Equal(t, "BarChart", modules[0].Name)
+
+	modules, err = c.Search("datasource")
+	require.NoError(t, err)
+	require.Len(t, modules, 1)
+	assert.Equal(t, "Prometheus", modules[0].Name)
+	assert.Equal(t, "0.6.0", modules[0].LatestVersion())
+}
+
+func TestClient_Resolve(t *testing.T) {
+	server := newTestRegistry(t)
+	c, err := New(server.URL+"/registry", t.TempDir(), false, 0)
+	require.NoError(t, err)
+
+	v, err := c.Resolve("prometheus", "")
+	require.NoError(t, err)
+	assert.Equal(t, "0.6.0", v.Version)
+
+	v, err = c.Resolve("Prometheus", "<0.6.0")
+	require.NoError(t, err)
+	assert.Equal(t, "0.5.0", v.Version)
+
+	_, err = c.Resolve("Prometheus", ">=1.0.0")
+	assert.True(t, errors.Is(err, ErrModuleNotFound))
+}
+
+func TestClient_Pull(t *testing.T) {
+	server := newTestRegistry(t)
+	cachePath := t.TempDir()
+	c, err := New(server.URL+"/registry", cachePath, false, 0)
+	require.NoError(t, err)
+
+	archivePath, err := c.Pull("Prometheus", "~0.5.0")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(cachePath, "Prometheus-0.5.0.tar.gz"), archivePath)
+	data, err := os.ReadFile(archivePath)
+	require.NoError(t, err)
+	assert.Equal(t, archiveContent, data)
+
+	_, err = c.Pull("BarChart", "")
+	assert.ErrorContains(t, err, "checksum mismatch")
+	assert.NoFileExists(t, filepath.Join(cachePath, "BarChart-1.0.0.zip"))
+
+	_, err = c.Pull("Unverified", "")
+	assert.ErrorContains(t, err, "doesn't provide the checksum")
+	assert.NoFileExists(t, filepath.Join(cachePath, "Unverified-1.0.0.zip"))
+}
+
+func TestClient_PullTooLarge(t *testing.T) {
+	server := newTestRegistry(t)
+	cachePath := t.TempDir()
+	c, err := New(server.URL+"/registry", cachePath, false, 1024)
+	require.NoError(t, err)
+	// The index is cached with a limit big enough to download it.
+	// With the lower limit, its download fails as well, so the cached index is used.
+	_, err = c.Index()
+	require.NoError(t, err)
+	c.maxArchiveSize = int64(len(archiveContent) - 1)
+
+	_, err = c.Pull("Prometheus", "0.6.0")
+	assert.ErrorContains(t, err, "is too large")
+	assert.NoFileExists(t, filepath.Join(cachePath, "Prometheus-0.6.0.tar.gz"))
+}
+
+func TestClient_PullOffline(t *testing.T) {
+	server := newTestRegistry(t)
+	cachePath := t.TempDir()
+	c, err := New(server.URL+"/registry", cachePath, false, 0)
+	require.NoError(t, err)
+	_, err = c.Pull("Prometheus", "0.6.0")
+	require.NoError(t, err)
+
+	// Once the registry is gone, the index and the archives are served from the cache.
+	server.Close()
+	archivePath, err := c.Pull("Prometheus", "0.6.0")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(cachePath, "Prometheus-0.6.0.tar.gz"), archivePath)
+
+	offline, err := New(server.URL+"/registry", cachePath, true, 0)
+	require.NoError(t, err)
+	_, err = offline.Pull("Prometheus", "0.6.0")
+	require.NoError(t, err)
+	_, err = offline.Pull("Prometheus", "0.5.0")
+	assert.ErrorContains(t, err, "is not available in the cache")
+}
@@ -0,0 +1,130 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"errors"
+	"fmt"
+
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/perses/spec/go/dashboard"
+	"github.com/perses/spec/go/dashboard/variable"
+	"github.com/perses/spec/go/plugin"
+)
+
+// Policy decides if a plugin can be used. It returns an error if the plugin is not allowed.
+// The pluginKind is the kind of the plugin as it appears in a resource (e.g. `TimeSeriesChart`).
+type Policy func(pluginKind string) error
+
+// WithPolicy returns a Schema that checks every plugin against the policy before validating it with the given schema.
+// If the policy is nil, the given schema is returned as is.
+func WithPolicy(s Schema, policy Policy) Schema {
+	if policy == nil {
+		return s
+	}
+	return &restrictedSchema{
+		Schema: s,
+		policy: policy,
+	}
+}
+
+type restrictedSchema struct {
+	Schema
+	policy Policy
+}
+
+func (s *restrictedSchema) ValidateDatasource(plg plugin.Plugin, dtsName string) error {
+	if err := s.policy(plg.Kind); err != nil {
+		return fmt.Errorf("datasource %q: %w", dtsName, err)
+	}
+	return s.Schema.ValidateDatasource(plg, dtsName)
+}
+
+func (s *restrictedSchema) ValidatePanels(panels map[string]*dashboard.Panel) error {
+	var errs []error
+	for panelName, panel := range panels {
+		if err := s.policy(panel.Spec.Plugin.Kind); err != nil {
+			errs = append(errs, fmt.Errorf("panel %q: %w", panelName, err))
+			continue
+		}
+		for _, query := range panel.Spec.Queries {
+			if err := s.policy(query.Spec.Plugin.Kind); err != nil {
+				errs = append(errs, fmt.Errorf("panel %q: %w", panelName, err))
+			}
+		}
+		for _, annotation := range panel.Spec.Annotations {
+			if err := s.policy(annotation.Plugin.Kind); err != nil {
+				errs = append(errs, fmt.Errorf("panel %q: %w", panelName, err))
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+	return s.Schema.ValidatePanels(panels)
+}
+
+func (s *restrictedSchema) ValidatePanel(plg plugin.Plugin, panelName string) error {
+	if err := s.policy(plg.Kind); err != nil {
+		return fmt.Errorf("panel %q: %w", panelName, err)
+	}
+	return s.Schema.ValidatePanel(plg, panelName)
+}
+
+func (s *restrictedSchema) ValidateGlobalVariable(v v1.VariableSpec) error {
+	if listVariableSpec, ok := v.Spec.(*variable.ListSpec); ok && v.Kind == variable.KindList {
+		if err := s.policy(listVariableSpec.Plugin.Kind); err != nil {
+			return err
+		}
+	}
+	return s.Schema.ValidateGlobalVariable(v)
+}
+
+func (s *restrictedSchema) ValidateDashboardVariables(variables []dashboard.Variable) error {
+	var errs []error
+	for _, v := range variables {
+		if v.Kind != variable.KindList {
+			continue
+		}
+		if listVariableSpec, ok := v.Spec.(*dashboard.ListVariableSpec); ok {
+			if err := s.policy(listVariableSpec.Plugin.Kind); err != nil {
+				errs = append(errs, fmt.Errorf("variable %q: %w", listVariableSpec.GetName(), err))
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+	return s.Schema.ValidateDashboardVariables(variables)
+}
+
+func (s *restrictedSchema) ValidateDashboardAnnotations(annotations []dashboard.AnnotationSpec) error {
+	var errs []error
+	for _, a := range annotations {
+		if err := s.policy(a.Plugin.Kind); err != nil {
+			errs = append(errs, fmt.Errorf("annotation %q: %w", a.Display.Name, err))
+		}
+	}
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+	return s.Schema.ValidateDashboardAnnotations(annotations)
+}
+
+func (s *restrictedSchema) ValidateVariable(plg plugin.Plugin, varName string) error {
+	if err := s.policy(plg.Kind); err != nil {
+		return fmt.Errorf("variable %q: %w", varName, err)
+	}
+	return s.Schema.ValidateVariable(plg, varName)
+}
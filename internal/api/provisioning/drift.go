@@ -0,0 +1,186 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provisioning
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	databaseModel "github.com/perses/perses/internal/api/database/model"
+	"github.com/perses/perses/internal/api/dependency"
+	apiInterface "github.com/perses/perses/internal/api/interface"
+	"github.com/perses/perses/internal/api/provisioning/managed"
+	"github.com/perses/perses/internal/cli/resource"
+	modelAPI "github.com/perses/perses/pkg/model/api"
+	modelV1 "github.com/perses/perses/pkg/model/api/v1"
+)
+
+type DriftStatus string
+
+const (
+	// DriftStatusInSync means the resource stored is identical to the one declared in its file.
+	DriftStatusInSync DriftStatus = "in_sync"
+	// DriftStatusDrifted means the resource stored has been modified since it has been provisioned.
+	DriftStatusDrifted DriftStatus = "drifted"
+	// DriftStatusMissing means the resource is declared in a file but doesn't exist.
+	DriftStatusMissing DriftStatus = "missing"
+	// DriftStatusOrphaned means the resource has been provisioned from a file that no longer declares it.
+	DriftStatusOrphaned DriftStatus = "orphaned"
+	// DriftStatusUnknown means the resource cannot be compared, as its sensitive fields are not returned by the API.
+	DriftStatusUnknown DriftStatus = "unknown"
+)
+
+// uncomparableKinds are the kinds whose spec stored differs from the one provisioned, as it is hashed or encrypted.
+var uncomparableKinds = map[modelV1.Kind]bool{
+	modelV1.KindGlobalSecret: true,
+	modelV1.KindSecret:       true,
+	modelV1.KindUser:         true,
+}
+
+// DriftResource is the drift status of a resource provisioned from a folder.
+type DriftResource struct {
+	Kind    modelV1.Kind `json:"kind"`
+	Project string       `json:"project,omitempty"`
+	Name    string       `json:"name"`
+	// Source is the path of the file declaring the resource.
+	// For an orphaned resource, it is the path recorded when the resource has been provisioned, if any.
+	Source string      `json:"source,omitempty"`
+	Status DriftStatus `json:"status"`
+}
+
+// DriftReport compares the resources declared in the provisioning folders with the ones stored.
+type DriftReport struct {
+	Resources []DriftResource `json:"resources"`
+	// Errors contains the errors raised while loading the files or reading the resources stored.
+	Errors []string `json:"errors,omitempty"`
+}
+
+// Drift computes the drift between the provisioning folders and the resources stored.
+type Drift struct {
+	svc     *provisioningService
+	folders []string
+}
+
+func NewDrift(serviceManager dependency.ServiceManager, folders []string, caseSensitive bool) *Drift {
+	return &Drift{
+		svc: &provisioningService{
+			serviceManager: serviceManager,
+			caseSensitive:  caseSensitive,
+		},
+		folders: folders,
+	}
+}
+
+// Report reads the provisioning folders and compares every resource they declare with the one stored.
+// The resources carrying the provisioning tag but no longer declared in any file are reported as orphaned.
+func (d *Drift) Report() DriftReport {
+	report := DriftReport{Resources: []DriftResource{}}
+	entities, sources, loadErrs := d.svc.loadFolders(d.folders)
+	for _, err := range loadErrs {
+		report.Errors = append(report.Errors, err.Error())
+	}
+	expected := make(map[string]bool, len(entities))
+	for _, entity := range entities {
+		kind := modelV1.Kind(entity.GetKind())
+		key := entityKey(kind, entity.GetMetadata())
+		expected[key] = true
+		status, err := d.compare(kind, entity)
+		if err != nil {
+			report.Errors = append(report.Errors, err.Error())
+			continue
+		}
+		report.Resources = append(report.Resources, DriftResource{
+			Kind:    kind,
+			Project: resource.GetProject(entity.GetMetadata(), ""),
+			Name:    entity.GetMetadata().GetName(),
+			Source:  sources[key],
+			Status:  status,
+		})
+	}
+	for _, kind := range prunableKinds {
+		svc, err := d.svc.getKindService(kind)
+		if err != nil {
+			report.Errors = append(report.Errors, err.Error())
+			continue
+		}
+		list, listErr := svc.list()
+		if listErr != nil {
+			report.Errors = append(report.Errors, fmt.Sprintf("unable to list the %q: %s", kind, listErr))
+			continue
+		}
+		for _, entity := range list {
+			tags := managed.Tags(entity.GetMetadata())
+			if tags == nil || !tags.Contains(managed.FileTag) || expected[entityKey(kind, entity.GetMetadata())] {
+				continue
+			}
+			report.Resources = append(report.Resources, DriftResource{
+				Kind:    kind,
+				Project: resource.GetProject(entity.GetMetadata(), ""),
+				Name:    entity.GetMetadata().GetName(),
+				Source:  getSource(entity.GetMetadata()),
+				Status:  DriftStatusOrphaned,
+			})
+		}
+	}
+	return report
+}
+
+func (d *Drift) compare(kind modelV1.Kind, entity modelAPI.Entity) (DriftStatus, error) {
+	svc, err := d.svc.getKindService(kind)
+	if err != nil {
+		return "", err
+	}
+	stored, err := svc.get(apiInterface.Parameters{
+		Name:    entity.GetMetadata().GetName(),
+		Project: resource.GetProject(entity.GetMetadata(), ""),
+	})
+	if err != nil {
+		if databaseModel.IsKeyNotFound(err) {
+			return DriftStatusMissing, nil
+		}
+		return "", fmt.Errorf("unable to get the %q %q: %w", kind, entity.GetMetadata().GetName(), err)
+	}
+	if uncomparableKinds[kind] {
+		return DriftStatusUnknown, nil
+	}
+	equal, err := sameSpec(entity.GetSpec(), stored.GetSpec())
+	if err != nil {
+		return "", fmt.Errorf("unable to compare the %q %q: %w", kind, entity.GetMetadata().GetName(), err)
+	}
+	if !equal {
+		return DriftStatusDrifted, nil
+	}
+	return DriftStatusInSync, nil
+}
+
+// sameSpec compares the JSON representation of both specs, as it is the one provisioned and returned by the API.
+func sameSpec(a, b any) (bool, error) {
+	var left, right any
+	if err := jsonRoundTrip(a, &left); err != nil {
+		return false, err
+	}
+	if err := jsonRoundTrip(b, &right); err != nil {
+		return false, err
+	}
+	return reflect.DeepEqual(left, right), nil
+}
+
+func jsonRoundTrip(from any, to any) error {
+	data, err := json.Marshal(from)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, to)
+}
@@ -0,0 +1,388 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provisioning
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/cuecontext"
+	"cuelang.org/go/cue/load"
+	"github.com/go-git/go-git/v5"
+	gitConfig "github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/go-git/go-git/v5/plumbing/transport/client"
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/go-git/go-git/v5/plumbing/transport/server"
+	"github.com/perses/perses/internal/api/dependency"
+	"github.com/perses/perses/internal/api/interface/v1/globalsecret"
+	"github.com/perses/perses/internal/api/provisioning/managed"
+	dacDependency "github.com/perses/perses/internal/cli/cmd/dac/dependency"
+	"github.com/perses/perses/internal/cli/file"
+	modelAPI "github.com/perses/perses/pkg/model/api"
+	"github.com/perses/perses/pkg/model/api/config"
+	modelV1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/sirupsen/logrus"
+)
+
+func init() {
+	// By default, go-git relies on the git binaries to use a local repository.
+	// The file protocol is replaced by the pure Go implementation, so Perses doesn't depend on git being installed.
+	client.InstallProtocol("file", server.NewClient(server.DefaultLoader))
+}
+
+// GitStatus is the status of the synchronization of a Git repository.
+type GitStatus struct {
+	Name   string `json:"name"`
+	URL    string `json:"url"`
+	Branch string `json:"branch"`
+	Path   string `json:"path,omitempty"`
+	// LastCommit is the hash of the commit applied during the last synchronization.
+	LastCommit             string     `json:"lastCommit,omitempty"`
+	LastSyncTime           *time.Time `json:"lastSyncTime,omitempty"`
+	LastSuccessfulSyncTime *time.Time `json:"lastSuccessfulSyncTime,omitempty"`
+	// Errors contains the errors raised during the last synchronization.
+	Errors []string `json:"errors,omitempty"`
+}
+
+type gitRepository struct {
+	config config.GitProvisioningConfig
+	dir    string
+	// retrieveSecret returns the decrypted spec of the GlobalSecret referenced by the auth of the repository.
+	retrieveSecret func(name string) (*modelV1.SecretSpec, error)
+	mutex          sync.RWMutex
+	status         GitStatus
+}
+
+func (r *gitRepository) tag() string {
+	return managed.GitTagPrefix + r.config.Name
+}
+
+func (r *gitRepository) auth() (transport.AuthMethod, error) {
+	if r.config.Auth == nil {
+		return nil, nil
+	}
+	if len(r.config.Auth.Secret) == 0 {
+		return &githttp.BasicAuth{
+			Username: r.config.Auth.Username,
+			Password: string(r.config.Auth.Password),
+		}, nil
+	}
+	scrt, err := r.retrieveSecret(r.config.Auth.Secret)
+	if err != nil {
+		return nil, fmt.Errorf("unable to retrieve the global secret %q: %w", r.config.Auth.Secret, err)
+	}
+	if scrt.BasicAuth != nil {
+		password, passwordErr := scrt.BasicAuth.GetPassword()
+		if passwordErr != nil {
+			return nil, passwordErr
+		}
+		return &githttp.BasicAuth{Username: scrt.BasicAuth.Username, Password: password}, nil
+	}
+	if scrt.Authorization != nil {
+		credentials, credentialsErr := scrt.Authorization.GetCredentials()
+		if credentialsErr != nil {
+			return nil, credentialsErr
+		}
+		return &githttp.TokenAuth{Token: credentials}, nil
+	}
+	return nil, fmt.Errorf("the global secret %q must contain a basicAuth or an authorization", r.config.Auth.Secret)
+}
+
+// open returns the local clone of the repository, or nil if it doesn't exist or doesn't point to the configured URL.
+func (r *gitRepository) open() (*git.Repository, error) {
+	repo, err := git.PlainOpen(r.dir)
+	if err != nil {
+		if errors.Is(err, git.ErrRepositoryNotExists) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	remote, err := repo.Remote(git.DefaultRemoteName)
+	if err != nil || len(remote.Config().URLs) == 0 || remote.Config().URLs[0] != r.config.URL {
+		return nil, nil
+	}
+	return repo, nil
+}
+
+// pull clones or fetches the repository, then checks out the last commit of the configured branch.
+// It returns the hash of this commit.
+func (r *gitRepository) pull(ctx context.Context) (string, error) {
+	auth, err := r.auth()
+	if err != nil {
+		return "", err
+	}
+	repo, err := r.open()
+	if err != nil {
+		return "", fmt.Errorf("unable to open the local clone: %w", err)
+	}
+	if repo == nil {
+		if removeErr := os.RemoveAll(r.dir); removeErr != nil {
+			return "", fmt.Errorf("unable to clean the local clone: %w", removeErr)
+		}
+		repo, err = git.PlainCloneContext(ctx, r.dir, false, &git.CloneOptions{
+			URL:           r.config.URL,
+			Auth:          auth,
+			ReferenceName: plumbing.NewBranchReferenceName(r.config.Branch),
+			SingleBranch:  true,
+		})
+		if err != nil {
+			return "", fmt.Errorf("unable to clone the repository: %w", err)
+		}
+	} else {
+		fetchErr := repo.FetchContext(ctx, &git.FetchOptions{
+			RemoteName: git.DefaultRemoteName,
+			Auth:       auth,
+			RefSpecs: []gitConfig.RefSpec{
+				gitConfig.RefSpec(fmt.Sprintf("+refs/heads/%s:refs/remotes/%s/%s", r.config.Branch, git.DefaultRemoteName, r.config.Branch)),
+			},
+			Force: true,
+		})
+		if fetchErr != nil && !errors.Is(fetchErr, git.NoErrAlreadyUpToDate) {
+			return "", fmt.Errorf("unable to fetch the repository: %w", fetchErr)
+		}
+	}
+	ref, err := repo.Reference(plumbing.NewRemoteReferenceName(git.DefaultRemoteName, r.config.Branch), true)
+	if err != nil {
+		return "", fmt.Errorf("unable to find the branch %q: %w", r.config.Branch, err)
+	}
+	worktree, err := repo.Worktree()
+	if err != nil {
+		return "", err
+	}
+	if resetErr := worktree.Reset(&git.ResetOptions{Commit: ref.Hash(), Mode: git.HardReset}); resetErr != nil {
+		return "", fmt.Errorf("unable to checkout the commit %s: %w", ref.Hash(), resetErr)
+	}
+	return ref.Hash().String(), nil
+}
+
+// Git synchronizes the resources stored in Git repositories: on every execution, each repository is pulled,
+// its resources are applied, and the resources it provisioned before but no longer contains are pruned.
+type Git struct {
+	svc          *provisioningService
+	repositories []*gitRepository
+}
+
+func NewGit(serviceManager dependency.ServiceManager, globalSecretDAO globalsecret.DAO, cfg config.ProvisioningConfig, caseSensitive bool) *Git {
+	g := &Git{
+		svc: &provisioningService{
+			serviceManager: serviceManager,
+			caseSensitive:  caseSensitive,
+		},
+	}
+	retrieveSecret := func(name string) (*modelV1.SecretSpec, error) {
+		scrt, err := globalSecretDAO.Get(name)
+		if err != nil {
+			return nil, err
+		}
+		if _, decryptErr := serviceManager.GetCrypto().Decrypt(&scrt.Spec); decryptErr != nil {
+			return nil, decryptErr
+		}
+		return &scrt.Spec, nil
+	}
+	for _, repoConfig := range cfg.Git {
+		g.repositories = append(g.repositories, &gitRepository{
+			config:         repoConfig,
+			dir:            filepath.Join(cfg.GitFolder, repoConfig.Name),
+			retrieveSecret: retrieveSecret,
+			status: GitStatus{
+				Name:   repoConfig.Name,
+				URL:    redactURL(repoConfig.URL),
+				Branch: repoConfig.Branch,
+				Path:   repoConfig.Path,
+			},
+		})
+	}
+	return g
+}
+
+func (g *Git) Execute(ctx context.Context, _ context.CancelFunc) error {
+	for _, repo := range g.repositories {
+		if ctx.Err() != nil {
+			return nil
+		}
+		g.sync(ctx, repo)
+	}
+	return nil
+}
+
+func (g *Git) String() string {
+	return "git provisioning task service"
+}
+
+// Status returns the status of the last synchronization of every repository.
+func (g *Git) Status() []GitStatus {
+	result := make([]GitStatus, 0, len(g.repositories))
+	for _, repo := range g.repositories {
+		repo.mutex.RLock()
+		result = append(result, repo.status)
+		repo.mutex.RUnlock()
+	}
+	return result
+}
+
+func (g *Git) sync(ctx context.Context, repo *gitRepository) {
+	now := time.Now().UTC()
+	commit, errs := g.syncRepository(ctx, repo)
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+	repo.status.LastSyncTime = &now
+	if len(commit) > 0 {
+		repo.status.LastCommit = commit
+	}
+	repo.status.Errors = nil
+	for _, err := range errs {
+		repo.status.Errors = append(repo.status.Errors, err.Error())
+	}
+	if len(errs) == 0 {
+		repo.status.LastSuccessfulSyncTime = &now
+	}
+}
+
+func (g *Git) syncRepository(ctx context.Context, repo *gitRepository) (string, []error) {
+	commit, err := repo.pull(ctx)
+	if err != nil {
+		logrus.WithError(err).Errorf("unable to pull the git repository %q", repo.config.Name)
+		return "", []error{err}
+	}
+	entities, loadErrs := loadGitEntities(filepath.Join(repo.dir, repo.config.Path), repo.config.BuildCUE)
+	for _, loadErr := range loadErrs {
+		logrus.WithError(loadErr).Warningf("unable to load entity from the git repository %q", repo.config.Name)
+	}
+	tag := repo.tag()
+	addTag(entities, tag)
+	errs := append(loadErrs, g.svc.applyEntity(entities)...)
+	// When a file can't be loaded, the resources it declares are unknown.
+	// Pruning is then skipped, so these resources are not deleted by mistake.
+	if len(loadErrs) == 0 {
+		errs = append(errs, g.svc.prune(tag, entities)...)
+	}
+	return commit, errs
+}
+
+// loadGitEntities reads the resources stored in the given folder.
+// The hidden files and folders, like .git or .github, and the cue.mod folders are skipped. The JSON and YAML files that
+// don't declare any Perses resource, like a package.json, are ignored, so they don't prevent the pruning.
+// When buildCUE is true, the Dashboard-as-Code CUE files are evaluated as well.
+func loadGitEntities(dir string, buildCUE bool) ([]modelAPI.Entity, []error) {
+	var entities []modelAPI.Entity
+	var errs []error
+	cueContext := cuecontext.New()
+	walkErr := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if path != dir && strings.HasPrefix(d.Name(), ".") {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() {
+			if d.Name() == "cue.mod" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		switch filepath.Ext(path) {
+		case ".json", ".yaml", ".yml":
+			declaresResources, readErr := file.DeclaresResources(path)
+			if readErr != nil {
+				errs = append(errs, readErr)
+				return nil
+			}
+			if !declaresResources {
+				logrus.Debugf("skipping the file %q, it doesn't declare any resource", path)
+				return nil
+			}
+			es, unmarshalErr := file.UnmarshalEntitiesFromFile(path)
+			if unmarshalErr != nil {
+				errs = append(errs, unmarshalErr)
+				return nil
+			}
+			entities = append(entities, es...)
+		case dacDependency.CueFileExt:
+			if !buildCUE || !dacDependency.IsCueDashboardFile(path) {
+				return nil
+			}
+			entity, buildErr := buildCUEEntity(cueContext, path)
+			if buildErr != nil {
+				errs = append(errs, fmt.Errorf("unable to build the file %q: %w", path, buildErr))
+				return nil
+			}
+			entities = append(entities, entity)
+		}
+		return nil
+	})
+	if walkErr != nil {
+		errs = append(errs, walkErr)
+	}
+	return entities, errs
+}
+
+// buildCUEEntity evaluates the given CUE file and decodes the result as a Perses resource.
+func buildCUEEntity(cueContext *cue.Context, path string) (modelAPI.Entity, error) {
+	instances := load.Instances([]string{filepath.Base(path)}, &load.Config{Dir: filepath.Dir(path)})
+	if len(instances) != 1 {
+		return nil, fmt.Errorf("expected one CUE instance, got %d", len(instances))
+	}
+	if instances[0].Err != nil {
+		return nil, instances[0].Err
+	}
+	value := cueContext.BuildInstance(instances[0])
+	if value.Err() != nil {
+		return nil, value.Err()
+	}
+	if err := value.Validate(cue.Concrete(true)); err != nil {
+		return nil, err
+	}
+	data, err := value.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	var object struct {
+		Kind modelV1.Kind `json:"kind"`
+	}
+	if unmarshalErr := json.Unmarshal(data, &object); unmarshalErr != nil {
+		return nil, unmarshalErr
+	}
+	entity, err := modelV1.GetStruct(object.Kind)
+	if err != nil {
+		return nil, err
+	}
+	if unmarshalErr := json.Unmarshal(data, entity); unmarshalErr != nil {
+		return nil, unmarshalErr
+	}
+	return entity, nil
+}
+
+// redactURL hides the password that could be part of the URL, so it is not exposed through the status.
+func redactURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+	return u.Redacted()
+}
@@ -0,0 +1,57 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package managed tells which resources have been created by the provisioning.
+// It is kept apart from the provisioning, so the services it relies on can recognize the managed resources as well.
+package managed
+
+import (
+	"strings"
+
+	"github.com/perses/common/set"
+	modelAPI "github.com/perses/perses/pkg/model/api"
+	modelV1 "github.com/perses/perses/pkg/model/api/v1"
+)
+
+// FileTag is the tag set on every resource provisioned from a folder.
+// Only the resources carrying it can be pruned when their file is removed.
+const FileTag = "file-provisioning"
+
+// GitTagPrefix prefixes the tag set on every resource provisioned from a Git repository.
+// Only the resources carrying the tag of a repository can be pruned by its synchronization.
+const GitTagPrefix = "git-provisioning:"
+
+// Tags returns a pointer to the tags of the given metadata, or nil if the metadata doesn't support tags.
+func Tags(metadata modelAPI.Metadata) *set.Set[string] {
+	switch m := metadata.(type) {
+	case *modelV1.Metadata:
+		return &m.Tags
+	case *modelV1.ProjectMetadata:
+		return &m.Tags
+	}
+	return nil
+}
+
+// IsManaged returns true if the resource has been created by the provisioning, from a folder or from a Git repository.
+func IsManaged(metadata modelAPI.Metadata) bool {
+	tags := Tags(metadata)
+	if tags == nil {
+		return false
+	}
+	for tag := range *tags {
+		if tag == FileTag || strings.HasPrefix(tag, GitTagPrefix) {
+			return true
+		}
+	}
+	return false
+}
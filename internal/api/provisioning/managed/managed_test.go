@@ -0,0 +1,57 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package managed
+
+import (
+	"testing"
+
+	"github.com/perses/common/set"
+	modelAPI "github.com/perses/perses/pkg/model/api"
+	modelV1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsManaged(t *testing.T) {
+	testSuite := []struct {
+		title    string
+		metadata modelAPI.Metadata
+		expected bool
+	}{
+		{
+			title:    "no tag",
+			metadata: modelV1.NewProjectMetadata("perses", "dashboard"),
+			expected: false,
+		},
+		{
+			title:    "other tags",
+			metadata: &modelV1.Metadata{Name: "perses", Tags: set.New("team:a", "provisioning")},
+			expected: false,
+		},
+		{
+			title:    "provisioned from a folder",
+			metadata: &modelV1.ProjectMetadata{Metadata: modelV1.Metadata{Name: "dashboard", Tags: set.New(FileTag)}, ProjectMetadataWrapper: modelV1.ProjectMetadataWrapper{Project: "perses"}},
+			expected: true,
+		},
+		{
+			title:    "provisioned from a Git repository",
+			metadata: &modelV1.Metadata{Name: "perses", Tags: set.New(GitTagPrefix + "infra")},
+			expected: true,
+		},
+	}
+	for _, test := range testSuite {
+		t.Run(test.title, func(t *testing.T) {
+			assert.Equal(t, test.expected, IsManaged(test.metadata))
+		})
+	}
+}
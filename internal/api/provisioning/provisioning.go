@@ -23,6 +23,7 @@ import (
 	"github.com/fsnotify/fsnotify"
 	"github.com/perses/common/async"
 	"github.com/perses/perses/internal/api/dependency"
+	"github.com/perses/perses/pkg/model/api/config"
 	"github.com/sirupsen/logrus"
 )
 
@@ -31,16 +32,17 @@ import (
 // written at once) into a single applyEntity call.
 const watchDebounce = 500 * time.Millisecond
 
-func New(serviceManager dependency.ServiceManager, folders []string, caseSensitive bool) (async.SimpleTask, async.SimpleTask) {
+func New(serviceManager dependency.ServiceManager, cfg config.ProvisioningConfig, caseSensitive bool) (async.SimpleTask, async.SimpleTask) {
 	svc := &provisioningService{
 		serviceManager: serviceManager,
 		caseSensitive:  caseSensitive,
+		enablePrune:    cfg.Prune,
 	}
 	return &provisioningTask{
-			folders: folders,
+			folders: cfg.Folders,
 			svc:     svc,
 		}, &provisioningWatcher{
-			folders: folders,
+			folders: cfg.Folders,
 			svc:     svc,
 		}
 }
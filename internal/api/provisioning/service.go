@@ -15,11 +15,33 @@ package provisioning
 
 import (
 	"fmt"
+	"path/filepath"
 	"sort"
+	"strings"
 
+	"github.com/labstack/echo/v4"
+	"github.com/perses/common/set"
 	databaseModel "github.com/perses/perses/internal/api/database/model"
 	"github.com/perses/perses/internal/api/dependency"
 	apiInterface "github.com/perses/perses/internal/api/interface"
+	"github.com/perses/perses/internal/api/interface/v1/dashboard"
+	"github.com/perses/perses/internal/api/interface/v1/dashboardtemplate"
+	"github.com/perses/perses/internal/api/interface/v1/datasource"
+	"github.com/perses/perses/internal/api/interface/v1/folder"
+	"github.com/perses/perses/internal/api/interface/v1/globaldashboardtemplate"
+	"github.com/perses/perses/internal/api/interface/v1/globaldatasource"
+	"github.com/perses/perses/internal/api/interface/v1/globalpolicy"
+	"github.com/perses/perses/internal/api/interface/v1/globalrole"
+	"github.com/perses/perses/internal/api/interface/v1/globalrolebinding"
+	"github.com/perses/perses/internal/api/interface/v1/globalsecret"
+	"github.com/perses/perses/internal/api/interface/v1/globalvariable"
+	"github.com/perses/perses/internal/api/interface/v1/project"
+	"github.com/perses/perses/internal/api/interface/v1/role"
+	"github.com/perses/perses/internal/api/interface/v1/rolebinding"
+	"github.com/perses/perses/internal/api/interface/v1/secret"
+	"github.com/perses/perses/internal/api/interface/v1/user"
+	"github.com/perses/perses/internal/api/interface/v1/variable"
+	"github.com/perses/perses/internal/api/provisioning/managed"
 	"github.com/perses/perses/internal/cli/file"
 	"github.com/perses/perses/internal/cli/resource"
 	modelAPI "github.com/perses/perses/pkg/model/api"
@@ -27,8 +49,103 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
+// sourceTagPrefix prefixes the tag recording the path of the file a resource has been provisioned from.
+const sourceTagPrefix = "file-source:"
+
+// maxTagLength is the maximum number of characters of a tag accepted by the metadata validation.
+const maxTagLength = 50
+
 type insertFunc func() (modelAPI.Entity, error)
 
+// prunableKinds is the list of the kinds that can be pruned.
+// Projects come last, so the resources they contain are deleted before them.
+var prunableKinds = []modelV1.Kind{
+	modelV1.KindDashboard, modelV1.KindDashboardTemplate, modelV1.KindDatasource, modelV1.KindFolder, modelV1.KindRole,
+	modelV1.KindRoleBinding, modelV1.KindSecret, modelV1.KindVariable, modelV1.KindGlobalDashboardTemplate,
+	modelV1.KindGlobalDatasource, modelV1.KindGlobalPolicy, modelV1.KindGlobalRole, modelV1.KindGlobalRoleBinding,
+	modelV1.KindGlobalSecret, modelV1.KindGlobalVariable, modelV1.KindUser, modelV1.KindProject,
+}
+
+type managedService[K modelAPI.Entity, V databaseModel.Query] interface {
+	Get(parameters apiInterface.Parameters) (K, error)
+	MetadataList(query V) ([]modelAPI.Entity, error)
+	Delete(ctx echo.Context, parameters apiInterface.Parameters) error
+}
+
+// kindService gathers the operations of a resource service required to prune and to compare the managed resources.
+type kindService struct {
+	get    func(parameters apiInterface.Parameters) (modelAPI.Entity, error)
+	list   func() ([]modelAPI.Entity, error)
+	delete func(parameters apiInterface.Parameters) error
+}
+
+func newKindService[K modelAPI.Entity, V databaseModel.Query](svc managedService[K, V], query V) kindService {
+	return kindService{
+		get: func(parameters apiInterface.Parameters) (modelAPI.Entity, error) {
+			return svc.Get(parameters)
+		},
+		list: func() ([]modelAPI.Entity, error) {
+			return svc.MetadataList(query)
+		},
+		delete: func(parameters apiInterface.Parameters) error {
+			return svc.Delete(nil, parameters)
+		},
+	}
+}
+
+// entityKey identifies a resource regardless its kind is global or not.
+func entityKey(kind modelV1.Kind, metadata modelAPI.Metadata) string {
+	return fmt.Sprintf("%s/%s/%s", kind, resource.GetProject(metadata, ""), metadata.GetName())
+}
+
+// addTag adds the given tag to every entity supporting tags.
+func addTag(entities []modelAPI.Entity, tag string) {
+	for _, entity := range entities {
+		if tags := managed.Tags(entity.GetMetadata()); tags != nil {
+			if *tags == nil {
+				*tags = set.New[string]()
+			}
+			tags.Add(tag)
+		}
+	}
+}
+
+// sourceTag returns the tag recording the given source path.
+// When the path is too long to fit in a tag, only its end is kept, as it is the most meaningful part.
+func sourceTag(path string) string {
+	source := []rune(filepath.ToSlash(path))
+	maxLength := maxTagLength - len(sourceTagPrefix)
+	if len(source) > maxLength {
+		source = append([]rune("..."), source[len(source)-maxLength+3:]...)
+	}
+	return sourceTagPrefix + string(source)
+}
+
+// addSourceTag adds to every entity supporting tags the tag recording the file it is declared in.
+func addSourceTag(entities []modelAPI.Entity, sources map[string]string) {
+	for _, entity := range entities {
+		path, ok := sources[entityKey(modelV1.Kind(entity.GetKind()), entity.GetMetadata())]
+		if !ok {
+			continue
+		}
+		addTag([]modelAPI.Entity{entity}, sourceTag(path))
+	}
+}
+
+// getSource returns the path of the file the resource has been provisioned from, as recorded in its tags.
+func getSource(metadata modelAPI.Metadata) string {
+	tags := managed.Tags(metadata)
+	if tags == nil {
+		return ""
+	}
+	for tag := range *tags {
+		if source, ok := strings.CutPrefix(tag, sourceTagPrefix); ok {
+			return source
+		}
+	}
+	return ""
+}
+
 // provisioningServiceInterface is the minimal interface used by both the task and the watcher.
 type provisioningServiceInterface interface {
 	reloadAllEntities(folders []string)
@@ -37,22 +154,55 @@ type provisioningServiceInterface interface {
 type provisioningService struct {
 	serviceManager dependency.ServiceManager
 	caseSensitive  bool
+	// enablePrune is true when the resources whose file has been removed from the folders must be deleted.
+	enablePrune bool
 }
 
-// reload reads all entities from the given folders and applies them.
-func (p *provisioningService) reloadAllEntities(folders []string) {
+// loadFolders reads the entities from the given folders.
+// The names are flattened, and the entities are returned along with the path of the file declaring them, indexed by entityKey.
+func (p *provisioningService) loadFolders(folders []string) ([]modelAPI.Entity, map[string]string, []error) {
 	var entities []modelAPI.Entity
+	var errs []error
+	sources := make(map[string]string)
 	for _, dir := range folders {
-		objects, errors := file.UnmarshalEntitiesFromDirectory(dir)
-		for _, err := range errors {
+		files, loadErrs := file.UnmarshalEntitiesPerFileFromDirectory(dir)
+		for _, err := range loadErrs {
 			logrus.WithError(err).Warningf("unable to load entity from folder %q", dir)
 		}
-		entities = append(entities, objects...)
+		errs = append(errs, loadErrs...)
+		paths := make([]string, 0, len(files))
+		for path := range files {
+			paths = append(paths, path)
+		}
+		sort.Strings(paths)
+		for _, path := range paths {
+			for _, entity := range files[path] {
+				entity.GetMetadata().Flatten(p.caseSensitive)
+				sources[entityKey(modelV1.Kind(entity.GetKind()), entity.GetMetadata())] = path
+				entities = append(entities, entity)
+			}
+		}
 	}
+	return entities, sources, errs
+}
+
+// reload reads all entities from the given folders and applies them.
+// When pruning is enabled, the resources provisioned before but no longer declared in the folders are deleted.
+func (p *provisioningService) reloadAllEntities(folders []string) {
+	entities, sources, loadErrs := p.loadFolders(folders)
+	addTag(entities, managed.FileTag)
+	addSourceTag(entities, sources)
 	p.applyEntity(entities)
+	// When a file can't be loaded, the resources it declares are unknown.
+	// Pruning is then skipped, so these resources are not deleted by mistake.
+	if p.enablePrune && len(loadErrs) == 0 {
+		p.prune(managed.FileTag, entities)
+	}
 }
 
-func (p *provisioningService) applyEntity(entities []modelAPI.Entity) {
+// applyEntity creates or updates the given entities. Every failure is logged and returned.
+func (p *provisioningService) applyEntity(entities []modelAPI.Entity) []error {
+	var errs []error
 	// Provisioning files have no guaranteed order, yet a project-scoped resource
 	// can only be created once its parent project exists. So we apply every
 	// Project first, ensuring projects declared in the same provisioning batch
@@ -72,7 +222,9 @@ func (p *provisioningService) applyEntity(entities []modelAPI.Entity) {
 		// the resource would be silently persisted but never visible until the project is created.
 		// So we refuse to provision it and report the missing project.
 		if len(project) > 0 && !p.projectExists(project) {
-			logrus.Errorf("unable to provision the %q/%q: project %q doesn't exist", kind, name, project)
+			err := fmt.Errorf("unable to provision the %q/%q: project %q doesn't exist", kind, name, project)
+			logrus.Error(err)
+			errs = append(errs, err)
 			continue
 		}
 		param := apiInterface.Parameters{
@@ -82,6 +234,7 @@ func (p *provisioningService) applyEntity(entities []modelAPI.Entity) {
 		createFun, updateFunc, svcErr := p.getService(entity, param)
 		if svcErr != nil {
 			logrus.WithError(svcErr).Warningf("unable to retrieve the service associated to %q", kind)
+			errs = append(errs, svcErr)
 			continue
 		}
 
@@ -94,13 +247,95 @@ func (p *provisioningService) applyEntity(entities []modelAPI.Entity) {
 
 		if !databaseModel.IsKeyConflict(createErr) {
 			logrus.WithError(createErr).Errorf("unable to create the %q %q", kind, name)
+			errs = append(errs, fmt.Errorf("unable to create the %q %q: %w", kind, name, createErr))
 			continue
 		}
 
 		if _, updateError := updateFunc(); updateError != nil {
 			logrus.WithError(updateError).Errorf("unable to update the %q %q", kind, name)
+			errs = append(errs, fmt.Errorf("unable to update the %q %q: %w", kind, name, updateError))
+		}
+	}
+	return errs
+}
+
+// prune deletes the resources carrying the given tag that are not part of the given entities.
+// The entities must have been applied before, so their names are flattened the same way as in the database.
+func (p *provisioningService) prune(tag string, entities []modelAPI.Entity) []error {
+	expected := set.New[string]()
+	for _, entity := range entities {
+		expected.Add(entityKey(modelV1.Kind(entity.GetKind()), entity.GetMetadata()))
+	}
+	var errs []error
+	for _, kind := range prunableKinds {
+		svc, err := p.getKindService(kind)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		list, listErr := svc.list()
+		if listErr != nil {
+			logrus.WithError(listErr).Errorf("unable to list the %q to prune", kind)
+			errs = append(errs, fmt.Errorf("unable to list the %q to prune: %w", kind, listErr))
+			continue
+		}
+		for _, entity := range list {
+			tags := managed.Tags(entity.GetMetadata())
+			if tags == nil || !tags.Contains(tag) || expected.Contains(entityKey(kind, entity.GetMetadata())) {
+				continue
+			}
+			name := entity.GetMetadata().GetName()
+			projectName := resource.GetProject(entity.GetMetadata(), "")
+			if deleteErr := svc.delete(apiInterface.Parameters{Name: name, Project: projectName}); deleteErr != nil && !databaseModel.IsKeyNotFound(deleteErr) {
+				logrus.WithError(deleteErr).Errorf("unable to prune the %q %q", kind, name)
+				errs = append(errs, fmt.Errorf("unable to prune the %q %q: %w", kind, name, deleteErr))
+				continue
+			}
+			logrus.Infof("%q %q pruned as it is no longer provisioned", kind, name)
 		}
 	}
+	return errs
+}
+
+func (p *provisioningService) getKindService(kind modelV1.Kind) (kindService, error) {
+	switch kind {
+	case modelV1.KindDashboard:
+		return newKindService(p.serviceManager.GetDashboard(), &dashboard.Query{}), nil
+	case modelV1.KindDashboardTemplate:
+		return newKindService(p.serviceManager.GetDashboardTemplate(), &dashboardtemplate.Query{}), nil
+	case modelV1.KindDatasource:
+		return newKindService(p.serviceManager.GetDatasource(), &datasource.Query{}), nil
+	case modelV1.KindFolder:
+		return newKindService(p.serviceManager.GetFolder(), &folder.Query{}), nil
+	case modelV1.KindGlobalDashboardTemplate:
+		return newKindService(p.serviceManager.GetGlobalDashboardTemplate(), &globaldashboardtemplate.Query{}), nil
+	case modelV1.KindGlobalDatasource:
+		return newKindService(p.serviceManager.GetGlobalDatasource(), &globaldatasource.Query{}), nil
+	case modelV1.KindGlobalPolicy:
+		return newKindService(p.serviceManager.GetGlobalPolicy(), &globalpolicy.Query{}), nil
+	case modelV1.KindGlobalRole:
+		return newKindService(p.serviceManager.GetGlobalRole(), &globalrole.Query{}), nil
+	case modelV1.KindGlobalRoleBinding:
+		return newKindService(p.serviceManager.GetGlobalRoleBinding(), &globalrolebinding.Query{}), nil
+	case modelV1.KindGlobalSecret:
+		return newKindService(p.serviceManager.GetGlobalSecret(), &globalsecret.Query{}), nil
+	case modelV1.KindGlobalVariable:
+		return newKindService(p.serviceManager.GetGlobalVariable(), &globalvariable.Query{}), nil
+	case modelV1.KindProject:
+		return newKindService(p.serviceManager.GetProject(), &project.Query{}), nil
+	case modelV1.KindRole:
+		return newKindService(p.serviceManager.GetRole(), &role.Query{}), nil
+	case modelV1.KindRoleBinding:
+		return newKindService(p.serviceManager.GetRoleBinding(), &rolebinding.Query{}), nil
+	case modelV1.KindSecret:
+		return newKindService(p.serviceManager.GetSecret(), &secret.Query{}), nil
+	case modelV1.KindUser:
+		return newKindService(p.serviceManager.GetUser(), &user.Query{}), nil
+	case modelV1.KindVariable:
+		return newKindService(p.serviceManager.GetVariable(), &variable.Query{}), nil
+	default:
+		return kindService{}, fmt.Errorf("resource %q not supported by the provisioning service", kind)
+	}
 }
 
 // projectExists returns true if a project with the given name exists in the database.
@@ -123,6 +358,14 @@ func (p *provisioningService) getService(object modelAPI.Entity, parameters apiI
 			func() (modelAPI.Entity, error) {
 				return svc.Update(nil, entity, parameters)
 			}, nil
+	case *modelV1.DashboardTemplate:
+		svc := p.serviceManager.GetDashboardTemplate()
+		return func() (modelAPI.Entity, error) {
+				return svc.Create(nil, entity)
+			},
+			func() (modelAPI.Entity, error) {
+				return svc.Update(nil, entity, parameters)
+			}, nil
 	case *modelV1.Datasource:
 		svc := p.serviceManager.GetDatasource()
 		return func() (modelAPI.Entity, error) {
@@ -139,6 +382,14 @@ func (p *provisioningService) getService(object modelAPI.Entity, parameters apiI
 			func() (modelAPI.Entity, error) {
 				return svc.Update(nil, entity, parameters)
 			}, nil
+	case *modelV1.GlobalDashboardTemplate:
+		svc := p.serviceManager.GetGlobalDashboardTemplate()
+		return func() (modelAPI.Entity, error) {
+				return svc.Create(nil, entity)
+			},
+			func() (modelAPI.Entity, error) {
+				return svc.Update(nil, entity, parameters)
+			}, nil
 	case *modelV1.GlobalDatasource:
 		svc := p.serviceManager.GetGlobalDatasource()
 		return func() (modelAPI.Entity, error) {
@@ -147,6 +398,14 @@ func (p *provisioningService) getService(object modelAPI.Entity, parameters apiI
 			func() (modelAPI.Entity, error) {
 				return svc.Update(nil, entity, parameters)
 			}, nil
+	case *modelV1.GlobalPolicy:
+		svc := p.serviceManager.GetGlobalPolicy()
+		return func() (modelAPI.Entity, error) {
+				return svc.Create(nil, entity)
+			},
+			func() (modelAPI.Entity, error) {
+				return svc.Update(nil, entity, parameters)
+			}, nil
 	case *modelV1.GlobalRole:
 		svc := p.serviceManager.GetGlobalRole()
 		return func() (modelAPI.Entity, error) {
@@ -0,0 +1,66 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provisioning
+
+import (
+	"testing"
+
+	"github.com/perses/common/set"
+	"github.com/perses/perses/internal/api/provisioning/managed"
+	modelV1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/perses/spec/go/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSourceTag(t *testing.T) {
+	testSuite := []struct {
+		title    string
+		path     string
+		expected string
+	}{
+		{
+			title:    "short path",
+			path:     "/provisioning/dashboards/cpu.json",
+			expected: "file-source:/provisioning/dashboards/cpu.json",
+		},
+		{
+			title:    "path too long",
+			path:     "/etc/perses/provisioning/dashboards/team-a/cpu.json",
+			expected: "file-source:...isioning/dashboards/team-a/cpu.json",
+		},
+	}
+	for _, test := range testSuite {
+		t.Run(test.title, func(t *testing.T) {
+			tag := sourceTag(test.path)
+			assert.Equal(t, test.expected, tag)
+			assert.LessOrEqual(t, len(tag), maxTagLength)
+			assert.Equal(t, test.expected[len(sourceTagPrefix):], getSource(&modelV1.Metadata{Name: "cpu", Tags: set.New(managed.FileTag, tag)}))
+		})
+	}
+}
+
+func TestSameSpec(t *testing.T) {
+	provisioned := modelV1.ProjectSpec{Display: &common.Display{Name: "Perses"}}
+	assert.True(t, mustSameSpec(t, provisioned, modelV1.ProjectSpec{Display: &common.Display{Name: "Perses"}}))
+	assert.False(t, mustSameSpec(t, provisioned, modelV1.ProjectSpec{Display: &common.Display{Name: "Edited"}}))
+}
+
+func mustSameSpec(t *testing.T, a, b any) bool {
+	t.Helper()
+	equal, err := sameSpec(a, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return equal
+}
@@ -16,16 +16,19 @@ package toolbox
 import (
 	"encoding/json"
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/brunoga/deep"
 	"github.com/labstack/echo/v4"
 	"github.com/perses/common/async"
 	apiInterface "github.com/perses/perses/internal/api/interface"
+	"github.com/perses/perses/internal/api/utils"
 	"github.com/perses/perses/pkg/model/api"
 	modelV1 "github.com/perses/perses/pkg/model/api/v1"
 	"github.com/perses/perses/pkg/model/api/v1/role"
 	"github.com/perses/spec/go/common"
+	"github.com/sirupsen/logrus"
 	"github.com/tidwall/gjson"
 )
 
@@ -83,15 +86,29 @@ func (t *toolbox[T, K, V]) listWhenPermissionIsActivated(ctx echo.Context, param
 	if err != nil {
 		return nil, err
 	}
+	// Get the list of the project where the user has only access to some resources.
+	restrictedProjects, err := t.authz.GetUserRestrictedProjects(ctx, role.ReadAction, *scope)
+	if err != nil {
+		return nil, err
+	}
 
 	// If there is no project associated with the user, then we should just return an empty list.
-	if len(projects) == 0 {
+	if len(projects) == 0 && len(restrictedProjects) == 0 {
 		return []api.Entity{}, nil
 	}
 
+	result, err := t.listAuthorizedProjects(parameters, *scope, append(slices.Clone(projects), restrictedProjects...), q)
+	if err != nil || len(restrictedProjects) == 0 {
+		return result, err
+	}
+	// The resources of the restricted projects are filtered one by one.
+	return t.filterRestrictedResources(ctx, *scope, projects, result), nil
+}
+
+func (t *toolbox[T, K, V]) listAuthorizedProjects(parameters apiInterface.Parameters, scope role.Scope, projects []string, q V) (any, error) {
 	// Special case if the user is getting the list of the project, as "project" is not considered has a global scope.
 	// More explanation about why it's not a global scope available here: https://github.com/perses/perses/blob/611b7993257dcadb18d48de945ad4def18889bec/pkg/model/api/v1/role/scope.go#L137-L138
-	if *scope == role.ProjectScope {
+	if scope == role.ProjectScope {
 		return t.listProjectWhenPermissionIsActivated(projects, q)
 	}
 
@@ -100,9 +117,9 @@ func (t *toolbox[T, K, V]) listWhenPermissionIsActivated(ctx echo.Context, param
 		return t.metadataOrFullList(q)
 	}
 
-	// In case, there is one result; it can mean the user has global access to the resource across the project.
-	// Or it can mean he has access to only one project. If he has global access, then we should return the complete list.
-	if len(projects) == 1 && projects[0] == modelV1.WildcardProject {
+	// In case, the list contains the wildcard, it means the user has global access to the resource across the project.
+	// If he has global access, then we should return the complete list.
+	if slices.Contains(projects, modelV1.WildcardProject) {
 		return t.metadataOrFullList(q)
 	}
 
@@ -136,7 +153,7 @@ func (t *toolbox[T, K, V]) listWhenPermissionIsActivated(ctx echo.Context, param
 
 func (t *toolbox[T, K, V]) listProjectWhenPermissionIsActivated(projects []string, query V) (any, error) {
 	// User has global access to all projects and should get the complete list.
-	if projects[0] == modelV1.WildcardProject {
+	if slices.Contains(projects, modelV1.WildcardProject) {
 		return t.metadataOrFullList(query)
 	}
 
@@ -180,6 +197,80 @@ func (t *toolbox[T, K, V]) listProjectWhenPermissionIsActivated(projects []strin
 	return []any{}, nil
 }
 
+// filterRestrictedResources removes from the list the resources the user cannot read.
+// The resources of the projects listed in unrestrictedProjects are kept without further check.
+func (t *toolbox[T, K, V]) filterRestrictedResources(ctx echo.Context, scope role.Scope, unrestrictedProjects []string, list any) any {
+	if slices.Contains(unrestrictedProjects, modelV1.WildcardProject) {
+		return list
+	}
+	keep := func(project string, resource role.Resource) bool {
+		if role.IsGlobalScope(scope) {
+			project = modelV1.WildcardProject
+		} else if scope == role.ProjectScope {
+			project = resource.Name
+		}
+		if slices.Contains(unrestrictedProjects, project) {
+			return true
+		}
+		return t.authz.HasResourcePermission(ctx, role.ReadAction, project, scope, resource)
+	}
+	switch typedList := list.(type) {
+	case []K:
+		return filterList(typedList, keep)
+	case []api.Entity:
+		return filterList(typedList, keep)
+	case []json.RawMessage:
+		return filterList(typedList, keep)
+	case []any:
+		return filterList(typedList, keep)
+	}
+	// The resources of an unknown list can't be checked, so none of them is returned.
+	logrus.Errorf("unable to filter the restricted resources of a list of type %T", list)
+	return []any{}
+}
+
+func filterList[E any](list []E, keep func(project string, resource role.Resource) bool) []E {
+	result := make([]E, 0, len(list))
+	for _, item := range list {
+		var project string
+		var resource role.Resource
+		switch typedItem := any(item).(type) {
+		case json.RawMessage:
+			project, resource = resourceFromRaw(typedItem)
+		case api.Entity:
+			project = utils.GetMetadataProject(typedItem.GetMetadata())
+			resource = resourceFromMetadata(typedItem.GetMetadata())
+		default:
+			continue
+		}
+		if keep(project, resource) {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// resourceFromMetadata returns the attributes of a resource used to evaluate the permissions restricted to some resources.
+func resourceFromMetadata(metadata api.Metadata) role.Resource {
+	resource := role.Resource{Name: metadata.GetName()}
+	switch met := metadata.(type) {
+	case *modelV1.Metadata:
+		resource.Tags = met.Tags.TransformAsSlice()
+	case *modelV1.ProjectMetadata:
+		resource.Tags = met.Tags.TransformAsSlice()
+	}
+	return resource
+}
+
+func resourceFromRaw(raw json.RawMessage) (string, role.Resource) {
+	metadata := gjson.GetBytes(raw, "metadata")
+	resource := role.Resource{Name: metadata.Get("name").String()}
+	for _, tag := range metadata.Get("tags").Array() {
+		resource.Tags = append(resource.Tags, tag.String())
+	}
+	return metadata.Get("project").String(), resource
+}
+
 func (t *toolbox[T, K, V]) metadataOrFullList(query V) (any, error) {
 	if query.GetMetadataOnlyQueryParam() {
 		if query.IsRawMetadataQueryAllowed() {
@@ -107,3 +107,10 @@ func TestListAcrossAuthorizedProjects(t *testing.T) {
 	}
 	require.ElementsMatch(t, []string{"project-1", "project-2"}, projects)
 }
+
+func TestFilterRestrictedResourcesDeniesUnknownList(t *testing.T) {
+	tb := &toolbox[*v1.Dashboard, *v1.Dashboard, *dashboard.Query]{authz: &listAuthorization{}}
+	ctx := echo.New().NewContext(httptest.NewRequest(http.MethodGet, "/", nil), httptest.NewRecorder())
+	result := tb.filterRestrictedResources(ctx, role.DashboardScope, nil, []string{"checkout"})
+	require.Equal(t, []any{}, result)
+}
@@ -16,6 +16,7 @@ package toolbox
 import (
 	"fmt"
 	"net/http"
+	"slices"
 	"strings"
 
 	"github.com/labstack/echo/v4"
@@ -85,7 +86,7 @@ func (t *toolbox[T, K, V]) checkPermissionList(ctx echo.Context, parameters apiI
 	}
 	projectName := parameters.Project
 	if role.IsGlobalScope(*scope) {
-		if ok := t.authz.HasPermission(ctx, role.ReadAction, v1.WildcardProject, *scope); !ok {
+		if ok := t.authz.HasPermission(ctx, role.ReadAction, v1.WildcardProject, *scope); !ok && !hasRestrictedPermission(ctx, t.authz, role.ReadAction, v1.WildcardProject, *scope) {
 			return apiInterface.HandleForbiddenError(fmt.Sprintf("missing '%s' global permission for '%s' kind", role.ReadAction, *scope))
 		}
 		return nil
@@ -97,22 +98,32 @@ func (t *toolbox[T, K, V]) checkPermissionList(ctx echo.Context, parameters apiI
 		// In this particular context, the user would like to get every resource to every project he has access to.
 		return nil
 	}
-	if ok := t.authz.HasPermission(ctx, role.ReadAction, projectName, *scope); !ok {
+	// A permission restricted to some resources is enough to list the project, as the list is filtered afterward.
+	if ok := t.authz.HasPermission(ctx, role.ReadAction, projectName, *scope); !ok && !hasRestrictedPermission(ctx, t.authz, role.ReadAction, projectName, *scope) {
 		return apiInterface.HandleForbiddenError(fmt.Sprintf("missing '%s' permission in '%s' project for '%s' kind", role.ReadAction, projectName, *scope))
 	}
 	return nil
 }
 
 func (t *toolbox[T, K, V]) checkPermission(ctx echo.Context, entity api.Entity, parameters apiInterface.Parameters, action role.Action) error {
-	if !t.authz.IsEnabled() {
+	return CheckPermission(ctx, t.authz, t.kind, entity, parameters, action, func() (api.Entity, error) {
+		return t.service.Get(parameters)
+	})
+}
+
+// CheckPermission verifies the user can perform the action on the resource of the given kind targeted by the parameters.
+// entity is the resource sent by the user, if any. getStored returns the resource currently stored,
+// required to evaluate the permissions restricted to some resources.
+func CheckPermission(ctx echo.Context, authz authorization.Authorization, kind v1.Kind, entity api.Entity, parameters apiInterface.Parameters, action role.Action, getStored func() (api.Entity, error)) error {
+	if !authz.IsEnabled() {
 		return nil
 	}
-	scope, err := role.GetScope(string(t.kind))
+	scope, err := role.GetScope(string(kind))
 	if err != nil {
 		return err
 	}
 	if role.IsGlobalScope(*scope) {
-		if ok := t.authz.HasPermission(ctx, action, v1.WildcardProject, *scope); !ok {
+		if ok := authz.HasPermission(ctx, action, v1.WildcardProject, *scope); !ok && !hasResourcePermission(ctx, authz, entity, parameters, action, v1.WildcardProject, *scope, getStored) {
 			return apiInterface.HandleForbiddenError(fmt.Sprintf("missing '%s' global permission for '%s' kind", action, *scope))
 		}
 		return nil
@@ -132,18 +143,58 @@ func (t *toolbox[T, K, V]) checkPermission(ctx echo.Context, entity api.Entity,
 
 	// Project creation permission is handled separately as the check differs between authorization providers.
 	if *scope == role.ProjectScope && action == role.CreateAction {
-		if ok := t.authz.HasCreateProjectPermission(ctx, projectName); !ok {
+		if ok := authz.HasCreateProjectPermission(ctx, projectName); !ok {
 			return apiInterface.HandleForbiddenError(fmt.Sprintf("missing '%s' permission for '%s' kind", action, *scope))
 		}
 		return nil
 	}
 
-	if ok := t.authz.HasPermission(ctx, action, projectName, *scope); !ok {
+	if ok := authz.HasPermission(ctx, action, projectName, *scope); !ok && !hasResourcePermission(ctx, authz, entity, parameters, action, projectName, *scope, getStored) {
 		return apiInterface.HandleForbiddenError(fmt.Sprintf("missing '%s' permission in '%s' project for '%s' kind", action, projectName, *scope))
 	}
 	return nil
 }
 
+// hasRestrictedPermission returns true if the user has a permission restricted to some resources of the project.
+func hasRestrictedPermission(ctx echo.Context, authz authorization.Authorization, action role.Action, projectName string, scope role.Scope) bool {
+	projects, err := authz.GetUserRestrictedProjects(ctx, action, scope)
+	if err != nil {
+		logrus.WithError(err).Error("unable to get the projects where the user has restricted permissions")
+		return false
+	}
+	return slices.Contains(projects, v1.WildcardProject) || slices.Contains(projects, projectName)
+}
+
+// hasResourcePermission checks the permissions restricted to some resources.
+// The resources checked are the entity provided and, except for the creation, the one currently stored in the database,
+// so a user cannot take over a resource by changing its name or its tags.
+func hasResourcePermission(ctx echo.Context, authz authorization.Authorization, entity api.Entity, parameters apiInterface.Parameters, action role.Action, projectName string, scope role.Scope, getStored func() (api.Entity, error)) bool {
+	if !hasRestrictedPermission(ctx, authz, action, projectName, scope) {
+		return false
+	}
+	var entities []api.Entity
+	if entity != nil {
+		entities = append(entities, entity)
+	}
+	if action != role.CreateAction && len(parameters.Name) > 0 {
+		stored, err := getStored()
+		if err != nil {
+			// Whether the resource exists or not is not disclosed to a user without the permission on it.
+			return false
+		}
+		entities = append(entities, stored)
+	}
+	if len(entities) == 0 {
+		return false
+	}
+	for _, e := range entities {
+		if !authz.HasResourcePermission(ctx, action, projectName, scope, resourceFromMetadata(e.GetMetadata())) {
+			return false
+		}
+	}
+	return true
+}
+
 func (t *toolbox[T, K, V]) Create(ctx echo.Context, entity T) error {
 	if err := t.bind(ctx, entity); err != nil {
 		return err
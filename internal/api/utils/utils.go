@@ -20,51 +20,62 @@ import (
 )
 
 const (
-	ParamDashboard         = "dashboard"
-	ParamName              = "name"
-	ParamProject           = "project"
-	APIPrefix              = "/api"
-	PathAuth               = "auth"
-	PathAuthProviders      = "auth/providers"
-	PathLogin              = "login"
-	PathCallback           = "callback"
-	PathLogout             = "logout"
-	PathRefresh            = "refresh"
-	PathDeviceCode         = "device/code"
-	PathToken              = "token"
-	AuthnKindNative        = "native"
-	AuthnKindOIDC          = "oidc"
-	AuthnKindOAuth         = "oauth"
-	AuthnKindKubernetes    = "kubernetes"
-	APIV1Prefix            = "/api/v1"
-	PathDashboard          = "dashboards"
-	PathDatasource         = "datasources"
-	PathEphemeralDashboard = "ephemeraldashboards"
-	PathFolder             = "folders"
-	PathGlobalDatasource   = "globaldatasources"
-	PathGlobalRole         = "globalroles"
-	PathGlobalRoleBinding  = "globalrolebindings"
-	PathGlobalSecret       = "globalsecrets"
-	PathGlobalVariable     = "globalvariables"
-	PathProject            = "projects"
-	PathRole               = "roles"
-	PathRoleBinding        = "rolebindings"
-	PathSecret             = "secrets"
-	PathUnsaved            = "unsaved"
-	PathUser               = "users"
-	PathCurrentUser        = "user"
-	PathVariable           = "variables"
-	PathView               = "view"
-	PathWhoAmI             = "whoami"
-	PathSearch             = "search"
-	ContextKeyAnonymous    = "anonymous"
+	ParamDashboard              = "dashboard"
+	ParamName                   = "name"
+	ParamProject                = "project"
+	APIPrefix                   = "/api"
+	PathAuth                    = "auth"
+	PathAuthProviders           = "auth/providers"
+	PathLogin                   = "login"
+	PathCallback                = "callback"
+	PathLogout                  = "logout"
+	PathRefresh                 = "refresh"
+	PathDeviceCode              = "device/code"
+	PathToken                   = "token"
+	AuthnKindNative             = "native"
+	AuthnKindOIDC               = "oidc"
+	AuthnKindOAuth              = "oauth"
+	AuthnKindKubernetes         = "kubernetes"
+	APIV1Prefix                 = "/api/v1"
+	PathDashboard               = "dashboards"
+	PathDashboardTemplate       = "dashboardtemplates"
+	PathDatasource              = "datasources"
+	PathEphemeralDashboard      = "ephemeraldashboards"
+	PathFolder                  = "folders"
+	PathGlobalDashboardTemplate = "globaldashboardtemplates"
+	PathGlobalDatasource        = "globaldatasources"
+	PathGlobalPolicy            = "globalpolicies"
+	PathGlobalRole              = "globalroles"
+	PathGlobalRoleBinding       = "globalrolebindings"
+	PathGlobalSecret            = "globalsecrets"
+	PathGlobalVariable          = "globalvariables"
+	PathProject                 = "projects"
+	PathRole                    = "roles"
+	PathRoleBinding             = "rolebindings"
+	PathSecret                  = "secrets"
+	PathUnsaved                 = "unsaved"
+	PathUser                    = "users"
+	PathCurrentUser             = "user"
+	PathVariable                = "variables"
+	PathView                    = "view"
+	PathAnalytics               = "analytics"
+	PathWhoAmI                  = "whoami"
+	PathPreferences             = "preferences"
+	PathSearch                  = "search"
+	PathAuthz                   = "authz"
+	PathCheck                   = "check"
+	PathExplain                 = "explain"
+	PathProvisioning            = "provisioning"
+	PathInstantiate             = "instantiate"
+	PathRender                  = "render"
+	ContextKeyAnonymous         = "anonymous"
 )
 
 const MetricNamespace = "perses"
 
 // ProjectResourcePathList is containing the list of the resource path that is part of a project.
 var ProjectResourcePathList = []string{
-	PathDashboard, PathEphemeralDashboard, PathDatasource, PathFolder, PathRole, PathRoleBinding, PathSecret, PathVariable,
+	PathDashboard, PathDashboardTemplate, PathEphemeralDashboard, PathDatasource, PathFolder, PathRole, PathRoleBinding, PathSecret, PathVariable,
 }
 
 func GetNameParameter(ctx echo.Context) string {
@@ -0,0 +1,168 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cani
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	persesCMD "github.com/perses/perses/internal/cli/cmd"
+	"github.com/perses/perses/internal/cli/config"
+	"github.com/perses/perses/internal/cli/opt"
+	"github.com/perses/perses/internal/cli/output"
+	"github.com/perses/perses/internal/cli/resource"
+	"github.com/perses/perses/pkg/client/api"
+	v1 "github.com/perses/perses/pkg/client/api/v1"
+	"github.com/perses/perses/pkg/model/api/v1/role"
+	"github.com/spf13/cobra"
+)
+
+var columnHeader = []string{
+	"SOURCE",
+	"NAME",
+	"PROJECT",
+	"BINDINGS",
+	"BOUND",
+	"RESTRICTED",
+}
+
+type option struct {
+	persesCMD.Option
+	opt.OutputOption
+	opt.ProjectOption
+	action    role.Action
+	scope     role.Scope
+	as        string
+	writer    io.Writer
+	errWriter io.Writer
+	apiClient api.ClientInterface
+}
+
+func (o *option) Complete(args []string) error {
+	if len(args) != 2 {
+		return fmt.Errorf("you must provide an action and a kind")
+	}
+	action, err := role.GetAction(args[0])
+	if err != nil {
+		return err
+	}
+	o.action = *action
+	kind, err := resource.GetKind(args[1])
+	if err != nil {
+		return err
+	}
+	scope, err := role.GetScope(string(kind))
+	if err != nil {
+		return err
+	}
+	o.scope = *scope
+	// Contrary to the other commands, the project is optional: without it, the permission is checked on every project.
+	if len(o.Project) == 0 && !role.IsGlobalScope(o.scope) {
+		o.Project = config.Global.Project
+	}
+	if len(o.Output) > 0 {
+		if outputErr := o.OutputOption.Complete(); outputErr != nil {
+			return outputErr
+		}
+	}
+	apiClient, err := config.Global.GetAPIClient()
+	if err != nil {
+		return err
+	}
+	o.apiClient = apiClient
+	return nil
+}
+
+func (o *option) Validate() error {
+	return nil
+}
+
+func (o *option) Execute() error {
+	explanation, err := o.apiClient.V1().Authz().Explain(v1.ExplainQuery{
+		User:    o.as,
+		Action:  o.action,
+		Scope:   o.scope,
+		Project: o.Project,
+	})
+	if err != nil {
+		return err
+	}
+	if len(o.Output) > 0 {
+		return output.Handle(o.writer, o.Output, explanation)
+	}
+	answer := "no"
+	if explanation.Allowed {
+		answer = "yes"
+	}
+	if len(explanation.Reason) > 0 {
+		answer = fmt.Sprintf("%s - %s", answer, explanation.Reason)
+	}
+	if err := output.HandleString(o.writer, answer); err != nil {
+		return err
+	}
+	if len(explanation.Grants) == 0 {
+		return nil
+	}
+	var matrix [][]string
+	for _, grant := range explanation.Grants {
+		matrix = append(matrix, []string{
+			string(grant.Source),
+			grant.Name,
+			grant.Project,
+			strings.Join(grant.Bindings, ", "),
+			strconv.FormatBool(grant.Bound),
+			strconv.FormatBool(grant.Restricted),
+		})
+	}
+	return output.HandlerTable(o.writer, columnHeader, matrix)
+}
+
+func (o *option) SetWriter(writer io.Writer) {
+	o.writer = writer
+}
+
+func (o *option) SetErrWriter(errWriter io.Writer) {
+	o.errWriter = errWriter
+}
+
+func NewCMD() *cobra.Command {
+	o := &option{}
+	cmd := &cobra.Command{
+		Use:   "can-i ACTION KIND",
+		Short: "Check whether an action is allowed",
+		Long: `Check whether the current user, or the user given with --as, is allowed to perform an action on a kind of resource.
+The answer comes with the roles (or the policies) granting the permission, or that would grant it if the user was bound to them.
+With the Kubernetes authorization, the reason returned by Kubernetes is displayed instead.
+Checking the permissions of another user requires the permission to read the GlobalRoleBindings (the GlobalPolicies with the CEL authorization).`,
+		Example: `
+# Check whether you can create dashboards in the project "perses"
+percli auth can-i create dashboard --project perses
+
+# Check whether the user "jane" can read the global secrets
+percli auth can-i read globalsecret --as jane
+
+# Get the full explanation in json
+percli auth can-i update datasource -p perses -ojson
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return persesCMD.Run(o, cmd, args)
+		},
+	}
+	opt.AddOutputFlags(cmd, &o.OutputOption)
+	opt.AddProjectFlags(cmd, &o.ProjectOption)
+	cmd.Flags().StringVar(&o.as, "as", "", "The user whose permissions are checked. By default, it is the current user.")
+	return cmd
+}
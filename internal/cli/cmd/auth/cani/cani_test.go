@@ -0,0 +1,66 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cani
+
+import (
+	"testing"
+
+	cmdTest "github.com/perses/perses/internal/cli/test"
+	fakeapi "github.com/perses/perses/pkg/client/fake/api"
+)
+
+func TestCanICMD(t *testing.T) {
+	testSuite := []cmdTest.Suite{
+		{
+			Title:           "missing the kind",
+			Args:            []string{"read"},
+			IsErrorExpected: true,
+			ExpectedMessage: "you must provide an action and a kind",
+		},
+		{
+			Title:           "kind not managed",
+			Args:            []string{"read", "whatever"},
+			IsErrorExpected: true,
+			ExpectedMessage: "resource \"whatever\" not managed",
+		},
+		{
+			Title:           "not connected to any API",
+			Args:            []string{"read", "dashboard"},
+			IsErrorExpected: true,
+			ExpectedMessage: "you are not connected to any API",
+		},
+		{
+			Title:           "action not allowed",
+			Args:            []string{"create", "dashboards", "-p", "perses"},
+			APIClient:       fakeapi.New(),
+			IsErrorExpected: false,
+			ExpectedMessage: "no - no role grants the permission\n",
+		},
+		{
+			Title:                "action allowed with the role granting it",
+			Args:                 []string{"read", "dashboard", "-p", "perses", "--as", "jane"},
+			APIClient:            fakeapi.New(),
+			IsErrorExpected:      false,
+			ExpectedRegexMessage: `(?s)^yes\n.*GlobalRole.*viewer.*viewers.*true`,
+		},
+		{
+			Title:           "explanation in json",
+			Args:            []string{"read", "globalsecret", "-ojson"},
+			APIClient:       fakeapi.New(),
+			IsErrorExpected: false,
+			ExpectedMessage: `{"user":"admin","action":"read","scope":"GlobalSecret","allowed":true,"grants":[{"source":"GlobalRole","name":"viewer","bindings":["viewers"],"bound":true}]}` + "\n",
+		},
+	}
+	cmdTest.ExecuteSuiteTest(t, NewCMD, testSuite)
+}
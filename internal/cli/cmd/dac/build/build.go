@@ -22,10 +22,13 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"runtime"
 	"slices"
 	"strings"
+	"sync"
 
 	persesCMD "github.com/perses/perses/internal/cli/cmd"
+	"github.com/perses/perses/internal/cli/cmd/dac/dependency"
 	"github.com/perses/perses/internal/cli/config"
 	"github.com/perses/perses/internal/cli/opt"
 	"github.com/perses/perses/internal/cli/output"
@@ -34,13 +37,14 @@ import (
 )
 
 const (
-	modeFile     = "file"
-	modeStdout   = "stdout"
-	goExtension  = ".go"
-	cueExtension = ".cue"
+	modeFile         = "file"
+	modeStdout       = "stdout"
+	goExtension      = ".go"
+	cueExtension     = ".cue"
+	jsonnetExtension = ".jsonnet"
 )
 
-var folderToIgnore = []string{".git", ".github", ".idea", config.DefaultOutputFolder, "cue.mod"}
+var folderToIgnore = []string{".git", ".github", ".idea", config.DefaultOutputFolder, "cue.mod", dependency.JsonnetVendorDir}
 
 // isGoMainPackage reports whether the Go source file at path belongs to
 // package main. Files in non-main packages (library helpers, sub-packages)
@@ -65,6 +69,12 @@ type Option struct {
 	errWriter io.Writer
 	args      []string
 	Mode      string
+	// Concurrency is the number of files built at the same time.
+	Concurrency int
+	// UseCache skips the files whose inputs didn't change since their last successful build.
+	UseCache bool
+	// JPaths are the library paths where the Jsonnet imports are searched.
+	JPaths []string
 }
 
 func (o *Option) Complete(args []string) error {
@@ -80,6 +90,10 @@ func (o *Option) Validate() error {
 		return fmt.Errorf("invalid mode provided: must be either `file` or `stdout`")
 	}
 
+	if o.Concurrency < 1 {
+		return fmt.Errorf("invalid concurrency provided: must be at least 1")
+	}
+
 	if o.File != "" {
 		return o.FileOption.Validate()
 	}
@@ -88,11 +102,15 @@ func (o *Option) Validate() error {
 
 func (o *Option) Execute() error {
 	if o.File != "" {
-		return o.processFile(o.File, filepath.Ext(o.File))
+		res := o.buildFiles(filepath.Dir(o.File), []string{o.File})[0]
+		if res.err != nil {
+			return res.err
+		}
+		return output.HandleString(o.writer, res.message)
 	}
 
-	var errs []error
-	// Else it's a directory, thus walk it and process each file
+	// Else it's a directory, thus walk it and collect each file to process
+	var files []string
 	err := filepath.Walk(o.Directory, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
 			return err
@@ -107,7 +125,7 @@ func (o *Option) Execute() error {
 		}
 
 		extension := filepath.Ext(path)
-		if extension == goExtension || extension == cueExtension {
+		if extension == goExtension || extension == cueExtension || extension == jsonnetExtension {
 			if extension == goExtension {
 				// Only process files declared as package main. Supporting packages that
 				// live alongside a main entrypoint (e.g. helper packages in sub-dirs)
@@ -117,11 +135,7 @@ func (o *Option) Execute() error {
 					return nil
 				}
 			}
-			err = o.processFile(path, extension)
-			if err != nil {
-				// Append the error to highlight the issue to the user on a later stage but don't stop the processing
-				errs = append(errs, fmt.Errorf("error processing file %q: %w", path, err))
-			}
+			files = append(files, path)
 		}
 
 		return nil
@@ -131,6 +145,18 @@ func (o *Option) Execute() error {
 		return fmt.Errorf("error processing directory %q: %v", o.Directory, err)
 	}
 
+	var errs []error
+	for i, res := range o.buildFiles(o.Directory, files) {
+		if res.err != nil {
+			// Append the error to highlight the issue to the user on a later stage but don't stop the processing
+			errs = append(errs, fmt.Errorf("error processing file %q: %w", files[i], res.err))
+			continue
+		}
+		if outputErr := output.HandleString(o.writer, res.message); outputErr != nil {
+			return outputErr
+		}
+	}
+
 	if len(errs) > 0 {
 		_, _ = fmt.Fprintln(o.errWriter, "  FAILED:")
 		for _, e := range errs {
@@ -142,19 +168,108 @@ func (o *Option) Execute() error {
 	return nil
 }
 
-func (o *Option) processFile(file string, extension string) error {
+type buildResult struct {
+	message string
+	err     error
+}
+
+// buildFiles builds the files with a pool of workers, and returns the result of each file in the same order.
+// When the cache is used, the files whose inputs didn't change since their last build are skipped.
+func (o *Option) buildFiles(sourceDir string, files []string) []buildResult {
+	results := make([]buildResult, len(files))
+	var cache buildCache
+	var resolver *dependency.Resolver
+	hashes := make([]string, len(files))
+	if o.UseCache && o.Mode == modeFile {
+		cache = loadCache()
+		resolver = &dependency.Resolver{
+			SourceDir:   sourceDir,
+			ModulePaths: dependency.DetectModulePaths(sourceDir),
+			SkipDirectory: func(path string) bool {
+				return slices.Contains(folderToIgnore, filepath.Base(path))
+			},
+			JPaths: o.JPaths,
+		}
+	}
+
+	var toBuild []int
+	for i, file := range files {
+		if cache != nil {
+			hash, err := o.computeHash(resolver, file)
+			if err != nil {
+				logrus.WithError(err).Debugf("unable to compute the hash of %q, it will be built", file)
+			} else if outputFilePath := o.BuildOutputFilePath(file); cache.isUpToDate(file, hash, outputFilePath) {
+				results[i] = buildResult{message: fmt.Sprintf("Skipped %s, unchanged since it was built at %s", file, outputFilePath)}
+				continue
+			}
+			hashes[i] = hash
+		}
+		toBuild = append(toBuild, i)
+	}
+
+	filesToBuild := make([]string, 0, len(toBuild))
+	for _, i := range toBuild {
+		filesToBuild = append(filesToBuild, files[i])
+	}
+	binaries, cleanup := compileGoDashboards(filesToBuild)
+	defer cleanup()
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	// The option can be created without being validated (e.g. by the watch command), so at least one worker is started.
+	for range max(1, min(o.Concurrency, len(toBuild))) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				message, err := o.processFile(files[i], filepath.Ext(files[i]), binaries[files[i]])
+				results[i] = buildResult{message: message, err: err}
+			}
+		}()
+	}
+	for _, i := range toBuild {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	if cache != nil {
+		for _, i := range toBuild {
+			if results[i].err == nil && len(hashes[i]) > 0 {
+				cache[files[i]] = hashes[i]
+			} else {
+				delete(cache, files[i])
+			}
+		}
+		if err := cache.save(); err != nil {
+			logrus.WithError(err).Warn("unable to save the build cache")
+		}
+	}
+	return results
+}
+
+// processFile builds the file and returns the message to display.
+// binary is the compiled Go dashboard to execute. When it is empty, the Go dashboard is run with `go run`.
+func (o *Option) processFile(file string, extension string, binary string) (string, error) {
 	var cmd *exec.Cmd
+	var cmdOutput []byte
 
 	if extension == goExtension { //nolint: staticcheck
 		// The command `go run` must be executed in the directory where the file is located.
 		// That's because, go is searching the go.mod file, first in the current directory, then in the parent directories.
 		// So when using multiple go submodules, to ensure it is the correct go.mod considered,
 		// we must be in the closest directory to the file.
+		// The compiled dashboards are executed in the same directory, so they behave the same.
 		folder := filepath.Dir(file)
-		extractedFile := filepath.Base(file)
-		goArgs := []string{"run", extractedFile, "--output", o.Output}
-		goArgs = append(goArgs, o.args...)
-		cmd = exec.Command("go", goArgs...) // #nosec
+		if len(binary) > 0 {
+			binaryArgs := append([]string{"--output", o.Output}, o.args...)
+			cmd = exec.Command(binary, binaryArgs...) // #nosec
+		} else {
+			extractedFile := filepath.Base(file)
+			goArgs := []string{"run", extractedFile, "--output", o.Output}
+			goArgs = append(goArgs, o.args...)
+			cmd = exec.Command("go", goArgs...) // #nosec
+		}
 		cmd.Dir = folder
 	} else if extension == cueExtension {
 		// NB: most of the work of the `build` command is actually made by the `eval` command of the cue CLI.
@@ -163,31 +278,41 @@ func (o *Option) processFile(file string, extension string) error {
 		//      See https://github.com/cue-lang/cue/blob/master/cmd/cue/cmd/eval.go#L87
 		// NB3: #nosec is needed here even if the user-fed parts of the command are sanitized upstream
 		cmd = exec.Command("cue", "eval", file, "--out", o.Output, "--concrete") // #nosec
+	} else if extension == jsonnetExtension {
+		// Unlike for CUE & Go, the Jsonnet VM is embedded, so no external binary is required.
+		result, err := o.evaluateJsonnet(file)
+		if err != nil {
+			return "", fmt.Errorf("failed to build %s: %w", file, err)
+		}
+		cmdOutput = result
 	} else {
-		return output.HandleString(o.writer, fmt.Sprintf("skipping %q because it is neither a `cue` or `go` file", file))
+		return fmt.Sprintf("skipping %q because it is neither a `cue`, `go` or `jsonnet` file", file), nil
 	}
 
-	// Capture the output of the command
-	cmdOutput, err := cmd.Output()
-	if err != nil {
-		var exitErr *exec.ExitError
-		if errors.As(err, &exitErr) {
-			return fmt.Errorf("failed to build %s: %s", file, string(exitErr.Stderr))
+	if cmd != nil {
+		// Capture the output of the command
+		result, err := cmd.Output()
+		if err != nil {
+			var exitErr *exec.ExitError
+			if errors.As(err, &exitErr) {
+				return "", fmt.Errorf("failed to build %s: %s", file, string(exitErr.Stderr))
+			}
+			return "", err
 		}
-		return err
+		cmdOutput = result
 	}
 
 	// If mode = stdout, print the command result on the standard output & don't go further
 	if o.Mode == modeStdout {
-		return output.HandleString(o.writer, string(cmdOutput))
+		return string(cmdOutput), nil
 	}
 
 	// Otherwise, create an output file under the output directory:
 
 	// Create the folder (+ any parent folder if applicable) where to store the output
-	err = os.MkdirAll(filepath.Join(config.Global.Dac.OutputFolder, filepath.Dir(file)), 0750)
+	err := os.MkdirAll(filepath.Join(config.Global.Dac.OutputFolder, filepath.Dir(file)), 0750)
 	if err != nil {
-		return fmt.Errorf("error creating the output folder: %v", err)
+		return "", fmt.Errorf("error creating the output folder: %v", err)
 	}
 
 	// Build the path of the file where to store the command output
@@ -195,9 +320,9 @@ func (o *Option) processFile(file string, extension string) error {
 
 	// Write the output to the file
 	if writeErr := os.WriteFile(outputFilePath, cmdOutput, 0644); writeErr != nil { // nolint: gosec
-		return fmt.Errorf("error writing to %s: %v", outputFilePath, writeErr)
+		return "", fmt.Errorf("error writing to %s: %v", outputFilePath, writeErr)
 	}
-	return output.HandleString(o.writer, fmt.Sprintf("Successfully built %s at %s", file, outputFilePath))
+	return fmt.Sprintf("Successfully built %s at %s", file, outputFilePath), nil
 }
 
 // BuildOutputFilePath generates the output file path based on the input file path
@@ -231,12 +356,18 @@ Generate the final output (YAML by default, or JSON) of the given DaC file - or
 The supported languages for a DaC are:
 - CUE
 - Go
+- Jsonnet
 
 The result(s) is/are by default stored in a/multiple file(s) under the 'built' folder, but can also be printed on the standard output instead.
 For Go, file must comply with "go run": the file package must be main and a main function, starting with 'exec := sdk.NewExec()' and finishing with 'exec.BuildDashboard(...)'
 
 NB: "percli dac build -f my_dashboard.cue -m stdout" is basically doing the same as "cue eval my_dashboard.cue", however be aware that "percli dac build -d mydir -m stdout" is not equivalent to "cue eval mydir": in the case of percli each CUE file encountered in the directory is evaluated independently.
 And "percli dac build -f main.go -m stdout" is basically doing the same as "go run main.go"
+
+Jsonnet files are evaluated by a Jsonnet VM embedded in percli, no external binary is required. Only the .jsonnet files are built, the .libsonnet files being considered as libraries.
+The imports that are not relative to the importing file are searched in the library paths (default: vendor).
+
+When building a directory, the files are built in parallel. The Go dashboards of a same module, each alone in its directory, are compiled with a single "go build" and the resulting binaries are executed, instead of running "go run" for each of them.
 `,
 		Example: `
 # build a given file
@@ -251,9 +382,15 @@ percli dac build -f my_dashboard.cue -m stdout | percli apply -f -
 # build all the files under a given directory
 percli dac build -d my_dashboards
 
+# build 8 files at a time, skipping the ones that didn't change since the last build
+percli dac build -d my_dashboards --concurrency 8 --cache
+
 # build all the files under a given directory & deploy the resulting resources right away
 percli dac build -d my_dashboards && percli apply -d built
 
+# build a Jsonnet file, searching the imports in both the vendor and lib folders
+percli dac build -f my_dashboard.jsonnet -J vendor -J lib
+
 # build a given file as JSON providing extra arguments to the Go program (This is only applicable for Go files)
 percli dac build -f main.go -ojson -- --arg1=value1 --arg2=value2
 `,
@@ -261,6 +398,9 @@ percli dac build -f main.go -ojson -- --arg1=value1 --arg2=value2
 			return persesCMD.Run(o, cmd, args)
 		},
 	}
+	cmd.Flags().IntVar(&o.Concurrency, "concurrency", runtime.NumCPU(), "Number of files built at the same time.")
+	cmd.Flags().BoolVar(&o.UseCache, "cache", false, "Skip the files whose inputs (the file, the local packages it imports, the module files, the output format and the extra arguments) didn't change since their last successful build. The hashes of the inputs are stored in the output folder. It only applies when the mode is `file`.")
+	cmd.Flags().StringArrayVarP(&o.JPaths, "jpath", "J", []string{dependency.JsonnetVendorDir}, "Library path where the Jsonnet imports are searched. It can be provided multiple times.")
 	cmd.Flags().StringVarP(&o.Mode, "mode", "m", "file", "Mode for the output. Must be either `file` to automatically save the content to file(s), or `stdout` to print on the standard output. Default is file.")
 	opt.AddFileFlags(cmd, &o.FileOption)
 	opt.AddDirectoryFlags(cmd, &o.DirectoryOption)
@@ -22,6 +22,7 @@ import (
 	"testing"
 
 	"github.com/perses/perses/internal/cli/config"
+	"github.com/perses/perses/internal/cli/file"
 	cmdTest "github.com/perses/perses/internal/cli/test"
 )
 
@@ -58,6 +59,39 @@ func TestDacBuildCMD_GoExtraArgsAreSplit(t *testing.T) {
 	}
 }
 
+func TestDacBuildCMD_GoMultipleResources(t *testing.T) {
+	for _, outputFormat := range []string{"yaml", "json"} {
+		t.Run(outputFormat, func(t *testing.T) {
+			buffer := strings.Builder{}
+			cmd := NewCMD()
+			cmd.SetOut(&buffer)
+			cmd.SetErr(&buffer)
+			cmd.SetArgs([]string{"-f", filepath.Join("testdata", "go", "resources", "main.go"), "-o", outputFormat})
+
+			config.Global = &config.Config{}
+			config.Global.Dac.OutputFolder = config.DefaultOutputFolder
+
+			if err := cmd.Execute(); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			outputFilePath := filepath.Join(config.DefaultOutputFolder, "testdata", "go", "resources", "main_output."+outputFormat)
+			entities, err := file.UnmarshalEntitiesFromFile(outputFilePath)
+			if err != nil {
+				t.Fatalf("unable to read the resources generated: %v", err)
+			}
+			var kinds []string
+			for _, entity := range entities {
+				kinds = append(kinds, entity.GetKind())
+			}
+			expectedKinds := []string{"Project", "Role", "RoleBinding", "Folder"}
+			if strings.Join(kinds, ",") != strings.Join(expectedKinds, ",") {
+				t.Fatalf("expected the kinds %v, got %v", expectedKinds, kinds)
+			}
+		})
+	}
+}
+
 func TestDacBuildCMD(t *testing.T) {
 	// os-specific separator is required for the tests that go through the code that calls filepath.Walk
 	separator := string(os.PathSeparator)
@@ -115,12 +149,13 @@ func TestDacBuildCMD(t *testing.T) {
 			Title:           "directory with non-main sub-packages skips them without error",
 			Args:            []string{"-d", filepath.Join("testdata", "go")},
 			IsErrorExpected: false,
-			// args/main.go and cmd/main.go are both package main and must be built;
+			// args/main.go, cmd/main.go and resources/main.go are package main and must be built;
 			// prometheus/query/*.go are package query and must be silently skipped.
 			ExpectedMessage: fmt.Sprintf(
-				"Successfully built %s at %s\nSuccessfully built %s at %s\n",
+				"Successfully built %s at %s\nSuccessfully built %s at %s\nSuccessfully built %s at %s\n",
 				filepath.Join("testdata", "go", "args", "main.go"), filepath.Join("built", "testdata", "go", "args", "main_output.yaml"),
 				filepath.Join("testdata", "go", "cmd", "main.go"), filepath.Join("built", "testdata", "go", "cmd", "main_output.yaml"),
+				filepath.Join("testdata", "go", "resources", "main.go"), filepath.Join("built", "testdata", "go", "resources", "main_output.yaml"),
 			),
 		},
 	}
@@ -173,3 +208,53 @@ func TestDacBuildCMD(t *testing.T) {
 	}
 	cmdTest.ExecuteSuiteTest(t, NewCMD, testSuiteCUE)
 }
+
+func TestDacBuildCMD_Jsonnet(t *testing.T) {
+	originalWD, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get working directory: %v", err)
+	}
+	// Change to the jsonnet test directory so the imports are searched in its vendor folder
+	if err := os.Chdir(filepath.Join("testdata", "jsonnet")); err != nil {
+		t.Fatalf("Failed to change directory: %v", err)
+	}
+	defer func() {
+		if err := os.Chdir(originalWD); err != nil {
+			t.Fatalf("Failed to change back to the original directory: %v", err)
+		}
+	}()
+
+	testSuite := []cmdTest.Suite{
+		{
+			Title:           "nominal case with a single jsonnet file",
+			Args:            []string{"-f", filepath.Join("valid", "dac.jsonnet")},
+			IsErrorExpected: false,
+			ExpectedMessage: fmt.Sprintf("Successfully built %s at %s\n", filepath.Join("valid", "dac.jsonnet"), filepath.Join("built", "valid", "dac_output.yaml")),
+		},
+		{
+			Title:           "libsonnet files are not built",
+			Args:            []string{"-d", "valid"},
+			IsErrorExpected: false,
+			ExpectedMessage: fmt.Sprintf("Successfully built %s at %s\n", filepath.Join("valid", "dac.jsonnet"), filepath.Join("built", "valid", "dac_output.yaml")),
+		},
+		{
+			Title:           "print on stdout as json",
+			Args:            []string{"-f", filepath.Join("valid", "dac.jsonnet"), "-m", "stdout", "-o", "json"},
+			IsErrorExpected: false,
+			ExpectedMessage: "{\"success\":true}\n",
+		},
+		{
+			Title:                "import not found in the library paths",
+			Args:                 []string{"-f", filepath.Join("valid", "dac.jsonnet"), "-J", "lib"},
+			IsErrorExpected:      true,
+			ExpectedRegexMessage: "couldn't open import \"mylib/main.libsonnet\": no match locally or in the Jsonnet library paths",
+		},
+		{
+			Title:                "invalid Jsonnet definition",
+			Args:                 []string{"-f", filepath.Join("invalid", "dac.jsonnet")},
+			IsErrorExpected:      true,
+			ExpectedRegexMessage: "Unknown variable: fals",
+		},
+	}
+	cmdTest.ExecuteSuiteTest(t, NewCMD, testSuite)
+}
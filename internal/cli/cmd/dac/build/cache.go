@@ -0,0 +1,149 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package build
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/perses/perses/internal/cli/cmd/dac/dependency"
+	"github.com/perses/perses/internal/cli/config"
+)
+
+// cacheFileName is the name of the file, in the output folder, storing the hash of the inputs of each dashboard built.
+const cacheFileName = ".build-cache.json"
+
+// buildCache maps each dashboard file to the hash of the inputs used the last time it was successfully built.
+type buildCache map[string]string
+
+func cachePath() string {
+	return filepath.Join(config.Global.Dac.OutputFolder, cacheFileName)
+}
+
+// loadCache returns the cache of the previous builds. A missing or corrupted cache is simply empty.
+func loadCache() buildCache {
+	cache := make(buildCache)
+	data, err := os.ReadFile(cachePath())
+	if err != nil {
+		return cache
+	}
+	if jsonErr := json.Unmarshal(data, &cache); jsonErr != nil {
+		return make(buildCache)
+	}
+	return cache
+}
+
+func (c buildCache) save() error {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return err
+	}
+	if mkdirErr := os.MkdirAll(config.Global.Dac.OutputFolder, 0750); mkdirErr != nil {
+		return mkdirErr
+	}
+	return os.WriteFile(cachePath(), data, 0644) // nolint: gosec
+}
+
+// isUpToDate returns true when the dashboard was built with the same inputs, and its output still exists.
+func (c buildCache) isUpToDate(file string, hash string, outputFilePath string) bool {
+	if c[file] != hash {
+		return false
+	}
+	_, err := os.Stat(outputFilePath)
+	return err == nil
+}
+
+// computeHash returns the hash of everything the output of the dashboard depends on:
+// the file itself, the local packages it imports (transitively), the module files pinning the remote dependencies,
+// the output format and the extra arguments.
+func (o *Option) computeHash(resolver *dependency.Resolver, file string) (string, error) {
+	deps := resolver.FindAllDependencies(file, make(map[string]bool))
+	files := make([]string, 0, len(deps))
+	for dep := range deps {
+		files = append(files, dep)
+	}
+	slices.Sort(files)
+	files = append([]string{file}, files...)
+	files = append(files, findModuleFiles(file)...)
+
+	h := sha256.New()
+	_, _ = fmt.Fprintf(h, "output=%s\nargs=%s\n", o.Output, strings.Join(o.args, "\x00"))
+	for _, f := range files {
+		content, err := os.ReadFile(f) //nolint: gosec
+		if err != nil {
+			return "", err
+		}
+		_, _ = fmt.Fprintf(h, "%s\n%d\n", filepath.ToSlash(f), len(content))
+		_, _ = h.Write(content)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// findModuleFiles returns the files describing the module of the DaC file: go.mod and go.sum for Go, cue.mod/module.cue for CUE,
+// jsonnetfile.json and jsonnetfile.lock.json (from jsonnet-bundler) for Jsonnet.
+func findModuleFiles(file string) []string {
+	var candidates []string
+	switch filepath.Ext(file) {
+	case goExtension:
+		root := findModuleRoot(filepath.Dir(file), "go.mod")
+		if len(root) == 0 {
+			return nil
+		}
+		candidates = []string{filepath.Join(root, "go.mod"), filepath.Join(root, "go.sum")}
+	case jsonnetExtension:
+		root := findModuleRoot(filepath.Dir(file), "jsonnetfile.json")
+		if len(root) == 0 {
+			return nil
+		}
+		candidates = []string{filepath.Join(root, "jsonnetfile.json"), filepath.Join(root, "jsonnetfile.lock.json")}
+	default:
+		root := findModuleRoot(filepath.Dir(file), filepath.Join("cue.mod", "module.cue"))
+		if len(root) == 0 {
+			return nil
+		}
+		candidates = []string{filepath.Join(root, "cue.mod", "module.cue")}
+	}
+	var result []string
+	for _, candidate := range candidates {
+		if _, err := os.Stat(candidate); err == nil {
+			result = append(result, candidate)
+		}
+	}
+	return result
+}
+
+// findModuleRoot returns the closest directory, starting from dir and going up, containing the given marker file.
+// It returns an empty string when there is none.
+func findModuleRoot(dir string, marker string) string {
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return ""
+	}
+	for {
+		if _, statErr := os.Stat(filepath.Join(absDir, marker)); statErr == nil {
+			return absDir
+		}
+		parent := filepath.Dir(absDir)
+		if parent == absDir {
+			return ""
+		}
+		absDir = parent
+	}
+}
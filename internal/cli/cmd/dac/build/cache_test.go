@@ -0,0 +1,112 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package build
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/perses/perses/internal/cli/cmd/dac/dependency"
+	"github.com/perses/perses/internal/cli/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFile(t *testing.T, path string, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0750))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+}
+
+func TestComputeHash(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "go.mod"), "module dac.example.com/m\n\ngo 1.23\n")
+	dashboard := filepath.Join(dir, "dashboards", "kafka", "main.go")
+	writeFile(t, dashboard, "package main\n\nimport _ \"dac.example.com/m/panels\"\n\nfunc main() {}\n")
+	library := filepath.Join(dir, "panels", "panels.go")
+	writeFile(t, library, "package panels\n")
+	unrelated := filepath.Join(dir, "variables", "variables.go")
+	writeFile(t, unrelated, "package variables\n")
+
+	resolver := &dependency.Resolver{SourceDir: dir, ModulePaths: dependency.DetectModulePaths(dir)}
+	o := &Option{}
+	o.Output = "yaml"
+	hash, err := o.computeHash(resolver, dashboard)
+	require.NoError(t, err)
+
+	// A file the dashboard doesn't import has no impact.
+	writeFile(t, unrelated, "package variables\n\nconst Name = \"job\"\n")
+	sameHash, err := o.computeHash(resolver, dashboard)
+	require.NoError(t, err)
+	assert.Equal(t, hash, sameHash)
+
+	// The output format is part of the inputs.
+	o.Output = "json"
+	jsonHash, err := o.computeHash(resolver, dashboard)
+	require.NoError(t, err)
+	assert.NotEqual(t, hash, jsonHash)
+	o.Output = "yaml"
+
+	// So are the local packages imported, and the module files.
+	writeFile(t, library, "package panels\n\nconst Title = \"lag\"\n")
+	libraryHash, err := o.computeHash(resolver, dashboard)
+	require.NoError(t, err)
+	assert.NotEqual(t, hash, libraryHash)
+
+	writeFile(t, filepath.Join(dir, "go.sum"), "dac.example.com/dep v1.0.0 h1:abc=\n")
+	moduleHash, err := o.computeHash(resolver, dashboard)
+	require.NoError(t, err)
+	assert.NotEqual(t, libraryHash, moduleHash)
+}
+
+func TestBuildCache(t *testing.T) {
+	config.Global = &config.Config{}
+	config.Global.Dac.OutputFolder = filepath.Join(t.TempDir(), "built")
+	outputFile := filepath.Join(config.Global.Dac.OutputFolder, "kafka_output.yaml")
+
+	cache := loadCache()
+	assert.Empty(t, cache)
+	cache["kafka.cue"] = "hash"
+	require.NoError(t, cache.save())
+
+	cache = loadCache()
+	assert.Equal(t, buildCache{"kafka.cue": "hash"}, cache)
+	// The output must still exist to skip the build.
+	assert.False(t, cache.isUpToDate("kafka.cue", "hash", outputFile))
+	writeFile(t, outputFile, "kind: Dashboard\n")
+	assert.True(t, cache.isUpToDate("kafka.cue", "hash", outputFile))
+	assert.False(t, cache.isUpToDate("kafka.cue", "another-hash", outputFile))
+	assert.False(t, cache.isUpToDate("lag.cue", "hash", outputFile))
+}
+
+func TestSplitByBinaryName(t *testing.T) {
+	root, err := filepath.Abs("testdata")
+	require.NoError(t, err)
+	batches := splitByBinaryName(root, []string{
+		filepath.Join("testdata", "kafka", "cmd", "main.go"),
+		filepath.Join("testdata", "redis", "cmd", "main.go"),
+		filepath.Join("testdata", "redis", "main.go"),
+		filepath.Join("testdata", "main.go"),
+	})
+	assert.Equal(t, []map[string]string{
+		{
+			filepath.Join("testdata", "kafka", "cmd", "main.go"): "./kafka/cmd",
+			filepath.Join("testdata", "redis", "main.go"):        "./redis",
+		},
+		{
+			filepath.Join("testdata", "redis", "cmd", "main.go"): "./redis/cmd",
+		},
+	}, batches)
+}
@@ -0,0 +1,162 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package build
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// compileGoDashboards compiles the Go dashboards of a same module with a single `go build`, instead of one `go run` per dashboard.
+// It returns the binary of each compiled dashboard, and the function removing them once the build is over.
+//
+// A dashboard is only compiled when it is alone in its directory, so the package being built is exactly the file `go run` would run.
+// The other dashboards, or the ones of a module failing to compile, are simply run with `go run`, which also provides an error per dashboard.
+func compileGoDashboards(files []string) (map[string]string, func()) {
+	binaries := make(map[string]string)
+	noop := func() {}
+
+	// Group the dashboards per module.
+	modules := make(map[string][]string)
+	for _, file := range files {
+		if filepath.Ext(file) != goExtension || !isAloneInDirectory(file) {
+			continue
+		}
+		root := findModuleRoot(filepath.Dir(file), "go.mod")
+		if len(root) == 0 {
+			continue
+		}
+		modules[root] = append(modules[root], file)
+	}
+
+	tmpDir := ""
+	for root, moduleFiles := range modules {
+		// Compiling is only worth it when the compilation is shared by several dashboards.
+		if len(moduleFiles) < 2 {
+			continue
+		}
+		if len(tmpDir) == 0 {
+			var err error
+			if tmpDir, err = os.MkdirTemp("", "percli-dac-build-"); err != nil {
+				logrus.WithError(err).Debug("unable to create the folder storing the compiled dashboards, falling back to go run")
+				return binaries, noop
+			}
+		}
+		for _, batch := range splitByBinaryName(root, moduleFiles) {
+			outputDir, err := os.MkdirTemp(tmpDir, "bin-")
+			if err != nil {
+				logrus.WithError(err).Debug("unable to create the folder storing the compiled dashboards, falling back to go run")
+				continue
+			}
+			if buildErr := buildPackages(root, outputDir, batch); buildErr != nil {
+				logrus.WithError(buildErr).Debugf("unable to compile the dashboards of the module %q, falling back to go run", root)
+				continue
+			}
+			for file, pkg := range batch {
+				binary := filepath.Join(outputDir, binaryName(pkg))
+				if runtime.GOOS == "windows" {
+					binary += ".exe"
+				}
+				binaries[file] = binary
+			}
+		}
+	}
+	if len(tmpDir) == 0 {
+		return binaries, noop
+	}
+	return binaries, func() {
+		if err := os.RemoveAll(tmpDir); err != nil {
+			logrus.WithError(err).Warnf("unable to remove the compiled dashboards in %q", tmpDir)
+		}
+	}
+}
+
+// isAloneInDirectory returns true when the file is the only Go file, besides the tests, of its directory.
+func isAloneInDirectory(file string) bool {
+	entries, err := os.ReadDir(filepath.Dir(file))
+	if err != nil {
+		return false
+	}
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || filepath.Ext(name) != goExtension || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		if name != filepath.Base(file) {
+			return false
+		}
+	}
+	return true
+}
+
+// splitByBinaryName returns the packages to build, per file, in batches where each binary name is unique,
+// as `go build -o <dir>/` names each binary after the last element of its package path.
+// The dashboards at the root of the module are left aside, since their binary is named after the module.
+func splitByBinaryName(root string, files []string) []map[string]string {
+	var batches []map[string]string
+	var names []map[string]bool
+	for _, file := range files {
+		absDir, err := filepath.Abs(filepath.Dir(file))
+		if err != nil {
+			continue
+		}
+		rel, err := filepath.Rel(root, absDir)
+		if err != nil || rel == "." {
+			continue
+		}
+		pkg := "./" + filepath.ToSlash(rel)
+		name := binaryName(pkg)
+		// Use the first batch not containing a binary with the same name yet.
+		i := slices.IndexFunc(names, func(batchNames map[string]bool) bool {
+			return !batchNames[name]
+		})
+		if i < 0 {
+			batches = append(batches, make(map[string]string))
+			names = append(names, make(map[string]bool))
+			i = len(batches) - 1
+		}
+		batches[i][file] = pkg
+		names[i][name] = true
+	}
+	return batches
+}
+
+func binaryName(pkg string) string {
+	return pkg[strings.LastIndex(pkg, "/")+1:]
+}
+
+// buildPackages compiles the packages of the module in the output directory.
+func buildPackages(root string, outputDir string, packages map[string]string) error {
+	args := []string{"build", "-o", outputDir + string(os.PathSeparator)}
+	for _, pkg := range packages {
+		args = append(args, pkg)
+	}
+	cmd := exec.Command("go", args...) // #nosec
+	cmd.Dir = root
+	if _, err := cmd.Output(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return errors.New(string(exitErr.Stderr))
+		}
+		return err
+	}
+	return nil
+}
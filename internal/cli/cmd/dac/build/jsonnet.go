@@ -0,0 +1,43 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package build
+
+import (
+	"encoding/json"
+
+	"github.com/google/go-jsonnet"
+	"github.com/perses/perses/internal/cli/output"
+	"gopkg.in/yaml.v3"
+)
+
+// evaluateJsonnet evaluates the Jsonnet file with the embedded VM and returns the result in the output format.
+func (o *Option) evaluateJsonnet(file string) ([]byte, error) {
+	vm := jsonnet.MakeVM()
+	vm.Importer(&jsonnet.FileImporter{JPaths: o.JPaths})
+	result, err := vm.EvaluateFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	// The VM always produces JSON, so it is decoded to be encoded again in the expected format,
+	// the same way the Go SDK does it.
+	var data any
+	if unmarshalErr := json.Unmarshal([]byte(result), &data); unmarshalErr != nil {
+		return nil, unmarshalErr
+	}
+	if o.Output == output.JSONOutput {
+		return json.Marshal(data)
+	}
+	return yaml.Marshal(data)
+}
@@ -0,0 +1,49 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"flag"
+
+	"github.com/perses/perses/go-sdk"
+	"github.com/perses/perses/go-sdk/folder"
+	"github.com/perses/perses/go-sdk/project"
+	"github.com/perses/perses/go-sdk/role"
+	rolebinding "github.com/perses/perses/go-sdk/role-binding"
+	roleModel "github.com/perses/perses/pkg/model/api/v1/role"
+)
+
+func main() {
+	flag.Parse()
+	exec := sdk.NewExec()
+
+	exec.AddResource(project.New("myproject",
+		project.DisplayName("My project"),
+	))
+	exec.AddResource(role.New("viewer",
+		role.ProjectName("myproject"),
+		role.AddPermission([]roleModel.Action{roleModel.ReadAction}, []roleModel.Scope{roleModel.WildcardScope}),
+	))
+	exec.AddResource(rolebinding.New("viewers",
+		rolebinding.ProjectName("myproject"),
+		rolebinding.Role("viewer"),
+		rolebinding.AddUser("alice", "bob"),
+	))
+	exec.AddResource(folder.New("infra",
+		folder.ProjectName("myproject"),
+		folder.AddDashboard("nodes"),
+		folder.AddFolder("network", folder.DashboardItem("traffic")),
+	))
+	exec.BuildResources()
+}
@@ -0,0 +1,3 @@
+{
+  success: true,
+}
@@ -16,8 +16,11 @@ package dac
 import (
 	"github.com/perses/perses/internal/cli/cmd/dac/build"
 	"github.com/perses/perses/internal/cli/cmd/dac/diff"
+	"github.com/perses/perses/internal/cli/cmd/dac/importer"
 	"github.com/perses/perses/internal/cli/cmd/dac/preview"
 	"github.com/perses/perses/internal/cli/cmd/dac/setup"
+	"github.com/perses/perses/internal/cli/cmd/dac/sync"
+	"github.com/perses/perses/internal/cli/cmd/dac/test"
 	"github.com/perses/perses/internal/cli/cmd/dac/watch"
 	"github.com/perses/perses/internal/cli/config"
 	"github.com/spf13/cobra"
@@ -36,8 +39,11 @@ func NewCMD() *cobra.Command {
 	}
 	cmd.AddCommand(build.NewCMD())
 	cmd.AddCommand(diff.NewCMD())
+	cmd.AddCommand(importer.NewCMD())
 	cmd.AddCommand(preview.NewCMD())
 	cmd.AddCommand(setup.NewCMD())
+	cmd.AddCommand(sync.NewCMD())
+	cmd.AddCommand(test.NewCMD())
 	cmd.AddCommand(watch.NewCMD())
 
 	cmd.PersistentFlags().StringVar(&dacOutputFolder, "dac.output_folder", config.DefaultOutputFolder, "Path to the folder where the dac-generated files are stored.")
@@ -0,0 +1,387 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package dependency finds the local files a DaC file depends on, by following its Go, CUE or Jsonnet imports.
+// It is shared by the `build` command, to know when a dashboard must be built again, and the `watch` command,
+// to know which dashboards are affected by a change.
+package dependency
+
+import (
+	"cmp"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	cueparser "cuelang.org/go/cue/parser"
+	cuemodfile "cuelang.org/go/mod/modfile"
+	"github.com/google/go-jsonnet/ast"
+	"github.com/google/go-jsonnet/formatter"
+	"github.com/google/go-jsonnet/toolutils"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/mod/modfile"
+)
+
+const (
+	GoFileExt        = ".go"
+	CueFileExt       = ".cue"
+	JsonnetFileExt   = ".jsonnet"
+	LibsonnetFileExt = ".libsonnet"
+	// JsonnetVendorDir is the default library path of the Jsonnet imports.
+	// It is where jsonnet-bundler vendors the libraries, and where `dac setup` vendors the Perses library.
+	JsonnetVendorDir = "vendor"
+)
+
+// IsSupportedFile checks if a file is written in one of the languages supported for DaC
+func IsSupportedFile(file string) bool {
+	switch filepath.Ext(file) {
+	case GoFileExt, CueFileExt, JsonnetFileExt, LibsonnetFileExt:
+		return true
+	}
+	return false
+}
+
+func isJsonnetFile(file string) bool {
+	ext := filepath.Ext(file)
+	return ext == JsonnetFileExt || ext == LibsonnetFileExt
+}
+
+// DetectModulePaths finds module paths for both Go and CUE projects.
+// This information is used to determine if an import is local (part of the project).
+func DetectModulePaths(sourceDir string) []string {
+	var paths []string
+
+	// Check for go.mod
+	goModPath := filepath.Join(sourceDir, "go.mod")
+	if data, err := os.ReadFile(goModPath); err == nil { //nolint: gosec
+		if parsed, err := modfile.Parse(goModPath, data, nil); err == nil && parsed.Module != nil {
+			paths = append(paths, parsed.Module.Mod.Path)
+		}
+	}
+
+	// Check for cue.mod/module.cue
+	cueModPath := filepath.Join(sourceDir, "cue.mod", "module.cue")
+	if data, err := os.ReadFile(cueModPath); err == nil { //nolint: gosec
+		if parsed, err := cuemodfile.Parse(data, cueModPath); err == nil {
+			paths = append(paths, parsed.Module)
+		}
+	}
+
+	return paths
+}
+
+// Resolver resolves the imports of the DaC files located in SourceDir.
+type Resolver struct {
+	SourceDir string
+	// ModulePaths are the module paths from go.mod and cue.mod, used to identify the local imports.
+	ModulePaths []string
+	// SkipDirectory returns true for the directories that must not be considered. It can be nil.
+	SkipDirectory func(path string) bool
+	// JPaths are the library paths where the Jsonnet imports are searched when they are not relative to the importing file.
+	JPaths []string
+}
+
+func (r *Resolver) shouldSkipDirectory(path string) bool {
+	return r.SkipDirectory != nil && r.SkipDirectory(path)
+}
+
+// IsLocalImport checks if an import path belongs to this project (not a remote dependency)
+func (r *Resolver) IsLocalImport(importPath string) bool {
+	// If no module paths detected, fall back to checking all imports (legacy behavior)
+	if len(r.ModulePaths) == 0 {
+		return true
+	}
+
+	// Check if the import starts with any of our module paths
+	for _, modulePath := range r.ModulePaths {
+		if strings.HasPrefix(importPath, modulePath+"/") || importPath == modulePath {
+			return true
+		}
+	}
+
+	return false
+}
+
+// FindAllDependencies recursively finds all dependencies (direct + transitive) for a file
+func (r *Resolver) FindAllDependencies(file string, visited map[string]bool) map[string]bool {
+	deps := make(map[string]bool)
+
+	// Prevent infinite loops from circular imports
+	if visited[file] {
+		return deps
+	}
+	visited[file] = true
+
+	// Get direct imports
+	imports := ParseImports(file)
+
+	for _, importPath := range imports {
+		var libraryFiles []string
+		if isJsonnetFile(file) {
+			libraryFiles = r.ResolveJsonnetImport(file, importPath)
+		} else {
+			libraryFiles = r.ResolveImportToFiles(importPath)
+		}
+
+		for _, libFile := range libraryFiles {
+			// Add this library file
+			deps[libFile] = true
+
+			// Recursively get its dependencies (transitive)
+			transitiveDeps := r.FindAllDependencies(libFile, visited)
+			for transFile := range transitiveDeps {
+				deps[transFile] = true
+			}
+		}
+	}
+
+	return deps
+}
+
+// ResolveImportToFiles attempts to resolve an import path to actual library files
+func (r *Resolver) ResolveImportToFiles(importPath string) []string {
+	// Skip remote/external imports - we only track local project files
+	if !r.IsLocalImport(importPath) {
+		return nil
+	}
+
+	var files []string
+
+	// For local imports, we need to find the directory that matches the import path
+	// Import examples:
+	//   "dac-example.com/m/mylibrary/variables" -> should match mylibrary/variables/
+	//   "dac-example.com/m/mylibrary/panels" -> should match mylibrary/panels/
+
+	// Look for directories matching the import in the source tree
+	if err := filepath.Walk(r.SourceDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return nil
+		}
+		if !info.IsDir() {
+			return nil
+		}
+
+		if r.shouldSkipDirectory(path) {
+			return filepath.SkipDir
+		}
+
+		// Get relative path from source directory
+		relPath, err := filepath.Rel(r.SourceDir, path)
+		if err != nil {
+			return nil
+		}
+
+		// Normalize path separators for comparison
+		relPathNormalized := filepath.ToSlash(relPath)
+
+		// Check if the import path ends with this relative path
+		// This ensures we match the exact directory structure
+		if strings.HasSuffix(importPath, relPathNormalized) ||
+			strings.HasSuffix(importPath, "/"+relPathNormalized) {
+			// Add all .go and .cue files from this directory (non-recursive)
+			entries, err := os.ReadDir(path)
+			if err != nil {
+				return nil
+			}
+			for _, entry := range entries {
+				if entry.IsDir() {
+					continue
+				}
+				filePath := filepath.Join(path, entry.Name())
+				ext := filepath.Ext(filePath)
+				if (ext == GoFileExt || ext == CueFileExt) && !IsDashboardFile(filePath) {
+					files = append(files, filePath)
+				}
+			}
+			return filepath.SkipDir
+		}
+		return nil
+	}); err != nil {
+		logrus.Warnf("Failed to resolve import %s: %v", importPath, err)
+	}
+
+	return files
+}
+
+// ResolveJsonnetImport resolves a Jsonnet import the same way the Jsonnet VM does:
+// first relatively to the importing file, then in each library path.
+func (r *Resolver) ResolveJsonnetImport(importedFrom string, importPath string) []string {
+	dirs := append([]string{filepath.Dir(importedFrom)}, r.JPaths...)
+	if filepath.IsAbs(importPath) {
+		dirs = []string{""}
+	}
+	for _, dir := range dirs {
+		path := filepath.Join(dir, importPath)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return []string{path}
+		}
+	}
+	return nil
+}
+
+// ParseImports extracts import paths from a Go, CUE or Jsonnet file
+func ParseImports(filePath string) []string {
+	ext := filepath.Ext(filePath)
+	switch ext {
+	case GoFileExt:
+		return ParseGoImports(filePath)
+	case CueFileExt:
+		return ParseCueImports(filePath)
+	case JsonnetFileExt, LibsonnetFileExt:
+		return ParseJsonnetImports(filePath)
+	}
+	return nil
+}
+
+// ParseGoImports parses import statements from a Go file
+func ParseGoImports(filePath string) []string {
+	fset := token.NewFileSet()
+	node, err := parser.ParseFile(fset, filePath, nil, parser.ImportsOnly)
+	if err != nil {
+		return nil
+	}
+
+	var imports []string
+	for _, imp := range node.Imports {
+		importPath := strings.Trim(imp.Path.Value, `"`)
+		imports = append(imports, importPath)
+	}
+	return imports
+}
+
+// ParseCueImports parses import statements from a CUE file
+func ParseCueImports(filePath string) []string {
+	content, err := os.ReadFile(filePath) //nolint: gosec
+	if err != nil {
+		return nil
+	}
+
+	// Parse the CUE file using the official parser
+	file, err := cueparser.ParseFile(filePath, content)
+	if err != nil {
+		return nil
+	}
+
+	var imports []string
+	for imp := range file.ImportSpecs() {
+		if imp.Path != nil {
+			// Import path includes quotes, so we need to trim them
+			importPath := strings.Trim(imp.Path.Value, `"`)
+			imports = append(imports, importPath)
+		}
+	}
+	return imports
+}
+
+// ParseJsonnetImports parses the import, importstr and importbin expressions from a Jsonnet file
+func ParseJsonnetImports(filePath string) []string {
+	content, err := os.ReadFile(filePath) //nolint: gosec
+	if err != nil {
+		return nil
+	}
+
+	node, _, err := formatter.SnippetToRawAST(filePath, string(content))
+	if err != nil {
+		return nil
+	}
+
+	var importNodes []*ast.LiteralString
+	var walk func(node ast.Node)
+	walk = func(node ast.Node) {
+		if node == nil {
+			return
+		}
+		switch imp := node.(type) {
+		case *ast.Import:
+			importNodes = append(importNodes, imp.File)
+		case *ast.ImportStr:
+			importNodes = append(importNodes, imp.File)
+		case *ast.ImportBin:
+			importNodes = append(importNodes, imp.File)
+		}
+		for _, child := range toolutils.Children(node) {
+			walk(child)
+		}
+	}
+	walk(node)
+
+	// The children of a node are not visited in the order they appear in the file
+	slices.SortFunc(importNodes, func(a, b *ast.LiteralString) int {
+		return cmp.Or(cmp.Compare(a.Loc().Begin.Line, b.Loc().Begin.Line), cmp.Compare(a.Loc().Begin.Column, b.Loc().Begin.Column))
+	})
+	var imports []string
+	for _, imp := range importNodes {
+		imports = append(imports, imp.Value)
+	}
+	return imports
+}
+
+// IsDashboardFile checks if a file is a dashboard file that should be built directly
+func IsDashboardFile(file string) bool {
+	ext := filepath.Ext(file)
+
+	switch ext {
+	case GoFileExt:
+		return IsGoDashboardFile(file)
+	case CueFileExt:
+		return IsCueDashboardFile(file)
+	case JsonnetFileExt:
+		// By convention, a .jsonnet file is an entrypoint while a .libsonnet file is a library.
+		return true
+	}
+
+	return false // Unknown files are not dashboards by default
+}
+
+// IsGoDashboardFile checks if a Go file is to be considered a dashboard.
+// It simply checks if the package is "main"
+func IsGoDashboardFile(file string) bool {
+	fset := token.NewFileSet()
+	node, err := parser.ParseFile(fset, file, nil, parser.PackageClauseOnly)
+	if err != nil {
+		return false // Can't parse = not a dashboard
+	}
+
+	// Go dashboards MUST be "package main"
+	return node.Name.Name == "main"
+}
+
+// IsCueDashboardFile checks if a CUE file is to be considered a dashboard
+// No 100% reliable way here, thus we take a heuristic approach based on import patterns and filename
+func IsCueDashboardFile(file string) bool {
+	content, err := os.ReadFile(file) //nolint: gosec
+	if err != nil {
+		return false
+	}
+
+	parsed, err := cueparser.ParseFile(file, content)
+	if err != nil {
+		return false
+	}
+
+	// Strategy 1: Check for named import "dashboardBuilder"
+	for imp := range parsed.ImportSpecs() {
+		if imp.Name != nil && imp.Name.String() == "dashboardBuilder" {
+			return true
+		}
+	}
+
+	// Strategy 2: Check if filename contains "dashboard"
+	if strings.Contains(strings.ToLower(filepath.Base(file)), "dashboard") {
+		return true
+	}
+
+	return false
+}
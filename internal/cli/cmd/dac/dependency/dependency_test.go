@@ -0,0 +1,134 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dependency
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestParseGoImports(t *testing.T) {
+	tests := []struct {
+		name     string
+		file     string
+		expected []string
+	}{
+		{
+			name: "dashboard with single import",
+			file: filepath.Join("testdata", "go-simple", "dashboards", "dash1", "main.go"),
+			expected: []string{
+				"test-dac.com/m/mylibrary",
+			},
+		},
+		{
+			name: "library with nested import",
+			file: filepath.Join("testdata", "go-simple", "mylibrary", "lib.go"),
+			expected: []string{
+				"test-dac.com/m/mylibrary/nested",
+			},
+		},
+		{
+			name:     "nested library with no imports",
+			file:     filepath.Join("testdata", "go-simple", "mylibrary", "nested", "deep.go"),
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ParseGoImports(tt.file)
+			if !slices.Equal(result, tt.expected) {
+				t.Errorf("parseGoImports(%s) = %v, expected %v", tt.file, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseCueImports(t *testing.T) {
+	tests := []struct {
+		name     string
+		file     string
+		expected []string
+	}{
+		{
+			name: "dashboard with multiple imports",
+			file: filepath.Join("testdata", "cue-simple", "dashboards", "dash1.cue"),
+			expected: []string{
+				"github.com/perses/perses/cue/dac-utils/dashboard",
+				"test-dac-cue.com/m/panels",
+			},
+		},
+		{
+			name: "dashboard with single import",
+			file: filepath.Join("testdata", "cue-simple", "dashboards", "my-dashboard.cue"),
+			expected: []string{
+				"test-dac-cue.com/m/panels",
+			},
+		},
+		{
+			name:     "library with no imports",
+			file:     filepath.Join("testdata", "cue-simple", "panels", "panel.cue"),
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ParseCueImports(tt.file)
+			if !slices.Equal(result, tt.expected) {
+				t.Errorf("parseCueImports(%s) = %v, expected %v", tt.file, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseJsonnetImports(t *testing.T) {
+	tests := []struct {
+		name     string
+		file     string
+		expected []string
+	}{
+		{
+			name: "dashboard with multiple imports",
+			file: filepath.Join("testdata", "jsonnet-simple", "dashboards", "dash1.jsonnet"),
+			expected: []string{
+				"perses/main.libsonnet",
+				"../lib/panels.libsonnet",
+			},
+		},
+		{
+			name: "dashboard with import and importstr",
+			file: filepath.Join("testdata", "jsonnet-simple", "dashboards", "dash2.jsonnet"),
+			expected: []string{
+				"../lib/panels.libsonnet",
+				"../lib/description.txt",
+			},
+		},
+		{
+			name:     "library with no imports",
+			file:     filepath.Join("testdata", "jsonnet-simple", "lib", "common.libsonnet"),
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ParseJsonnetImports(tt.file)
+			if !slices.Equal(result, tt.expected) {
+				t.Errorf("parseJsonnetImports(%s) = %v, expected %v", tt.file, result, tt.expected)
+			}
+		})
+	}
+}
@@ -50,7 +50,8 @@ func marshalIndent(dashboard *modelV1.Dashboard) ([]byte, error) {
 	return json.MarshalIndent(dashboard.Spec, "", "  ")
 }
 
-func dashboardDiff(previous, after *modelV1.Dashboard) (string, error) {
+// DashboardDiff returns the diff between the spec of the two given dashboards.
+func DashboardDiff(previous, after *modelV1.Dashboard) (string, error) {
 	previousJSON, err := marshalIndent(previous)
 	if err != nil {
 		return "", err
@@ -134,7 +135,7 @@ func (o *option) processDashboardDiff(updatedDashboard *modelV1.Dashboard, proje
 		return statusError, ""
 	}
 
-	diff, err := dashboardDiff(currentDashboard, updatedDashboard)
+	diff, err := DashboardDiff(currentDashboard, updatedDashboard)
 	if err != nil {
 		logrus.WithError(err).Warningf("Diff generation failed for dashboard %s in project %s", updatedDashboard.Metadata.Name, project)
 		return statusError, ""
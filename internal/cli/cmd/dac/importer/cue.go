@@ -0,0 +1,338 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package importer
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"cuelang.org/go/cue/format"
+)
+
+var cueImports = map[string]string{
+	"dashboardBuilder":   `dashboardBuilder "github.com/perses/perses/cue/dac-utils/dashboard"`,
+	"panelGroupBuilder":  `panelGroupBuilder "github.com/perses/perses/cue/dac-utils/panelgroup"`,
+	"panelGroupsBuilder": `panelGroupsBuilder "github.com/perses/perses/cue/dac-utils/panelgroups"`,
+	"varGroupBuilder":    `varGroupBuilder "github.com/perses/perses/cue/dac-utils/variable/group"`,
+	"listVarBuilder":     `listVarBuilder "github.com/perses/perses/cue/dac-utils/variable/list"`,
+	"textVarBuilder":     `textVarBuilder "github.com/perses/perses/cue/dac-utils/variable/text"`,
+}
+
+// cueGenerator writes the CUE code building a dashboard with the CUE SDK.
+// The plugin specs and the panels are written as JSON, which is valid CUE. The final formatting takes care of making it idiomatic.
+type cueGenerator struct {
+	body    bytes.Buffer
+	imports map[string]bool
+}
+
+func (g *cueGenerator) use(pkg string) string {
+	g.imports[pkg] = true
+	return pkg
+}
+
+func (g *cueGenerator) printf(format string, args ...any) {
+	fmt.Fprintf(&g.body, format, args...)
+}
+
+// generateCUE returns the CUE file building the given dashboard with the CUE SDK.
+func generateCUE(d *importDashboard) ([]byte, error) {
+	g := &cueGenerator{imports: make(map[string]bool)}
+	if err := g.dashboard(d); err != nil {
+		return nil, err
+	}
+
+	var buffer bytes.Buffer
+	fmt.Fprintf(&buffer, "package %s\n\nimport (\n", defaultCUEPackage)
+	for _, pkg := range sortedKeys(g.imports) {
+		fmt.Fprintf(&buffer, "\t%s\n", cueImports[pkg])
+	}
+	buffer.WriteString(")\n\n")
+	buffer.Write(g.body.Bytes())
+	return format.Source(buffer.Bytes(), format.Simplify())
+}
+
+func (g *cueGenerator) dashboard(d *importDashboard) error {
+	if len(d.Spec.Variables) > 0 {
+		if err := g.variables(d.Spec.Variables); err != nil {
+			return err
+		}
+	}
+
+	g.printf("%s & {\n", g.use("dashboardBuilder"))
+	g.printf("#name: %s\n", cueString(d.Metadata.Name))
+	if len(d.Metadata.Project) > 0 {
+		g.printf("#project: %s\n", cueString(d.Metadata.Project))
+	}
+	if d.Spec.Display != nil && (len(d.Spec.Display.Name) > 0 || len(d.Spec.Display.Description) > 0) {
+		g.printf("#display: {\n")
+		if len(d.Spec.Display.Name) > 0 {
+			g.printf("name: %s\n", cueString(d.Spec.Display.Name))
+		}
+		if len(d.Spec.Display.Description) > 0 {
+			g.printf("description: %s\n", cueString(d.Spec.Display.Description))
+		}
+		g.printf("}\n")
+	}
+	if len(d.Spec.Duration) > 0 {
+		g.printf("#duration: %s\n", cueString(d.Spec.Duration))
+	}
+	if len(d.Spec.RefreshInterval) > 0 {
+		g.printf("#refreshInterval: %s\n", cueString(d.Spec.RefreshInterval))
+	}
+	if len(d.Spec.Datasources) > 0 {
+		g.printf("#datasources: {\n")
+		for _, name := range sortedKeys(d.Spec.Datasources) {
+			dts, err := rawCUEValue(d.Spec.Datasources[name])
+			if err != nil {
+				return err
+			}
+			g.printf("%s: %s\n", cueString(name), dts)
+		}
+		g.printf("}\n")
+	}
+	if len(d.Spec.Variables) > 0 {
+		g.printf("#variables: #variables.variables\n")
+	}
+
+	groups, err := d.panelGroups()
+	if err != nil {
+		return err
+	}
+	if err := g.panelGroups(groups); err != nil {
+		return err
+	}
+	g.printf("}\n")
+	return nil
+}
+
+func (g *cueGenerator) variables(variables []importVariable) error {
+	g.printf("#variables: %s & {\n#input: [\n", g.use("varGroupBuilder"))
+	for _, v := range variables {
+		switch v.Kind {
+		case textVariableKind:
+			g.printf("%s & {\n", g.use("textVarBuilder"))
+			g.printf("#name: %s\n", cueString(v.Spec.Name))
+			g.variableDisplay(v.Spec.Display)
+			g.printf("#value: %s\n", cueString(v.Spec.Value))
+			if v.Spec.Constant {
+				g.printf("#constant: true\n")
+			}
+		case listVariableKind:
+			g.printf("%s & {\n", g.use("listVarBuilder"))
+			g.printf("#name: %s\n", cueString(v.Spec.Name))
+			g.variableDisplay(v.Spec.Display)
+			g.printf("#pluginKind: %s\n", cueString(v.Spec.Plugin.Kind))
+			if v.Spec.AllowAllValue {
+				g.printf("#allowAllValue: true\n")
+			}
+			if v.Spec.AllowMultiple {
+				g.printf("#allowMultiple: true\n")
+			}
+			if len(v.Spec.CustomAllValue) > 0 {
+				g.printf("#customAllValue: %s\n", cueString(v.Spec.CustomAllValue))
+			}
+			if len(v.Spec.CapturingRegexp) > 0 {
+				g.printf("#capturingRegexp: %s\n", cueString(v.Spec.CapturingRegexp))
+			}
+			if len(v.Spec.Sort) > 0 {
+				g.printf("#sort: %s\n", cueString(v.Spec.Sort))
+			}
+			// The list variable builder doesn't provide any parameter for the default value and the plugin spec,
+			// so they are set directly on the variable built.
+			var spec []string
+			if v.Spec.DefaultValue != nil {
+				defaultValue, err := cueValue(v.Spec.DefaultValue)
+				if err != nil {
+					return err
+				}
+				spec = append(spec, fmt.Sprintf("defaultValue: %s", defaultValue))
+			}
+			if v.Spec.Plugin.Spec != nil {
+				pluginSpec, err := cueValue(v.Spec.Plugin.Spec)
+				if err != nil {
+					return err
+				}
+				spec = append(spec, fmt.Sprintf("plugin: spec: %s", pluginSpec))
+			}
+			if len(spec) > 0 {
+				g.printf("variable: spec: {\n%s\n}\n", strings.Join(spec, "\n"))
+			}
+		default:
+			return fmt.Errorf("variable %q: kind %q not supported", v.Spec.Name, v.Kind)
+		}
+		g.printf("},\n")
+	}
+	g.printf("]\n}\n\n")
+	return nil
+}
+
+func (g *cueGenerator) variableDisplay(display *importDisplay) {
+	if display == nil || (len(display.Name) == 0 && len(display.Description) == 0 && !display.Hidden) {
+		return
+	}
+	g.printf("#display: {\n")
+	if len(display.Name) > 0 {
+		g.printf("name: %s\n", cueString(display.Name))
+	}
+	if len(display.Description) > 0 {
+		g.printf("description: %s\n", cueString(display.Description))
+	}
+	if display.Hidden {
+		g.printf("hidden: true\n")
+	}
+	g.printf("}\n")
+}
+
+// panelGroups writes the panel groups. When every group is regular, the panel groups builder is used.
+// Otherwise, the panel groups are indexed manually, so the irregular ones can be written with their own layout.
+func (g *cueGenerator) panelGroups(groups []panelGroup) error {
+	if len(groups) == 0 {
+		return nil
+	}
+	allRegular := true
+	for _, group := range groups {
+		allRegular = allRegular && group.isRegular
+	}
+	if allRegular {
+		g.printf("#panelGroups: %s & {\n#input: [\n", g.use("panelGroupsBuilder"))
+		for _, group := range groups {
+			g.printf("{\n")
+			if err := g.regularPanelGroup(group); err != nil {
+				return err
+			}
+			g.printf("},\n")
+		}
+		g.printf("]\n}\n")
+		return nil
+	}
+
+	g.printf("#panelGroups: {\n")
+	for i, group := range groups {
+		if group.isRegular {
+			g.printf("\"%d\": %s & {\n#groupIndex: %d\n", i, g.use("panelGroupBuilder"), i)
+			if err := g.regularPanelGroup(group); err != nil {
+				return err
+			}
+		} else {
+			g.printf("\"%d\": {\n", i)
+			if err := g.customPanelGroup(i, group); err != nil {
+				return err
+			}
+		}
+		g.printf("}\n")
+	}
+	g.printf("}\n")
+	return nil
+}
+
+func (g *cueGenerator) regularPanelGroup(group panelGroup) error {
+	g.printf("#title: %s\n", cueString(group.title))
+	g.printf("#cols: %d\n", gridColumns/group.panelWidth)
+	if group.panelHeight != defaultPanelHeight {
+		g.printf("#height: %d\n", group.panelHeight)
+	}
+	if group.collapsed != nil {
+		g.printf("#isCollapsed: %t\n", *group.collapsed)
+	}
+	if len(group.repeatVariable) > 0 {
+		g.printf("#repeatVariable: %s\n", cueString(group.repeatVariable))
+	}
+	g.printf("#panels: [\n")
+	for _, raw := range group.panels {
+		panel, err := rawCUEValue(raw)
+		if err != nil {
+			return err
+		}
+		g.printf("%s,\n", panel)
+	}
+	g.printf("]\n")
+	return nil
+}
+
+// customPanelGroup writes the layout and the panels of the group as they are.
+// The panels are renamed the same way the builders do, to avoid any conflict with the other groups.
+func (g *cueGenerator) customPanelGroup(index int, group panelGroup) error {
+	layout := map[string]any{
+		"kind": "Grid",
+	}
+	display := map[string]any{"title": group.title}
+	if group.collapsed != nil {
+		display["collapse"] = map[string]any{"open": !*group.collapsed}
+	}
+	var items []any
+	for i, item := range group.items {
+		items = append(items, map[string]any{
+			"x":       item.X,
+			"y":       item.Y,
+			"width":   item.Width,
+			"height":  item.Height,
+			"content": map[string]any{"$ref": fmt.Sprintf("%s%d_%d", panelRefPrefix, index, i)},
+		})
+	}
+	spec := map[string]any{
+		"display": display,
+		"items":   items,
+	}
+	if len(group.repeatVariable) > 0 {
+		spec["repeatVariable"] = group.repeatVariable
+	}
+	layout["spec"] = spec
+	layoutValue, err := cueValue(layout)
+	if err != nil {
+		return err
+	}
+	g.printf("layout: %s\n", layoutValue)
+	g.printf("panels: {\n")
+	for i, raw := range group.panels {
+		panel, err := rawCUEValue(raw)
+		if err != nil {
+			return err
+		}
+		g.printf("\"%d_%d\": %s\n", index, i, panel)
+	}
+	g.printf("}\n")
+	return nil
+}
+
+// cueValue returns the CUE literal of the given value, written as JSON.
+func cueValue(value any) (string, error) {
+	var buffer bytes.Buffer
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+	// The indentation puts each field on its own line, which the CUE formatter needs to simplify the structs.
+	encoder.SetIndent("", "\t")
+	if err := encoder.Encode(value); err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(buffer.String()), nil
+}
+
+// rawCUEValue returns the CUE literal of the given JSON.
+// It is decoded and encoded again, to avoid the escaping of the HTML characters done by json.Marshal.
+func rawCUEValue(raw json.RawMessage) (string, error) {
+	var value any
+	if err := decode(raw, &value); err != nil {
+		return "", err
+	}
+	return cueValue(value)
+}
+
+// cueString returns the CUE literal of the string.
+func cueString(s string) string {
+	// Encoding a string can't fail.
+	result, _ := cueValue(s)
+	return result
+}
@@ -0,0 +1,344 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package importer
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var goImports = map[string]string{
+	"dashboard":    `"github.com/perses/perses/go-sdk/dashboard"`,
+	"datasource":   `"github.com/perses/perses/go-sdk/datasource"`,
+	"link":         `"github.com/perses/perses/go-sdk/link"`,
+	"panel":        `"github.com/perses/perses/go-sdk/panel"`,
+	"panelgroup":   `panelgroup "github.com/perses/perses/go-sdk/panel-group"`,
+	"query":        `"github.com/perses/perses/go-sdk/query"`,
+	"listVar":      `listVar "github.com/perses/perses/go-sdk/variable/list-variable"`,
+	"txtVar":       `txtVar "github.com/perses/perses/go-sdk/variable/text-variable"`,
+	"plugin":       `"github.com/perses/spec/go/plugin"`,
+	"variableSpec": `variableSpec "github.com/perses/spec/go/dashboard/variable"`,
+}
+
+// goGenerator writes the Go code building a dashboard with the Go SDK.
+type goGenerator struct {
+	body    bytes.Buffer
+	imports map[string]bool
+}
+
+func (g *goGenerator) use(pkg string) string {
+	g.imports[pkg] = true
+	return pkg
+}
+
+func (g *goGenerator) printf(format string, args ...any) {
+	fmt.Fprintf(&g.body, format, args...)
+}
+
+// generateGo returns the Go program building the given dashboard with the Go SDK.
+func generateGo(d *importDashboard) ([]byte, error) {
+	g := &goGenerator{imports: make(map[string]bool)}
+	if err := g.dashboard(d); err != nil {
+		return nil, err
+	}
+
+	var buffer bytes.Buffer
+	buffer.WriteString("package main\n\nimport (\n\t\"flag\"\n\n\t\"github.com/perses/perses/go-sdk\"\n")
+	var imports []string
+	for pkg := range g.imports {
+		imports = append(imports, goImports[pkg])
+	}
+	slices.Sort(imports)
+	for _, imp := range imports {
+		fmt.Fprintf(&buffer, "\t%s\n", imp)
+	}
+	buffer.WriteString(")\n\nfunc main() {\n\tflag.Parse()\n\texec := sdk.NewExec()\n\n")
+	buffer.Write(g.body.Bytes())
+	buffer.WriteString("\texec.BuildDashboard(builder, buildErr)\n}\n")
+	return format.Source(buffer.Bytes())
+}
+
+func (g *goGenerator) dashboard(d *importDashboard) error {
+	g.printf("builder, buildErr := %s.New(%s,\n", g.use("dashboard"), goString(d.Metadata.Name))
+	if len(d.Metadata.Project) > 0 {
+		g.printf("dashboard.ProjectName(%s),\n", goString(d.Metadata.Project))
+	}
+	if d.Spec.Display != nil {
+		if len(d.Spec.Display.Name) > 0 {
+			g.printf("dashboard.DisplayName(%s),\n", goString(d.Spec.Display.Name))
+		}
+		if len(d.Spec.Display.Description) > 0 {
+			g.printf("dashboard.Description(%s),\n", goString(d.Spec.Display.Description))
+		}
+	}
+	if len(d.Spec.Duration) > 0 {
+		g.printf("dashboard.DurationAsString(%s),\n", goString(d.Spec.Duration))
+	}
+	if len(d.Spec.RefreshInterval) > 0 {
+		g.printf("dashboard.RefreshIntervalAsString(%s),\n", goString(d.Spec.RefreshInterval))
+	}
+
+	if len(d.Spec.Variables) > 0 {
+		g.printf("\n// VARIABLES\n")
+	}
+	for _, v := range d.Spec.Variables {
+		if err := g.variable(v); err != nil {
+			return err
+		}
+	}
+
+	groups, err := d.panelGroups()
+	if err != nil {
+		return err
+	}
+	if len(groups) > 0 {
+		g.printf("\n// PANEL GROUPS\n")
+	}
+	for _, group := range groups {
+		if groupErr := g.panelGroup(group); groupErr != nil {
+			return groupErr
+		}
+	}
+
+	if len(d.Spec.Datasources) > 0 {
+		g.printf("\n// DATASOURCES\n")
+	}
+	for _, name := range sortedKeys(d.Spec.Datasources) {
+		if dtsErr := g.datasource(name, d.Spec.Datasources[name]); dtsErr != nil {
+			return dtsErr
+		}
+	}
+	g.printf(")\n")
+	return nil
+}
+
+func (g *goGenerator) variable(v importVariable) error {
+	switch v.Kind {
+	case textVariableKind:
+		g.printf("dashboard.AddVariable(%s,\n%s.Text(%s,\n", goString(v.Spec.Name), g.use("txtVar"), goString(v.Spec.Value))
+		if v.Spec.Constant {
+			g.printf("txtVar.Constant(true),\n")
+		}
+		g.variableDisplay("txtVar", v.Spec.Display)
+	case listVariableKind:
+		g.printf("dashboard.AddVariable(%s,\n%s.List(\n", goString(v.Spec.Name), g.use("listVar"))
+		g.printf("listVar.Plugin(%s),\n", g.plugin(v.Spec.Plugin))
+		g.variableDisplay("listVar", v.Spec.Display)
+		values, isMultiple, err := v.Spec.defaultValues()
+		if err != nil {
+			return err
+		}
+		if isMultiple {
+			var args []string
+			for _, value := range values {
+				args = append(args, goString(value))
+			}
+			g.printf("listVar.DefaultValues(%s),\n", strings.Join(args, ", "))
+		} else if len(values) == 1 {
+			g.printf("listVar.DefaultValue(%s),\n", goString(values[0]))
+		}
+		if v.Spec.AllowAllValue {
+			g.printf("listVar.AllowAllValue(true),\n")
+		}
+		if v.Spec.AllowMultiple {
+			g.printf("listVar.AllowMultiple(true),\n")
+		}
+		if len(v.Spec.CustomAllValue) > 0 {
+			g.printf("listVar.CustomAllValue(%s),\n", goString(v.Spec.CustomAllValue))
+		}
+		if len(v.Spec.CapturingRegexp) > 0 {
+			g.printf("listVar.CapturingRegexp(%s),\n", goString(v.Spec.CapturingRegexp))
+		}
+		if len(v.Spec.Sort) > 0 {
+			g.printf("listVar.SortingBy(%s.Sort(%s)),\n", g.use("variableSpec"), goString(v.Spec.Sort))
+		}
+	default:
+		return fmt.Errorf("variable %q: kind %q not supported", v.Spec.Name, v.Kind)
+	}
+	g.printf("),\n),\n")
+	return nil
+}
+
+func (g *goGenerator) variableDisplay(pkg string, display *importDisplay) {
+	if display == nil {
+		return
+	}
+	if len(display.Name) > 0 {
+		g.printf("%s.DisplayName(%s),\n", pkg, goString(display.Name))
+	}
+	if len(display.Description) > 0 {
+		g.printf("%s.Description(%s),\n", pkg, goString(display.Description))
+	}
+	if display.Hidden {
+		g.printf("%s.Hidden(true),\n", pkg)
+	}
+}
+
+func (g *goGenerator) panelGroup(group panelGroup) error {
+	if group.isRegular {
+		g.printf("dashboard.AddPanelGroup(%s,\n", goString(group.title))
+		if group.panelWidth != defaultPanelWidth {
+			g.printf("%s.PanelWidth(%d),\n", g.use("panelgroup"), group.panelWidth)
+		}
+		if group.panelHeight != defaultPanelHeight {
+			g.printf("%s.PanelHeight(%d),\n", g.use("panelgroup"), group.panelHeight)
+		}
+	} else {
+		g.printf("dashboard.AddCustomPanelGroup(%s,\n[]dashboard.GridItem{\n", goString(group.title))
+		for _, item := range group.items {
+			g.printf("{X: %d, Y: %d, W: %d, H: %d},\n", item.X, item.Y, item.Width, item.Height)
+		}
+		g.printf("},\n")
+	}
+	if group.collapsed != nil {
+		g.printf("%s.Collapsed(%t),\n", g.use("panelgroup"), *group.collapsed)
+	}
+	if len(group.repeatVariable) > 0 {
+		g.printf("%s.RepeatVariable(%s),\n", g.use("panelgroup"), goString(group.repeatVariable))
+	}
+	for _, raw := range group.panels {
+		if err := g.panel(raw); err != nil {
+			return err
+		}
+	}
+	g.printf("),\n")
+	return nil
+}
+
+func (g *goGenerator) panel(raw json.RawMessage) error {
+	p := &importPanel{}
+	if err := decode(raw, p); err != nil {
+		return err
+	}
+	title := ""
+	if p.Spec.Display != nil {
+		title = p.Spec.Display.Name
+	}
+	g.printf("%s.AddPanel(%s,\n", g.use("panelgroup"), goString(title))
+	if p.Spec.Display != nil && len(p.Spec.Display.Description) > 0 {
+		g.printf("%s.Description(%s),\n", g.use("panel"), goString(p.Spec.Display.Description))
+	}
+	g.printf("%s.Plugin(%s),\n", g.use("panel"), g.plugin(p.Spec.Plugin))
+	if len(p.Spec.Queries) > 0 {
+		g.printf("panel.AddQuery(\n")
+		for _, q := range p.Spec.Queries {
+			g.printf("%s.Option{\nKind: %s,\nPlugin: %s,\n},\n", g.use("query"), goString(q.Kind), g.plugin(q.Spec.Plugin))
+		}
+		g.printf("),\n")
+	}
+	for _, l := range p.Spec.Links {
+		g.printf("panel.AddLink(%s", goString(l.URL))
+		if len(l.Name) > 0 {
+			g.printf(", %s.Name(%s)", g.use("link"), goString(l.Name))
+		}
+		if len(l.Tooltip) > 0 {
+			g.printf(", %s.Tooltip(%s)", g.use("link"), goString(l.Tooltip))
+		}
+		if l.RenderVariables {
+			g.printf(", %s.RenderVariable(true)", g.use("link"))
+		}
+		if l.TargetBlank {
+			g.printf(", %s.TargetBlank(true)", g.use("link"))
+		}
+		g.printf("),\n")
+	}
+	g.printf("),\n")
+	return nil
+}
+
+func (g *goGenerator) datasource(name string, raw json.RawMessage) error {
+	dts := &importDatasource{}
+	if err := decode(raw, dts); err != nil {
+		return err
+	}
+	g.printf("dashboard.AddDatasource(%s,\n", goString(name))
+	if dts.Display != nil && len(dts.Display.Name) > 0 {
+		g.printf("%s.DisplayName(%s),\n", g.use("datasource"), goString(dts.Display.Name))
+	}
+	if dts.Default {
+		g.printf("%s.Default(true),\n", g.use("datasource"))
+	}
+	g.printf("%s.Plugin(%s),\n),\n", g.use("datasource"), g.plugin(dts.Plugin))
+	return nil
+}
+
+func (g *goGenerator) plugin(p importPlugin) string {
+	g.use("plugin")
+	if p.Spec == nil {
+		return fmt.Sprintf("plugin.Plugin{\nKind: %s,\n}", goString(p.Kind))
+	}
+	return fmt.Sprintf("plugin.Plugin{\nKind: %s,\nSpec: %s,\n}", goString(p.Kind), goValue(p.Spec))
+}
+
+// goValue returns the Go literal of a value decoded from JSON.
+func goValue(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return "nil"
+	case bool:
+		return strconv.FormatBool(v)
+	case json.Number:
+		return v.String()
+	case string:
+		return goString(v)
+	case []any:
+		if len(v) == 0 {
+			return "[]any{}"
+		}
+		var sb strings.Builder
+		sb.WriteString("[]any{\n")
+		for _, item := range v {
+			sb.WriteString(goValue(item))
+			sb.WriteString(",\n")
+		}
+		sb.WriteString("}")
+		return sb.String()
+	case map[string]any:
+		if len(v) == 0 {
+			return "map[string]any{}"
+		}
+		var sb strings.Builder
+		sb.WriteString("map[string]any{\n")
+		for _, key := range sortedKeys(v) {
+			fmt.Fprintf(&sb, "%s: %s,\n", goString(key), goValue(v[key]))
+		}
+		sb.WriteString("}")
+		return sb.String()
+	default:
+		return fmt.Sprintf("%#v", v)
+	}
+}
+
+// goString returns the Go literal of the string.
+// Raw strings are preferred when the string contains double quotes, which is common in the queries.
+func goString(s string) string {
+	if strings.Contains(s, `"`) && strconv.CanBackquote(s) {
+		return "`" + s + "`"
+	}
+	return strconv.Quote(s)
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
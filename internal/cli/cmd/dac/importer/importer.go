@@ -0,0 +1,213 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package importer provides the command generating the Dashboard-as-Code of existing dashboards.
+package importer
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+
+	persesCMD "github.com/perses/perses/internal/cli/cmd"
+	"github.com/perses/perses/internal/cli/config"
+	"github.com/perses/perses/internal/cli/file"
+	"github.com/perses/perses/internal/cli/opt"
+	"github.com/perses/perses/internal/cli/output"
+	"github.com/perses/perses/pkg/client/api"
+	modelV1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+const (
+	cueLanguage = "cue"
+	goLanguage  = "go"
+)
+
+type option struct {
+	persesCMD.Option
+	opt.ProjectOption
+	opt.FileOption
+	opt.DirectoryOption
+	language   string
+	outputDir  string
+	names      []string
+	writer     io.Writer
+	errWriter  io.Writer
+	apiClient  api.ClientInterface
+	dashboards []*modelV1.Dashboard
+}
+
+func (o *option) Complete(args []string) error {
+	o.names = args
+	if len(o.File) > 0 || len(o.Directory) > 0 {
+		return o.setDashboardsFromFiles()
+	}
+	if err := o.ProjectOption.Complete(); err != nil {
+		return err
+	}
+	apiClient, err := config.Global.GetAPIClient()
+	if err != nil {
+		return err
+	}
+	o.apiClient = apiClient
+	return o.setDashboardsFromAPI()
+}
+
+func (o *option) setDashboardsFromFiles() error {
+	entities, err := file.UnmarshalEntities(o.File, o.Directory)
+	if err != nil {
+		return err
+	}
+	for _, e := range entities {
+		dashboard, ok := e.(*modelV1.Dashboard)
+		if !ok {
+			continue
+		}
+		if len(o.names) == 0 || slices.Contains(o.names, dashboard.Metadata.Name) {
+			o.dashboards = append(o.dashboards, dashboard)
+		}
+	}
+	return nil
+}
+
+func (o *option) setDashboardsFromAPI() error {
+	if len(o.names) == 0 {
+		dashboards, err := o.apiClient.V1().Dashboard(o.Project).List("")
+		if err != nil {
+			return err
+		}
+		o.dashboards = dashboards
+		return nil
+	}
+	for _, name := range o.names {
+		dashboard, err := o.apiClient.V1().Dashboard(o.Project).Get(name)
+		if err != nil {
+			return fmt.Errorf("unable to get the dashboard %q: %w", name, err)
+		}
+		o.dashboards = append(o.dashboards, dashboard)
+	}
+	return nil
+}
+
+func (o *option) Validate() error {
+	if o.language != cueLanguage && o.language != goLanguage {
+		return fmt.Errorf("language %q not supported, possible values: %s, %s", o.language, cueLanguage, goLanguage)
+	}
+	if len(o.dashboards) == 0 {
+		return fmt.Errorf("no dashboard found to import")
+	}
+	return nil
+}
+
+func (o *option) Execute() error {
+	msg := ""
+	for _, dashboard := range o.dashboards {
+		filePath, err := o.importDashboard(dashboard)
+		if err != nil {
+			return fmt.Errorf("unable to import the dashboard %q: %w", dashboard.Metadata.Name, err)
+		}
+		msg += fmt.Sprintf("dashboard %q imported in %s\n", dashboard.Metadata.Name, filePath)
+	}
+	return output.HandleString(o.writer, msg)
+}
+
+// importDashboard generates the code of the dashboard and writes it in the output folder.
+// The files are grouped by project. With Go, each dashboard is a program on its own, so it gets its own folder.
+func (o *option) importDashboard(dashboard *modelV1.Dashboard) (string, error) {
+	d, err := newImportDashboard(dashboard)
+	if err != nil {
+		return "", err
+	}
+	unmapped, err := unmappedFields(dashboard, o.language)
+	if err != nil {
+		return "", err
+	}
+	for _, field := range unmapped {
+		logrus.Warnf("dashboard %q: the field %q is not supported by the code generation, it is ignored", dashboard.Metadata.Name, field)
+	}
+	var data []byte
+	var filePath string
+	dir := filepath.Join(o.outputDir, dashboard.Metadata.Project)
+	if o.language == goLanguage {
+		data, err = generateGo(d)
+		dir = filepath.Join(dir, dashboard.Metadata.Name)
+		filePath = filepath.Join(dir, generatedGoFileName)
+	} else {
+		data, err = generateCUE(d)
+		filePath = filepath.Join(dir, fmt.Sprintf("%s.cue", dashboard.Metadata.Name))
+	}
+	if err != nil {
+		return "", err
+	}
+	if mkdirErr := os.MkdirAll(dir, 0750); mkdirErr != nil {
+		return "", fmt.Errorf("error creating the folder %q: %w", dir, mkdirErr)
+	}
+	if writeErr := os.WriteFile(filePath, data, 0644); writeErr != nil { // nolint: gosec
+		return "", writeErr
+	}
+	return filePath, nil
+}
+
+func (o *option) SetWriter(writer io.Writer) {
+	o.writer = writer
+}
+
+func (o *option) SetErrWriter(errWriter io.Writer) {
+	o.errWriter = errWriter
+}
+
+func NewCMD() *cobra.Command {
+	o := &option{}
+	cmd := &cobra.Command{
+		Use:   "import [DASHBOARD_NAME...]",
+		Short: "Generate the Dashboard-as-Code of existing dashboards",
+		Long: `Generate the Dashboard-as-Code of existing dashboards, using the CUE SDK or the Go SDK.
+
+The dashboards are retrieved from the API, in the current project or in the project set with the flag --project.
+When no name is given, every dashboard of the project is imported.
+With the flag --file or --directory, the dashboards are read from the files instead.
+
+Variables, panel groups, panels and queries are mapped to the builders provided by the SDK.
+The specs of the plugins are kept as they are, so the generated code doesn't depend on the SDK of the plugins.
+A warning is printed for every field of the dashboard that the SDK cannot set, like the tags, as it is not part of the generated code.
+
+The generated files are grouped by project in the output folder:
+- with CUE, each dashboard is written in <project>/<dashboard>.cue.
+- with Go, each dashboard is a program written in <project>/<dashboard>/main.go.
+They can then be built with 'percli dac build'.`,
+		Example: `
+# Import every dashboard of the project MyProject as CUE code
+percli dac import --project MyProject
+
+# Import a dashboard as Go code
+percli dac import MyDashboard --language go --output-dir ./dac
+
+# Import the dashboards from a file
+percli dac import -f ./dashboards.json
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return persesCMD.Run(o, cmd, args)
+		},
+	}
+	opt.AddProjectFlags(cmd, &o.ProjectOption)
+	opt.AddFileFlags(cmd, &o.FileOption)
+	opt.AddDirectoryFlags(cmd, &o.DirectoryOption)
+	cmd.MarkFlagsMutuallyExclusive("file", "directory")
+	cmd.Flags().StringVar(&o.language, "language", cueLanguage, "Language of the generated code. Possible value: cue, go.")
+	cmd.Flags().StringVar(&o.outputDir, "output-dir", ".", "Folder where the generated files are written.")
+	return cmd
+}
@@ -0,0 +1,254 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package importer
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/perses/common/set"
+	"github.com/perses/perses/internal/cli/config"
+	cmdTest "github.com/perses/perses/internal/cli/test"
+	fakeapi "github.com/perses/perses/pkg/client/fake/api"
+	modelV1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImportCMD(t *testing.T) {
+	outputDir := t.TempDir()
+	testSuite := []cmdTest.Suite{
+		{
+			Title:           "not connected to any API",
+			Args:            []string{"node-exporter", "--project", "perses"},
+			IsErrorExpected: true,
+			ExpectedMessage: "you are not connected to any API",
+		},
+		{
+			Title:           "no dashboard",
+			Args:            []string{"--project", "perses"},
+			APIClient:       fakeapi.New(),
+			IsErrorExpected: true,
+			ExpectedMessage: "no dashboard found to import",
+		},
+		{
+			Title:           "unsupported language",
+			Args:            []string{"-f", "./testdata/dashboard.json", "--language", "jsonnet"},
+			IsErrorExpected: true,
+			ExpectedMessage: "language \"jsonnet\" not supported, possible values: cue, go",
+		},
+		{
+			Title:           "import from the API",
+			Args:            []string{"node-exporter", "--output-dir", outputDir},
+			APIClient:       fakeapi.New(),
+			Config:          config.Config{Project: "perses"},
+			ExpectedMessage: "dashboard \"node-exporter\" imported in " + filepath.Join(outputDir, "perses", "node-exporter.cue") + "\n",
+		},
+		{
+			Title:           "import from a file as Go code",
+			Args:            []string{"-f", "./testdata/dashboard.json", "--language", "go", "--output-dir", outputDir},
+			ExpectedMessage: "dashboard \"node-exporter\" imported in " + filepath.Join(outputDir, "perses", "node-exporter", "main.go") + "\n",
+		},
+	}
+	cmdTest.ExecuteSuiteTest(t, NewCMD, testSuite)
+}
+
+func readModelDashboard(t *testing.T) *modelV1.Dashboard {
+	data, err := os.ReadFile("./testdata/dashboard.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dashboard := &modelV1.Dashboard{}
+	if unmarshalErr := json.Unmarshal(data, dashboard); unmarshalErr != nil {
+		t.Fatal(unmarshalErr)
+	}
+	return dashboard
+}
+
+func readDashboard(t *testing.T) *importDashboard {
+	d, err := newImportDashboard(readModelDashboard(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return d
+}
+
+// inlinePanels returns the spec of the dashboard, with the panels written in the layouts instead of being referenced.
+// The builders are naming the panels after their position, so the names differ from the original dashboard.
+func inlinePanels(t *testing.T, dashboard *modelV1.Dashboard) map[string]any {
+	data, err := json.Marshal(dashboard.Spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var spec map[string]any
+	if unmarshalErr := json.Unmarshal(data, &spec); unmarshalErr != nil {
+		t.Fatal(unmarshalErr)
+	}
+	panels, _ := spec["panels"].(map[string]any)
+	layouts, _ := spec["layouts"].([]any)
+	for _, layout := range layouts {
+		items, _ := layout.(map[string]any)["spec"].(map[string]any)["items"].([]any)
+		for _, item := range items {
+			content := item.(map[string]any)["content"].(map[string]any)
+			ref, _ := content["$ref"].(string)
+			item.(map[string]any)["content"] = panels[strings.TrimPrefix(ref, panelRefPrefix)]
+		}
+	}
+	delete(spec, "panels")
+	return spec
+}
+
+func TestGenerateGoRoundTrip(t *testing.T) {
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go is not installed, the generated code cannot be built")
+	}
+	// The generated program is built the same way percli dac build does.
+	cmd := exec.Command("go", "run", generatedGoFileName, "--output", "json") // #nosec
+	cmd.Dir = filepath.Join("testdata", "expected")
+	result, err := cmd.Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			t.Fatalf("unable to build the generated code: %s", exitErr.Stderr)
+		}
+		t.Fatal(err)
+	}
+	built := &modelV1.Dashboard{}
+	if unmarshalErr := json.Unmarshal(result, built); unmarshalErr != nil {
+		t.Fatal(unmarshalErr)
+	}
+	original := readModelDashboard(t)
+	assert.Equal(t, original.Metadata.Name, built.Metadata.Name)
+	assert.Equal(t, original.Metadata.Project, built.Metadata.Project)
+	expectedSpec, err := json.Marshal(inlinePanels(t, original))
+	if err != nil {
+		t.Fatal(err)
+	}
+	builtSpec, err := json.Marshal(inlinePanels(t, built))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.JSONEq(t, string(expectedSpec), string(builtSpec))
+}
+
+func TestUnmappedFields(t *testing.T) {
+	dashboard := readModelDashboard(t)
+	for _, language := range []string{cueLanguage, goLanguage} {
+		result, err := unmappedFields(dashboard, language)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Empty(t, result, language)
+	}
+
+	dashboard.Metadata.Tags = set.New("infra")
+	for _, language := range []string{cueLanguage, goLanguage} {
+		result, err := unmappedFields(dashboard, language)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, []string{"metadata.tags"}, result, language)
+	}
+}
+
+func TestDiffFields(t *testing.T) {
+	original := map[string]any{
+		"name":   "foo",
+		"empty":  "",
+		"hidden": false,
+		"nested": map[string]any{"kept": "a", "lost": "b"},
+		"items":  []any{map[string]any{"x": "1", "y": "2"}},
+	}
+	kept := map[string]any{
+		"name":   "foo",
+		"nested": map[string]any{"kept": "a"},
+		"items":  []any{map[string]any{"x": "1"}},
+	}
+	assert.Equal(t, []string{"items[0].y", "nested.lost"}, diffFields(original, kept, ""))
+}
+
+func TestGenerateGo(t *testing.T) {
+	expected, err := os.ReadFile("./testdata/expected/main.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := generateGo(readDashboard(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, string(expected), string(result))
+}
+
+func TestGenerateCUE(t *testing.T) {
+	expected, err := os.ReadFile("./testdata/expected/node-exporter.cue")
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := generateCUE(readDashboard(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, string(expected), string(result))
+}
+
+func TestIsRegularGrid(t *testing.T) {
+	testSuite := []struct {
+		title          string
+		items          []importGridItem
+		expectedResult bool
+		expectedWidth  int
+		expectedHeight int
+	}{
+		{
+			title:          "one panel per line",
+			items:          []importGridItem{{X: 0, Y: 0, Width: 24, Height: 6}, {X: 0, Y: 6, Width: 24, Height: 6}},
+			expectedResult: true,
+			expectedWidth:  24,
+			expectedHeight: 6,
+		},
+		{
+			title:          "three panels per line",
+			items:          []importGridItem{{X: 0, Y: 0, Width: 8, Height: 8}, {X: 8, Y: 0, Width: 8, Height: 8}, {X: 16, Y: 0, Width: 8, Height: 8}, {X: 0, Y: 8, Width: 8, Height: 8}},
+			expectedResult: true,
+			expectedWidth:  8,
+			expectedHeight: 8,
+		},
+		{
+			title: "different sizes",
+			items: []importGridItem{{X: 0, Y: 0, Width: 6, Height: 4}, {X: 6, Y: 0, Width: 18, Height: 8}},
+		},
+		{
+			title: "width not dividing the grid",
+			items: []importGridItem{{X: 0, Y: 0, Width: 10, Height: 4}, {X: 10, Y: 0, Width: 10, Height: 4}},
+		},
+		{
+			title: "gap between the panels",
+			items: []importGridItem{{X: 0, Y: 0, Width: 12, Height: 4}, {X: 0, Y: 4, Width: 12, Height: 4}},
+		},
+	}
+	for _, test := range testSuite {
+		t.Run(test.title, func(t *testing.T) {
+			result, width, height := isRegularGrid(test.items)
+			assert.Equal(t, test.expectedResult, result)
+			if test.expectedResult {
+				assert.Equal(t, test.expectedWidth, width)
+				assert.Equal(t, test.expectedHeight, height)
+			}
+		})
+	}
+}
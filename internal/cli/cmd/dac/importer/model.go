@@ -0,0 +1,364 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package importer
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	modelV1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	gridColumns         = 24
+	defaultPanelWidth   = 12
+	defaultPanelHeight  = 8
+	maxPanelHeight      = 24
+	panelRefPrefix      = "#/spec/panels/"
+	textVariableKind    = "TextVariable"
+	listVariableKind    = "ListVariable"
+	defaultCUEPackage   = "dac"
+	generatedGoFileName = "main.go"
+)
+
+// ignoredFields are the fields of a dashboard that are not expected to be part of its code, as they are set by the API.
+var ignoredFields = map[string]bool{
+	"kind":               true,
+	"metadata.createdAt": true,
+	"metadata.updatedAt": true,
+	"metadata.version":   true,
+}
+
+// The types below describe the part of a dashboard the code generation relies on.
+// They are decoded from the JSON representation of the dashboard, so the plugin specs are kept as generic values.
+
+type importPlugin struct {
+	Kind string `json:"kind"`
+	Spec any    `json:"spec"`
+}
+
+type importDisplay struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Hidden      bool   `json:"hidden"`
+}
+
+type importDatasource struct {
+	Default bool           `json:"default"`
+	Display *importDisplay `json:"display"`
+	Plugin  importPlugin   `json:"plugin"`
+}
+
+type importVariableSpec struct {
+	Name    string         `json:"name"`
+	Display *importDisplay `json:"display"`
+	// Fields of a TextVariable
+	Value    string `json:"value"`
+	Constant bool   `json:"constant"`
+	// Fields of a ListVariable
+	DefaultValue    any          `json:"defaultValue"`
+	AllowAllValue   bool         `json:"allowAllValue"`
+	AllowMultiple   bool         `json:"allowMultiple"`
+	CustomAllValue  string       `json:"customAllValue"`
+	CapturingRegexp string       `json:"capturingRegexp"`
+	Sort            string       `json:"sort"`
+	Plugin          importPlugin `json:"plugin"`
+}
+
+type importVariable struct {
+	Kind string             `json:"kind"`
+	Spec importVariableSpec `json:"spec"`
+}
+
+type importQuery struct {
+	Kind string `json:"kind"`
+	Spec struct {
+		Plugin importPlugin `json:"plugin"`
+	} `json:"spec"`
+}
+
+type importLink struct {
+	Name            string `json:"name"`
+	URL             string `json:"url"`
+	Tooltip         string `json:"tooltip"`
+	RenderVariables bool   `json:"renderVariables"`
+	TargetBlank     bool   `json:"targetBlank"`
+}
+
+type importPanel struct {
+	Kind string `json:"kind"`
+	Spec struct {
+		Display *importDisplay `json:"display"`
+		Plugin  importPlugin   `json:"plugin"`
+		Queries []importQuery  `json:"queries"`
+		Links   []importLink   `json:"links"`
+	} `json:"spec"`
+}
+
+type importGridItem struct {
+	X       int `json:"x"`
+	Y       int `json:"y"`
+	Width   int `json:"width"`
+	Height  int `json:"height"`
+	Content struct {
+		Ref string `json:"$ref"`
+	} `json:"content"`
+}
+
+type importLayout struct {
+	Kind string `json:"kind"`
+	Spec struct {
+		Display *struct {
+			Title    string `json:"title"`
+			Collapse *struct {
+				Open bool `json:"open"`
+			} `json:"collapse"`
+		} `json:"display"`
+		Items          []importGridItem `json:"items"`
+		RepeatVariable string           `json:"repeatVariable"`
+	} `json:"spec"`
+}
+
+type importDashboard struct {
+	Metadata struct {
+		Name    string `json:"name"`
+		Project string `json:"project"`
+	} `json:"metadata"`
+	Spec struct {
+		Display         *importDisplay             `json:"display"`
+		Datasources     map[string]json.RawMessage `json:"datasources"`
+		Variables       []importVariable           `json:"variables"`
+		Panels          map[string]json.RawMessage `json:"panels"`
+		Layouts         []importLayout             `json:"layouts"`
+		Duration        string                     `json:"duration"`
+		RefreshInterval string                     `json:"refreshInterval"`
+	} `json:"spec"`
+}
+
+// panelGroup is a grid layout along with the panels it displays, in the order of the grid items.
+type panelGroup struct {
+	title          string
+	collapsed      *bool
+	repeatVariable string
+	items          []importGridItem
+	panels         []json.RawMessage
+	// isRegular is true when the panels all have the same size and are placed one after the other, line by line.
+	// In this case, the panel group can be generated by the builders without providing the position of each panel.
+	isRegular   bool
+	panelWidth  int
+	panelHeight int
+}
+
+// decode unmarshals the given JSON while keeping the numbers as they are written.
+func decode(data []byte, v any) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	return decoder.Decode(v)
+}
+
+func newImportDashboard(dashboard *modelV1.Dashboard) (*importDashboard, error) {
+	data, err := json.Marshal(dashboard)
+	if err != nil {
+		return nil, err
+	}
+	result := &importDashboard{}
+	if decodeErr := decode(data, result); decodeErr != nil {
+		return nil, decodeErr
+	}
+	return result, nil
+}
+
+// unmappedFields returns the path of the fields set in the dashboard that are not part of the generated code.
+// With CUE, the datasources and the panels are written as they are, while with Go they are mapped to the builders.
+func unmappedFields(dashboard *modelV1.Dashboard, language string) ([]string, error) {
+	data, err := json.Marshal(dashboard)
+	if err != nil {
+		return nil, err
+	}
+	d := &importDashboard{}
+	result, err := diffDecoded(data, d, "")
+	if err != nil {
+		return nil, err
+	}
+	if language != goLanguage {
+		return result, nil
+	}
+	for _, name := range sortedKeys(d.Spec.Datasources) {
+		fields, dtsErr := diffDecoded(d.Spec.Datasources[name], &importDatasource{}, "spec.datasources."+name)
+		if dtsErr != nil {
+			return nil, dtsErr
+		}
+		result = append(result, fields...)
+	}
+	for _, key := range sortedKeys(d.Spec.Panels) {
+		fields, panelErr := diffDecoded(d.Spec.Panels[key], &importPanel{}, "spec.panels."+key)
+		if panelErr != nil {
+			return nil, panelErr
+		}
+		result = append(result, fields...)
+	}
+	return result, nil
+}
+
+// diffDecoded decodes the data in the given value and returns the path of the fields that have not been kept by the decoding.
+func diffDecoded(data []byte, v any, path string) ([]string, error) {
+	var original any
+	if err := decode(data, &original); err != nil {
+		return nil, err
+	}
+	if err := decode(data, v); err != nil {
+		return nil, err
+	}
+	keptData, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var kept any
+	if decodeErr := decode(keptData, &kept); decodeErr != nil {
+		return nil, decodeErr
+	}
+	return diffFields(original, kept, path), nil
+}
+
+func diffFields(original any, kept any, path string) []string {
+	var result []string
+	switch o := original.(type) {
+	case map[string]any:
+		k, _ := kept.(map[string]any)
+		for _, key := range sortedKeys(o) {
+			fieldPath := key
+			if len(path) > 0 {
+				fieldPath = path + "." + key
+			}
+			if ignoredFields[fieldPath] || isEmptyValue(o[key]) {
+				continue
+			}
+			keptValue, ok := k[key]
+			if !ok {
+				result = append(result, fieldPath)
+				continue
+			}
+			result = append(result, diffFields(o[key], keptValue, fieldPath)...)
+		}
+	case []any:
+		k, _ := kept.([]any)
+		for i := range o {
+			if i < len(k) {
+				result = append(result, diffFields(o[i], k[i], fmt.Sprintf("%s[%d]", path, i))...)
+			}
+		}
+	}
+	return result
+}
+
+// isEmptyValue returns true when the value is the one a field gets when it is not set.
+func isEmptyValue(value any) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return len(v) == 0
+	case bool:
+		return !v
+	case map[string]any:
+		return len(v) == 0
+	case []any:
+		return len(v) == 0
+	}
+	return false
+}
+
+func (d *importDashboard) panelGroups() ([]panelGroup, error) {
+	referenced := make(map[string]bool)
+	var groups []panelGroup
+	for i, layout := range d.Spec.Layouts {
+		if layout.Kind != "Grid" {
+			return nil, fmt.Errorf("layout %d: kind %q not supported", i, layout.Kind)
+		}
+		group := panelGroup{
+			repeatVariable: layout.Spec.RepeatVariable,
+			items:          layout.Spec.Items,
+		}
+		if layout.Spec.Display != nil {
+			group.title = layout.Spec.Display.Title
+			if layout.Spec.Display.Collapse != nil {
+				isCollapsed := !layout.Spec.Display.Collapse.Open
+				group.collapsed = &isCollapsed
+			}
+		}
+		for _, item := range layout.Spec.Items {
+			panelKey := strings.TrimPrefix(item.Content.Ref, panelRefPrefix)
+			panel, ok := d.Spec.Panels[panelKey]
+			if !ok {
+				return nil, fmt.Errorf("layout %d: panel %q not found", i, item.Content.Ref)
+			}
+			referenced[panelKey] = true
+			group.panels = append(group.panels, panel)
+		}
+		group.isRegular, group.panelWidth, group.panelHeight = isRegularGrid(layout.Spec.Items)
+		groups = append(groups, group)
+	}
+	for key := range d.Spec.Panels {
+		if !referenced[key] {
+			logrus.Warnf("dashboard %q: panel %q is not used in any layout, it is ignored", d.Metadata.Name, key)
+		}
+	}
+	return groups, nil
+}
+
+// isRegularGrid checks whether the items are placed the same way the panel group builders are placing the panels.
+// It returns as well the width and the height shared by the panels.
+func isRegularGrid(items []importGridItem) (bool, int, int) {
+	if len(items) == 0 {
+		return true, defaultPanelWidth, defaultPanelHeight
+	}
+	width := items[0].Width
+	height := items[0].Height
+	if width < 1 || width > gridColumns || gridColumns%width != 0 || height < 1 || height > maxPanelHeight {
+		return false, 0, 0
+	}
+	for i, item := range items {
+		x := (i * width) % gridColumns
+		y := (i * width) / gridColumns * height
+		if item.Width != width || item.Height != height || item.X != x || item.Y != y {
+			return false, 0, 0
+		}
+	}
+	return true, width, height
+}
+
+// defaultValues returns the default value(s) of a list variable.
+// isMultiple is true when the default value is a list of values.
+func (v importVariableSpec) defaultValues() (values []string, isMultiple bool, err error) {
+	switch defaultValue := v.DefaultValue.(type) {
+	case nil:
+		return nil, false, nil
+	case string:
+		return []string{defaultValue}, false, nil
+	case []any:
+		for _, value := range defaultValue {
+			s, ok := value.(string)
+			if !ok {
+				return nil, false, fmt.Errorf("variable %q: default value %v is not a string", v.Name, value)
+			}
+			values = append(values, s)
+		}
+		return values, true, nil
+	default:
+		return nil, false, fmt.Errorf("variable %q: unsupported default value %v", v.Name, v.DefaultValue)
+	}
+}
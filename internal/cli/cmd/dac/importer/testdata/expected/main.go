@@ -0,0 +1,164 @@
+package main
+
+import (
+	"flag"
+
+	"github.com/perses/perses/go-sdk"
+	"github.com/perses/perses/go-sdk/dashboard"
+	"github.com/perses/perses/go-sdk/datasource"
+	"github.com/perses/perses/go-sdk/link"
+	"github.com/perses/perses/go-sdk/panel"
+	panelgroup "github.com/perses/perses/go-sdk/panel-group"
+	"github.com/perses/perses/go-sdk/query"
+	listVar "github.com/perses/perses/go-sdk/variable/list-variable"
+	txtVar "github.com/perses/perses/go-sdk/variable/text-variable"
+	variableSpec "github.com/perses/spec/go/dashboard/variable"
+	"github.com/perses/spec/go/plugin"
+)
+
+func main() {
+	flag.Parse()
+	exec := sdk.NewExec()
+
+	builder, buildErr := dashboard.New("node-exporter",
+		dashboard.ProjectName("perses"),
+		dashboard.DisplayName("Node Exporter"),
+		dashboard.Description("Overview of the nodes"),
+		dashboard.DurationAsString("1h"),
+		dashboard.RefreshIntervalAsString("30s"),
+
+		// VARIABLES
+		dashboard.AddVariable("env",
+			txtVar.Text("production",
+				txtVar.Constant(true),
+				txtVar.DisplayName("Environment"),
+			),
+		),
+		dashboard.AddVariable("instance",
+			listVar.List(
+				listVar.Plugin(plugin.Plugin{
+					Kind: "PrometheusLabelValuesVariable",
+					Spec: map[string]any{
+						"labelName": "instance",
+						"matchers": []any{
+							`up{job="node"}`,
+						},
+					},
+				}),
+				listVar.DisplayName("Instance"),
+				listVar.DefaultValues("localhost:9100"),
+				listVar.AllowAllValue(true),
+				listVar.AllowMultiple(true),
+				listVar.SortingBy(variableSpec.Sort("alphabetical-asc")),
+			),
+		),
+
+		// PANEL GROUPS
+		dashboard.AddPanelGroup("Resources",
+			panelgroup.PanelHeight(6),
+			panelgroup.Collapsed(false),
+			panelgroup.RepeatVariable("instance"),
+			panelgroup.AddPanel("CPU usage",
+				panel.Description("CPU usage per instance"),
+				panel.Plugin(plugin.Plugin{
+					Kind: "TimeSeriesChart",
+					Spec: map[string]any{
+						"legend": map[string]any{
+							"position": "bottom",
+						},
+						"yAxis": map[string]any{
+							"format": map[string]any{
+								"unit": "percent-decimal",
+							},
+							"max": 1,
+						},
+					},
+				}),
+				panel.AddQuery(
+					query.Option{
+						Kind: "TimeSeriesQuery",
+						Plugin: plugin.Plugin{
+							Kind: "PrometheusTimeSeriesQuery",
+							Spec: map[string]any{
+								"query": `1 - avg by (instance) (rate(node_cpu_seconds_total{mode="idle",instance=~"$instance"}[5m]))`,
+							},
+						},
+					},
+				),
+				panel.AddLink("https://example.com/nodes/${instance}", link.Name("Node details"), link.TargetBlank(true)),
+			),
+			panelgroup.AddPanel("Memory usage",
+				panel.Plugin(plugin.Plugin{
+					Kind: "TimeSeriesChart",
+					Spec: map[string]any{},
+				}),
+				panel.AddQuery(
+					query.Option{
+						Kind: "TimeSeriesQuery",
+						Plugin: plugin.Plugin{
+							Kind: "PrometheusTimeSeriesQuery",
+							Spec: map[string]any{
+								"query": `1 - node_memory_MemAvailable_bytes{instance=~"$instance"} / node_memory_MemTotal_bytes`,
+							},
+						},
+					},
+				),
+			),
+		),
+		dashboard.AddCustomPanelGroup("System",
+			[]dashboard.GridItem{
+				{X: 0, Y: 0, W: 6, H: 4},
+				{X: 6, Y: 0, W: 18, H: 8},
+			},
+			panelgroup.AddPanel("Uptime",
+				panel.Plugin(plugin.Plugin{
+					Kind: "StatChart",
+					Spec: map[string]any{
+						"calculation": "last-number",
+					},
+				}),
+				panel.AddQuery(
+					query.Option{
+						Kind: "TimeSeriesQuery",
+						Plugin: plugin.Plugin{
+							Kind: "PrometheusTimeSeriesQuery",
+							Spec: map[string]any{
+								"query": `time() - node_boot_time_seconds{instance=~"$instance"}`,
+							},
+						},
+					},
+				),
+			),
+			panelgroup.AddPanel("Load",
+				panel.Plugin(plugin.Plugin{
+					Kind: "TimeSeriesChart",
+					Spec: map[string]any{},
+				}),
+				panel.AddQuery(
+					query.Option{
+						Kind: "TimeSeriesQuery",
+						Plugin: plugin.Plugin{
+							Kind: "PrometheusTimeSeriesQuery",
+							Spec: map[string]any{
+								"query": `node_load1{instance=~"$instance"}`,
+							},
+						},
+					},
+				),
+			),
+		),
+
+		// DATASOURCES
+		dashboard.AddDatasource("prometheus",
+			datasource.DisplayName("Prometheus"),
+			datasource.Default(true),
+			datasource.Plugin(plugin.Plugin{
+				Kind: "PrometheusDatasource",
+				Spec: map[string]any{
+					"directUrl": "http://localhost:9090",
+				},
+			}),
+		),
+	)
+	exec.BuildDashboard(builder, buildErr)
+}
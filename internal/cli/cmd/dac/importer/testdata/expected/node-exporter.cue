@@ -0,0 +1,189 @@
+package dac
+
+import (
+	dashboardBuilder "github.com/perses/perses/cue/dac-utils/dashboard"
+	listVarBuilder "github.com/perses/perses/cue/dac-utils/variable/list"
+	panelGroupBuilder "github.com/perses/perses/cue/dac-utils/panelgroup"
+	textVarBuilder "github.com/perses/perses/cue/dac-utils/variable/text"
+	varGroupBuilder "github.com/perses/perses/cue/dac-utils/variable/group"
+)
+
+#variables: varGroupBuilder & {
+	#input: [
+		textVarBuilder & {
+			#name: "env"
+			#display: name: "Environment"
+			#value:    "production"
+			#constant: true
+		},
+		listVarBuilder & {
+			#name: "instance"
+			#display: name: "Instance"
+			#pluginKind:    "PrometheusLabelValuesVariable"
+			#allowAllValue: true
+			#allowMultiple: true
+			#sort:          "alphabetical-asc"
+			variable: spec: {
+				defaultValue: [
+					"localhost:9100",
+				]
+				plugin: spec: {
+					labelName: "instance"
+					matchers: [
+						"up{job=\"node\"}",
+					]
+				}
+			}
+		},
+	]
+}
+
+dashboardBuilder & {
+	#name:    "node-exporter"
+	#project: "perses"
+	#display: {
+		name:        "Node Exporter"
+		description: "Overview of the nodes"
+	}
+	#duration:        "1h"
+	#refreshInterval: "30s"
+	#datasources: prometheus: {
+		default: true
+		display: name: "Prometheus"
+		plugin: {
+			kind: "PrometheusDatasource"
+			spec: directUrl: "http://localhost:9090"
+		}
+	}
+	#variables: #variables.variables
+	#panelGroups: {
+		"0": panelGroupBuilder & {
+			#groupIndex:     0
+			#title:          "Resources"
+			#cols:           2
+			#height:         6
+			#isCollapsed:    false
+			#repeatVariable: "instance"
+			#panels: [
+				{
+					kind: "Panel"
+					spec: {
+						display: {
+							description: "CPU usage per instance"
+							name:        "CPU usage"
+						}
+						links: [
+							{
+								name:        "Node details"
+								targetBlank: true
+								url:         "https://example.com/nodes/${instance}"
+							},
+						]
+						plugin: {
+							kind: "TimeSeriesChart"
+							spec: {
+								legend: position: "bottom"
+								yAxis: {
+									format: unit: "percent-decimal"
+									max: 1
+								}
+							}
+						}
+						queries: [
+							{
+								kind: "TimeSeriesQuery"
+								spec: plugin: {
+									kind: "PrometheusTimeSeriesQuery"
+									spec: query: "1 - avg by (instance) (rate(node_cpu_seconds_total{mode=\"idle\",instance=~\"$instance\"}[5m]))"
+								}
+							},
+						]
+					}
+				},
+				{
+					kind: "Panel"
+					spec: {
+						display: name: "Memory usage"
+						plugin: {
+							kind: "TimeSeriesChart"
+							spec: {}
+						}
+						queries: [
+							{
+								kind: "TimeSeriesQuery"
+								spec: plugin: {
+									kind: "PrometheusTimeSeriesQuery"
+									spec: query: "1 - node_memory_MemAvailable_bytes{instance=~\"$instance\"} / node_memory_MemTotal_bytes"
+								}
+							},
+						]
+					}
+				},
+			]
+		}
+		"1": {
+			layout: {
+				kind: "Grid"
+				spec: {
+					display: title: "System"
+					items: [
+						{
+							content: $ref: "#/spec/panels/1_0"
+							height: 4
+							width:  6
+							x:      0
+							y:      0
+						},
+						{
+							content: $ref: "#/spec/panels/1_1"
+							height: 8
+							width:  18
+							x:      6
+							y:      0
+						},
+					]
+				}
+			}
+			panels: {
+				"1_0": {
+					kind: "Panel"
+					spec: {
+						display: name: "Uptime"
+						plugin: {
+							kind: "StatChart"
+							spec: calculation: "last-number"
+						}
+						queries: [
+							{
+								kind: "TimeSeriesQuery"
+								spec: plugin: {
+									kind: "PrometheusTimeSeriesQuery"
+									spec: query: "time() - node_boot_time_seconds{instance=~\"$instance\"}"
+								}
+							},
+						]
+					}
+				}
+				"1_1": {
+					kind: "Panel"
+					spec: {
+						display: name: "Load"
+						plugin: {
+							kind: "TimeSeriesChart"
+							spec: {}
+						}
+						queries: [
+							{
+								kind: "TimeSeriesQuery"
+								spec: plugin: {
+									kind: "PrometheusTimeSeriesQuery"
+									spec: query: "node_load1{instance=~\"$instance\"}"
+								}
+							},
+						]
+					}
+				}
+			}
+		}
+	}
+}
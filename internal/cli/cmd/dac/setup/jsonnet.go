@@ -0,0 +1,265 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package setup
+
+import (
+	_ "embed"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/go-jsonnet/formatter"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/perses/spec/go/dashboard"
+	"github.com/perses/spec/go/plugin"
+)
+
+const (
+	// jsonnetLibraryDir is the folder, in the Jsonnet vendor folder, where the Perses library is vendored.
+	// The library is then imported with `import 'perses/main.libsonnet'`.
+	jsonnetLibraryDir = "perses"
+	jsonnetMainFile   = "main.libsonnet"
+	jsonnetGenFile    = "gen.libsonnet"
+	jsonnetGenHeader  = `// Code generated by "percli dac setup" from the Perses data model. DO NOT EDIT.
+// This file only contains the setters of the resources, import main.libsonnet instead.
+`
+)
+
+//go:embed jsonnet/main.libsonnet
+var jsonnetMainLibrary []byte
+
+var (
+	jsonnetIdentifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+	jsonnetKeywords   = []string{"assert", "else", "error", "false", "for", "function", "if", "import", "importbin", "importstr", "in", "local", "null", "self", "super", "tailstrict", "then", "true"}
+	// jsonnetIgnoredFields are the fields managed by the Perses server, for which no setter is generated.
+	jsonnetIgnoredFields = map[reflect.Type][]string{
+		reflect.TypeFor[v1.Metadata](): {"createdAt", "updatedAt", "version"},
+	}
+)
+
+// jsonnetResource is an entry of the generated Jsonnet library, e.g. `perses.dashboard`.
+type jsonnetResource struct {
+	name string
+	// model is a value of the Go type from which the setters are generated.
+	model any
+	// path is where the fields of the model are located in the resource. It is empty when the model is the resource itself.
+	path []string
+	// constructor is the Jsonnet definition of the `new` function.
+	constructor string
+}
+
+var jsonnetResources = []jsonnetResource{
+	{
+		name:        "dashboard",
+		model:       v1.Dashboard{},
+		constructor: `new(name): { kind: 'Dashboard', metadata: { name: name }, spec: { duration: '1h', panels: {}, layouts: [] } }`,
+	},
+	{
+		name:        "datasource",
+		model:       v1.Datasource{},
+		constructor: `new(name): { kind: 'Datasource', metadata: { name: name }, spec: {} }`,
+	},
+	{
+		name:        "globalDatasource",
+		model:       v1.GlobalDatasource{},
+		constructor: `new(name): { kind: 'GlobalDatasource', metadata: { name: name }, spec: {} }`,
+	},
+	{
+		name:        "variable",
+		model:       v1.Variable{},
+		constructor: `new(name): { kind: 'Variable', metadata: { name: name }, spec: {} }`,
+	},
+	{
+		name:        "globalVariable",
+		model:       v1.GlobalVariable{},
+		constructor: `new(name): { kind: 'GlobalVariable', metadata: { name: name }, spec: {} }`,
+	},
+	{
+		name:        "panel",
+		model:       dashboard.Panel{},
+		constructor: `new(name): { kind: 'Panel', spec: { display: { name: name } } }`,
+	},
+	{
+		name:        "query",
+		model:       dashboard.Query{},
+		constructor: `new(kind, plugin): { kind: kind, spec: { plugin: plugin } }`,
+	},
+	{
+		name:        "listVariable",
+		model:       dashboard.ListVariableSpec{},
+		path:        []string{"spec"},
+		constructor: `new(name): { kind: 'ListVariable', spec: { name: name } }`,
+	},
+	{
+		name:        "textVariable",
+		model:       dashboard.TextVariableSpec{},
+		path:        []string{"spec"},
+		constructor: `new(name, value): { kind: 'TextVariable', spec: { name: name, value: value } }`,
+	},
+	{
+		name:        "link",
+		model:       dashboard.Link{},
+		constructor: `new(url): { url: url }`,
+	},
+	{
+		name:        "plugin",
+		model:       plugin.Plugin{},
+		constructor: `new(kind, spec={}): { kind: kind, spec: spec }`,
+	},
+}
+
+// vendorJsonnetLibrary writes the Perses Jsonnet library in the given folder:
+// the setters generated from the data model of this version of percli, and the hand-written helpers.
+func vendorJsonnetLibrary(dir string) error {
+	gen, err := generateJsonnetLibrary(jsonnetResources)
+	if err != nil {
+		return fmt.Errorf("unable to generate the Jsonnet library: %w", err)
+	}
+	if mkdirErr := os.MkdirAll(dir, 0750); mkdirErr != nil {
+		return mkdirErr
+	}
+	if writeErr := os.WriteFile(filepath.Join(dir, jsonnetGenFile), []byte(gen), 0600); writeErr != nil {
+		return writeErr
+	}
+	return os.WriteFile(filepath.Join(dir, jsonnetMainFile), jsonnetMainLibrary, 0600)
+}
+
+// generateJsonnetLibrary generates a `with<Field>` function for each field of the resources, in the style of Grafonnet.
+// Nested objects are reachable through a field of the same name, e.g. `dashboard.spec.withDuration('6h')`.
+// The lists and maps get an additional `with<Field>Mixin` function, to append to the existing value instead of replacing it.
+func generateJsonnetLibrary(resources []jsonnetResource) (string, error) {
+	var b strings.Builder
+	b.WriteString(jsonnetGenHeader)
+	b.WriteString("{\n")
+	for _, r := range resources {
+		fmt.Fprintf(&b, "%s: {\n%s,\n", r.name, r.constructor)
+		modelType := reflect.TypeOf(r.model)
+		writeJsonnetSetters(&b, modelType, r.path, []reflect.Type{modelType})
+		b.WriteString("},\n")
+	}
+	b.WriteString("}\n")
+	return formatter.Format(jsonnetGenFile, b.String(), formatter.DefaultOptions())
+}
+
+// writeJsonnetSetters writes the setters of the fields of t. parents are the types being generated,
+// to stop on the recursive types.
+func writeJsonnetSetters(b *strings.Builder, t reflect.Type, path []string, parents []reflect.Type) {
+	for _, field := range jsonFields(t) {
+		fieldPath := append(slices.Clone(path), field.jsonName)
+		fieldType := indirectType(field.goType)
+		name := "with" + field.goName
+		switch {
+		case isJSONScalar(fieldType):
+			fmt.Fprintf(b, "%s(value): %s,\n", name, nestJsonnetValue(fieldPath, "value", false))
+		case fieldType.Kind() == reflect.Slice || fieldType.Kind() == reflect.Array:
+			value := "if std.isArray(value) then value else [value]"
+			fmt.Fprintf(b, "%s(value): %s,\n", name, nestJsonnetValue(fieldPath, value, false))
+			fmt.Fprintf(b, "%sMixin(value): %s,\n", name, nestJsonnetValue(fieldPath, value, true))
+		case fieldType.Kind() == reflect.Map:
+			fmt.Fprintf(b, "%s(value): %s,\n", name, nestJsonnetValue(fieldPath, "value", false))
+			fmt.Fprintf(b, "%sMixin(value): %s,\n", name, nestJsonnetValue(fieldPath, "value", true))
+		case fieldType.Kind() == reflect.Struct && !slices.Contains(parents, fieldType):
+			fmt.Fprintf(b, "%s(value): %s,\n", name, nestJsonnetValue(fieldPath, "value", false))
+			fmt.Fprintf(b, "%sMixin(value): %s,\n", name, nestJsonnetValue(fieldPath, "value", true))
+			fmt.Fprintf(b, "%s: {\n", jsonnetFieldName(field.jsonName))
+			writeJsonnetSetters(b, fieldType, fieldPath, append(parents, fieldType))
+			b.WriteString("},\n")
+		default:
+			fmt.Fprintf(b, "%s(value): %s,\n", name, nestJsonnetValue(fieldPath, "value", false))
+		}
+	}
+}
+
+type jsonField struct {
+	goName   string
+	jsonName string
+	goType   reflect.Type
+}
+
+// jsonFields returns the fields of the struct as they are encoded in JSON, the embedded structs being flattened.
+func jsonFields(t reflect.Type) []jsonField {
+	var fields []jsonField
+	for i := range t.NumField() {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if field.Anonymous && len(name) == 0 && indirectType(field.Type).Kind() == reflect.Struct {
+			fields = append(fields, jsonFields(indirectType(field.Type))...)
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if len(name) == 0 {
+			name = field.Name
+		}
+		if slices.Contains(jsonnetIgnoredFields[t], name) || slices.ContainsFunc(fields, func(f jsonField) bool { return f.jsonName == name }) {
+			continue
+		}
+		fields = append(fields, jsonField{goName: field.Name, jsonName: name, goType: field.Type})
+	}
+	return fields
+}
+
+func indirectType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
+
+// isJSONScalar returns true when the type can't be described field by field, because it has its own JSON encoding.
+func isJSONScalar(t reflect.Type) bool {
+	if t == reflect.TypeFor[time.Time]() || t.Kind() == reflect.Interface {
+		return true
+	}
+	pointer := reflect.PointerTo(t)
+	for _, marshaler := range []reflect.Type{reflect.TypeFor[json.Marshaler](), reflect.TypeFor[encoding.TextMarshaler]()} {
+		if t.Implements(marshaler) || pointer.Implements(marshaler) {
+			return true
+		}
+	}
+	return false
+}
+
+// nestJsonnetValue returns the object setting the value at the given path, e.g. `{ spec+: { duration: value } }`.
+// When mixin is true, the value is merged with the existing one.
+func nestJsonnetValue(path []string, value string, mixin bool) string {
+	operator := ":"
+	if mixin {
+		operator = "+:"
+	}
+	result := fmt.Sprintf("{ %s%s %s }", jsonnetFieldName(path[len(path)-1]), operator, value)
+	for i := len(path) - 2; i >= 0; i-- {
+		result = fmt.Sprintf("{ %s+: %s }", jsonnetFieldName(path[i]), result)
+	}
+	return result
+}
+
+func jsonnetFieldName(name string) string {
+	if jsonnetIdentifier.MatchString(name) && !slices.Contains(jsonnetKeywords, name) {
+		return name
+	}
+	return fmt.Sprintf("'%s'", name)
+}
@@ -0,0 +1,79 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Entry point of the Perses Jsonnet library, vendored by "percli dac setup --language jsonnet".
+// The setters of the resources are generated from the Perses data model in gen.libsonnet,
+// this file adds the helpers that can't be generated, like the panel groups.
+local gen = import 'gen.libsonnet';
+
+local gridLayout(group, ref) = {
+  local width = group.panelsWidth,
+  local height = group.panelsHeight,
+  kind: 'Grid',
+  spec: {
+    display: {
+      title: group.title,
+      [if group.collapsed != null then 'collapse']: { open: !group.collapsed },
+    },
+    items: [
+      {
+        x: (i * width) % 24,
+        y: std.floor(i * width / 24) * height,
+        width: width,
+        height: height,
+        content: { '$ref': '#/spec/panels/' + ref(i) },
+      }
+      for i in std.range(0, std.length(group.panels) - 1)
+    ],
+    [if group.repeatVariable != null then 'repeatVariable']: group.repeatVariable,
+  },
+};
+
+gen {
+  panelGroup: {
+    // new creates a group of panels, displayed in a grid. By default, each panel is 12 columns wide and 8 rows high.
+    new(title, panels=[]): {
+      title: title,
+      panels: panels,
+      panelsWidth: 12,
+      panelsHeight: 8,
+      collapsed: null,
+      repeatVariable: null,
+    },
+    withPanels(value): { panels: if std.isArray(value) then value else [value] },
+    withPanelsMixin(value): { panels+: if std.isArray(value) then value else [value] },
+    withPanelsWidth(value): { panelsWidth: value },
+    withPanelsHeight(value): { panelsHeight: value },
+    withCollapsed(value): { collapsed: value },
+    withRepeatVariable(value): { repeatVariable: value },
+  },
+
+  dashboard+: {
+    // withPanelGroups adds the panels of each group to the dashboard, together with the grid layout of the group.
+    withPanelGroups(groups): {
+      spec+: {
+        local offset = if 'layouts' in super then std.length(super.layouts) else 0,
+        local ref(g, i) = '%d_%d' % [offset + g, i],
+        panels+: {
+          [ref(g, i)]: groups[g].panels[i]
+          for g in std.range(0, std.length(groups) - 1)
+          for i in std.range(0, std.length(groups[g].panels) - 1)
+        },
+        layouts+: [
+          gridLayout(groups[g], function(i) ref(g, i))
+          for g in std.range(0, std.length(groups) - 1)
+        ],
+      },
+    },
+  },
+}
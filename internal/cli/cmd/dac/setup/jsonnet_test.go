@@ -0,0 +1,181 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package setup
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/go-jsonnet"
+	"github.com/perses/common/set"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testMetadata struct {
+	Name      string          `json:"name"`
+	Tags      set.Set[string] `json:"tags,omitempty"`
+	CreatedAt time.Time       `json:"createdAt"`
+}
+
+type testDisplay struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+type testTree struct {
+	Value    string     `json:"value"`
+	Children []testTree `json:"children,omitempty"`
+	Parent   *testTree  `json:"parent,omitempty"`
+}
+
+type testSpec struct {
+	Display *testDisplay   `json:"display,omitempty"`
+	Panels  map[string]any `json:"panels"`
+	Layouts []any          `json:"layouts"`
+	Ref     string         `json:"$ref"`
+	Tree    testTree       `json:"tree"`
+	Ignored string         `json:"-"`
+}
+
+type testResource struct {
+	Kind     string       `json:"kind"`
+	Metadata testMetadata `json:"metadata"`
+	Spec     testSpec     `json:"spec"`
+}
+
+func evaluateJsonnet(t *testing.T, resources []jsonnetResource, snippet string) string {
+	gen, err := generateJsonnetLibrary(resources)
+	require.NoError(t, err)
+	vm := jsonnet.MakeVM()
+	vm.Importer(&jsonnet.MemoryImporter{Data: map[string]jsonnet.Contents{
+		jsonnetGenFile:  jsonnet.MakeContents(gen),
+		jsonnetMainFile: jsonnet.MakeContents(string(jsonnetMainLibrary)),
+	}})
+	result, err := vm.EvaluateAnonymousSnippet("test.jsonnet", snippet)
+	require.NoError(t, err)
+	return result
+}
+
+func TestGenerateJsonnetLibrary(t *testing.T) {
+	jsonnetIgnoredFields[reflect.TypeFor[testMetadata]()] = []string{"createdAt"}
+	defer delete(jsonnetIgnoredFields, reflect.TypeFor[testMetadata]())
+
+	resources := []jsonnetResource{
+		{
+			name:        "resource",
+			model:       testResource{},
+			constructor: `new(name): { kind: 'Test', metadata: { name: name } }`,
+		},
+		{
+			name:        "display",
+			model:       testDisplay{},
+			path:        []string{"spec", "display"},
+			constructor: `new(name): { spec: { display: { name: name } } }`,
+		},
+	}
+	snippet := `
+local gen = import 'gen.libsonnet';
+{
+  resource: gen.resource.new('test')
+    + gen.resource.metadata.withTags(['a', 'b'])
+    + gen.resource.spec.display.withName('Test')
+    + gen.resource.spec.withPanelsMixin({ a: 1 })
+    + gen.resource.spec.withPanelsMixin({ b: 2 })
+    + gen.resource.spec.withLayouts('x')
+    + gen.resource.spec.withLayoutsMixin(['y'])
+    + gen.resource.spec.withRef('#/ref')
+    + gen.resource.spec.tree.withValue('root')
+    + gen.resource.spec.tree.withChildren({ value: 'child' }),
+  display: gen.display.new('name') + gen.display.withDescription('description'),
+  hasCreatedAt: std.objectHas(gen.resource.metadata, 'withCreatedAt'),
+  hasIgnored: std.objectHas(gen.resource.spec, 'withIgnored'),
+  hasRecursiveParent: std.objectHas(gen.resource.spec.tree, 'parent'),
+}
+`
+	expected := `{
+  "display": {"spec": {"display": {"description": "description", "name": "name"}}},
+  "hasCreatedAt": false,
+  "hasIgnored": false,
+  "hasRecursiveParent": false,
+  "resource": {
+    "kind": "Test",
+    "metadata": {"name": "test", "tags": ["a", "b"]},
+    "spec": {
+      "$ref": "#/ref",
+      "display": {"name": "Test"},
+      "layouts": ["x", "y"],
+      "panels": {"a": 1, "b": 2},
+      "tree": {"children": [{"value": "child"}], "value": "root"}
+    }
+  }
+}`
+	assert.JSONEq(t, expected, evaluateJsonnet(t, resources, snippet))
+}
+
+func TestJsonnetPanelGroups(t *testing.T) {
+	resources := []jsonnetResource{
+		{
+			name:        "dashboard",
+			model:       testResource{},
+			constructor: `new(name): { kind: 'Dashboard', metadata: { name: name }, spec: { panels: {}, layouts: [] } }`,
+		},
+	}
+	snippet := `
+local perses = import 'main.libsonnet';
+perses.dashboard.new('test')
++ perses.dashboard.withPanelGroups([
+  perses.panelGroup.new('first', [{ name: 'a' }, { name: 'b' }, { name: 'c' }]),
+])
++ perses.dashboard.withPanelGroups([
+  perses.panelGroup.new('second')
+  + perses.panelGroup.withPanels({ name: 'd' })
+  + perses.panelGroup.withPanelsWidth(24)
+  + perses.panelGroup.withCollapsed(true)
+  + perses.panelGroup.withRepeatVariable('instance'),
+])
+`
+	expected := `{
+  "kind": "Dashboard",
+  "metadata": {"name": "test"},
+  "spec": {
+    "panels": {"0_0": {"name": "a"}, "0_1": {"name": "b"}, "0_2": {"name": "c"}, "1_0": {"name": "d"}},
+    "layouts": [
+      {
+        "kind": "Grid",
+        "spec": {
+          "display": {"title": "first"},
+          "items": [
+            {"x": 0, "y": 0, "width": 12, "height": 8, "content": {"$ref": "#/spec/panels/0_0"}},
+            {"x": 12, "y": 0, "width": 12, "height": 8, "content": {"$ref": "#/spec/panels/0_1"}},
+            {"x": 0, "y": 8, "width": 12, "height": 8, "content": {"$ref": "#/spec/panels/0_2"}}
+          ]
+        }
+      },
+      {
+        "kind": "Grid",
+        "spec": {
+          "display": {"title": "second", "collapse": {"open": false}},
+          "items": [
+            {"x": 0, "y": 0, "width": 24, "height": 8, "content": {"$ref": "#/spec/panels/1_0"}}
+          ],
+          "repeatVariable": "instance"
+        }
+      }
+    ]
+  }
+}`
+	assert.JSONEq(t, expected, evaluateJsonnet(t, resources, snippet))
+}
@@ -19,9 +19,11 @@ import (
 	"io"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"strings"
 
 	persesCMD "github.com/perses/perses/internal/cli/cmd"
+	"github.com/perses/perses/internal/cli/cmd/dac/dependency"
 	"github.com/perses/perses/internal/cli/config"
 	"github.com/perses/perses/internal/cli/output"
 	"github.com/sirupsen/logrus"
@@ -30,11 +32,13 @@ import (
 )
 
 const (
-	cueLanguage      = "cue"
-	goLanguage       = "go"
-	minVersionForGo  = "v0.44.0" // Release that introduced the Go SDK
-	minVersionForCue = "v0.51.0" // Release that brought the move to CUE's new modules
-	exampleCUEDac    = `package mydac
+	cueLanguage          = "cue"
+	goLanguage           = "go"
+	jsonnetLanguage      = "jsonnet"
+	minVersionForGo      = "v0.44.0" // Release that introduced the Go SDK
+	minVersionForCue     = "v0.51.0" // Release that brought the move to CUE's new modules
+	minVersionForJsonnet = "v0.55.0" // Release that introduced the Jsonnet library
+	exampleCUEDac        = `package mydac
 
 import "github.com/perses/perses/cue/dac-utils/dashboard@v0"
 
@@ -57,6 +61,11 @@ func main() {
 	)
 	exec.BuildDashboard(builder, buildErr)
 }`
+	exampleJsonnetDac = `local perses = import 'perses/main.libsonnet';
+
+perses.dashboard.new('myDashboardAsCode')
++ perses.dashboard.metadata.withProject('myProject')
+`
 )
 
 func addOutputDirToGitignore() error {
@@ -169,6 +178,11 @@ func (o *option) Validate() error {
 		if err := exec.Command("go", "version").Run(); err != nil {
 			return fmt.Errorf("unable to use the required go binary: %w", err)
 		}
+	case jsonnetLanguage:
+		// No binary is required: the library is generated by percli, and the Jsonnet VM is embedded in percli.
+		if semver.Compare(o.version, minVersionForJsonnet) == -1 {
+			return fmt.Errorf("version should be at least %s or higher", minVersionForJsonnet)
+		}
 	default:
 		return fmt.Errorf("language %q is not supported", o.language)
 	}
@@ -225,6 +239,18 @@ func (o *option) Execute() error {
 		if err := cmd.Run(); err != nil {
 			return fmt.Errorf("unable to get the go dependencies github.com/perses/perses@%s : %w", o.version, err)
 		}
+	} else if o.language == jsonnetLanguage {
+		// Vendor the library where the imports are searched by default by `percli dac build`
+		libraryDir := filepath.Join(dependency.JsonnetVendorDir, jsonnetLibraryDir)
+		if err := vendorJsonnetLibrary(libraryDir); err != nil {
+			return fmt.Errorf("unable to vendor the Jsonnet library in %s: %w", libraryDir, err)
+		}
+		logrus.Debugf("Jsonnet library vendored successfully in %s", libraryDir)
+		exampleFilePath := "example.jsonnet"
+		if err := os.WriteFile(exampleFilePath, []byte(exampleJsonnetDac), 0600); err != nil {
+			return fmt.Errorf("failed to create example.jsonnet: %w", err)
+		}
+		logrus.Debugf("example.jsonnet file created successfully")
 	} else {
 		return fmt.Errorf("language %q is not supported", o.language)
 	}
@@ -249,6 +275,9 @@ func NewCMD() *cobra.Command {
 This command takes care of setting up a ready-to-use development environment to code dashboards.
 It mainly consists in retrieving the Perses libraries to start coding dashboards. The setup is language-specific (default: CUE).
 
+For Jsonnet, the library is generated from the data model of this version of percli, and vendored in the 'vendor/perses' folder.
+It can then be imported with "local perses = import 'perses/main.libsonnet';".
+
 /!\ This command must be executed at the root of your repo.
 `,
 		Example: `
@@ -257,12 +286,15 @@ percli dac setup
 
 # DaC setup when you are not connected to a server, you need to provide the Perses version to consider for dependencies retrieval
 percli dac setup --version 0.47.1
+
+# DaC setup for Jsonnet
+percli dac setup --language jsonnet --version 0.55.0
 `,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return persesCMD.Run(o, cmd, args)
 		},
 	}
-	cmd.Flags().StringVar(&o.language, "language", "cue", "language choosen for the setup. Possible value: cue, go.")
+	cmd.Flags().StringVar(&o.language, "language", "cue", "language choosen for the setup. Possible value: cue, go, jsonnet.")
 	cmd.Flags().StringVar(&o.version, "version", "", "Version of Perses from which to retrieve the CUE dependencies.")
 
 	return cmd
@@ -15,6 +15,7 @@ package setup
 
 import (
 	"os"
+	"path/filepath"
 	"testing"
 
 	cmdTest "github.com/perses/perses/internal/cli/test"
@@ -77,3 +78,40 @@ func TestDacSetupCMD(t *testing.T) {
 	_, err = os.Stat("main.go")
 	require.NoError(t, err)
 }
+
+func TestDacSetupJsonnetCMD(t *testing.T) {
+	originalWD, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get working directory: %v", err)
+	}
+	// The Jsonnet setup doesn't require any module file, so it is done in an empty directory
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatalf("Failed to change directory: %v", err)
+	}
+	defer func() {
+		if err := os.Chdir(originalWD); err != nil {
+			t.Fatalf("Failed to change back to the original directory: %v", err)
+		}
+	}()
+
+	testSuite := []cmdTest.Suite{
+		{
+			Title:           "too-old Perses version submitted",
+			Args:            []string{"--language", "jsonnet", "--version", "v0.54.0"},
+			IsErrorExpected: true,
+			ExpectedMessage: "version should be at least v0.55.0 or higher",
+		},
+		{
+			Title:                "Nominal case with Jsonnet",
+			Args:                 []string{"--language", "jsonnet", "--version", "v0.55.0"},
+			IsErrorExpected:      false,
+			ExpectedRegexMessage: "DaC setup for jsonnet finished successfully\n",
+		},
+	}
+	cmdTest.ExecuteSuiteTest(t, NewCMD, testSuite)
+
+	for _, file := range []string{"example.jsonnet", filepath.Join("vendor", "perses", "main.libsonnet"), filepath.Join("vendor", "perses", "gen.libsonnet")} {
+		_, err = os.Stat(file)
+		require.NoError(t, err)
+	}
+}
@@ -0,0 +1,474 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sync
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/efficientgo/core/merrors"
+	"github.com/perses/common/set"
+	persesCMD "github.com/perses/perses/internal/cli/cmd"
+	"github.com/perses/perses/internal/cli/cmd/dac/diff"
+	"github.com/perses/perses/internal/cli/config"
+	"github.com/perses/perses/internal/cli/file"
+	"github.com/perses/perses/internal/cli/opt"
+	"github.com/perses/perses/internal/cli/output"
+	"github.com/perses/perses/internal/cli/resource"
+	"github.com/perses/perses/internal/cli/service"
+	"github.com/perses/perses/pkg/client/api"
+	modelV1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// ownerTagPrefix is the prefix of the tag set on every dashboard pushed by the sync.
+	// Only the dashboards carrying the tag of the current owner can be deleted by the sync.
+	ownerTagPrefix = "managed-by:"
+	defaultOwner   = "dac"
+	// maxTagLength is the maximum length of a tag accepted by the API.
+	maxTagLength = 50
+)
+
+type action string
+
+const (
+	actionCreate action = "create"
+	actionUpdate action = "update"
+	actionDelete action = "delete"
+	// actionConflict is a remote dashboard sharing its name with a local one, but not carrying the owner tag.
+	// It is never applied: the sync only takes over such a dashboard with the flag --adopt.
+	actionConflict action = "conflict"
+)
+
+type change struct {
+	action  action
+	project string
+	name    string
+	// dashboard is the dashboard to create or to update. It is nil when the dashboard is deleted.
+	dashboard *modelV1.Dashboard
+	diff      string
+}
+
+type plan struct {
+	changes []change
+}
+
+func (p *plan) isEmpty() bool {
+	return len(p.changes) == 0
+}
+
+func (p *plan) count(a action) int {
+	result := 0
+	for _, c := range p.changes {
+		if c.action == a {
+			result++
+		}
+	}
+	return result
+}
+
+// String renders the plan the same way Terraform does: one line per change, grouped by project.
+func (p *plan) String() string {
+	if p.isEmpty() {
+		return "No changes. The remote dashboards are up to date with the local ones."
+	}
+	var b strings.Builder
+	b.WriteString("The following actions will be performed:\n")
+	currentProject := ""
+	for i, c := range p.changes {
+		if i == 0 || c.project != currentProject {
+			currentProject = c.project
+			fmt.Fprintf(&b, "\nproject %q:\n", currentProject)
+		}
+		switch c.action {
+		case actionCreate:
+			fmt.Fprintf(&b, "  + dashboard %q will be created\n", c.name)
+		case actionUpdate:
+			fmt.Fprintf(&b, "  ~ dashboard %q will be updated\n", c.name)
+			for _, line := range changedLines(c.diff) {
+				fmt.Fprintf(&b, "      %s\n", line)
+			}
+		case actionDelete:
+			fmt.Fprintf(&b, "  - dashboard %q will be deleted\n", c.name)
+		case actionConflict:
+			fmt.Fprintf(&b, "  ! dashboard %q already exists and is not managed by the sync\n", c.name)
+		}
+	}
+	fmt.Fprintf(&b, "\nPlan: %d to create, %d to update, %d to delete", p.count(actionCreate), p.count(actionUpdate), p.count(actionDelete))
+	if conflicts := p.count(actionConflict); conflicts > 0 {
+		fmt.Fprintf(&b, ", %d in conflict", conflicts)
+	}
+	b.WriteString(".")
+	return b.String()
+}
+
+// changedLines returns only the lines added or removed in the given diff.
+func changedLines(d string) []string {
+	var result []string
+	for _, line := range strings.Split(d, "\n") {
+		if strings.HasPrefix(line, "+") || strings.HasPrefix(line, "-") {
+			result = append(result, line)
+		}
+	}
+	return result
+}
+
+func ownerTag(owner string) string {
+	return ownerTagPrefix + owner
+}
+
+func isOwnedBy(dashboard *modelV1.Dashboard, tag string) bool {
+	return dashboard.Metadata.Tags != nil && dashboard.Metadata.Tags.Contains(tag)
+}
+
+// sortedTags returns the sorted tags of the dashboard, without the owner tag.
+func sortedTags(dashboard *modelV1.Dashboard, ownerTag string) []string {
+	var result []string
+	if dashboard.Metadata.Tags != nil {
+		for _, tag := range dashboard.Metadata.Tags.TransformAsSlice() {
+			if tag != ownerTag {
+				result = append(result, tag)
+			}
+		}
+	}
+	slices.Sort(result)
+	return result
+}
+
+// tagsDiff returns the tags removed and added between the current dashboard and the new one, in the same format as the
+// diff of the spec. The owner tag is ignored, as it is only missing on the remote dashboards taken over by the sync.
+func tagsDiff(current *modelV1.Dashboard, dashboard *modelV1.Dashboard, ownerTag string) string {
+	currentTags := sortedTags(current, ownerTag)
+	newTags := sortedTags(dashboard, ownerTag)
+	var lines []string
+	for _, tag := range currentTags {
+		if !slices.Contains(newTags, tag) {
+			lines = append(lines, fmt.Sprintf("-   tag %q", tag))
+		}
+	}
+	for _, tag := range newTags {
+		if !slices.Contains(currentTags, tag) {
+			lines = append(lines, fmt.Sprintf("+   tag %q", tag))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// computePlan compares the local dashboards with the remote ones (indexed by project) and returns the list of changes
+// required to make the remote state match the local one.
+// A remote dashboard absent from the local dashboards is only deleted when it carries the given owner tag.
+// A remote dashboard sharing its name with a local one, but not carrying the owner tag, is a conflict unless adopt is true.
+// A dashboard is updated when its spec or its tags changed.
+func computePlan(local []*modelV1.Dashboard, remote map[string][]*modelV1.Dashboard, tag string, adopt bool) (*plan, error) {
+	localByProject := make(map[string][]*modelV1.Dashboard)
+	for _, dashboard := range local {
+		localByProject[dashboard.Metadata.Project] = append(localByProject[dashboard.Metadata.Project], dashboard)
+	}
+	projects := set.New[string]()
+	for project := range localByProject {
+		projects.Add(project)
+	}
+	for project := range remote {
+		projects.Add(project)
+	}
+	sortedProjects := projects.TransformAsSlice()
+	slices.Sort(sortedProjects)
+
+	result := &plan{}
+	for _, project := range sortedProjects {
+		remoteByName := make(map[string]*modelV1.Dashboard)
+		for _, dashboard := range remote[project] {
+			remoteByName[dashboard.Metadata.Name] = dashboard
+		}
+		localDashboards := localByProject[project]
+		slices.SortFunc(localDashboards, func(a, b *modelV1.Dashboard) int {
+			return strings.Compare(a.Metadata.Name, b.Metadata.Name)
+		})
+		localNames := set.New[string]()
+		for _, dashboard := range localDashboards {
+			name := dashboard.Metadata.Name
+			localNames.Add(name)
+			current, exists := remoteByName[name]
+			if !exists {
+				result.changes = append(result.changes, change{action: actionCreate, project: project, name: name, dashboard: dashboard})
+				continue
+			}
+			isOwned := isOwnedBy(current, tag)
+			if !isOwned && !adopt {
+				result.changes = append(result.changes, change{action: actionConflict, project: project, name: name})
+				continue
+			}
+			d, err := diff.DashboardDiff(current, dashboard)
+			if err != nil {
+				return nil, fmt.Errorf("unable to compute the diff of the dashboard %q in the project %q: %w", name, project, err)
+			}
+			if t := tagsDiff(current, dashboard, tag); len(t) > 0 {
+				d = strings.TrimRight(d, "\n") + "\n" + t
+			}
+			if len(changedLines(d)) > 0 || !isOwned {
+				result.changes = append(result.changes, change{action: actionUpdate, project: project, name: name, dashboard: dashboard, diff: d})
+			}
+		}
+
+		remoteDashboards := remote[project]
+		slices.SortFunc(remoteDashboards, func(a, b *modelV1.Dashboard) int {
+			return strings.Compare(a.Metadata.Name, b.Metadata.Name)
+		})
+		for _, dashboard := range remoteDashboards {
+			if !localNames.Contains(dashboard.Metadata.Name) && isOwnedBy(dashboard, tag) {
+				result.changes = append(result.changes, change{action: actionDelete, project: project, name: dashboard.Metadata.Name})
+			}
+		}
+	}
+	return result, nil
+}
+
+type option struct {
+	persesCMD.Option
+	opt.ProjectOption
+	opt.FileOption
+	opt.DirectoryOption
+	owner         string
+	adopt         bool
+	autoApprove   bool
+	dryRun        bool
+	projectScoped bool
+	writer        io.Writer
+	errWriter     io.Writer
+	reader        io.Reader
+	apiClient     api.ClientInterface
+	// scope is the list of the projects the sync is looking at to find the dashboards to delete.
+	scope      []string
+	dashboards []*modelV1.Dashboard
+}
+
+func (o *option) Complete(args []string) error {
+	if len(args) > 0 {
+		return fmt.Errorf("no args are supported by the command 'dac sync'")
+	}
+	if len(o.Directory) == 0 && len(o.File) == 0 {
+		o.Directory = config.Global.Dac.OutputFolder
+		if len(o.Directory) == 0 {
+			return fmt.Errorf("you need to set the flag --directory or --file or to set the output folder for the 'dac' command")
+		}
+	}
+	apiClient, err := config.Global.GetAPIClient()
+	if err != nil {
+		return err
+	}
+	o.apiClient = apiClient
+
+	// When a project is explicitly given, the sync is limited to this project.
+	// Otherwise, it is limited to the projects of the local dashboards, so the repositories using the same owner for
+	// different projects don't delete the dashboards of each other.
+	if len(o.Project) > 0 {
+		o.projectScoped = true
+		o.scope = []string{o.Project}
+	} else {
+		o.Project = config.Global.Project
+	}
+	if dashboardsErr := o.setDashboards(); dashboardsErr != nil {
+		return dashboardsErr
+	}
+	if !o.projectScoped {
+		o.scope = localProjects(o.dashboards)
+	}
+	return nil
+}
+
+// localProjects returns the sorted list of the projects of the given dashboards.
+func localProjects(dashboards []*modelV1.Dashboard) []string {
+	projects := set.New[string]()
+	for _, dashboard := range dashboards {
+		if len(dashboard.Metadata.Project) > 0 {
+			projects.Add(dashboard.Metadata.Project)
+		}
+	}
+	result := projects.TransformAsSlice()
+	slices.Sort(result)
+	return result
+}
+
+func (o *option) Validate() error {
+	if len(o.owner) == 0 {
+		return fmt.Errorf("the owner cannot be empty")
+	}
+	if strings.ContainsFunc(o.owner, unicode.IsSpace) {
+		return fmt.Errorf("the owner %q cannot contain any whitespace", o.owner)
+	}
+	if utf8.RuneCountInString(ownerTag(o.owner)) > maxTagLength {
+		return fmt.Errorf("the owner %q is too long, the tag %q cannot contain more than %d characters", o.owner, ownerTag(o.owner), maxTagLength)
+	}
+	result := merrors.New()
+	for _, dashboard := range o.dashboards {
+		if len(dashboard.Metadata.Project) == 0 {
+			result.Add(fmt.Errorf("project is not defined for the dashboard %q. Please set it in the dashboard or using the flag --project", dashboard.Metadata.Name))
+		} else if o.projectScoped && dashboard.Metadata.Project != o.Project {
+			result.Add(fmt.Errorf("the dashboard %q belongs to the project %q while the sync is limited to the project %q", dashboard.Metadata.Name, dashboard.Metadata.Project, o.Project))
+		}
+	}
+	return result.Err()
+}
+
+func (o *option) Execute() error {
+	remote := make(map[string][]*modelV1.Dashboard)
+	for _, project := range o.scope {
+		dashboards, err := o.apiClient.V1().Dashboard(project).List("")
+		if err != nil {
+			return fmt.Errorf("unable to list the dashboards of the project %q: %w", project, err)
+		}
+		remote[project] = dashboards
+	}
+	p, err := computePlan(o.dashboards, remote, ownerTag(o.owner), o.adopt)
+	if err != nil {
+		return err
+	}
+	if outputErr := output.HandleString(o.writer, p.String()); outputErr != nil {
+		return outputErr
+	}
+	if p.isEmpty() || o.dryRun {
+		return nil
+	}
+	if conflicts := p.count(actionConflict); conflicts > 0 {
+		return fmt.Errorf("%d dashboard(s) already exist and are not managed by the sync. Rename the local dashboards, or use the flag --adopt to take over the remote ones", conflicts)
+	}
+	if !o.autoApprove {
+		approved, confirmErr := o.confirm()
+		if confirmErr != nil {
+			return confirmErr
+		}
+		if !approved {
+			return fmt.Errorf("sync cancelled")
+		}
+	}
+	return o.applyPlan(p)
+}
+
+func (o *option) confirm() (bool, error) {
+	if _, err := fmt.Fprint(o.writer, "\nDo you want to perform these actions? Only 'yes' will be accepted to approve.\n\nEnter a value: "); err != nil {
+		return false, err
+	}
+	input, err := bufio.NewReader(o.reader).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return false, err
+	}
+	return strings.TrimSpace(input) == "yes", nil
+}
+
+func (o *option) applyPlan(p *plan) error {
+	result := merrors.New()
+	for _, c := range p.changes {
+		svc, err := service.New(modelV1.KindDashboard, c.project, o.apiClient)
+		if err != nil {
+			return err
+		}
+		var message string
+		switch c.action {
+		case actionCreate:
+			_, err = svc.CreateResource(c.dashboard)
+			message = fmt.Sprintf("object %q %q has been created", modelV1.KindDashboard, c.name)
+		case actionUpdate:
+			_, err = svc.UpdateResource(c.dashboard)
+			message = fmt.Sprintf("object %q %q has been updated", modelV1.KindDashboard, c.name)
+		case actionDelete:
+			err = svc.DeleteResource(c.name)
+			message = fmt.Sprintf("object %q %q has been deleted", modelV1.KindDashboard, c.name)
+		}
+		if err != nil {
+			result.Add(fmt.Errorf("unable to %s the dashboard %q in the project %q: %w", c.action, c.name, c.project, err))
+			continue
+		}
+		if outputErr := resource.HandleSuccessMessage(o.writer, modelV1.KindDashboard, c.project, message); outputErr != nil {
+			return outputErr
+		}
+	}
+	return result.Err()
+}
+
+func (o *option) setDashboards() error {
+	entities, err := file.UnmarshalEntities(o.File, o.Directory)
+	if err != nil {
+		return err
+	}
+	tag := ownerTag(o.owner)
+	for _, e := range entities {
+		if e.GetKind() != string(modelV1.KindDashboard) {
+			continue
+		}
+		dashboard := e.(*modelV1.Dashboard)
+		dashboard.Metadata.Project = resource.GetProject(dashboard.GetMetadata(), o.Project)
+		if dashboard.Metadata.Tags == nil {
+			dashboard.Metadata.Tags = set.New[string]()
+		}
+		dashboard.Metadata.Tags.Add(tag)
+		o.dashboards = append(o.dashboards, dashboard)
+	}
+	if len(o.dashboards) == 0 {
+		return fmt.Errorf("no dashboard found to sync")
+	}
+	return nil
+}
+
+func (o *option) SetWriter(writer io.Writer) {
+	o.writer = writer
+}
+
+func (o *option) SetErrWriter(errWriter io.Writer) {
+	o.errWriter = errWriter
+}
+
+func NewCMD() *cobra.Command {
+	o := &option{}
+	cmd := &cobra.Command{
+		Use:   "sync (-f [FILENAME] | -d [DIRECTORY_NAME])",
+		Short: "Synchronize the remote dashboards with the local ones, including the deletion of the removed dashboards",
+		Long: `
+Compute the full plan (dashboards to create, to update and to delete) required to make the remote dashboards match the
+local ones, print it, and apply it once confirmed.
+
+Every dashboard pushed by the sync is tagged with "managed-by:<owner>". Only the remote dashboards carrying this tag
+can be updated or deleted by the sync, so the dashboards created by hand are never touched. A remote dashboard without
+the tag sharing its name with a local one is reported as a conflict, and the plan is not applied. Use the flag --adopt
+to update such dashboards and take them over.
+
+When a project is given with the flag --project, the sync is limited to this project. Otherwise, it is limited to the
+projects of the local dashboards: the dashboards of a project without any local dashboard are never deleted.
+`,
+		Example: `
+# Print the plan without applying it
+percli dac sync -d ./built --dry-run
+
+# Apply the plan without asking for confirmation
+percli dac sync -d ./built --project my-project --auto-approve
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			o.reader = cmd.InOrStdin()
+			return persesCMD.Run(o, cmd, args)
+		},
+	}
+	opt.AddProjectFlags(cmd, &o.ProjectOption)
+	opt.AddFileFlags(cmd, &o.FileOption)
+	opt.AddDirectoryFlags(cmd, &o.DirectoryOption)
+	cmd.Flags().StringVar(&o.owner, "owner", defaultOwner, "Name identifying the owner of the synchronized dashboards. Only the dashboards tagged with this owner can be deleted.")
+	cmd.Flags().BoolVar(&o.adopt, "adopt", false, "Update the remote dashboards sharing their name with a local one but not tagged with the owner, and tag them.")
+	cmd.Flags().BoolVar(&o.autoApprove, "auto-approve", false, "Apply the plan without asking for confirmation.")
+	cmd.Flags().BoolVar(&o.dryRun, "dry-run", false, "Only print the plan, without applying it.")
+	return cmd
+}
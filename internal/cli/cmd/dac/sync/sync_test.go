@@ -0,0 +1,239 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sync
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/perses/common/set"
+	"github.com/perses/perses/internal/cli/config"
+	cmdTest "github.com/perses/perses/internal/cli/test"
+	fakeapi "github.com/perses/perses/pkg/client/fake/api"
+	modelV1 "github.com/perses/perses/pkg/model/api/v1"
+	commonSpec "github.com/perses/spec/go/common"
+	dashboardSpec "github.com/perses/spec/go/dashboard"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSyncCMD(t *testing.T) {
+	testSuite := []cmdTest.Suite{
+		{
+			Title:           "empty args",
+			Args:            []string{},
+			IsErrorExpected: true,
+			ExpectedMessage: "you are not connected to any API",
+		},
+		{
+			Title:           "no dashboard",
+			Args:            []string{},
+			APIClient:       fakeapi.New(),
+			Config:          config.Config{Dac: config.Dac{OutputFolder: "./emptybuild"}},
+			IsErrorExpected: true,
+			ExpectedMessage: "no dashboard found to sync",
+		},
+		{
+			Title:           "owner with whitespace",
+			Args:            []string{"-d", "./testdata", "--owner", "my team"},
+			APIClient:       fakeapi.New(),
+			IsErrorExpected: true,
+			ExpectedMessage: "the owner \"my team\" cannot contain any whitespace",
+		},
+		{
+			Title:           "dashboard outside of the project",
+			Args:            []string{"-d", "./testdata", "--project", "Amadeus"},
+			APIClient:       fakeapi.New(),
+			IsErrorExpected: true,
+			ExpectedMessage: "the dashboard \"node-exporter\" belongs to the project \"perses\" while the sync is limited to the project \"Amadeus\"",
+		},
+		{
+			Title:     "dry run",
+			Args:      []string{"-d", "./testdata", "--dry-run"},
+			APIClient: fakeapi.New(),
+			ExpectedMessage: `The following actions will be performed:
+
+project "perses":
+  + dashboard "node-exporter" will be created
+
+Plan: 1 to create, 0 to update, 0 to delete.
+`,
+		},
+		{
+			Title:     "auto approve",
+			Args:      []string{"-d", "./testdata", "--project", "perses", "--auto-approve"},
+			APIClient: fakeapi.New(),
+			ExpectedMessage: `The following actions will be performed:
+
+project "perses":
+  + dashboard "node-exporter" will be created
+
+Plan: 1 to create, 0 to update, 0 to delete.
+object "Dashboard" "node-exporter" has been created in the project "perses"
+`,
+		},
+	}
+	cmdTest.ExecuteSuiteTest(t, NewCMD, testSuite)
+}
+
+func newDashboard(project, name string, duration time.Duration, tags ...string) *modelV1.Dashboard {
+	dashboard := &modelV1.Dashboard{
+		Kind: modelV1.KindDashboard,
+		Metadata: modelV1.ProjectMetadata{
+			Metadata: modelV1.Metadata{
+				Name: name,
+			},
+			ProjectMetadataWrapper: modelV1.ProjectMetadataWrapper{
+				Project: project,
+			},
+		},
+		Spec: dashboardSpec.Spec{
+			Duration: commonSpec.Duration(duration),
+		},
+	}
+	if len(tags) > 0 {
+		dashboard.Metadata.Tags = set.New(tags...)
+	}
+	return dashboard
+}
+
+func TestComputePlan(t *testing.T) {
+	tag := ownerTag(defaultOwner)
+	local := []*modelV1.Dashboard{
+		newDashboard("perses", "unchanged", time.Hour, tag),
+		newDashboard("perses", "updated", 6*time.Hour, tag),
+		newDashboard("perses", "adopted", time.Hour, tag),
+		newDashboard("perses", "created", time.Hour, tag),
+	}
+	remote := map[string][]*modelV1.Dashboard{
+		"perses": {
+			newDashboard("perses", "unchanged", time.Hour, tag),
+			newDashboard("perses", "updated", time.Hour, tag),
+			newDashboard("perses", "adopted", time.Hour),
+			newDashboard("perses", "removed", time.Hour, tag),
+			newDashboard("perses", "handmade", time.Hour),
+			newDashboard("perses", "other-owner", time.Hour, ownerTag("other")),
+		},
+		"Amadeus": {
+			newDashboard("Amadeus", "removed", time.Hour, tag),
+		},
+	}
+	p, err := computePlan(local, remote, tag, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, c := range p.changes {
+		got = append(got, string(c.action)+" "+c.project+"/"+c.name)
+	}
+	assert.Equal(t, []string{
+		"delete Amadeus/removed",
+		"update perses/adopted",
+		"create perses/created",
+		"update perses/updated",
+		"delete perses/removed",
+	}, got)
+	assert.Equal(t, 1, p.count(actionCreate))
+	assert.Equal(t, 2, p.count(actionUpdate))
+	assert.Equal(t, 2, p.count(actionDelete))
+	assert.True(t, strings.HasSuffix(p.String(), "Plan: 1 to create, 2 to update, 2 to delete."))
+	assert.Len(t, changedLines(p.changes[3].diff), 2)
+	assert.Contains(t, p.String(), "  ~ dashboard \"updated\" will be updated\n      -")
+}
+
+func TestComputePlanConflict(t *testing.T) {
+	tag := ownerTag(defaultOwner)
+	local := []*modelV1.Dashboard{
+		newDashboard("perses", "handmade", 6*time.Hour, tag),
+		newDashboard("perses", "other-owner", time.Hour, tag),
+	}
+	remote := map[string][]*modelV1.Dashboard{
+		"perses": {
+			newDashboard("perses", "handmade", time.Hour),
+			newDashboard("perses", "other-owner", time.Hour, ownerTag("other")),
+		},
+	}
+	p, err := computePlan(local, remote, tag, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 2, p.count(actionConflict))
+	assert.Equal(t, 0, p.count(actionUpdate))
+	assert.Contains(t, p.String(), "  ! dashboard \"handmade\" already exists and is not managed by the sync\n")
+	assert.True(t, strings.HasSuffix(p.String(), "Plan: 0 to create, 0 to update, 0 to delete, 2 in conflict."))
+}
+
+func TestComputePlanTags(t *testing.T) {
+	tag := ownerTag(defaultOwner)
+	local := []*modelV1.Dashboard{
+		newDashboard("perses", "adopted", time.Hour, tag, "team-a"),
+		newDashboard("perses", "retagged", time.Hour, tag, "team-b"),
+		newDashboard("perses", "unchanged", time.Hour, tag, "team-a"),
+	}
+	remote := map[string][]*modelV1.Dashboard{
+		"perses": {
+			newDashboard("perses", "adopted", time.Hour, "team-a"),
+			newDashboard("perses", "retagged", time.Hour, tag, "team-a"),
+			newDashboard("perses", "unchanged", time.Hour, tag, "team-a"),
+		},
+	}
+	p, err := computePlan(local, remote, tag, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Len(t, p.changes, 2)
+	// The owner tag missing on the adopted dashboard isn't reported as a change of its tags.
+	assert.Equal(t, "adopted", p.changes[0].name)
+	assert.Empty(t, changedLines(p.changes[0].diff))
+	assert.Equal(t, "retagged", p.changes[1].name)
+	assert.Equal(t, []string{`-   tag "team-a"`, `+   tag "team-b"`}, changedLines(p.changes[1].diff))
+}
+
+func TestComputePlanNoChanges(t *testing.T) {
+	tag := ownerTag(defaultOwner)
+	local := []*modelV1.Dashboard{newDashboard("perses", "unchanged", time.Hour, tag)}
+	remote := map[string][]*modelV1.Dashboard{"perses": {newDashboard("perses", "unchanged", time.Hour, tag)}}
+	p, err := computePlan(local, remote, tag, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.True(t, p.isEmpty())
+}
+
+func TestLocalProjects(t *testing.T) {
+	local := []*modelV1.Dashboard{
+		newDashboard("perses", "node-exporter", time.Hour),
+		newDashboard("Amadeus", "kafka", time.Hour),
+		newDashboard("perses", "blackbox", time.Hour),
+		newDashboard("", "no-project", time.Hour),
+	}
+	assert.Equal(t, []string{"Amadeus", "perses"}, localProjects(local))
+}
+
+func TestConfirm(t *testing.T) {
+	for _, test := range []struct {
+		input    string
+		expected bool
+	}{
+		{input: "yes\n", expected: true},
+		{input: "yes", expected: true},
+		{input: "y\n", expected: false},
+		{input: "", expected: false},
+	} {
+		o := &option{writer: &strings.Builder{}, reader: strings.NewReader(test.input)}
+		approved, err := o.confirm()
+		assert.NoError(t, err)
+		assert.Equal(t, test.expected, approved, "input %q", test.input)
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"encoding/xml"
+	"os"
+	"strings"
+
+	"github.com/perses/perses/go-sdk/dactest"
+)
+
+// The structs below describe the subset of the JUnit XML format understood by the CI tools.
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Content string `xml:",chardata"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitReport struct {
+	XMLName    xml.Name         `xml:"testsuites"`
+	Tests      int              `xml:"tests,attr"`
+	Failures   int              `xml:"failures,attr"`
+	TestSuites []junitTestSuite `xml:"testsuite"`
+}
+
+func newJUnitReport() *junitReport {
+	return &junitReport{}
+}
+
+// addSuite adds the results of a test file to the report. Each dashboard tested by a test case is a JUnit test case.
+func (r *junitReport) addSuite(name string, results []dactest.Result) {
+	suite := junitTestSuite{Name: name}
+	for _, result := range results {
+		testCase := junitTestCase{
+			Name:      result.Case,
+			ClassName: dashboardID(result),
+		}
+		if !result.Passed() {
+			testCase.Failure = &junitFailure{
+				Message: result.Failures[0],
+				Content: strings.Join(result.Failures, "\n"),
+			}
+			suite.Failures++
+		}
+		suite.TestCases = append(suite.TestCases, testCase)
+		suite.Tests++
+	}
+	r.Tests += suite.Tests
+	r.Failures += suite.Failures
+	r.TestSuites = append(r.TestSuites, suite)
+}
+
+func (r *junitReport) marshal() ([]byte, error) {
+	data, err := xml.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), append(data, '\n')...), nil
+}
+
+func (r *junitReport) write(path string) error {
+	data, err := r.marshal()
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644) // nolint: gosec
+}
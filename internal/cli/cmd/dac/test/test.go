@@ -0,0 +1,227 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/perses/perses/go-sdk/dactest"
+	persesCMD "github.com/perses/perses/internal/cli/cmd"
+	"github.com/perses/perses/internal/cli/config"
+	"github.com/perses/perses/internal/cli/file"
+	"github.com/perses/perses/internal/cli/opt"
+	"github.com/perses/perses/internal/cli/output"
+	modelV1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/spf13/cobra"
+)
+
+const cueExtension = ".cue"
+
+// suiteFile is a test suite along with the file it comes from.
+type suiteFile struct {
+	path  string
+	suite dactest.Suite
+}
+
+type option struct {
+	persesCMD.Option
+	opt.FileOption
+	opt.DirectoryOption
+	writer       io.Writer
+	errWriter    io.Writer
+	junitPath    string
+	updateGolden bool
+	suites       []suiteFile
+	dashboards   []*modelV1.Dashboard
+}
+
+func (o *option) Complete(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("at least one test file must be provided")
+	}
+	for _, path := range args {
+		suite, err := readSuite(path)
+		if err != nil {
+			return fmt.Errorf("unable to read the test file %q: %w", path, err)
+		}
+		o.suites = append(o.suites, suiteFile{path: path, suite: suite})
+	}
+	if len(o.Directory) == 0 && len(o.File) == 0 {
+		o.Directory = config.Global.Dac.OutputFolder
+		if len(o.Directory) == 0 {
+			return fmt.Errorf("you need to set the flag --directory or --file or to set the output folder for the 'dac' command")
+		}
+	}
+	entities, err := file.UnmarshalEntities(o.File, o.Directory)
+	if err != nil {
+		return err
+	}
+	for _, entity := range entities {
+		if dashboard, ok := entity.(*modelV1.Dashboard); ok {
+			o.dashboards = append(o.dashboards, dashboard)
+		}
+	}
+	return nil
+}
+
+// readSuite reads a test suite written in JSON, YAML or CUE.
+// The golden folders are relative to the test file.
+func readSuite(path string) (dactest.Suite, error) {
+	var suite dactest.Suite
+	if filepath.Ext(path) == cueExtension {
+		// Like for `percli dac build`, the CUE file is evaluated by the cue CLI, so its dependencies are resolved the same way.
+		cmd := exec.Command("cue", "eval", path, "--out", "json", "--concrete") // #nosec
+		data, err := cmd.Output()
+		if err != nil {
+			var exitErr *exec.ExitError
+			if errors.As(err, &exitErr) {
+				return suite, errors.New(string(exitErr.Stderr))
+			}
+			return suite, err
+		}
+		if unmarshalErr := json.Unmarshal(data, &suite); unmarshalErr != nil {
+			return suite, unmarshalErr
+		}
+	} else if err := file.Unmarshal(path, &suite); err != nil {
+		return suite, err
+	}
+	if err := suite.Verify(); err != nil {
+		return suite, err
+	}
+	for _, c := range suite.Tests {
+		if len(c.Golden) > 0 && !filepath.IsAbs(c.Golden) {
+			c.Golden = filepath.Join(filepath.Dir(path), c.Golden)
+		}
+	}
+	return suite, nil
+}
+
+func (o *option) Validate() error {
+	if len(o.dashboards) == 0 {
+		return fmt.Errorf("no dashboard found to test")
+	}
+	return nil
+}
+
+func (o *option) Execute() error {
+	var msg strings.Builder
+	report := newJUnitReport()
+	total := 0
+	failed := 0
+	for _, s := range o.suites {
+		var results []dactest.Result
+		for _, c := range s.suite.Tests {
+			results = append(results, c.Run(o.dashboards, o.updateGolden)...)
+		}
+		report.addSuite(s.path, results)
+		fmt.Fprintf(&msg, "=== %s\n", s.path)
+		for _, result := range results {
+			total++
+			status := "PASS"
+			if !result.Passed() {
+				failed++
+				status = "FAIL"
+			}
+			fmt.Fprintf(&msg, "--- %s: %s (%s)\n", status, result.Case, dashboardID(result))
+			for _, failure := range result.Failures {
+				fmt.Fprintf(&msg, "    %s\n", strings.ReplaceAll(failure, "\n", "\n    "))
+			}
+		}
+	}
+	if len(o.junitPath) > 0 {
+		if err := report.write(o.junitPath); err != nil {
+			return fmt.Errorf("unable to write the JUnit report: %w", err)
+		}
+	}
+	if failed > 0 {
+		fmt.Fprintf(&msg, "FAIL: %d of %d test(s) failed", failed, total)
+	} else {
+		fmt.Fprintf(&msg, "PASS: %d test(s)", total)
+	}
+	if err := output.HandleString(o.writer, msg.String()); err != nil {
+		return err
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d test(s) failed", failed)
+	}
+	return nil
+}
+
+func dashboardID(result dactest.Result) string {
+	if len(result.Project) == 0 {
+		return result.Dashboard
+	}
+	return fmt.Sprintf("%s/%s", result.Project, result.Dashboard)
+}
+
+func (o *option) SetWriter(writer io.Writer) {
+	o.writer = writer
+}
+
+func (o *option) SetErrWriter(errWriter io.Writer) {
+	o.errWriter = errWriter
+}
+
+func NewCMD() *cobra.Command {
+	o := &option{}
+	cmd := &cobra.Command{
+		Use:   "test TEST_FILE...",
+		Short: "Run the tests of the dashboards built",
+		Long: `Run the tests of the dashboards built, by default the ones stored in the output folder of 'percli dac build'.
+
+A test file contains a list of tests, each of them being run against every dashboard, or only the dashboards it lists:
+
+tests:
+  - name: conventions
+    rules:
+      - name: every panel has a description
+        target: "$.spec"
+        assertion: '!has(value.panels) || value.panels.all(k, has(value.panels[k].spec.display.description))'
+        message: every panel must have a description
+  - name: no regression
+    dashboards:
+      - node-exporter
+    golden: ./golden
+
+The rules are the custom lint rules also used by the API and 'percli lint'.
+With 'golden', each dashboard is compared with the file <name>.json stored in the given folder, relative to the test file.
+
+The test files can be written in JSON, YAML or CUE. In CUE, the package github.com/perses/perses/cue/dac-utils/test provides predefined rules.`,
+		Example: `
+# Build the dashboards and test them
+percli dac build -d dac && percli dac test ./tests.yaml
+
+# Write the report of the tests in the JUnit format, to be displayed by the CI
+percli dac test ./tests.cue --junit ./report.xml
+
+# Update the golden files with the dashboards built
+percli dac test ./tests.yaml --update-golden
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return persesCMD.Run(o, cmd, args)
+		},
+	}
+	opt.AddFileFlags(cmd, &o.FileOption)
+	opt.AddDirectoryFlags(cmd, &o.DirectoryOption)
+	cmd.MarkFlagsMutuallyExclusive("file", "directory")
+	cmd.Flags().StringVar(&o.junitPath, "junit", "", "Path to the file where the report of the tests is written in the JUnit XML format.")
+	cmd.Flags().BoolVar(&o.updateGolden, "update-golden", false, "Write the golden files with the dashboards instead of comparing them.")
+	return cmd
+}
@@ -0,0 +1,76 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	cmdTest "github.com/perses/perses/internal/cli/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDacTestCMD(t *testing.T) {
+	junitPath := filepath.Join(t.TempDir(), "report.xml")
+	testSuite := []cmdTest.Suite{
+		{
+			Title:           "no test file",
+			Args:            []string{"-d", "./testdata/built"},
+			IsErrorExpected: true,
+			ExpectedMessage: "at least one test file must be provided",
+		},
+		{
+			Title:           "empty test file",
+			Args:            []string{"./testdata/empty-tests.yaml", "-d", "./testdata/built"},
+			IsErrorExpected: true,
+			ExpectedMessage: "unable to read the test file \"./testdata/empty-tests.yaml\": the test suite doesn't contain any test",
+		},
+		{
+			Title: "passing tests",
+			Args:  []string{"./testdata/tests.yaml", "-d", "./testdata/built"},
+			ExpectedMessage: `=== ./testdata/tests.yaml
+--- PASS: conventions (perses/node-exporter)
+PASS: 1 test(s)
+`,
+		},
+		{
+			Title:           "failing tests",
+			Args:            []string{"./testdata/tests.yaml", "./testdata/failing-tests.yaml", "-d", "./testdata/built", "--junit", junitPath},
+			IsErrorExpected: true,
+			ExpectedMessage: "2 test(s) failed",
+		},
+	}
+	cmdTest.ExecuteSuiteTest(t, NewCMD, testSuite)
+
+	report, err := os.ReadFile(junitPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites tests="3" failures="2">
+  <testsuite name="./testdata/tests.yaml" tests="1" failures="0">
+    <testcase name="conventions" classname="perses/node-exporter"></testcase>
+  </testsuite>
+  <testsuite name="./testdata/failing-tests.yaml" tests="2" failures="2">
+    <testcase name="descriptions" classname="perses/node-exporter">
+      <failure message="every panel must have a description">every panel must have a description</failure>
+    </testcase>
+    <testcase name="unknown dashboard" classname="unknown">
+      <failure message="dashboard not found">dashboard not found</failure>
+    </testcase>
+  </testsuite>
+</testsuites>
+`, string(report))
+}
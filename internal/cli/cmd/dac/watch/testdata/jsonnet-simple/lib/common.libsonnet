@@ -0,0 +1,6 @@
+{
+  panel(name): {
+    kind: 'Panel',
+    spec: { display: { name: name }, plugin: { kind: 'TimeSeriesChart', spec: {} } },
+  },
+}
@@ -0,0 +1,5 @@
+local common = import 'common.libsonnet';
+
+{
+  cpu: common.panel('CPU usage'),
+}
@@ -158,20 +158,21 @@ func NewCMD() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "watch [source-dir]",
 		Short: "Watch Dashboard-as-Code files and auto-rebuild on changes",
-		Long: `Watch Go/CUE files in the specified directory and automatically rebuild JSON/YAML outputs when files are saved.
+		Long: `Watch Go/CUE/Jsonnet files in the specified directory and automatically rebuild JSON/YAML outputs when files are saved.
 
 This command provides a development workflow similar to frontend hot-reload, where changes to your Dashboard-as-Code files are immediately compiled and made available for provisioning.
 
 The watcher will:
 - Perform an initial build
-- Monitor all .go and .cue files in the source directory
+- Monitor all .go, .cue, .jsonnet and .libsonnet files in the source directory
 - Rebuild automatically when files are modified
 - Output results to the build directory (default: ./built)
 
 File identification:
 - Go files: Must be "package main" to be built as dashboards
 - CUE files: Identified as dashboards if they have a named import "dashboardBuilder" OR if the filename contains "dashboard"
-- Library files: Other .go/.cue files are treated as libraries and trigger rebuilds of dependent dashboards
+- Jsonnet files: .jsonnet files are dashboards, .libsonnet files are libraries
+- Library files: Other .go/.cue/.libsonnet files are treated as libraries and trigger rebuilds of dependent dashboards
 
 Combine this with Perses provisioning to see your dashboard changes reflected in the UI automatically.`,
 		Example: `# Watch current directory, output to ./built
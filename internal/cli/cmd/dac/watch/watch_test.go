@@ -23,89 +23,6 @@ import (
 	"github.com/perses/spec/go/common"
 )
 
-func TestParseGoImports(t *testing.T) {
-	tests := []struct {
-		name     string
-		file     string
-		expected []string
-	}{
-		{
-			name: "dashboard with single import",
-			file: filepath.Join("testdata", "go-simple", "dashboards", "dash1", "main.go"),
-			expected: []string{
-				"test-dac.com/m/mylibrary",
-			},
-		},
-		{
-			name: "library with nested import",
-			file: filepath.Join("testdata", "go-simple", "mylibrary", "lib.go"),
-			expected: []string{
-				"test-dac.com/m/mylibrary/nested",
-			},
-		},
-		{
-			name:     "nested library with no imports",
-			file:     filepath.Join("testdata", "go-simple", "mylibrary", "nested", "deep.go"),
-			expected: nil,
-		},
-	}
-
-	w := &watcher{
-		sourceDir: filepath.Join("testdata", "go-simple"),
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			result := w.parseGoImports(tt.file)
-			if !equalStringSlices(result, tt.expected) {
-				t.Errorf("parseGoImports(%s) = %v, expected %v", tt.file, result, tt.expected)
-			}
-		})
-	}
-}
-
-func TestParseCueImports(t *testing.T) {
-	tests := []struct {
-		name     string
-		file     string
-		expected []string
-	}{
-		{
-			name: "dashboard with multiple imports",
-			file: filepath.Join("testdata", "cue-simple", "dashboards", "dash1.cue"),
-			expected: []string{
-				"github.com/perses/perses/cue/dac-utils/dashboard",
-				"test-dac-cue.com/m/panels",
-			},
-		},
-		{
-			name: "dashboard with single import",
-			file: filepath.Join("testdata", "cue-simple", "dashboards", "my-dashboard.cue"),
-			expected: []string{
-				"test-dac-cue.com/m/panels",
-			},
-		},
-		{
-			name:     "library with no imports",
-			file:     filepath.Join("testdata", "cue-simple", "panels", "panel.cue"),
-			expected: nil,
-		},
-	}
-
-	w := &watcher{
-		sourceDir: filepath.Join("testdata", "cue-simple"),
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			result := w.parseCueImports(tt.file)
-			if !equalStringSlices(result, tt.expected) {
-				t.Errorf("parseCueImports(%s) = %v, expected %v", tt.file, result, tt.expected)
-			}
-		})
-	}
-}
-
 func TestFindDashboardFiles(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -131,6 +48,15 @@ func TestFindDashboardFiles(t *testing.T) {
 				filepath.Join("testdata", "cue-simple", "dashboards", "my-dashboard.cue"),
 			},
 		},
+		{
+			name:      "Jsonnet project with 2 dashboards",
+			sourceDir: filepath.Join("testdata", "jsonnet-simple"),
+			buildDir:  "built",
+			expected: []string{
+				filepath.Join("testdata", "jsonnet-simple", "dashboards", "dash1.jsonnet"),
+				filepath.Join("testdata", "jsonnet-simple", "dashboards", "dash2.jsonnet"),
+			},
+		},
 		{
 			// The build directory must be skipped: pointing it at dash2 excludes it.
 			name:      "Go project with build dir inside dashboards",
@@ -198,6 +124,20 @@ func TestBuildDependencyMap(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:             "Jsonnet project dependencies",
+			sourceDir:        filepath.Join("testdata", "jsonnet-simple"),
+			expectedLibCount: 3, // lib/panels.libsonnet, lib/common.libsonnet and lib/description.txt
+			libraryChecks: map[string][]string{
+				filepath.Join("testdata", "jsonnet-simple", "lib", "common.libsonnet"): {
+					filepath.Join("testdata", "jsonnet-simple", "dashboards", "dash1.jsonnet"),
+					filepath.Join("testdata", "jsonnet-simple", "dashboards", "dash2.jsonnet"),
+				},
+				filepath.Join("testdata", "jsonnet-simple", "lib", "description.txt"): {
+					filepath.Join("testdata", "jsonnet-simple", "dashboards", "dash2.jsonnet"),
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -15,28 +15,18 @@ package watch
 
 import (
 	"context"
-	"go/parser"
-	"go/token"
 	"io"
 	"os"
 	"path/filepath"
-	"strings"
 	"time"
 
-	cueparser "cuelang.org/go/cue/parser"
-	cuemodfile "cuelang.org/go/mod/modfile"
 	"github.com/fsnotify/fsnotify"
 	"github.com/perses/common/async"
 	"github.com/perses/perses/internal/cli/cmd/dac/build"
+	"github.com/perses/perses/internal/cli/cmd/dac/dependency"
 	"github.com/perses/perses/internal/cli/opt"
 	"github.com/perses/spec/go/common"
 	"github.com/sirupsen/logrus"
-	"golang.org/x/mod/modfile"
-)
-
-const (
-	goFileExt  = ".go"
-	cueFileExt = ".cue"
 )
 
 // fileExists checks if a file or directory exists
@@ -70,47 +60,6 @@ func (w *watcher) shouldSkipDirectory(path string) bool {
 	return absPath == w.absoluteBuildDir
 }
 
-// detectModulePaths finds module paths for both Go and CUE projects.
-// This information is used to determine if an import is local (part of the project).
-func detectModulePaths(sourceDir string) []string {
-	var paths []string
-
-	// Check for go.mod
-	goModPath := filepath.Join(sourceDir, "go.mod")
-	if data, err := os.ReadFile(goModPath); err == nil { //nolint: gosec
-		if parsed, err := modfile.Parse(goModPath, data, nil); err == nil && parsed.Module != nil {
-			paths = append(paths, parsed.Module.Mod.Path)
-		}
-	}
-
-	// Check for cue.mod/module.cue
-	cueModPath := filepath.Join(sourceDir, "cue.mod", "module.cue")
-	if data, err := os.ReadFile(cueModPath); err == nil { //nolint: gosec
-		if parsed, err := cuemodfile.Parse(data, cueModPath); err == nil {
-			paths = append(paths, parsed.Module)
-		}
-	}
-
-	return paths
-}
-
-// isLocalImport checks if an import path belongs to this project (not a remote dependency)
-func (w *watcher) isLocalImport(importPath string) bool {
-	// If no module paths detected, fall back to checking all imports (legacy behavior)
-	if len(w.modulePaths) == 0 {
-		return true
-	}
-
-	// Check if the import starts with any of our module paths
-	for _, modulePath := range w.modulePaths {
-		if strings.HasPrefix(importPath, modulePath+"/") || importPath == modulePath {
-			return true
-		}
-	}
-
-	return false
-}
-
 type watcher struct {
 	async.SimpleTask
 	sourceDir        string
@@ -133,6 +82,7 @@ func newWatcher(sourceDir, absoluteBuildDir, outputFormat string, buildArgs []st
 		DirectoryOption: opt.DirectoryOption{Directory: sourceDir},
 		OutputOption:    opt.OutputOption{Output: outputFormat},
 		Mode:            "file",
+		JPaths:          []string{dependency.JsonnetVendorDir},
 	}
 	buildOpt.SetWriter(writer)
 	buildOpt.SetErrWriter(errWriter)
@@ -145,7 +95,7 @@ func newWatcher(sourceDir, absoluteBuildDir, outputFormat string, buildArgs []st
 		writer:           writer,
 		errWriter:        errWriter,
 		buildOption:      buildOpt,
-		modulePaths:      detectModulePaths(sourceDir),
+		modulePaths:      dependency.DetectModulePaths(sourceDir),
 		changedFiles:     make(map[string]bool),
 		dependencyMap:    make(map[string][]string),
 	}
@@ -285,8 +235,7 @@ func (w *watcher) handleNewDirectory(fsWatcher *fsnotify.Watcher, name string, d
 		if err != nil || info.IsDir() {
 			return nil
 		}
-		ext := filepath.Ext(path)
-		if (ext == goFileExt || ext == cueFileExt) && w.isDashboardFile(path) {
+		if dependency.IsSupportedFile(path) && dependency.IsDashboardFile(path) {
 			w.changedFiles[path] = true
 			logrus.Debugf("   Found new dashboard: %s", path)
 		}
@@ -308,8 +257,7 @@ func (w *watcher) handleNewDirectory(fsWatcher *fsnotify.Watcher, name string, d
 // handleFileEvent handles file modification events
 func (w *watcher) handleFileEvent(event fsnotify.Event, debounceTimer **time.Timer, debouncePending *bool) {
 	// Check if it's a file we care about
-	ext := filepath.Ext(event.Name)
-	if ext != goFileExt && ext != cueFileExt {
+	if !dependency.IsSupportedFile(event.Name) {
 		return
 	}
 
@@ -324,7 +272,7 @@ func (w *watcher) handleFileEvent(event fsnotify.Event, debounceTimer **time.Tim
 	w.changedFiles[event.Name] = true
 
 	// Rebuild dependency map to discover new files or catch new imports in dashboards
-	if event.Op&(fsnotify.Create|fsnotify.Rename) != 0 || w.isDashboardFile(event.Name) {
+	if event.Op&(fsnotify.Create|fsnotify.Rename) != 0 || dependency.IsDashboardFile(event.Name) {
 		w.buildDependencyMap()
 	}
 
@@ -364,7 +312,7 @@ func (w *watcher) rebuildAffectedFiles() {
 	// If this is directly dashboards, add them directly into the set of dashboards
 	libraryFiles := make(map[string]bool)
 	for _, file := range filesToBuild {
-		isDash := w.isDashboardFile(file)
+		isDash := dependency.IsDashboardFile(file)
 		logrus.Debugf("   - %s (library: %v)", file, !isDash)
 		if isDash {
 			dashboardFiles[file] = true
@@ -409,6 +357,7 @@ func (w *watcher) buildFile(file string) (string, error) {
 		FileOption:   opt.FileOption{File: file},
 		OutputOption: w.buildOption.OutputOption,
 		Mode:         "file",
+		JPaths:       w.buildOption.JPaths,
 	}
 	// Suppress build option's own output - we'll log via logrus instead
 	fileOpt.SetWriter(io.Discard)
@@ -442,10 +391,9 @@ func (w *watcher) findDashboardFiles() []string {
 			return nil
 		}
 
-		ext := filepath.Ext(path)
-		if ext == goFileExt || ext == cueFileExt {
+		if dependency.IsSupportedFile(path) {
 			// Check if it's a dashboard file
-			if w.isDashboardFile(path) {
+			if dependency.IsDashboardFile(path) {
 				dashboards = append(dashboards, path)
 			}
 		}
@@ -491,92 +439,6 @@ func (w *watcher) buildDashboards(dashboards []string) {
 	}
 }
 
-// isDashboardFile checks if a file is a dashboard file that should be built directly
-func (w *watcher) isDashboardFile(file string) bool {
-	ext := filepath.Ext(file)
-
-	switch ext {
-	case goFileExt:
-		return w.isGoDashboardFile(file)
-	case cueFileExt:
-		return w.isCueDashboardFile(file)
-	}
-
-	return false // Unknown files are not dashboards by default
-}
-
-// isGoDashboardFile checks if a Go file is to be considered a dashboard.
-// It simply checks if the package is "main"
-func (w *watcher) isGoDashboardFile(file string) bool {
-	fset := token.NewFileSet()
-	node, err := parser.ParseFile(fset, file, nil, parser.PackageClauseOnly)
-	if err != nil {
-		return false // Can't parse = not a dashboard
-	}
-
-	// Go dashboards MUST be "package main"
-	return node.Name.Name == "main"
-}
-
-// isCueDashboardFile checks if a CUE file is to be considered a dashboard
-// No 100% reliable way here, thus we take a heuristic approach based on import patterns and filename
-func (w *watcher) isCueDashboardFile(file string) bool {
-	content, err := os.ReadFile(file) //nolint: gosec
-	if err != nil {
-		return false
-	}
-
-	parsed, err := cueparser.ParseFile(file, content)
-	if err != nil {
-		return false
-	}
-
-	// Strategy 1: Check for named import "dashboardBuilder"
-	for imp := range parsed.ImportSpecs() {
-		if imp.Name != nil && imp.Name.String() == "dashboardBuilder" {
-			return true
-		}
-	}
-
-	// Strategy 2: Check if filename contains "dashboard"
-	if strings.Contains(strings.ToLower(filepath.Base(file)), "dashboard") {
-		return true
-	}
-
-	return false
-}
-
-// findAllDependencies recursively finds all dependencies (direct + transitive) for a file
-func (w *watcher) findAllDependencies(file string, visited map[string]bool) map[string]bool {
-	deps := make(map[string]bool)
-
-	// Prevent infinite loops from circular imports
-	if visited[file] {
-		return deps
-	}
-	visited[file] = true
-
-	// Get direct imports
-	imports := w.parseImports(file)
-
-	for _, importPath := range imports {
-		libraryFiles := w.resolveImportToFiles(importPath)
-
-		for _, libFile := range libraryFiles {
-			// Add this library file
-			deps[libFile] = true
-
-			// Recursively get its dependencies (transitive)
-			transitiveDeps := w.findAllDependencies(libFile, visited)
-			for transFile := range transitiveDeps {
-				deps[transFile] = true
-			}
-		}
-	}
-
-	return deps
-}
-
 // buildDependencyMap builds a map of library files to dashboards that import them (including transitive dependencies)
 func (w *watcher) buildDependencyMap() {
 	w.dependencyMap = make(map[string][]string)
@@ -590,9 +452,15 @@ func (w *watcher) buildDependencyMap() {
 
 	logrus.Debug("🔍 Building dependency graph (including transitive dependencies)...")
 
+	resolver := &dependency.Resolver{
+		SourceDir:     w.sourceDir,
+		ModulePaths:   w.modulePaths,
+		SkipDirectory: w.shouldSkipDirectory,
+		JPaths:        []string{dependency.JsonnetVendorDir},
+	}
 	for _, dashboard := range dashboards {
 		// Recursively find ALL dependencies (direct + transitive)
-		allDeps := w.findAllDependencies(dashboard, make(map[string]bool))
+		allDeps := resolver.FindAllDependencies(dashboard, make(map[string]bool))
 
 		for libFile := range allDeps {
 			w.dependencyMap[libFile] = append(w.dependencyMap[libFile], dashboard)
@@ -613,129 +481,12 @@ func (w *watcher) buildDependencyMap() {
 	}
 }
 
-// parseImports extracts import paths from a Go or CUE file
-func (w *watcher) parseImports(filePath string) []string {
-	ext := filepath.Ext(filePath)
-	switch ext {
-	case goFileExt:
-		return w.parseGoImports(filePath)
-	case cueFileExt:
-		return w.parseCueImports(filePath)
-	}
-	return nil
-}
-
-// parseGoImports parses import statements from a Go file
-func (w *watcher) parseGoImports(filePath string) []string {
-	fset := token.NewFileSet()
-	node, err := parser.ParseFile(fset, filePath, nil, parser.ImportsOnly)
-	if err != nil {
-		return nil
-	}
-
-	var imports []string
-	for _, imp := range node.Imports {
-		importPath := strings.Trim(imp.Path.Value, `"`)
-		imports = append(imports, importPath)
-	}
-	return imports
-}
-
-// parseCueImports parses import statements from a CUE file
-func (w *watcher) parseCueImports(filePath string) []string {
-	content, err := os.ReadFile(filePath) //nolint: gosec
-	if err != nil {
-		return nil
-	}
-
-	// Parse the CUE file using the official parser
-	file, err := cueparser.ParseFile(filePath, content)
-	if err != nil {
-		return nil
-	}
-
-	var imports []string
-	for imp := range file.ImportSpecs() {
-		if imp.Path != nil {
-			// Import path includes quotes, so we need to trim them
-			importPath := strings.Trim(imp.Path.Value, `"`)
-			imports = append(imports, importPath)
-		}
-	}
-	return imports
-}
-
-// resolveImportToFiles attempts to resolve an import path to actual library files
-func (w *watcher) resolveImportToFiles(importPath string) []string {
-	// Skip remote/external imports - we only track local project files
-	if !w.isLocalImport(importPath) {
-		return nil
-	}
-
-	var files []string
-
-	// For local imports, we need to find the directory that matches the import path
-	// Import examples:
-	//   "dac-example.com/m/mylibrary/variables" -> should match mylibrary/variables/
-	//   "dac-example.com/m/mylibrary/panels" -> should match mylibrary/panels/
-
-	// Look for directories matching the import in the source tree
-	if err := filepath.Walk(w.sourceDir, func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			return nil
-		}
-		if !info.IsDir() {
-			return nil
-		}
-
-		if w.shouldSkipDirectory(path) {
-			return filepath.SkipDir
-		}
-
-		// Get relative path from source directory
-		relPath, err := filepath.Rel(w.sourceDir, path)
-		if err != nil {
-			return nil
-		}
-
-		// Normalize path separators for comparison
-		relPathNormalized := filepath.ToSlash(relPath)
-
-		// Check if the import path ends with this relative path
-		// This ensures we match the exact directory structure
-		if strings.HasSuffix(importPath, relPathNormalized) ||
-			strings.HasSuffix(importPath, "/"+relPathNormalized) {
-			// Add all .go and .cue files from this directory (non-recursive)
-			entries, err := os.ReadDir(path)
-			if err != nil {
-				return nil
-			}
-			for _, entry := range entries {
-				if entry.IsDir() {
-					continue
-				}
-				filePath := filepath.Join(path, entry.Name())
-				ext := filepath.Ext(filePath)
-				if (ext == goFileExt || ext == cueFileExt) && !w.isDashboardFile(filePath) {
-					files = append(files, filePath)
-				}
-			}
-			return filepath.SkipDir
-		}
-		return nil
-	}); err != nil {
-		logrus.Warnf("Failed to resolve import %s: %v", importPath, err)
-	}
-
-	return files
-}
-
 // findAffectedDashboards returns dashboard files that depend on the given changed files
 func (w *watcher) findAffectedDashboards(changedFiles []string) []string {
 	dashboardSet := make(map[string]bool)
 
 	for _, changedFile := range changedFiles {
-		if w.isDashboardFile(changedFile) {
+		if dependency.IsDashboardFile(changedFile) {
 			continue
 		}
 
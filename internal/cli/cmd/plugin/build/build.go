@@ -44,6 +44,7 @@ type option struct {
 	isSchemaRequired          bool
 	schemaPathFromPackageJSON string
 	cueVendor                 *cueVendor
+	signingKeyPath            string
 	writer                    io.Writer
 	errWriter                 io.Writer
 }
@@ -126,9 +127,19 @@ func (o *option) Execute() error {
 	if err != nil {
 		return err
 	}
-	if archiveBuildErr := archive.Build(filepath.Join(o.pluginPath, fmt.Sprintf("%s-%s", manifest.Name, npmPackageData.Version)), o.archiveFormat, files); archiveBuildErr != nil {
+	archiveName := filepath.Join(o.pluginPath, fmt.Sprintf("%s-%s", manifest.Name, npmPackageData.Version))
+	if archiveBuildErr := archive.Build(archiveName, o.archiveFormat, files); archiveBuildErr != nil {
 		return fmt.Errorf("archive creation failed: %w", archiveBuildErr)
 	}
+	if len(o.signingKeyPath) > 0 {
+		key, keyErr := archive.ReadPrivateKey(o.signingKeyPath)
+		if keyErr != nil {
+			return keyErr
+		}
+		if _, signErr := archive.Sign(fmt.Sprintf("%s.%s", archiveName, o.archiveFormat), manifest.Name, manifest.Metadata.BuildInfo.Version, key); signErr != nil {
+			return fmt.Errorf("archive signature failed: %w", signErr)
+		}
+	}
 	return output.HandleString(o.writer, fmt.Sprintf("%s built successfully", manifest.Name))
 }
 
@@ -195,6 +206,7 @@ func NewCMD() *cobra.Command {
   - static: folder containing the UI part
   - schemas: folder containing the schema files
   - cue.mod: folder containing the CUE module & eventual vendored dependencies
+- Sign the archive if a private key is provided with the flag --signing-key. The detached signature is written next to the archive (<archive>.sig).
 `,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return persesCMD.Run(o, cmd, args)
@@ -204,6 +216,7 @@ func NewCMD() *cobra.Command {
 	cmd.Flags().BoolVar(&o.skipNPMBuild, "skip.npm-build", false, "The command will run `npm run build` to ensure the frontend is built before creating the archive. If you want to skip this step, you can use this flag.")
 	cmd.Flags().BoolVar(&o.skipNPMInstall, "skip.npm-install", false, "The command will run `npm ci` if it doesn't find the node_modules folder. If you want to skip this step, you can use this flag.")
 	cmd.Flags().StringVar(&o.cfgPath, "config", "", "Relative path to the configuration file. It is relative, because it will use as a root path the one set with the flag ---plugin.path. By default, the command will look for a file named 'perses_plugin_config.yaml'")
+	cmd.Flags().StringVar(&o.signingKeyPath, "signing-key", "", "Path to a PEM encoded ed25519 private key. When set, the archive is signed and the detached signature is written next to it. See 'percli plugin sign' for more details.")
 	cmd.Flags().StringVar(&o.pluginPath, "plugin.path", "", "Path to the plugin. By default, the command will look at the folder where the command is running.")
 
 	return cmd
@@ -0,0 +1,180 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generate
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/cuecontext"
+	"github.com/perses/perses/internal/api/plugin"
+	"github.com/perses/perses/internal/api/plugin/schema"
+	persesCMD "github.com/perses/perses/internal/cli/cmd"
+	"github.com/perses/perses/internal/cli/cmd/plugin/config"
+	"github.com/perses/perses/internal/cli/output"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	pluginSpec "github.com/perses/spec/go/plugin"
+	"github.com/spf13/cobra"
+)
+
+const defaultGoSDKOutput = "sdk/go"
+
+type goSDKOption struct {
+	persesCMD.Option
+	cfg                config.PluginConfig
+	cfgPath            string
+	pluginPath         string
+	relativeSchemaPath string
+	outputDir          string
+	writer             io.Writer
+	errWriter          io.Writer
+}
+
+func (o *goSDKOption) Complete(args []string) error {
+	if len(args) > 0 {
+		return fmt.Errorf("no args are supported by the command 'plugin generate go-sdk'")
+	}
+	cfg, err := config.Resolve(o.pluginPath, o.cfgPath)
+	if err != nil {
+		return fmt.Errorf("unable to resolve the configuration: %w", err)
+	}
+	o.cfg = cfg
+	// Overriding the paths using the plugin path
+	o.cfg.FrontendPath = filepath.Join(o.pluginPath, o.cfg.FrontendPath)
+	o.relativeSchemaPath = o.cfg.SchemasPath
+	if len(o.outputDir) == 0 {
+		o.outputDir = filepath.Join(o.pluginPath, defaultGoSDKOutput)
+	}
+	return nil
+}
+
+func (o *goSDKOption) Validate() error {
+	return nil
+}
+
+func (o *goSDKOption) Execute() error {
+	npmPackageData, readErr := plugin.ReadPackage(o.cfg.FrontendPath)
+	if readErr != nil {
+		return fmt.Errorf("unable to read plugin package.json: %w", readErr)
+	}
+	if !plugin.IsSchemaRequired(*v1.NewModuleSpec(npmPackageData.Perses)) {
+		return output.HandleString(o.writer, "No schemas found in this plugin, nothing to generate")
+	}
+	if _, err := os.Stat(filepath.Join(o.pluginPath, plugin.CuelangModuleFolder)); os.IsNotExist(err) {
+		return errors.New("CUE module not found")
+	}
+	// Like for the lint command, the schema path set in the configuration takes precedence over the one set in package.json.
+	npmPackageData.Perses.SchemasPath = o.relativeSchemaPath
+	schemas, err := schema.Load(o.pluginPath, *v1.NewModuleSpec(npmPackageData.Perses))
+	if err != nil {
+		return err
+	}
+
+	msg := "Go SDK generated successfully"
+	ctx := cuecontext.New()
+	for _, s := range schemas {
+		category, ok := getGoSDKCategory(s.Kind)
+		if !ok {
+			msg += fmt.Sprintf("\n- %s skipped: %s plugins are not supported by the Go SDK", s.Name, s.Kind)
+			continue
+		}
+		spec := ctx.BuildInstance(s.Instance).LookupPath(cue.ParsePath("spec"))
+		if spec.Err() != nil {
+			return fmt.Errorf("unable to read the spec of the plugin %q: %w", s.Name, spec.Err())
+		}
+		p, buildErr := buildGoSDKPlugin(s.Name, string(s.Kind), category, spec)
+		if buildErr != nil {
+			return fmt.Errorf("unable to generate the Go SDK of the plugin %q: %w", s.Name, buildErr)
+		}
+		pluginFolder := GetKebabCase(s.Name)
+		files, generateErr := generateGoSDKFiles(p, pluginFolder)
+		if generateErr != nil {
+			return fmt.Errorf("unable to generate the Go SDK of the plugin %q: %w", s.Name, generateErr)
+		}
+		pluginDir := filepath.Join(o.outputDir, pluginFolder)
+		if mkdirErr := os.MkdirAll(pluginDir, 0750); mkdirErr != nil {
+			return fmt.Errorf("error creating output directory %q: %w", pluginDir, mkdirErr)
+		}
+		for _, f := range files {
+			if writeErr := os.WriteFile(filepath.Join(pluginDir, f.Name), f.Content, 0644); writeErr != nil { // nolint: gosec
+				return fmt.Errorf("error writing the file %q: %w", f.Name, writeErr)
+			}
+			msg += fmt.Sprintf("\n- %s", filepath.Join(pluginFolder, f.Name))
+		}
+	}
+	return output.HandleString(o.writer, msg)
+}
+
+func (o *goSDKOption) SetWriter(writer io.Writer) {
+	o.writer = writer
+}
+
+func (o *goSDKOption) SetErrWriter(errWriter io.Writer) {
+	o.errWriter = errWriter
+}
+
+// getGoSDKCategory returns the kind of Go SDK option that can be generated for the given plugin kind.
+func getGoSDKCategory(kind pluginSpec.Kind) (goSDKCategory, bool) {
+	if kind.IsQuery() {
+		return goSDKCategoryQuery, true
+	}
+	switch kind {
+	case pluginSpec.KindDatasource:
+		return goSDKCategoryDatasource, true
+	case pluginSpec.KindPanel:
+		return goSDKCategoryPanel, true
+	case pluginSpec.KindVariable:
+		return goSDKCategoryVariable, true
+	}
+	return "", false
+}
+
+func newGoSDKCMD() *cobra.Command {
+	o := &goSDKOption{}
+	cmd := &cobra.Command{
+		Use:   "go-sdk",
+		Short: "Generate the Go SDK of the plugins of a module, based on their CUE schemas",
+		Long: `
+Generate a typed Go builder for each panel, query, variable and datasource plugin of the module.
+The builders are generated from the CUE model schemas, so they can be used in a Dashboard-as-Code written in Go.
+
+Each plugin gets its own Go package, named after the plugin, containing:
+- the struct of the plugin spec, along with the structs nested in it.
+- an option for each field of the spec.
+- a constructor taking the required fields as parameters and returning the matching option of the Go SDK
+  (panel.Option, query.Option, listvariable.Option or datasource.Option).
+
+The generated files must not be modified manually, run the command again when the schemas change.
+`,
+		Example: `
+# Generate the Go SDK of the plugins in the default folder "sdk/go"
+$ percli plugin generate go-sdk --plugin.path ./my-plugin
+
+# Generate the Go SDK in a specific folder
+$ percli plugin generate go-sdk --output ./go/plugins
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return persesCMD.Run(o, cmd, args)
+		},
+	}
+	cmd.Flags().StringVar(&o.cfgPath, "config", "", "Relative path to the configuration file. It is relative, because it will use as a root path the one set with the flag --plugin.path. By default, the command will look for a file named 'perses_plugin_config.yaml'")
+	cmd.Flags().StringVar(&o.pluginPath, "plugin.path", "", "Path to the plugin. By default, the command will look at the folder where the command is running.")
+	cmd.Flags().StringVar(&o.outputDir, "output", "", "Path to the folder where the Go packages are generated. By default, the folder 'sdk/go' of the plugin is used.")
+
+	return cmd
+}
@@ -0,0 +1,438 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generate
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/token"
+	"go/types"
+	"path"
+	"slices"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"cuelang.org/go/cue"
+)
+
+const goSDKTemplateDir = "templates/gosdk"
+
+// goSDKCategory is the kind of Go SDK option a generated plugin builder produces.
+type goSDKCategory string
+
+const (
+	goSDKCategoryDatasource goSDKCategory = "datasource"
+	goSDKCategoryPanel      goSDKCategory = "panel"
+	goSDKCategoryQuery      goSDKCategory = "query"
+	goSDKCategoryVariable   goSDKCategory = "variable"
+)
+
+// goSDKReservedParams are the names that cannot be used as a parameter of the generated constructor,
+// because they would shadow an import or a variable used in its body.
+var goSDKReservedParams = map[string]bool{
+	"builder":      true,
+	"datasource":   true,
+	"err":          true,
+	"listvariable": true,
+	"options":      true,
+	"panel":        true,
+	"plg":          true,
+	"plugin":       true,
+	"query":        true,
+}
+
+type goSDKField struct {
+	// Name is the name of the field in the Go struct.
+	Name string
+	// Label is the name of the field in the CUE schema, used as the json/yaml key.
+	Label string
+	Type  string
+	// Setter is the name of the option generated for the field. Only set on the fields of the plugin spec.
+	Setter string
+	// ValueType is the type accepted by the setter. It differs from Type when the field is a pointer.
+	ValueType string
+	IsPointer bool
+	OmitEmpty bool
+}
+
+type goSDKStruct struct {
+	Name   string
+	Fields []goSDKField
+}
+
+type goSDKEnumValue struct {
+	// Name is the name of the constant declared for the value.
+	Name  string
+	Value string
+}
+
+// goSDKEnum is a string type generated for a disjunction of strings, like "small" | "medium" | "large".
+type goSDKEnum struct {
+	Name   string
+	Values []goSDKEnumValue
+}
+
+type goSDKParam struct {
+	Name   string
+	Type   string
+	Setter string
+}
+
+type goSDKPlugin struct {
+	Package     string
+	PluginKind  string
+	PluginType  string
+	Category    goSDKCategory
+	Constructor string
+	Spec        goSDKStruct
+	Structs     []goSDKStruct
+	Enums       []goSDKEnum
+	Params      []goSDKParam
+}
+
+// goSDKFile is a file generated for a plugin, with a path relative to the package folder.
+type goSDKFile struct {
+	Name    string
+	Content []byte
+}
+
+// goSDKResolver converts the CUE schema of a plugin spec into the Go types used by the generated builder.
+type goSDKResolver struct {
+	// usedNames contains every identifier declared at the package level, to avoid any collision.
+	usedNames map[string]bool
+	structs   []goSDKStruct
+	enums     []goSDKEnum
+}
+
+func (r *goSDKResolver) uniqueName(name string) string {
+	result := name
+	for i := 2; r.usedNames[result]; i++ {
+		result = fmt.Sprintf("%s%d", name, i)
+	}
+	r.usedNames[result] = true
+	return result
+}
+
+// goType returns the Go type matching the given CUE value.
+// Every struct met along the way is registered as a new type, named after the given name.
+func (r *goSDKResolver) goType(v cue.Value, name string) (string, error) {
+	// A nullable field is represented by its non-null type.
+	kind := v.IncompleteKind() &^ cue.NullKind
+	switch kind {
+	case cue.StringKind:
+		if values := goSDKEnumValues(v); len(values) > 1 {
+			return r.goEnum(values, name), nil
+		}
+		return "string", nil
+	case cue.BoolKind:
+		return "bool", nil
+	case cue.IntKind:
+		return "int", nil
+	case cue.FloatKind, cue.NumberKind:
+		return "float64", nil
+	case cue.ListKind:
+		item := v.LookupPath(cue.MakePath(cue.AnyIndex))
+		if !item.Exists() {
+			return "[]any", nil
+		}
+		itemType, err := r.goType(item, strings.TrimSuffix(name, "Spec")+"ItemSpec")
+		if err != nil {
+			return "", err
+		}
+		return "[]" + itemType, nil
+	case cue.StructKind:
+		s, isEmpty, err := r.goStruct(v, name)
+		if err != nil {
+			return "", err
+		}
+		if !isEmpty {
+			return s.Name, nil
+		}
+		// A struct without any field is a map, for example {[string]: string}.
+		value := v.LookupPath(cue.MakePath(cue.AnyString))
+		if !value.Exists() {
+			return "map[string]any", nil
+		}
+		valueType, err := r.goType(value, strings.TrimSuffix(name, "Spec")+"ValueSpec")
+		if err != nil {
+			return "", err
+		}
+		return "map[string]" + valueType, nil
+	default:
+		return "any", nil
+	}
+}
+
+// goSDKEnumValues returns the strings of the given CUE disjunction, like "small" | "medium" | "large".
+// null is ignored, as a nullable field is represented by its non-null type.
+// It returns nil when the value is not a disjunction of concrete strings.
+func goSDKEnumValues(v cue.Value) []string {
+	op, args := v.Expr()
+	if op != cue.OrOp {
+		return nil
+	}
+	var values []string
+	for _, arg := range args {
+		if arg.Kind() == cue.NullKind {
+			continue
+		}
+		if nestedOp, _ := arg.Expr(); nestedOp == cue.OrOp {
+			nested := goSDKEnumValues(arg)
+			if nested == nil {
+				return nil
+			}
+			values = append(values, nested...)
+			continue
+		}
+		value, err := arg.String()
+		if err != nil {
+			return nil
+		}
+		if !slices.Contains(values, value) {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// goEnum registers the string type matching the given values, along with a constant for each value.
+// The constants are named after the type, without its suffix "Spec", followed by the value.
+func (r *goSDKResolver) goEnum(values []string, name string) string {
+	enum := goSDKEnum{Name: r.uniqueName(name)}
+	prefix := strings.TrimSuffix(enum.Name, "Spec")
+	for _, value := range values {
+		valueName := "Empty"
+		if value != "" {
+			valueName = goSDKIdentifier(value)
+		}
+		enum.Values = append(enum.Values, goSDKEnumValue{
+			Name:  r.uniqueName(prefix + valueName),
+			Value: value,
+		})
+	}
+	r.enums = append(r.enums, enum)
+	return enum.Name
+}
+
+// goStruct builds the Go struct matching the given CUE struct and registers it.
+// isEmpty is true when the CUE struct doesn't declare any field. In this case, nothing is registered.
+func (r *goSDKResolver) goStruct(v cue.Value, name string) (goSDKStruct, bool, error) {
+	fields, values, err := collectGoSDKFields(v)
+	if err != nil {
+		return goSDKStruct{}, false, err
+	}
+	if len(fields) == 0 {
+		return goSDKStruct{}, true, nil
+	}
+	s, err := r.resolveStruct(r.uniqueName(name), fields, values)
+	if err != nil {
+		return goSDKStruct{}, false, err
+	}
+	r.structs = append(r.structs, s)
+	return s, false, nil
+}
+
+// resolveStruct sets the Go type of each field of the struct.
+func (r *goSDKResolver) resolveStruct(name string, fields []goSDKField, values []cue.Value) (goSDKStruct, error) {
+	for i := range fields {
+		fieldType, err := r.goType(values[i], goSDKTypePrefix(name)+fields[i].Name+"Spec")
+		if err != nil {
+			return goSDKStruct{}, fmt.Errorf("unable to resolve the type of the field %q: %w", fields[i].Label, err)
+		}
+		fields[i].Type = fieldType
+		fields[i].ValueType = fieldType
+		// Optional structs are pointers, so they are not marshalled when they are not set.
+		if fields[i].OmitEmpty && r.isStruct(fieldType) {
+			fields[i].IsPointer = true
+			fields[i].Type = "*" + fieldType
+		}
+	}
+	return goSDKStruct{Name: name, Fields: fields}, nil
+}
+
+// collectGoSDKFields returns the regular fields of the given CUE struct, along with their value.
+func collectGoSDKFields(v cue.Value) ([]goSDKField, []cue.Value, error) {
+	iter, err := v.Fields(cue.Optional(true))
+	if err != nil {
+		return nil, nil, err
+	}
+	var fields []goSDKField
+	var values []cue.Value
+	fieldNames := make(map[string]bool)
+	for iter.Next() {
+		selector := iter.Selector()
+		if selector.IsDefinition() || selector.PkgPath() != "" {
+			continue
+		}
+		label := selector.Unquoted()
+		fieldName := goSDKIdentifier(label)
+		for i := 2; fieldNames[fieldName]; i++ {
+			fieldName = fmt.Sprintf("%s%d", goSDKIdentifier(label), i)
+		}
+		fieldNames[fieldName] = true
+		// An open list like [...string] always comes with the empty list as default value, so it is ignored.
+		_, hasDefault := iter.Value().Default()
+		hasDefault = hasDefault && iter.Value().IncompleteKind() != cue.ListKind
+		fields = append(fields, goSDKField{
+			Name:      fieldName,
+			Label:     label,
+			OmitEmpty: selector.ConstraintType() == cue.OptionalConstraint || hasDefault,
+		})
+		values = append(values, iter.Value())
+	}
+	return fields, values, nil
+}
+
+func (r *goSDKResolver) isStruct(typeName string) bool {
+	for _, s := range r.structs {
+		if s.Name == typeName {
+			return true
+		}
+	}
+	return false
+}
+
+// goSDKTypePrefix removes the suffix "Spec" of a parent type, so the nested types don't end up with names like "QuerySpecFilterSpec".
+// The root type of the plugin is not used as a prefix at all.
+func goSDKTypePrefix(parent string) string {
+	if parent == "PluginSpec" {
+		return ""
+	}
+	return strings.TrimSuffix(parent, "Spec")
+}
+
+// goSDKIdentifier returns an exported Go identifier built from the given name.
+func goSDKIdentifier(name string) string {
+	var sb strings.Builder
+	for _, r := range GetPascalCase(name) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			sb.WriteRune(r)
+		}
+	}
+	result := sb.String()
+	if result == "" {
+		return "Field"
+	}
+	if !unicode.IsUpper([]rune(result)[0]) {
+		return "X" + result
+	}
+	return result
+}
+
+// goSDKPackageName returns the name of the Go package generated for the given plugin.
+func goSDKPackageName(pluginName string) string {
+	var sb strings.Builder
+	for _, r := range strings.ToLower(pluginName) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			sb.WriteRune(r)
+		}
+	}
+	result := sb.String()
+	if result == "" || unicode.IsDigit([]rune(result)[0]) {
+		return "plugin" + result
+	}
+	return result
+}
+
+func goSDKParamName(fieldName string, usedParams map[string]bool) string {
+	runes := []rune(fieldName)
+	runes[0] = unicode.ToLower(runes[0])
+	name := string(runes)
+	if token.IsKeyword(name) || types.Universe.Lookup(name) != nil || goSDKReservedParams[name] || usedParams[name] {
+		name += "Value"
+	}
+	usedParams[name] = true
+	return name
+}
+
+// buildGoSDKPlugin describes the Go builder of a plugin, based on the CUE value of its spec.
+func buildGoSDKPlugin(pluginName string, pluginType string, category goSDKCategory, spec cue.Value) (*goSDKPlugin, error) {
+	constructor := goSDKIdentifier(pluginName)
+	resolver := &goSDKResolver{
+		usedNames: map[string]bool{
+			"PluginKind": true,
+			"PluginSpec": true,
+			"Option":     true,
+			"Builder":    true,
+			constructor:  true,
+		},
+	}
+	fields, values, err := collectGoSDKFields(spec)
+	if err != nil {
+		return nil, err
+	}
+	// Each field of the plugin spec comes with an option. Their names are reserved before resolving the nested types,
+	// so the types are the one getting renamed in case of collision.
+	for i := range fields {
+		if resolver.usedNames[fields[i].Name] {
+			fields[i].Setter = resolver.uniqueName(fields[i].Name + "Option")
+		} else {
+			fields[i].Setter = resolver.uniqueName(fields[i].Name)
+		}
+	}
+	pluginSpec, err := resolver.resolveStruct("PluginSpec", fields, values)
+	if err != nil {
+		return nil, err
+	}
+
+	// The required fields, without any default value, become the parameters of the constructor.
+	var params []goSDKParam
+	usedParams := make(map[string]bool)
+	for _, f := range pluginSpec.Fields {
+		if f.OmitEmpty {
+			continue
+		}
+		params = append(params, goSDKParam{
+			Name:   goSDKParamName(f.Name, usedParams),
+			Type:   f.ValueType,
+			Setter: f.Setter,
+		})
+	}
+	return &goSDKPlugin{
+		Package:     goSDKPackageName(pluginName),
+		PluginKind:  pluginName,
+		PluginType:  pluginType,
+		Category:    category,
+		Constructor: constructor,
+		Spec:        pluginSpec,
+		Structs:     resolver.structs,
+		Enums:       resolver.enums,
+		Params:      params,
+	}, nil
+}
+
+// generateGoSDKFiles renders the Go files of the plugin builder.
+func generateGoSDKFiles(p *goSDKPlugin, fileName string) ([]goSDKFile, error) {
+	templateSet := template.New("root")
+	templateFiles, err := CollectTemplatePaths(goSDKTemplateDir, templateSet)
+	if err != nil {
+		return nil, err
+	}
+	var files []goSDKFile
+	for _, relTemplatePath := range templateFiles {
+		outputFile := strings.ReplaceAll(strings.TrimSuffix(relTemplatePath, tmplExt), "plugin_name__", fileName)
+		var buffer bytes.Buffer
+		if execErr := templateSet.ExecuteTemplate(&buffer, relTemplatePath, p); execErr != nil {
+			return nil, fmt.Errorf("error executing template %q: %w", relTemplatePath, execErr)
+		}
+		content, formatErr := format.Source(buffer.Bytes())
+		if formatErr != nil {
+			return nil, fmt.Errorf("unable to format the generated file %q: %w", path.Base(outputFile), formatErr)
+		}
+		files = append(files, goSDKFile{Name: outputFile, Content: content})
+	}
+	return files, nil
+}
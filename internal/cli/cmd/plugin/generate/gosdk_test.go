@@ -0,0 +1,173 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generate
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"cuelang.org/go/cue/cuecontext"
+	cmdTest "github.com/perses/perses/internal/cli/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPluginGenerateGoSDKCMD(t *testing.T) {
+	removeTestFiles()
+	defer removeTestFiles()
+
+	outputDir := filepath.Join(testFolder, "go-sdk")
+	testSuite := []cmdTest.Suite{
+		{
+			Title:           "Generate the Go SDK of a module",
+			Args:            []string{"--plugin.path", filepath.Join("testdata", "go-sdk-plugin"), "--output", outputDir},
+			IsErrorExpected: false,
+			ExpectedMessage: "Go SDK generated successfully\n" + getFileList([]string{
+				"my-datasource/options.go",
+				"my-datasource/my-datasource.go",
+				"my-panel/options.go",
+				"my-panel/my-panel.go",
+				"my-query/options.go",
+				"my-query/my-query.go",
+				"my-variable/options.go",
+				"my-variable/my-variable.go",
+			}),
+		},
+	}
+	cmdTest.ExecuteSuiteTest(t, newGoSDKCMD, testSuite)
+
+	expectedDir := filepath.Join("testdata", "go-sdk-expected")
+	err := filepath.WalkDir(expectedDir, func(path string, d os.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		relPath, relErr := filepath.Rel(expectedDir, path)
+		if relErr != nil {
+			return relErr
+		}
+		expected, readErr := os.ReadFile(path)
+		if readErr != nil {
+			return readErr
+		}
+		generated, readErr := os.ReadFile(filepath.Join(outputDir, relPath))
+		if readErr != nil {
+			return readErr
+		}
+		assert.Equal(t, string(expected), string(generated), relPath)
+		return nil
+	})
+	require.NoError(t, err)
+
+	// The generated packages belong to this module, so they are compiled against the Go SDK of this repository.
+	if _, lookErr := exec.LookPath("go"); lookErr != nil {
+		t.Skip("go is not installed, the generated packages cannot be compiled")
+	}
+	output, buildErr := exec.Command("go", "vet", "./"+filepath.ToSlash(outputDir)+"/...").CombinedOutput() // #nosec
+	assert.NoError(t, buildErr, string(output))
+}
+
+func TestGoSDKEnumValues(t *testing.T) {
+	testSuite := []struct {
+		title    string
+		schema   string
+		expected []string
+	}{
+		{
+			title:    "string",
+			schema:   `string`,
+			expected: nil,
+		},
+		{
+			title:    "single value",
+			schema:   `"small"`,
+			expected: nil,
+		},
+		{
+			title:    "disjunction of strings",
+			schema:   `"small" | "medium" | "large"`,
+			expected: []string{"small", "medium", "large"},
+		},
+		{
+			title:    "default value",
+			schema:   `*"timeseries" | "table"`,
+			expected: []string{"timeseries", "table"},
+		},
+		{
+			title:    "nullable",
+			schema:   `null | "a" | "b"`,
+			expected: []string{"a", "b"},
+		},
+		{
+			title:    "nested disjunction",
+			schema:   `("a" | "b") | "c"`,
+			expected: []string{"a", "b", "c"},
+		},
+		{
+			title:    "mixed kinds",
+			schema:   `"a" | int`,
+			expected: nil,
+		},
+		{
+			title:    "not concrete",
+			schema:   `"a" | =~"^b"`,
+			expected: nil,
+		},
+	}
+	for _, test := range testSuite {
+		t.Run(test.title, func(t *testing.T) {
+			v := cuecontext.New().CompileString(test.schema)
+			require.NoError(t, v.Err())
+			assert.Equal(t, test.expected, goSDKEnumValues(v))
+		})
+	}
+}
+
+func TestBuildGoSDKPluginNaming(t *testing.T) {
+	spec := cuecontext.New().CompileString(`{
+	myPanel:     string
+	type:        string
+	"string":    string
+	"foo-bar"?:  int
+	fooBar?:     int
+	querySpec?:  string
+	query?: {a: string}
+	#internal:   string
+}`)
+	p, err := buildGoSDKPlugin("MyPanel", "Panel", goSDKCategoryPanel, spec)
+	require.NoError(t, err)
+
+	var fields []goSDKField
+	for _, f := range p.Spec.Fields {
+		fields = append(fields, goSDKField{Name: f.Name, Label: f.Label, Type: f.Type, Setter: f.Setter})
+	}
+	assert.Equal(t, []goSDKField{
+		{Name: "MyPanel", Label: "myPanel", Type: "string", Setter: "MyPanelOption"},
+		{Name: "Type", Label: "type", Type: "string", Setter: "Type"},
+		{Name: "String", Label: "string", Type: "string", Setter: "String"},
+		{Name: "FooBar", Label: "foo-bar", Type: "int", Setter: "FooBar"},
+		{Name: "FooBar2", Label: "fooBar", Type: "int", Setter: "FooBar2"},
+		{Name: "QuerySpec", Label: "querySpec", Type: "string", Setter: "QuerySpec"},
+		{Name: "Query", Label: "query", Type: "*QuerySpec2", Setter: "Query"},
+	}, fields)
+	assert.Equal(t, []goSDKParam{
+		{Name: "myPanel", Type: "string", Setter: "MyPanelOption"},
+		{Name: "typeValue", Type: "string", Setter: "Type"},
+		{Name: "stringValue", Type: "string", Setter: "String"},
+	}, p.Params)
+
+	_, err = generateGoSDKFiles(p, "my-panel")
+	assert.NoError(t, err)
+}
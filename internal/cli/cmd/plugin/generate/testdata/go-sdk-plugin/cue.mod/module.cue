@@ -0,0 +1,4 @@
+module: "github.com/test/go-sdk-plugin@v0"
+language: {
+	version: "v0.13.2"
+}
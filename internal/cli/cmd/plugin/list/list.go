@@ -16,12 +16,14 @@ package list
 import (
 	"fmt"
 	"io"
+	"strings"
 
 	persesCMD "github.com/perses/perses/internal/cli/cmd"
 	"github.com/perses/perses/internal/cli/config"
 	"github.com/perses/perses/internal/cli/opt"
 	"github.com/perses/perses/internal/cli/output"
 	v1 "github.com/perses/perses/pkg/client/api/v1"
+	modelV1 "github.com/perses/perses/pkg/model/api/v1"
 	"github.com/perses/spec/go/module"
 	"github.com/spf13/cobra"
 )
@@ -32,6 +34,8 @@ var columnHeader = []string{
 	"TYPE",
 	"LOADED",
 	"FROM DEV",
+	"DEPENDS ON",
+	"CONFLICTS",
 }
 
 type option struct {
@@ -95,11 +99,37 @@ func (o *option) Execute() error {
 			kind,
 			fmt.Sprintf("%t", status.IsLoaded),
 			fmt.Sprintf("%t", status.InDev),
+			formatDependencies(plugin.Spec.Dependencies),
+			formatConflicts(plugin.Conflicts),
 		})
 	}
 	return output.HandlerTable(o.writer, columnHeader, matrix)
 }
 
+func formatDependencies(dependencies []modelV1.ModuleDependency) string {
+	result := make([]string, 0, len(dependencies))
+	for _, dep := range dependencies {
+		if len(dep.Version) > 0 {
+			result = append(result, fmt.Sprintf("%s (%s)", dep.Name, dep.Version))
+		} else {
+			result = append(result, dep.Name)
+		}
+	}
+	return strings.Join(result, ", ")
+}
+
+func formatConflicts(conflicts []modelV1.PluginConflict) string {
+	result := make([]string, 0, len(conflicts))
+	for _, conflict := range conflicts {
+		modules := make([]string, 0, len(conflict.Modules))
+		for _, m := range conflict.Modules {
+			modules = append(modules, m.Name)
+		}
+		result = append(result, fmt.Sprintf("%s/%s (%s)", conflict.Kind, conflict.Name, strings.Join(modules, ", ")))
+	}
+	return strings.Join(result, ", ")
+}
+
 func (o *option) SetWriter(writer io.Writer) {
 	o.writer = writer
 }
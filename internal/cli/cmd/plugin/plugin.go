@@ -18,6 +18,9 @@ import (
 	"github.com/perses/perses/internal/cli/cmd/plugin/generate"
 	"github.com/perses/perses/internal/cli/cmd/plugin/lint"
 	"github.com/perses/perses/internal/cli/cmd/plugin/list"
+	"github.com/perses/perses/internal/cli/cmd/plugin/pull"
+	"github.com/perses/perses/internal/cli/cmd/plugin/search"
+	"github.com/perses/perses/internal/cli/cmd/plugin/sign"
 	"github.com/perses/perses/internal/cli/cmd/plugin/start"
 	"github.com/perses/perses/internal/cli/cmd/plugin/testschemas"
 	"github.com/spf13/cobra"
@@ -32,6 +35,9 @@ func NewCMD() *cobra.Command {
 	cmd.AddCommand(build.NewCMD())
 	cmd.AddCommand(lint.NewCMD())
 	cmd.AddCommand(list.NewCMD())
+	cmd.AddCommand(pull.NewCMD())
+	cmd.AddCommand(search.NewCMD())
+	cmd.AddCommand(sign.NewCMD())
 	cmd.AddCommand(start.NewCMD())
 	cmd.AddCommand(testschemas.NewCMD())
 
@@ -0,0 +1,128 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pull
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/perses/perses/internal/api/archive"
+	"github.com/perses/perses/internal/api/plugin/registry"
+	persesCMD "github.com/perses/perses/internal/cli/cmd"
+	"github.com/perses/perses/internal/cli/opt"
+	"github.com/perses/perses/internal/cli/output"
+	"github.com/spf13/cobra"
+)
+
+type option struct {
+	persesCMD.Option
+	opt.RegistryOption
+	name      string
+	version   string
+	outputDir string
+	writer    io.Writer
+	errWriter io.Writer
+	client    *registry.Client
+}
+
+func (o *option) Complete(args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("the name of the plugin module to pull must be provided")
+	}
+	o.name = args[0]
+	if err := o.RegistryOption.Complete(); err != nil {
+		return err
+	}
+	client, err := registry.New(o.Registry, o.CachePath, o.Offline, registry.DefaultMaxArchiveSize)
+	if err != nil {
+		return err
+	}
+	o.client = client
+	return nil
+}
+
+func (o *option) Validate() error {
+	return nil
+}
+
+func (o *option) Execute() error {
+	archivePath, err := o.client.Pull(o.name, o.version)
+	if err != nil {
+		return err
+	}
+	if len(o.outputDir) == 0 {
+		return output.HandleString(o.writer, fmt.Sprintf("plugin module %q pulled in %s", o.name, archivePath))
+	}
+	if mkdirErr := os.MkdirAll(o.outputDir, 0750); mkdirErr != nil {
+		return fmt.Errorf("unable to create the directory %q: %w", o.outputDir, mkdirErr)
+	}
+	target := filepath.Join(o.outputDir, filepath.Base(archivePath))
+	if copyErr := copyFile(archivePath, target); copyErr != nil {
+		return copyErr
+	}
+	// The signature is copied along with the archive, so it can be verified by Perses.
+	sigPath := archive.SignatureFilePath(archivePath)
+	if _, statErr := os.Stat(sigPath); statErr == nil {
+		if copyErr := copyFile(sigPath, archive.SignatureFilePath(target)); copyErr != nil {
+			return copyErr
+		}
+	}
+	return output.HandleString(o.writer, fmt.Sprintf("plugin module %q pulled in %s", o.name, target))
+}
+
+func copyFile(src string, dst string) error {
+	data, err := os.ReadFile(src) //nolint: gosec
+	if err != nil {
+		return fmt.Errorf("unable to read %q: %w", src, err)
+	}
+	if writeErr := os.WriteFile(dst, data, 0644); writeErr != nil { // nolint: gosec
+		return fmt.Errorf("unable to write %q: %w", dst, writeErr)
+	}
+	return nil
+}
+
+func (o *option) SetWriter(writer io.Writer) {
+	o.writer = writer
+}
+
+func (o *option) SetErrWriter(errWriter io.Writer) {
+	o.errWriter = errWriter
+}
+
+func NewCMD() *cobra.Command {
+	o := &option{}
+	cmd := &cobra.Command{
+		Use:   "pull <MODULE>",
+		Short: "Pull a plugin module from a plugin registry.",
+		Long: `Pull the archive of a plugin module from a plugin registry.
+The highest version matching the flag --version is downloaded in the cache, and copied in the folder set with the flag --output-dir.
+An archive already present in the cache is not downloaded again, so a module can be pulled without reaching the registry once it has been cached.`,
+		Example: `
+# Pull the latest version of the Prometheus module in the archive folder used by Perses
+percli plugin pull Prometheus --registry https://registry.example.com --output-dir ./plugins-archive
+
+# Pull a specific version
+percli plugin pull Prometheus --version "^0.6.0" --registry https://registry.example.com --output-dir ./plugins-archive
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return persesCMD.Run(o, cmd, args)
+		},
+	}
+	opt.AddRegistryFlags(cmd, &o.RegistryOption)
+	cmd.Flags().StringVar(&o.version, "version", "", "Version, or range of versions, of the module to pull. By default, the latest version is pulled.")
+	cmd.Flags().StringVar(&o.outputDir, "output-dir", "", "Folder where the archive is copied. By default, the archive is only kept in the cache.")
+	return cmd
+}
@@ -0,0 +1,44 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pull
+
+import (
+	"testing"
+
+	cmdTest "github.com/perses/perses/internal/cli/test"
+)
+
+func TestPluginPullCMD(t *testing.T) {
+	testSuite := []cmdTest.Suite{
+		{
+			Title:           "no module",
+			Args:            []string{"--registry", "https://registry.example.com"},
+			IsErrorExpected: true,
+			ExpectedMessage: "the name of the plugin module to pull must be provided",
+		},
+		{
+			Title:           "no registry",
+			Args:            []string{"Prometheus"},
+			IsErrorExpected: true,
+			ExpectedMessage: "the URL of the plugin registry must be provided with the flag --registry",
+		},
+		{
+			Title:                "offline without cache",
+			Args:                 []string{"Prometheus", "--registry", "https://registry.example.com", "--cache", t.TempDir(), "--offline"},
+			IsErrorExpected:      true,
+			ExpectedRegexMessage: "^the registry cannot be reached and no index is available in the cache",
+		},
+	}
+	cmdTest.ExecuteSuiteTest(t, NewCMD, testSuite)
+}
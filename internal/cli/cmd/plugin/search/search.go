@@ -0,0 +1,126 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package search
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/perses/perses/internal/api/plugin/registry"
+	persesCMD "github.com/perses/perses/internal/cli/cmd"
+	"github.com/perses/perses/internal/cli/opt"
+	"github.com/perses/perses/internal/cli/output"
+	"github.com/spf13/cobra"
+)
+
+var columnHeader = []string{
+	"NAME",
+	"LATEST VERSION",
+	"VERSIONS",
+	"DESCRIPTION",
+}
+
+type option struct {
+	persesCMD.Option
+	opt.OutputOption
+	opt.RegistryOption
+	term      string
+	writer    io.Writer
+	errWriter io.Writer
+	client    *registry.Client
+}
+
+func (o *option) Complete(args []string) error {
+	if len(args) > 1 {
+		return fmt.Errorf("only one search term is supported by the command 'plugin search'")
+	}
+	if len(args) == 1 {
+		o.term = args[0]
+	}
+	if len(o.Output) > 0 {
+		if outputErr := o.OutputOption.Complete(); outputErr != nil {
+			return outputErr
+		}
+	}
+	if err := o.RegistryOption.Complete(); err != nil {
+		return err
+	}
+	client, err := registry.New(o.Registry, o.CachePath, o.Offline, registry.DefaultMaxArchiveSize)
+	if err != nil {
+		return err
+	}
+	o.client = client
+	return nil
+}
+
+func (o *option) Validate() error {
+	return nil
+}
+
+func (o *option) Execute() error {
+	modules, err := o.client.Search(o.term)
+	if err != nil {
+		return err
+	}
+	if len(o.Output) > 0 {
+		return output.Handle(o.writer, o.Output, modules)
+	}
+	var matrix [][]string
+	for _, m := range modules {
+		versions := make([]string, 0, len(m.Versions))
+		for _, v := range m.Versions {
+			versions = append(versions, v.Version)
+		}
+		matrix = append(matrix, []string{
+			m.Name,
+			m.LatestVersion(),
+			strings.Join(versions, ", "),
+			m.Description,
+		})
+	}
+	return output.HandlerTable(o.writer, columnHeader, matrix)
+}
+
+func (o *option) SetWriter(writer io.Writer) {
+	o.writer = writer
+}
+
+func (o *option) SetErrWriter(errWriter io.Writer) {
+	o.errWriter = errWriter
+}
+
+func NewCMD() *cobra.Command {
+	o := &option{}
+	cmd := &cobra.Command{
+		Use:   "search [TERM]",
+		Short: "Search plugin modules in a plugin registry.",
+		Long: `Search the plugin modules available in a plugin registry whose name or description contains the given term.
+Without any term, every module of the registry is listed.
+The index of the registry is cached, so the search still works when the registry cannot be reached.`,
+		Example: `
+# List every module available in the registry
+percli plugin search --registry https://registry.example.com
+
+# Search the modules related to Prometheus
+percli plugin search prometheus --registry https://registry.example.com
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return persesCMD.Run(o, cmd, args)
+		},
+	}
+	opt.AddOutputFlags(cmd, &o.OutputOption)
+	opt.AddRegistryFlags(cmd, &o.RegistryOption)
+	return cmd
+}
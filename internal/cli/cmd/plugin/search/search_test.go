@@ -0,0 +1,58 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package search
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	cmdTest "github.com/perses/perses/internal/cli/test"
+)
+
+func TestPluginSearchCMD(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"modules":[
+{"name":"Prometheus","description":"Prometheus datasource","versions":[{"version":"0.5.0","url":"Prometheus-0.5.0.tar.gz"},{"version":"0.6.0","url":"Prometheus-0.6.0.tar.gz"}]},
+{"name":"BarChart","versions":[{"version":"1.0.0","url":"BarChart-1.0.0.tar.gz"}]}
+]}`))
+	}))
+	defer server.Close()
+	cacheDir := t.TempDir()
+
+	testSuite := []cmdTest.Suite{
+		{
+			Title:           "no registry",
+			Args:            []string{"prometheus"},
+			IsErrorExpected: true,
+			ExpectedMessage: "the URL of the plugin registry must be provided with the flag --registry",
+		},
+		{
+			Title:           "too many terms",
+			Args:            []string{"prometheus", "tempo", "--registry", server.URL},
+			IsErrorExpected: true,
+			ExpectedMessage: "only one search term is supported by the command 'plugin search'",
+		},
+		{
+			Title:           "search a module",
+			Args:            []string{"prometheus", "--registry", server.URL, "--cache", cacheDir},
+			IsErrorExpected: false,
+			ExpectedMessage: `    NAME    │ LATEST VERSION │   VERSIONS   │      DESCRIPTION      
+────────────┼────────────────┼──────────────┼───────────────────────
+ Prometheus │ 0.6.0          │ 0.5.0, 0.6.0 │ Prometheus datasource 
+`,
+		},
+	}
+	cmdTest.ExecuteSuiteTest(t, NewCMD, testSuite)
+}
@@ -0,0 +1,109 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sign
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/perses/perses/internal/api/archive"
+	"github.com/perses/perses/internal/api/plugin"
+	persesCMD "github.com/perses/perses/internal/cli/cmd"
+	"github.com/perses/perses/internal/cli/output"
+	"github.com/spf13/cobra"
+)
+
+type option struct {
+	persesCMD.Option
+	archivePaths []string
+	keyPath      string
+	writer       io.Writer
+	errWriter    io.Writer
+}
+
+func (o *option) Complete(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("at least one plugin archive must be provided")
+	}
+	o.archivePaths = args
+	return nil
+}
+
+func (o *option) Validate() error {
+	if len(o.keyPath) == 0 {
+		return fmt.Errorf("the private key used to sign the archive must be provided with the flag --key")
+	}
+	for _, archivePath := range o.archivePaths {
+		if !archive.IsArchiveFile(archivePath) {
+			return fmt.Errorf("%q is not a supported plugin archive. Supported format are: tar.gz, tar, zip", archivePath)
+		}
+	}
+	return nil
+}
+
+func (o *option) Execute() error {
+	key, err := archive.ReadPrivateKey(o.keyPath)
+	if err != nil {
+		return err
+	}
+	for _, archivePath := range o.archivePaths {
+		manifest, readErr := plugin.ReadManifestFromArchive(archivePath)
+		if readErr != nil {
+			return readErr
+		}
+		sigPath, signErr := archive.Sign(archivePath, manifest.Name, manifest.Metadata.BuildInfo.Version, key)
+		if signErr != nil {
+			return signErr
+		}
+		if outputErr := output.HandleString(o.writer, fmt.Sprintf("%s signed successfully, signature written in %s", archivePath, sigPath)); outputErr != nil {
+			return outputErr
+		}
+	}
+	return nil
+}
+
+func (o *option) SetWriter(writer io.Writer) {
+	o.writer = writer
+}
+
+func (o *option) SetErrWriter(errWriter io.Writer) {
+	o.errWriter = errWriter
+}
+
+func NewCMD() *cobra.Command {
+	o := &option{}
+	cmd := &cobra.Command{
+		Use:   "sign [ARCHIVE...]",
+		Short: "Sign one or more plugin archives.",
+		Long: `Sign one or more plugin archives with an ed25519 private key (PEM encoded, PKCS #8).
+For each archive, a detached signature file named <archive>.sig is written next to it.
+The signature covers the content of the archive, as well as the name and the version of the plugin module it contains.
+This file must be shipped along with the archive in one of the 'archive_paths' folders so Perses can verify it against the configured 'public_keys'.
+
+A key pair can be generated with openssl:
+
+  openssl genpkey -algorithm ed25519 -out perses-plugin.key
+  openssl pkey -in perses-plugin.key -pubout -out perses-plugin.pub
+`,
+		Example: `
+# Sign a plugin archive
+percli plugin sign --key ./perses-plugin.key ./BarChart-0.9.0.tar.gz
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return persesCMD.Run(o, cmd, args)
+		},
+	}
+	cmd.Flags().StringVar(&o.keyPath, "key", "", "Path to the PEM encoded ed25519 private key used to sign the archives.")
+	return cmd
+}
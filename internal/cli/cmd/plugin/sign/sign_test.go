@@ -0,0 +1,44 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sign
+
+import (
+	"testing"
+
+	cmdTest "github.com/perses/perses/internal/cli/test"
+)
+
+func TestPluginSignCMD(t *testing.T) {
+	testSuite := []cmdTest.Suite{
+		{
+			Title:           "no archive",
+			Args:            []string{"--key", "private.key"},
+			IsErrorExpected: true,
+			ExpectedMessage: "at least one plugin archive must be provided",
+		},
+		{
+			Title:           "no key",
+			Args:            []string{"plugin.tar.gz"},
+			IsErrorExpected: true,
+			ExpectedMessage: "the private key used to sign the archive must be provided with the flag --key",
+		},
+		{
+			Title:           "not an archive",
+			Args:            []string{"--key", "private.key", "plugin.json"},
+			IsErrorExpected: true,
+			ExpectedMessage: "\"plugin.json\" is not a supported plugin archive. Supported format are: tar.gz, tar, zip",
+		},
+	}
+	cmdTest.ExecuteSuiteTest(t, NewCMD, testSuite)
+}
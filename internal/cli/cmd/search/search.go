@@ -0,0 +1,169 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package search
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strings"
+
+	persesCMD "github.com/perses/perses/internal/cli/cmd"
+	"github.com/perses/perses/internal/cli/config"
+	"github.com/perses/perses/internal/cli/opt"
+	"github.com/perses/perses/internal/cli/output"
+	"github.com/perses/perses/pkg/client/api"
+	v1 "github.com/perses/perses/pkg/client/api/v1"
+	"github.com/spf13/cobra"
+)
+
+var columnHeader = []string{
+	"KIND",
+	"PROJECT",
+	"NAME",
+	"DISPLAY NAME",
+}
+
+type option struct {
+	persesCMD.Option
+	opt.OutputOption
+	opt.ProjectOption
+	query     string
+	writer    io.Writer
+	errWriter io.Writer
+	apiClient api.ClientInterface
+}
+
+func (o *option) Complete(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("you must provide a query")
+	}
+	// The query can be given as a single quoted argument or as several ones, like `percli search tag:kafka lag`.
+	o.query = strings.Join(args, " ")
+	if len(o.Output) > 0 {
+		if outputErr := o.OutputOption.Complete(); outputErr != nil {
+			return outputErr
+		}
+	}
+	apiClient, err := config.Global.GetAPIClient()
+	if err != nil {
+		return err
+	}
+	o.apiClient = apiClient
+	return nil
+}
+
+func (o *option) Validate() error {
+	return nil
+}
+
+func (o *option) Execute() error {
+	response, err := o.apiClient.V1().Search().Search(v1.SearchQuery{
+		Query:   o.query,
+		Project: o.Project,
+	})
+	if err != nil {
+		return err
+	}
+	if len(o.Output) > 0 {
+		return output.Handle(o.writer, o.Output, response)
+	}
+	if len(response.Results) == 0 {
+		return output.HandleString(o.writer, "no resource matches the query")
+	}
+	var matrix [][]string
+	for _, result := range response.Results {
+		matrix = append(matrix, []string{
+			string(result.Kind),
+			result.Metadata.Project,
+			result.Metadata.Name,
+			result.DisplayName,
+		})
+	}
+	if tableErr := output.HandlerTable(o.writer, columnHeader, matrix); tableErr != nil {
+		return tableErr
+	}
+	return output.HandleString(o.writer, formatFacets(response.Facets))
+}
+
+// formatFacets returns a line per facet, with the values sorted by decreasing number of results.
+func formatFacets(facets map[string]map[string]int) string {
+	names := make([]string, 0, len(facets))
+	for name, values := range facets {
+		if len(values) > 0 {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	lines := make([]string, 0, len(names))
+	for _, name := range names {
+		values := make([]string, 0, len(facets[name]))
+		for value := range facets[name] {
+			values = append(values, value)
+		}
+		sort.Slice(values, func(i, j int) bool {
+			ci, cj := facets[name][values[i]], facets[name][values[j]]
+			if ci != cj {
+				return ci > cj
+			}
+			return values[i] < values[j]
+		})
+		counts := make([]string, 0, len(values))
+		for _, value := range values {
+			counts = append(counts, fmt.Sprintf("%s (%d)", value, facets[name][value]))
+		}
+		lines = append(lines, fmt.Sprintf("%s: %s", name, strings.Join(counts, ", ")))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (o *option) SetWriter(writer io.Writer) {
+	o.writer = writer
+}
+
+func (o *option) SetErrWriter(errWriter io.Writer) {
+	o.errWriter = errWriter
+}
+
+func NewCMD() *cobra.Command {
+	o := &option{}
+	cmd := &cobra.Command{
+		Use:   "search QUERY",
+		Short: "Search for resources",
+		Long: `Search for the resources you are allowed to read, through every project unless --project is set.
+Besides the free text, the query accepts the filters kind, name, project, tag, panel, datasource and plugin, like "tag:kafka".
+The terms are combined with AND by default. Use OR to match any of them, a leading "-" or NOT to exclude a term, and parentheses to group them.
+The results are followed by the number of results per kind, project, tag and plugin.`,
+		Example: `
+# Search for the resources matching "kafka lag"
+percli search kafka lag
+
+# Search for the dashboards of the project "infra" with the tag "kafka", having a panel named like "lag"
+percli search 'project:infra tag:kafka panel:"lag"'
+
+# Search for the resources using the datasource "prom-eu" or "prom-us", except the variables
+percli search '(datasource:prom-eu OR datasource:prom-us) -kind:variable'
+
+# Get the results and the facets in json
+percli search kafka -ojson
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return persesCMD.Run(o, cmd, args)
+		},
+	}
+	opt.AddOutputFlags(cmd, &o.OutputOption)
+	opt.AddProjectFlags(cmd, &o.ProjectOption)
+	return cmd
+}
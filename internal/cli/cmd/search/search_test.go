@@ -0,0 +1,60 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package search
+
+import (
+	"testing"
+
+	cmdTest "github.com/perses/perses/internal/cli/test"
+	fakeapi "github.com/perses/perses/pkg/client/fake/api"
+)
+
+func TestSearchCMD(t *testing.T) {
+	testSuite := []cmdTest.Suite{
+		{
+			Title:           "missing the query",
+			Args:            []string{},
+			IsErrorExpected: true,
+			ExpectedMessage: "you must provide a query",
+		},
+		{
+			Title:           "not connected to any API",
+			Args:            []string{"kafka"},
+			IsErrorExpected: true,
+			ExpectedMessage: "you are not connected to any API",
+		},
+		{
+			Title:           "no result",
+			Args:            []string{"whatever"},
+			APIClient:       fakeapi.New(),
+			IsErrorExpected: false,
+			ExpectedMessage: "no resource matches the query\n",
+		},
+		{
+			Title:                "results with the facets",
+			Args:                 []string{"kafka"},
+			APIClient:            fakeapi.New(),
+			IsErrorExpected:      false,
+			ExpectedRegexMessage: `(?s)Dashboard.*perses.*kafka-lag.*Kafka Lag.*Dashboard.*infra.*kafka-brokers.*\nkind: Dashboard \(2\)\nproject: infra \(1\), perses \(1\)\n$`,
+		},
+		{
+			Title:           "results of a project in json",
+			Args:            []string{"kafka", "-p", "infra", "-ojson"},
+			APIClient:       fakeapi.New(),
+			IsErrorExpected: false,
+			ExpectedMessage: `{"results":[{"kind":"Dashboard","metadata":{"name":"kafka-brokers","createdAt":"0001-01-01T00:00:00Z","updatedAt":"0001-01-01T00:00:00Z","version":0,"project":"infra"},"displayName":"Kafka Brokers","original":"kafka-brokers"}],"facets":{"kind":{"Dashboard":1},"project":{"infra":1}}}` + "\n",
+		},
+	}
+	cmdTest.ExecuteSuiteTest(t, NewCMD, testSuite)
+}
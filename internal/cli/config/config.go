@@ -116,6 +116,11 @@ func GetDefaultPath() string {
 	return filepath.Join(getRootFolder(), pathConfig, configFileName)
 }
 
+// GetDefaultPluginCachePath returns the path of the folder where the plugins pulled from a registry are cached.
+func GetDefaultPluginCachePath() string {
+	return filepath.Join(getRootFolder(), pathConfig, "plugins-cache")
+}
+
 // getRootFolder will return a root folder that will or that contains the Perses' config in a sub dir.
 func getRootFolder() string {
 	usr, err := user.Current()
@@ -94,6 +94,41 @@ func UnmarshalEntitiesFromDirectory(dir string) ([]modelAPI.Entity, []error) {
 	return entities, errors
 }
 
+// UnmarshalEntitiesPerFileFromDirectory works like UnmarshalEntitiesFromDirectory,
+// but the resources are grouped by the path of the file declaring them.
+func UnmarshalEntitiesPerFileFromDirectory(dir string) (map[string][]modelAPI.Entity, []error) {
+	files, err := visit(dir)
+	if err != nil {
+		return nil, []error{err}
+	}
+	result := make(map[string][]modelAPI.Entity, len(files))
+	var errors []error
+	for _, f := range files {
+		es, unmarshalErr := UnmarshalEntitiesFromFile(f)
+		if unmarshalErr != nil {
+			errors = append(errors, unmarshalErr)
+			continue
+		}
+		result[f] = es
+	}
+	return result, errors
+}
+
+// DeclaresResources returns true when the file contains at least one object with a kind supported by Perses.
+// It allows to ignore the JSON and YAML files that are not Perses resources, like a package.json or a CI workflow.
+func DeclaresResources(file string) (bool, error) {
+	u := &unmarshaller{file: file}
+	if err := u.read(); err != nil {
+		return false, err
+	}
+	for _, object := range u.objects {
+		if _, err := modelV1.GetStruct(modelV1.Kind(fmt.Sprintf("%v", object["kind"]))); err == nil {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 func UnmarshalEntitiesFromFile(file string) ([]modelAPI.Entity, error) {
 	u := &unmarshaller{file: file}
 	return u.unmarshal()
@@ -105,3 +105,26 @@ func (o *ProjectOption) Complete() error {
 func AddProjectFlags(cmd *cobra.Command, o *ProjectOption) {
 	cmd.Flags().StringVarP(&o.Project, "project", "p", o.Project, "If present, the project scope for this CLI request")
 }
+
+type RegistryOption struct {
+	Registry  string
+	CachePath string
+	Offline   bool
+}
+
+// Complete will check the registry is set and fill the attribute RegistryOption.CachePath if it is not set.
+func (o *RegistryOption) Complete() error {
+	if len(o.Registry) == 0 {
+		return fmt.Errorf("the URL of the plugin registry must be provided with the flag --registry")
+	}
+	if len(o.CachePath) == 0 {
+		o.CachePath = config.GetDefaultPluginCachePath()
+	}
+	return nil
+}
+
+func AddRegistryFlags(cmd *cobra.Command, o *RegistryOption) {
+	cmd.Flags().StringVar(&o.Registry, "registry", "", "URL of the plugin registry.")
+	cmd.Flags().StringVar(&o.CachePath, "cache", "", "Path to the folder where the index and the archives downloaded from the registry are cached (default is $HOME/.perses/plugins-cache).")
+	cmd.Flags().BoolVar(&o.Offline, "offline", false, "Only use the content of the cache, without reaching the registry.")
+}
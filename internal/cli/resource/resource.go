@@ -39,6 +39,14 @@ var resources = []resource{
 			"dashs",
 		},
 	},
+	{
+		kind:      modelV1.KindDashboardTemplate,
+		shortTerm: "dtpl",
+		aliases: []string{
+			"dashboardTemplates",
+			"dtpls",
+		},
+	},
 	{
 		kind:      modelV1.KindDatasource,
 		shortTerm: "dts",
@@ -62,6 +70,14 @@ var resources = []resource{
 			"flds",
 		},
 	},
+	{
+		kind:      modelV1.KindGlobalDashboardTemplate,
+		shortTerm: "gdtpl",
+		aliases: []string{
+			"globalDashboardTemplates",
+			"gdtpls",
+		},
+	},
 	{
 		kind:      modelV1.KindGlobalDatasource,
 		shortTerm: "gdts",
@@ -69,6 +85,14 @@ var resources = []resource{
 			"globalDatasources",
 		},
 	},
+	{
+		kind:      modelV1.KindGlobalPolicy,
+		shortTerm: "gp",
+		aliases: []string{
+			"globalPolicies",
+			"gpol",
+		},
+	},
 	{
 		kind:      modelV1.KindGlobalRole,
 		shortTerm: "grl",
@@ -68,6 +68,10 @@ func New(kind modelV1.Kind, projectName string, apiClient api.ClientInterface) (
 		return &dashboard{
 			apiClient: apiClient.V1().Dashboard(projectName),
 		}, nil
+	case modelV1.KindDashboardTemplate:
+		return &dashboardTemplate{
+			apiClient: apiClient.V1().DashboardTemplate(projectName),
+		}, nil
 	case modelV1.KindDatasource:
 		return &datasource{
 			apiClient: apiClient.V1().Datasource(projectName),
@@ -80,10 +84,18 @@ func New(kind modelV1.Kind, projectName string, apiClient api.ClientInterface) (
 		return &folder{
 			apiClient: apiClient.V1().Folder(projectName),
 		}, nil
+	case modelV1.KindGlobalDashboardTemplate:
+		return &globalDashboardTemplate{
+			apiClient: apiClient.V1().GlobalDashboardTemplate(),
+		}, nil
 	case modelV1.KindGlobalDatasource:
 		return &globalDatasource{
 			apiClient: apiClient.V1().GlobalDatasource(),
 		}, nil
+	case modelV1.KindGlobalPolicy:
+		return &globalPolicy{
+			apiClient: apiClient.V1().GlobalPolicy(),
+		}, nil
 	case modelV1.KindGlobalRole:
 		return &globalRole{
 			apiClient: apiClient.V1().GlobalRole(),
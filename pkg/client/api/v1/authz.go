@@ -0,0 +1,89 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"net/url"
+
+	"github.com/perses/perses/pkg/client/perseshttp"
+	"github.com/perses/perses/pkg/model/api/v1/policy"
+	"github.com/perses/perses/pkg/model/api/v1/role"
+)
+
+const (
+	authzExplainResource = "authz/explain"
+	authzCheckResource   = "authz/check"
+)
+
+// ExplainQuery describes the request to explain.
+type ExplainQuery struct {
+	// User is the user performing the request. When empty, it is the user of the client.
+	User   string
+	Action role.Action
+	Scope  role.Scope
+	// Project is the project targeted by the request. It is ignored for the global scopes.
+	Project string
+}
+
+func (q *ExplainQuery) GetValues() url.Values {
+	values := make(url.Values)
+	if len(q.User) > 0 {
+		values["user"] = []string{q.User}
+	}
+	values["action"] = []string{string(q.Action)}
+	values["scope"] = []string{string(q.Scope)}
+	if len(q.Project) > 0 {
+		values["project"] = []string{q.Project}
+	}
+	return values
+}
+
+type AuthzInterface interface {
+	// Explain returns the decision taken by the authorization for the request, and what granted, or would grant, the permission.
+	Explain(q ExplainQuery) (*role.Explanation, error)
+	// Check evaluates the policies for the request, without performing it.
+	// It is only available when the authorization relies on policies.
+	Check(input policy.Input) (*policy.Decision, error)
+}
+
+type authz struct {
+	AuthzInterface
+	client *perseshttp.RESTClient
+}
+
+func newAuthz(client *perseshttp.RESTClient) AuthzInterface {
+	return &authz{
+		client: client,
+	}
+}
+
+func (c *authz) Explain(q ExplainQuery) (*role.Explanation, error) {
+	result := &role.Explanation{}
+	err := c.client.Get().
+		Resource(authzExplainResource).
+		Query(&q).
+		Do().
+		Object(result)
+	return result, err
+}
+
+func (c *authz) Check(input policy.Input) (*policy.Decision, error) {
+	result := &policy.Decision{}
+	err := c.client.Post().
+		Resource(authzCheckResource).
+		Body(input).
+		Do().
+		Object(result)
+	return result, err
+}
@@ -21,11 +21,15 @@ import (
 
 type ClientInterface interface {
 	RESTClient() *perseshttp.RESTClient
+	Authz() AuthzInterface
 	Dashboard(project string) DashboardInterface
+	DashboardTemplate(project string) DashboardTemplateInterface
 	Datasource(project string) DatasourceInterface
 	EphemeralDashboard(project string) EphemeralDashboardInterface
 	Folder(project string) FolderInterface
+	GlobalDashboardTemplate() GlobalDashboardTemplateInterface
 	GlobalDatasource() GlobalDatasourceInterface
+	GlobalPolicy() GlobalPolicyInterface
 	GlobalRole() GlobalRoleInterface
 	GlobalRoleBinding() GlobalRoleBindingInterface
 	GlobalSecret() GlobalSecretInterface
@@ -35,6 +39,7 @@ type ClientInterface interface {
 	Project() ProjectInterface
 	Role(project string) RoleInterface
 	RoleBinding(project string) RoleBindingInterface
+	Search() SearchInterface
 	Secret(project string) SecretInterface
 	User() UserInterface
 	Variable(project string) VariableInterface
@@ -55,10 +60,18 @@ func (c *client) RESTClient() *perseshttp.RESTClient {
 	return c.restClient
 }
 
+func (c *client) Authz() AuthzInterface {
+	return newAuthz(c.restClient)
+}
+
 func (c *client) Dashboard(project string) DashboardInterface {
 	return newDashboard(c.restClient, project)
 }
 
+func (c *client) DashboardTemplate(project string) DashboardTemplateInterface {
+	return newDashboardTemplate(c.restClient, project)
+}
+
 func (c *client) Datasource(project string) DatasourceInterface {
 	return newDatasource(c.restClient, project)
 }
@@ -71,10 +84,18 @@ func (c *client) Folder(project string) FolderInterface {
 	return newFolder(c.restClient, project)
 }
 
+func (c *client) GlobalDashboardTemplate() GlobalDashboardTemplateInterface {
+	return newGlobalDashboardTemplate(c.restClient)
+}
+
 func (c *client) GlobalDatasource() GlobalDatasourceInterface {
 	return newGlobalDatasource(c.restClient)
 }
 
+func (c *client) GlobalPolicy() GlobalPolicyInterface {
+	return newGlobalPolicy(c.restClient)
+}
+
 func (c *client) GlobalRole() GlobalRoleInterface {
 	return newGlobalRole(c.restClient)
 }
@@ -111,6 +132,10 @@ func (c *client) RoleBinding(project string) RoleBindingInterface {
 	return newRoleBinding(c.restClient, project)
 }
 
+func (c *client) Search() SearchInterface {
+	return newSearch(c.restClient)
+}
+
 func (c *client) Secret(project string) SecretInterface {
 	return newSecret(c.restClient, project)
 }
@@ -0,0 +1,150 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/perses/perses/pkg/client/perseshttp"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+)
+
+const dashboardTemplateResource = "dashboardtemplates"
+
+type renderQuery struct {
+	force bool
+}
+
+func (q *renderQuery) GetValues() url.Values {
+	values := make(url.Values)
+	if q.force {
+		values["force"] = []string{strconv.FormatBool(q.force)}
+	}
+	return values
+}
+
+type DashboardTemplateInterface interface {
+	Create(entity *v1.DashboardTemplate) (*v1.DashboardTemplate, error)
+	Update(entity *v1.DashboardTemplate) (*v1.DashboardTemplate, error)
+	Delete(name string) error
+	// Get is returning a unique DashboardTemplate.
+	// As such name is the exact value of DashboardTemplate.metadata.name. It cannot be empty.
+	// If you want to perform a research by prefix, please use the method List
+	Get(name string) (*v1.DashboardTemplate, error)
+	// prefix is a prefix of the DashboardTemplate.metadata.name to search for.
+	// It can be empty in case you want to get the full list of DashboardTemplate available
+	List(prefix string) ([]*v1.DashboardTemplate, error)
+	// Instantiate renders the template and creates, or updates, the dashboard requested in the project of the template.
+	Instantiate(name string, request *v1.DashboardTemplateInstantiation) (*v1.Dashboard, error)
+	// Render renders again the dashboards instantiated from the template.
+	// Unless force is true, only the dashboards rendered from a previous version of the template are updated.
+	Render(name string, force bool) ([]v1.DashboardTemplateRenderResult, error)
+}
+
+type dashboardTemplate struct {
+	DashboardTemplateInterface
+	client  *perseshttp.RESTClient
+	project string
+}
+
+func newDashboardTemplate(client *perseshttp.RESTClient, project string) DashboardTemplateInterface {
+	return &dashboardTemplate{
+		client:  client,
+		project: project,
+	}
+}
+
+func (c *dashboardTemplate) Create(entity *v1.DashboardTemplate) (*v1.DashboardTemplate, error) {
+	result := &v1.DashboardTemplate{}
+	err := c.client.Post().
+		Resource(dashboardTemplateResource).
+		Project(c.project).
+		Body(entity).
+		Do().
+		Object(result)
+	return result, err
+}
+
+func (c *dashboardTemplate) Update(entity *v1.DashboardTemplate) (*v1.DashboardTemplate, error) {
+	result := &v1.DashboardTemplate{}
+	err := c.client.Put().
+		Resource(dashboardTemplateResource).
+		Name(entity.Metadata.Name).
+		Project(c.project).
+		Body(entity).
+		Do().
+		Object(result)
+	return result, err
+}
+
+func (c *dashboardTemplate) Delete(name string) error {
+	return c.client.Delete().
+		Resource(dashboardTemplateResource).
+		Name(name).
+		Project(c.project).
+		Do().
+		Error()
+}
+
+func (c *dashboardTemplate) Get(name string) (*v1.DashboardTemplate, error) {
+	result := &v1.DashboardTemplate{}
+	err := c.client.Get().
+		Resource(dashboardTemplateResource).
+		Name(name).
+		Project(c.project).
+		Do().
+		Object(result)
+	return result, err
+}
+
+func (c *dashboardTemplate) List(prefix string) ([]*v1.DashboardTemplate, error) {
+	var result []*v1.DashboardTemplate
+	err := c.client.Get().
+		Resource(dashboardTemplateResource).
+		Query(&query{
+			name: prefix,
+		}).
+		Project(c.project).
+		Do().
+		Object(&result)
+	return result, err
+}
+
+func (c *dashboardTemplate) Instantiate(name string, request *v1.DashboardTemplateInstantiation) (*v1.Dashboard, error) {
+	result := &v1.Dashboard{}
+	err := c.client.Post().
+		Resource(dashboardTemplateResource).
+		Name(fmt.Sprintf("%s/instantiate", name)).
+		Project(c.project).
+		Body(request).
+		Do().
+		Object(result)
+	return result, err
+}
+
+func (c *dashboardTemplate) Render(name string, force bool) ([]v1.DashboardTemplateRenderResult, error) {
+	var result []v1.DashboardTemplateRenderResult
+	err := c.client.Post().
+		Resource(dashboardTemplateResource).
+		Name(fmt.Sprintf("%s/render", name)).
+		Query(&renderQuery{
+			force: force,
+		}).
+		Project(c.project).
+		Do().
+		Object(&result)
+	return result, err
+}
@@ -0,0 +1,128 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"fmt"
+
+	"github.com/perses/perses/pkg/client/perseshttp"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+)
+
+const globalDashboardTemplateResource = "globaldashboardtemplates"
+
+type GlobalDashboardTemplateInterface interface {
+	Create(entity *v1.GlobalDashboardTemplate) (*v1.GlobalDashboardTemplate, error)
+	Update(entity *v1.GlobalDashboardTemplate) (*v1.GlobalDashboardTemplate, error)
+	Delete(name string) error
+	// Get is returning a unique GlobalDashboardTemplate.
+	// As such name is the exact value of GlobalDashboardTemplate.metadata.name. It cannot be empty.
+	// If you want to perform a research by prefix, please use the method List
+	Get(name string) (*v1.GlobalDashboardTemplate, error)
+	// prefix is a prefix of the GlobalDashboardTemplate.metadata.name to search for.
+	// It can be empty in case you want to get the full list of GlobalDashboardTemplate available
+	List(prefix string) ([]*v1.GlobalDashboardTemplate, error)
+	// Instantiate renders the template and creates, or updates, the dashboard requested.
+	// The project of the dashboard must be set in the request.
+	Instantiate(name string, request *v1.DashboardTemplateInstantiation) (*v1.Dashboard, error)
+	// Render renders again the dashboards instantiated from the template.
+	// Unless force is true, only the dashboards rendered from a previous version of the template are updated.
+	Render(name string, force bool) ([]v1.DashboardTemplateRenderResult, error)
+}
+
+type globalDashboardTemplate struct {
+	GlobalDashboardTemplateInterface
+	client *perseshttp.RESTClient
+}
+
+func newGlobalDashboardTemplate(client *perseshttp.RESTClient) GlobalDashboardTemplateInterface {
+	return &globalDashboardTemplate{
+		client: client,
+	}
+}
+
+func (c *globalDashboardTemplate) Create(entity *v1.GlobalDashboardTemplate) (*v1.GlobalDashboardTemplate, error) {
+	result := &v1.GlobalDashboardTemplate{}
+	err := c.client.Post().
+		Resource(globalDashboardTemplateResource).
+		Body(entity).
+		Do().
+		Object(result)
+	return result, err
+}
+
+func (c *globalDashboardTemplate) Update(entity *v1.GlobalDashboardTemplate) (*v1.GlobalDashboardTemplate, error) {
+	result := &v1.GlobalDashboardTemplate{}
+	err := c.client.Put().
+		Resource(globalDashboardTemplateResource).
+		Name(entity.Metadata.Name).
+		Body(entity).
+		Do().
+		Object(result)
+	return result, err
+}
+
+func (c *globalDashboardTemplate) Delete(name string) error {
+	return c.client.Delete().
+		Resource(globalDashboardTemplateResource).
+		Name(name).
+		Do().
+		Error()
+}
+
+func (c *globalDashboardTemplate) Get(name string) (*v1.GlobalDashboardTemplate, error) {
+	result := &v1.GlobalDashboardTemplate{}
+	err := c.client.Get().
+		Resource(globalDashboardTemplateResource).
+		Name(name).
+		Do().
+		Object(result)
+	return result, err
+}
+
+func (c *globalDashboardTemplate) List(prefix string) ([]*v1.GlobalDashboardTemplate, error) {
+	var result []*v1.GlobalDashboardTemplate
+	err := c.client.Get().
+		Resource(globalDashboardTemplateResource).
+		Query(&query{
+			name: prefix,
+		}).
+		Do().
+		Object(&result)
+	return result, err
+}
+
+func (c *globalDashboardTemplate) Instantiate(name string, request *v1.DashboardTemplateInstantiation) (*v1.Dashboard, error) {
+	result := &v1.Dashboard{}
+	err := c.client.Post().
+		Resource(globalDashboardTemplateResource).
+		Name(fmt.Sprintf("%s/instantiate", name)).
+		Body(request).
+		Do().
+		Object(result)
+	return result, err
+}
+
+func (c *globalDashboardTemplate) Render(name string, force bool) ([]v1.DashboardTemplateRenderResult, error) {
+	var result []v1.DashboardTemplateRenderResult
+	err := c.client.Post().
+		Resource(globalDashboardTemplateResource).
+		Name(fmt.Sprintf("%s/render", name)).
+		Query(&renderQuery{
+			force: force,
+		}).
+		Do().
+		Object(&result)
+	return result, err
+}
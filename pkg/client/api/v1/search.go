@@ -0,0 +1,83 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"net/url"
+
+	"github.com/perses/perses/pkg/client/perseshttp"
+	modelV1 "github.com/perses/perses/pkg/model/api/v1"
+)
+
+const searchResource = "search"
+
+// SearchResult is a resource matching the query.
+type SearchResult struct {
+	Kind modelV1.Kind `json:"kind" yaml:"kind"`
+	// Metadata is the metadata of the resource. The project is empty for the global resources.
+	Metadata    modelV1.ProjectMetadata `json:"metadata" yaml:"metadata"`
+	DisplayName string                  `json:"displayName" yaml:"displayName"`
+	// Original is the string matching the free text of the query.
+	Original string `json:"original,omitempty" yaml:"original,omitempty"`
+	Score    uint64 `json:"score,omitempty" yaml:"score,omitempty"`
+}
+
+// SearchResponse contains the resources matching the query and the number of results per value, for each facet.
+type SearchResponse struct {
+	Results []SearchResult            `json:"results" yaml:"results"`
+	Facets  map[string]map[string]int `json:"facets" yaml:"facets"`
+}
+
+// SearchQuery is the query sent to the search endpoint.
+type SearchQuery struct {
+	// Query is the free text, the filters and the operators to search for. For example: `project:infra tag:kafka lag`.
+	Query string
+	// Project restricts the search to a single project.
+	Project string
+}
+
+func (q *SearchQuery) GetValues() url.Values {
+	values := make(url.Values)
+	values["query"] = []string{q.Query}
+	if len(q.Project) > 0 {
+		values["project"] = []string{q.Project}
+	}
+	return values
+}
+
+type SearchInterface interface {
+	// Search returns the resources matching the query, among the ones the user is allowed to read.
+	Search(q SearchQuery) (*SearchResponse, error)
+}
+
+type search struct {
+	SearchInterface
+	client *perseshttp.RESTClient
+}
+
+func newSearch(client *perseshttp.RESTClient) SearchInterface {
+	return &search{
+		client: client,
+	}
+}
+
+func (c *search) Search(q SearchQuery) (*SearchResponse, error) {
+	result := &SearchResponse{}
+	err := c.client.Get().
+		Resource(searchResource).
+		Query(&q).
+		Do().
+		Object(result)
+	return result, err
+}
@@ -21,8 +21,9 @@ import (
 )
 
 const (
-	userResource   = "users"
-	whoAmIResource = "user/whoami"
+	userResource        = "users"
+	whoAmIResource      = "user/whoami"
+	preferencesResource = "user/preferences"
 )
 
 type UserInterface interface {
@@ -37,6 +38,10 @@ type UserInterface interface {
 	// It can be empty in case you want to get the full list of User available
 	List(prefix string) ([]*v1.PublicUser, error)
 	WhoAmI() (*v1.PublicUser, error)
+	// GetPreferences is returning the preferences of the authenticated user.
+	GetPreferences() (*v1.UserPreferences, error)
+	// UpdatePreferences is replacing the preferences of the authenticated user.
+	UpdatePreferences(preferences *v1.UserPreferences) (*v1.UserPreferences, error)
 }
 
 type user struct {
@@ -109,3 +114,22 @@ func (c *user) WhoAmI() (*v1.PublicUser, error) {
 		Object(result)
 	return result, err
 }
+
+func (c *user) GetPreferences() (*v1.UserPreferences, error) {
+	result := &v1.UserPreferences{}
+	err := c.client.Get().
+		Resource(preferencesResource).
+		Do().
+		Object(result)
+	return result, err
+}
+
+func (c *user) UpdatePreferences(preferences *v1.UserPreferences) (*v1.UserPreferences, error) {
+	result := &v1.UserPreferences{}
+	err := c.client.Put().
+		Resource(preferencesResource).
+		Body(preferences).
+		Do().
+		Object(result)
+	return result, err
+}
@@ -0,0 +1,47 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fakev1
+
+import (
+	v1 "github.com/perses/perses/pkg/client/api/v1"
+	"github.com/perses/perses/pkg/model/api/v1/role"
+)
+
+type authz struct {
+	v1.AuthzInterface
+}
+
+// Explain allows reading everything, and nothing else.
+func (c *authz) Explain(q v1.ExplainQuery) (*role.Explanation, error) {
+	user := q.User
+	if len(user) == 0 {
+		user = "admin"
+	}
+	result := &role.Explanation{
+		User:    user,
+		Action:  q.Action,
+		Scope:   q.Scope,
+		Project: q.Project,
+		Grants: []role.Grant{
+			{Source: role.GlobalRoleSource, Name: "viewer", Bindings: []string{"viewers"}, Bound: true},
+		},
+	}
+	if q.Action == role.ReadAction {
+		result.Allowed = true
+		return result, nil
+	}
+	result.Grants = nil
+	result.Reason = "no role grants the permission"
+	return result, nil
+}
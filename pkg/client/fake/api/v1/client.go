@@ -31,6 +31,10 @@ func (c *client) RESTClient() *perseshttp.RESTClient {
 	return c.restClient
 }
 
+func (c *client) Authz() v1.AuthzInterface {
+	return &authz{}
+}
+
 func (c *client) Dashboard(_ string) v1.DashboardInterface {
 	return &dashboard{}
 }
@@ -60,3 +64,7 @@ func (c *client) Plugin() v1.PluginInterface {
 func (c *client) Project() v1.ProjectInterface {
 	return &project{}
 }
+
+func (c *client) Search() v1.SearchInterface {
+	return &search{}
+}
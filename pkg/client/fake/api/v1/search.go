@@ -0,0 +1,64 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fakev1
+
+import (
+	"strings"
+
+	v1 "github.com/perses/perses/pkg/client/api/v1"
+	modelV1 "github.com/perses/perses/pkg/model/api/v1"
+)
+
+func searchResult(kind modelV1.Kind, project string, name string, displayName string) v1.SearchResult {
+	return v1.SearchResult{
+		Kind:        kind,
+		Metadata:    *modelV1.NewProjectMetadata(project, name),
+		DisplayName: displayName,
+		Original:    name,
+	}
+}
+
+type search struct {
+	v1.SearchInterface
+}
+
+// Search returns the resources whose name contains the query.
+func (c *search) Search(q v1.SearchQuery) (*v1.SearchResponse, error) {
+	initialList := []v1.SearchResult{
+		searchResult(modelV1.KindDashboard, "perses", "kafka-lag", "Kafka Lag"),
+		searchResult(modelV1.KindDashboard, "infra", "kafka-brokers", "Kafka Brokers"),
+		searchResult(modelV1.KindGlobalDatasource, "", "prometheus", "Prometheus"),
+	}
+	response := &v1.SearchResponse{
+		Results: []v1.SearchResult{},
+		Facets: map[string]map[string]int{
+			"kind":    {},
+			"project": {},
+		},
+	}
+	for _, result := range initialList {
+		if len(q.Project) > 0 && result.Metadata.Project != q.Project {
+			continue
+		}
+		if !strings.Contains(result.Metadata.Name, strings.ToLower(q.Query)) {
+			continue
+		}
+		response.Results = append(response.Results, result)
+		response.Facets["kind"][string(result.Kind)]++
+		if len(result.Metadata.Project) > 0 {
+			response.Facets["project"][result.Metadata.Project]++
+		}
+	}
+	return response, nil
+}
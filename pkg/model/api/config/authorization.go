@@ -14,8 +14,11 @@
 package config
 
 import (
+	"errors"
+	"fmt"
 	"time"
 
+	"github.com/perses/perses/pkg/model/api/v1/policy"
 	"github.com/perses/perses/pkg/model/api/v1/role"
 	"github.com/perses/spec/go/common"
 	"github.com/sirupsen/logrus"
@@ -89,11 +92,75 @@ func (n *NativeAuthorizationProvider) Verify() error {
 	return nil
 }
 
+type CELPolicy struct {
+	// Name identifies the policy in the decision logs and in the result of the dry-run endpoint.
+	Name string `json:"name" yaml:"name"`
+	// Effect is either `allow` or `deny`. Default: allow
+	Effect policy.Effect `json:"effect,omitempty" yaml:"effect,omitempty"`
+	// Expression is a CEL expression returning a boolean.
+	// Refer to https://github.com/google/cel-spec/blob/master/doc/langdef.md for the syntax.
+	Expression string `json:"expression" yaml:"expression"`
+	program    *policy.Program
+}
+
+func (p *CELPolicy) Verify() error {
+	if len(p.Name) == 0 {
+		return errors.New("name is required for a CEL policy")
+	}
+	if len(p.Effect) == 0 {
+		p.Effect = policy.AllowEffect
+	}
+	program, err := policy.Compile(p.Expression)
+	if err != nil {
+		return fmt.Errorf("invalid CEL policy %q: %w", p.Name, err)
+	}
+	p.program = program
+	return nil
+}
+
+// Policy returns the compiled policy. It must be called after Verify.
+func (p *CELPolicy) Policy() policy.Policy {
+	return policy.Policy{Name: p.Name, Effect: p.Effect, Program: p.program}
+}
+
+type CELAuthorizationProvider struct {
+	Enable bool `json:"enable,omitempty" yaml:"enable,omitempty"`
+	// Groups associates the name of a group to the list of the users it contains.
+	// The groups of the user are exposed to the policies through the `groups` variable.
+	Groups map[string][]string `json:"groups,omitempty" yaml:"groups,omitempty"`
+	// Policies is the list of the policies defined in the configuration.
+	// They are evaluated together with the GlobalPolicy resources.
+	Policies []CELPolicy `json:"policies,omitempty" yaml:"policies,omitempty"`
+	// CheckLatestUpdateInterval is the interval used to check if the GlobalPolicy resources need to be reloaded. Only for SQL database setup.
+	CheckLatestUpdateInterval common.Duration `json:"check_latest_update_interval,omitempty" yaml:"check_latest_update_interval,omitempty"`
+	// DecisionLogging logs every authorization decision, with the policies that led to it.
+	DecisionLogging bool `json:"decision_logging,omitempty" yaml:"decision_logging,omitempty"`
+}
+
+func (c *CELAuthorizationProvider) Verify() error {
+	if !c.Enable {
+		return nil
+	}
+	if c.CheckLatestUpdateInterval <= 0 {
+		c.CheckLatestUpdateInterval = common.Duration(defaultCacheInterval)
+	}
+	names := make(map[string]bool, len(c.Policies))
+	for _, p := range c.Policies {
+		if names[p.Name] {
+			return fmt.Errorf("the CEL policy %q is defined multiple times", p.Name)
+		}
+		names[p.Name] = true
+	}
+	return nil
+}
+
 type AuthorizationProvider struct {
 	// +optional
 	Kubernetes KubernetesAuthorizationProvider `json:"kubernetes,omitzero" yaml:"kubernetes,omitempty"`
 	// +optional
 	Native NativeAuthorizationProvider `json:"native,omitzero" yaml:"native,omitempty"`
+	// +optional
+	CEL CELAuthorizationProvider `json:"cel,omitzero" yaml:"cel,omitempty"`
 }
 
 type AuthorizationConfig struct {
@@ -101,10 +168,19 @@ type AuthorizationConfig struct {
 	CheckLatestUpdateInterval common.Duration `json:"check_latest_update_interval,omitempty" yaml:"check_latest_update_interval,omitempty"`
 	// DEPRECATED: use NativeAuthorizationProvider.GuestPermissions instead.
 	GuestPermissions []*role.Permission `json:"guest_permissions,omitempty" yaml:"guest_permissions,omitempty"`
+	// ReadGrantsQuery makes the `read` action on a datasource also grant the `query` action, as it was the case before the
+	// `query` action was introduced. It keeps the existing roles working and should be disabled once they are updated.
+	// Defaults to true when omitted.
+	ReadGrantsQuery *bool `json:"read_grants_query,omitempty" yaml:"read_grants_query,omitempty"`
 	// +optional
 	Provider AuthorizationProvider `json:"provider,omitzero" yaml:"provider,omitempty"`
 }
 
+// IsReadGrantingQuery returns true when the `read` action on a datasource also grants the `query` action.
+func (a *AuthorizationConfig) IsReadGrantingQuery() bool {
+	return a.ReadGrantsQuery == nil || *a.ReadGrantsQuery
+}
+
 func (a *AuthorizationConfig) Verify() error {
 	if a.CheckLatestUpdateInterval > 0 {
 		logrus.Warn("'security.authorization.check_latest_update_interval' is deprecated, use 'security.authorization.provider.native.check_latest_update_interval' instead.")
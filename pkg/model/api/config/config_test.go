@@ -32,6 +32,22 @@ func defaultConfig() Config {
 	return cfg
 }
 
+var defaultIndexKeys = IndexKeys{
+	Dashboard: []string{
+		"metadata.name",
+		"spec.display.name",
+		"spec.panels.@values.#.spec.display.name",
+		"spec.panels.@values.#.spec.display.description",
+		"spec.panels.@values.#.spec.queries.#.spec.plugin.spec.query",
+	},
+	Project:          []string{"metadata.name", "spec.display.name"},
+	Datasource:       []string{"metadata.name", "spec.display.name", "spec.plugin.spec"},
+	GlobalDatasource: []string{"metadata.name", "spec.display.name", "spec.plugin.spec"},
+	Variable:         []string{"metadata.name", "spec.spec.display.name", "spec.spec.plugin.spec"},
+	GlobalVariable:   []string{"metadata.name", "spec.spec.display.name", "spec.spec.plugin.spec"},
+	Folder:           []string{"metadata.name", "spec.display.name"},
+}
+
 func TestJSONMarshalConfig(t *testing.T) {
 	testSuite := []struct {
 		title string
@@ -173,6 +189,37 @@ func TestJSONMarshalConfig(t *testing.T) {
     "check_latest_update_interval": "30s",
     "index_keys": {
       "dashboard": [
+        "metadata.name",
+        "spec.display.name",
+        "spec.panels.@values.#.spec.display.name",
+        "spec.panels.@values.#.spec.display.description",
+        "spec.panels.@values.#.spec.queries.#.spec.plugin.spec.query"
+      ],
+      "project": [
+        "metadata.name",
+        "spec.display.name"
+      ],
+      "datasource": [
+        "metadata.name",
+        "spec.display.name",
+        "spec.plugin.spec"
+      ],
+      "global_datasource": [
+        "metadata.name",
+        "spec.display.name",
+        "spec.plugin.spec"
+      ],
+      "variable": [
+        "metadata.name",
+        "spec.spec.display.name",
+        "spec.spec.plugin.spec"
+      ],
+      "global_variable": [
+        "metadata.name",
+        "spec.spec.display.name",
+        "spec.spec.plugin.spec"
+      ],
+      "folder": [
         "metadata.name",
         "spec.display.name"
       ]
@@ -559,7 +606,7 @@ plugin:
 				Search: Search{
 					CheckLatestUpdateInterval: common.Duration(defaultCacheInterval),
 					ExcludedChars:             nil,
-					IndexKeys:                 IndexKeys{Dashboard: []string{"metadata.name", "spec.display.name"}},
+					IndexKeys:                 defaultIndexKeys,
 				},
 			},
 		},
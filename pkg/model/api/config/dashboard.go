@@ -17,11 +17,21 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/PaesslerAG/gval"
 	"github.com/PaesslerAG/jsonpath"
 	"github.com/google/cel-go/cel"
+	"github.com/perses/spec/go/common"
+)
+
+const (
+	defaultDashboardUsageRetention       = 90 * 24 * time.Hour
+	defaultDashboardUsageCleanupInterval = time.Hour
+	defaultDashboardLifecycleInterval    = time.Hour
+	defaultDashboardLifecycleTag         = "stale"
 )
 
 type CustomLintRule struct {
@@ -114,8 +124,104 @@ func (c *CustomLintRule) evaluateAndLoadCELExpression() error {
 	return nil
 }
 
+// DashboardUsage is the config to store the views of the dashboards, per dashboard, user and day,
+// and to get the most viewed, never viewed and slowest dashboards of a project.
+type DashboardUsage struct {
+	// Retention is how long the views are kept. The default is 90 days.
+	Retention common.Duration `json:"retention,omitempty" yaml:"retention,omitempty"`
+	// CleanupInterval is the interval at which the views older than the retention are removed. The default is 1 hour.
+	CleanupInterval common.Duration `json:"cleanup_interval,omitempty" yaml:"cleanup_interval,omitempty"`
+}
+
+func (d *DashboardUsage) Verify() error {
+	if d.Retention <= 0 {
+		d.Retention = common.Duration(defaultDashboardUsageRetention)
+	}
+	if d.CleanupInterval <= 0 {
+		d.CleanupInterval = common.Duration(defaultDashboardUsageCleanupInterval)
+	}
+	return nil
+}
+
+// DashboardLifecyclePolicy describes what happens to the dashboards of the selected projects once they are stale,
+// meaning they haven't been viewed or updated for a while.
+type DashboardLifecyclePolicy struct {
+	// Projects is the list of projects the policy applies to. When empty, the policy applies to every project.
+	// A project follows the first policy matching it.
+	Projects []string `json:"projects,omitempty" yaml:"projects,omitempty"`
+	// UnusedFor is the duration without any view or update after which a dashboard is stale.
+	UnusedFor common.Duration `json:"unused_for" yaml:"unused_for"`
+	// Tag is added to the stale dashboards. It is removed if the dashboard is viewed again.
+	Tag string `json:"tag,omitempty" yaml:"tag,omitempty"`
+	// ArchiveFolder is the name of the folder, in the same project, the stale dashboards are moved to.
+	// When empty, the dashboards stay in their folder.
+	ArchiveFolder string `json:"archive_folder,omitempty" yaml:"archive_folder,omitempty"`
+	// DeleteAfter is the grace period, once a dashboard is tagged, after which it is deleted if nobody viewed or updated it.
+	// When empty, the stale dashboards are never deleted.
+	DeleteAfter common.Duration `json:"delete_after,omitempty" yaml:"delete_after,omitempty"`
+}
+
+func (p *DashboardLifecyclePolicy) Verify() error {
+	if p.UnusedFor <= 0 {
+		return errors.New("unused_for must be set with a positive duration")
+	}
+	if p.DeleteAfter < 0 {
+		return errors.New("delete_after cannot be negative")
+	}
+	if len(p.Tag) == 0 {
+		p.Tag = defaultDashboardLifecycleTag
+	}
+	if strings.TrimSpace(p.Tag) != p.Tag || len(p.Tag) > 50 {
+		return fmt.Errorf("tag %q cannot start or end with whitespace, and cannot contain more than 50 characters", p.Tag)
+	}
+	if len(p.ArchiveFolder) > 0 {
+		if err := common.ValidateID(p.ArchiveFolder); err != nil {
+			return fmt.Errorf("invalid archive_folder: %w", err)
+		}
+	}
+	return nil
+}
+
+// Matches returns true if the policy applies to the project.
+func (p *DashboardLifecyclePolicy) Matches(project string) bool {
+	return len(p.Projects) == 0 || slices.Contains(p.Projects, project)
+}
+
+// DashboardLifecycle is the config of the task applying the lifecycle policies to the dashboards.
+type DashboardLifecycle struct {
+	// Interval is the interval at which the policies are applied. The default is 1 hour.
+	Interval common.Duration `json:"interval,omitempty" yaml:"interval,omitempty"`
+	// DryRun disables the task. The actions the policies would take are only available in the report of each project.
+	DryRun   bool                        `json:"dry_run,omitempty" yaml:"dry_run,omitempty"`
+	Policies []*DashboardLifecyclePolicy `json:"policies" yaml:"policies"`
+}
+
+func (l *DashboardLifecycle) Verify() error {
+	if len(l.Policies) == 0 {
+		return errors.New("at least one policy must be defined")
+	}
+	if l.Interval <= 0 {
+		l.Interval = common.Duration(defaultDashboardLifecycleInterval)
+	}
+	return nil
+}
+
+// GetPolicy returns the first policy applying to the project, or nil if there is none.
+func (l *DashboardLifecycle) GetPolicy(project string) *DashboardLifecyclePolicy {
+	for _, policy := range l.Policies {
+		if policy.Matches(project) {
+			return policy
+		}
+	}
+	return nil
+}
+
 type DashboardConfig struct {
 	CustomLintRules []*CustomLintRule `json:"custom_lint_rules,omitempty" yaml:"custom_lint_rules,omitempty"`
+	// Usage enables the dashboard usage reports, when it is set.
+	Usage *DashboardUsage `json:"usage,omitempty" yaml:"usage,omitempty"`
+	// Lifecycle enables the lifecycle policies of the stale dashboards, when it is set.
+	Lifecycle *DashboardLifecycle `json:"lifecycle,omitempty" yaml:"lifecycle,omitempty"`
 }
 
 func (c *DashboardConfig) Verify() error {
@@ -132,5 +238,21 @@ func (c *DashboardConfig) Verify() error {
 			return err
 		}
 	}
+	if c.Lifecycle != nil && c.Usage == nil {
+		// Without the views, a dashboard only updated by its provisioning or never edited would look unused while people open it every day.
+		return fmt.Errorf("the lifecycle policies require the dashboard usage to be enabled")
+	}
+	if c.Usage != nil && c.Lifecycle != nil {
+		retention := c.Usage.Retention
+		if retention <= 0 {
+			retention = common.Duration(defaultDashboardUsageRetention)
+		}
+		// A dashboard viewed before the retention would look unused, as its views are gone.
+		for _, policy := range c.Lifecycle.Policies {
+			if policy.UnusedFor > retention {
+				return fmt.Errorf("the unused_for of a lifecycle policy (%s) cannot be longer than the retention of the dashboard usage (%s)", time.Duration(policy.UnusedFor), time.Duration(retention))
+			}
+		}
+	}
 	return nil
 }
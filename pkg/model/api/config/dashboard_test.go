@@ -0,0 +1,61 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/perses/spec/go/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDashboardConfig_VerifyLifecycle(t *testing.T) {
+	testSuite := []struct {
+		title      string
+		usage      *DashboardUsage
+		errMessage string
+	}{
+		{
+			title: "lifecycle policies with the dashboard usage",
+			usage: &DashboardUsage{},
+		},
+		{
+			title:      "lifecycle policies without the dashboard usage",
+			errMessage: "the lifecycle policies require the dashboard usage to be enabled",
+		},
+		{
+			title:      "unused_for longer than the retention of the views",
+			usage:      &DashboardUsage{Retention: common.Duration(7 * 24 * time.Hour)},
+			errMessage: "cannot be longer than the retention of the dashboard usage",
+		},
+	}
+
+	for _, test := range testSuite {
+		t.Run(test.title, func(t *testing.T) {
+			c := &DashboardConfig{
+				Usage: test.usage,
+				Lifecycle: &DashboardLifecycle{
+					Policies: []*DashboardLifecyclePolicy{{UnusedFor: common.Duration(30 * 24 * time.Hour)}},
+				},
+			}
+			err := c.Verify()
+			if len(test.errMessage) == 0 {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, test.errMessage)
+			}
+		})
+	}
+}
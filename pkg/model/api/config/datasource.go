@@ -13,7 +13,14 @@
 
 package config
 
-import "fmt"
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+
+	"github.com/perses/spec/go/common"
+)
 
 type GlobalDatasourceConfig struct {
 	// Disable is used to disable the global datasource feature.
@@ -45,4 +52,113 @@ type DatasourceConfig struct {
 	// DisableLocal when used is preventing the possibility to add a datasource directly in the dashboard spec.
 	// It will also disable the associated proxy.
 	DisableLocal bool `json:"disable_local" yaml:"disable_local"`
+	// QueryAllowLists restricts the requests that can be sent through the proxy of some datasources.
+	QueryAllowLists []DatasourceQueryAllowList `json:"query_allow_lists,omitempty" yaml:"query_allow_lists,omitempty"`
+}
+
+func (c *DatasourceConfig) Verify() error {
+	for i, allowList := range c.QueryAllowLists {
+		for _, other := range c.QueryAllowLists[:i] {
+			if other.Project == allowList.Project && other.Datasource == allowList.Datasource {
+				return fmt.Errorf("the datasource %q of the project %q has several query allow-lists", allowList.Datasource, allowList.Project)
+			}
+		}
+	}
+	return nil
+}
+
+// FindQueryAllowList returns the query allow-list of the datasource, or nil if there is none.
+// The project is empty for a global datasource.
+func (c *DatasourceConfig) FindQueryAllowList(project string, datasource string) *DatasourceQueryAllowList {
+	for i := range c.QueryAllowLists {
+		if c.QueryAllowLists[i].Project == project && c.QueryAllowLists[i].Datasource == datasource {
+			return &c.QueryAllowLists[i]
+		}
+	}
+	return nil
+}
+
+// HasProjectQueryAllowList returns true if a query allow-list is defined for at least one datasource of the project.
+func (c *DatasourceConfig) HasProjectQueryAllowList(project string) bool {
+	return slices.ContainsFunc(c.QueryAllowLists, func(allowList DatasourceQueryAllowList) bool {
+		return allowList.Project == project
+	})
+}
+
+const defaultQueryAllowListParameter = "query"
+
+// DatasourceQueryAllowList restricts the requests that can be sent through the proxy of an HTTP datasource.
+// It applies on top of the allowed endpoints defined in the datasource itself: a request must satisfy both.
+type DatasourceQueryAllowList struct {
+	// Project is the project of the datasource. It must be empty to target a global datasource.
+	Project string `json:"project,omitempty" yaml:"project,omitempty"`
+	// Datasource is the name of the datasource.
+	Datasource string `json:"datasource" yaml:"datasource"`
+	// Endpoints is the list of the endpoints that can be called. When empty, every endpoint allowed by the datasource can be called.
+	Endpoints []QueryAllowedEndpoint `json:"endpoints,omitempty" yaml:"endpoints,omitempty"`
+	// Parameter is the name of the URL or form parameter containing the query. Default: `query`
+	Parameter string `json:"parameter,omitempty" yaml:"parameter,omitempty"`
+	// Queries is a list of regular expressions. When set, every query sent to the datasource must fully match one of them.
+	Queries []string `json:"queries,omitempty" yaml:"queries,omitempty"`
+
+	// queryPatterns contains the compiled Queries.
+	queryPatterns []*regexp.Regexp
+}
+
+func (a *DatasourceQueryAllowList) Verify() error {
+	if len(a.Datasource) == 0 {
+		return fmt.Errorf("the datasource of a query allow-list cannot be empty")
+	}
+	if len(a.Parameter) == 0 {
+		a.Parameter = defaultQueryAllowListParameter
+	}
+	a.queryPatterns = make([]*regexp.Regexp, 0, len(a.Queries))
+	for _, query := range a.Queries {
+		pattern, err := regexp.Compile(fmt.Sprintf("^(?:%s)$", query))
+		if err != nil {
+			return fmt.Errorf("invalid query pattern %q in the allow-list of the datasource %q: %w", query, a.Datasource, err)
+		}
+		a.queryPatterns = append(a.queryPatterns, pattern)
+	}
+	return nil
+}
+
+// IsEndpointAllowed returns true if the endpoint can be called with the given HTTP method.
+func (a *DatasourceQueryAllowList) IsEndpointAllowed(method string, path string) bool {
+	if len(a.Endpoints) == 0 {
+		return true
+	}
+	return slices.ContainsFunc(a.Endpoints, func(endpoint QueryAllowedEndpoint) bool {
+		return endpoint.Method == method && endpoint.EndpointPattern.MatchString(path)
+	})
+}
+
+// IsQueryAllowed returns true if the query matches one of the patterns of the allow-list.
+func (a *DatasourceQueryAllowList) IsQueryAllowed(query string) bool {
+	if len(a.queryPatterns) == 0 {
+		return true
+	}
+	return slices.ContainsFunc(a.queryPatterns, func(pattern *regexp.Regexp) bool {
+		return pattern.MatchString(query)
+	})
+}
+
+// QueryAllowedEndpoint is an endpoint of the datasource that can be called, with the same semantic as the allowed endpoints of the datasource.
+type QueryAllowedEndpoint struct {
+	// EndpointPattern is a regular expression matched against the path of the request.
+	EndpointPattern common.Regexp `json:"endpoint_pattern" yaml:"endpoint_pattern"`
+	// Method is the HTTP method of the request.
+	Method string `json:"method" yaml:"method"`
+}
+
+func (e *QueryAllowedEndpoint) Verify() error {
+	if e.EndpointPattern.Regexp == nil {
+		return fmt.Errorf("the endpoint pattern of an allowed endpoint cannot be empty")
+	}
+	switch e.Method {
+	case http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+	default:
+		return fmt.Errorf("invalid HTTP method %q for the endpoint %q", e.Method, e.EndpointPattern.String())
+	}
+	return nil
 }
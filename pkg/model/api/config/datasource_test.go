@@ -0,0 +1,67 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/perses/spec/go/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDatasourceQueryAllowList(t *testing.T) {
+	endpoint := QueryAllowedEndpoint{EndpointPattern: common.MustNewRegexp("/api/v1/query(_range)?"), Method: http.MethodPost}
+	require.NoError(t, endpoint.Verify())
+	allowList := &DatasourceQueryAllowList{
+		Datasource: "prometheus",
+		Endpoints:  []QueryAllowedEndpoint{endpoint},
+		Queries:    []string{`up\{job="[a-z]+"\}`, "sum by \\(job\\) \\(.+\\)"},
+	}
+	require.NoError(t, allowList.Verify())
+	assert.Equal(t, "query", allowList.Parameter)
+
+	assert.True(t, allowList.IsEndpointAllowed(http.MethodPost, "/api/v1/query_range"))
+	assert.False(t, allowList.IsEndpointAllowed(http.MethodGet, "/api/v1/query"))
+	assert.False(t, allowList.IsEndpointAllowed(http.MethodPost, "/api/v1/series"))
+
+	assert.True(t, allowList.IsQueryAllowed(`up{job="api"}`))
+	assert.True(t, allowList.IsQueryAllowed(`sum by (job) (rate(http_requests_total[5m]))`))
+	// The patterns must match the whole query.
+	assert.False(t, allowList.IsQueryAllowed(`up{job="api"} or secret_metric`))
+}
+
+func TestDatasourceConfig_VerifyDuplicatedQueryAllowList(t *testing.T) {
+	c := &DatasourceConfig{QueryAllowLists: []DatasourceQueryAllowList{
+		{Project: "shop", Datasource: "prometheus"},
+		{Datasource: "prometheus"},
+	}}
+	assert.NoError(t, c.Verify())
+	assert.Equal(t, "shop", c.FindQueryAllowList("shop", "prometheus").Project)
+	assert.Nil(t, c.FindQueryAllowList("bank", "prometheus"))
+
+	c.QueryAllowLists = append(c.QueryAllowLists, DatasourceQueryAllowList{Project: "shop", Datasource: "prometheus"})
+	assert.Error(t, c.Verify())
+}
+
+func TestDatasourceConfig_HasProjectQueryAllowList(t *testing.T) {
+	c := &DatasourceConfig{QueryAllowLists: []DatasourceQueryAllowList{
+		{Project: "shop", Datasource: "prometheus"},
+		{Datasource: "thanos"},
+	}}
+	assert.True(t, c.HasProjectQueryAllowList("shop"))
+	assert.True(t, c.HasProjectQueryAllowList(""))
+	assert.False(t, c.HasProjectQueryAllowList("bank"))
+}
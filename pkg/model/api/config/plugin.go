@@ -17,7 +17,9 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
+	"github.com/perses/spec/go/common"
 	"github.com/sirupsen/logrus"
 )
 
@@ -29,6 +31,7 @@ var (
 	DefaultPluginPathInContainer        = "/etc/perses/plugins"
 	DefaultArchivePluginPath            = "plugins-archive"
 	DefaultArchivePluginPathInContainer = "/etc/perses/plugins-archive"
+	DefaultPluginCachePath              = "plugins-cache"
 )
 
 func isFileExists(path string) bool {
@@ -36,6 +39,87 @@ func isFileExists(path string) bool {
 	return err == nil
 }
 
+const (
+	DefaultPluginBackendTimeout       = 10 * time.Second
+	DefaultPluginBackendMemoryLimit   = 64
+	DefaultPluginBackendMaxOutputSize = 32
+	DefaultPluginBackendMaxInputSize  = 64
+	// DefaultPluginRegistryMaxArchiveSize is the default maximum size, in MiB, of an archive downloaded from the plugin registry.
+	DefaultPluginRegistryMaxArchiveSize = 100
+)
+
+type PluginBackend struct {
+	// Enable activates the execution of the WASM modules shipped by the plugin modules to transform the response of the datasources.
+	Enable bool `json:"enable" yaml:"enable"`
+	// Timeout is the maximum duration of a single execution of a WASM module.
+	Timeout common.Duration `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+	// MemoryLimit is the maximum memory, in MiB, a WASM module can use.
+	MemoryLimit int `json:"memory_limit,omitempty" yaml:"memory_limit,omitempty"`
+	// MaxOutputSize is the maximum size, in MiB, of the output of a WASM module.
+	MaxOutputSize int `json:"max_output_size,omitempty" yaml:"max_output_size,omitempty"`
+	// MaxInputSize is the maximum size, in MiB, of the response of a datasource that can be given to a WASM module.
+	MaxInputSize int `json:"max_input_size,omitempty" yaml:"max_input_size,omitempty"`
+}
+
+func (b *PluginBackend) Verify() error {
+	if b.Timeout <= 0 {
+		b.Timeout = common.Duration(DefaultPluginBackendTimeout)
+	}
+	if b.MemoryLimit <= 0 {
+		b.MemoryLimit = DefaultPluginBackendMemoryLimit
+	}
+	// A WASM memory can not be bigger than 4GiB.
+	if b.MemoryLimit > 4096 {
+		return fmt.Errorf("the 'memory_limit' of the plugin backends can not exceed 4096 MiB")
+	}
+	if b.MaxOutputSize <= 0 {
+		b.MaxOutputSize = DefaultPluginBackendMaxOutputSize
+	}
+	if b.MaxInputSize <= 0 {
+		b.MaxInputSize = DefaultPluginBackendMaxInputSize
+	}
+	return nil
+}
+
+type PinnedPluginModule struct {
+	// Name is the name of the plugin module as it appears in the registry.
+	Name string `json:"name" yaml:"name"`
+	// Version is the version, or the range of versions, of the module to install. Leave empty to install the latest version.
+	// Example: `0.6.0`, `^0.6.0`, `>=0.5.0 <1.0.0`
+	Version string `json:"version,omitempty" yaml:"version,omitempty"`
+}
+
+type PluginRegistry struct {
+	// URL is the URL of the registry. The registry must serve the list of the modules available at `<url>/index.json`.
+	URL *common.URL `json:"url" yaml:"url"`
+	// CachePath is the path to the directory where the index and the archives downloaded from the registry are stored.
+	CachePath string `json:"cache_path,omitempty" yaml:"cache_path,omitempty"`
+	// Offline, when true, prevents Perses from reaching the registry. Only the modules already available in the cache are installed.
+	Offline bool `json:"offline,omitempty" yaml:"offline,omitempty"`
+	// MaxArchiveSize is the maximum size, in MiB, of an archive downloaded from the registry.
+	MaxArchiveSize int `json:"max_archive_size,omitempty" yaml:"max_archive_size,omitempty"`
+	// Modules is the list of the modules to install from the registry when Perses is starting.
+	Modules []PinnedPluginModule `json:"modules,omitempty" yaml:"modules,omitempty"`
+}
+
+func (r *PluginRegistry) Verify() error {
+	if r.URL == nil {
+		return fmt.Errorf("the 'url' of the plugin registry must be set")
+	}
+	if len(r.CachePath) == 0 {
+		r.CachePath = DefaultPluginCachePath
+	}
+	if r.MaxArchiveSize <= 0 {
+		r.MaxArchiveSize = DefaultPluginRegistryMaxArchiveSize
+	}
+	for _, m := range r.Modules {
+		if len(m.Name) == 0 {
+			return fmt.Errorf("the name of every module to install from the plugin registry must be set")
+		}
+	}
+	return nil
+}
+
 type Plugin struct {
 	// Path is the path to the directory containing the runtime plugins
 	Path string `json:"path,omitempty" yaml:"path,omitempty"`
@@ -58,6 +142,15 @@ type Plugin struct {
 	// The name can be the name of the plugin or the name of the module. For example, you can put `Prometheus` to disable the Prometheus module that contains query, variables and datasource plugin.
 	// Use either Enabled or Disabled. Both can not be used at the same time.
 	Disabled []string `json:"disabled,omitempty" yaml:"disabled,omitempty"`
+	// PublicKeys is the list of paths to the PEM encoded ed25519 public keys trusted to sign the plugin archives.
+	// Each archive can come with a detached signature file (`<archive>.sig`) that is verified against these keys before the archive is extracted.
+	PublicKeys []string `json:"public_keys,omitempty" yaml:"public_keys,omitempty"`
+	// RequireSigned, when true, refuses to load any plugin module that doesn't come from an archive signed with one of the trusted public keys.
+	RequireSigned bool `json:"require_signed,omitempty" yaml:"require_signed,omitempty"`
+	// Registry is the remote registry used to install the plugin modules when Perses is starting.
+	Registry *PluginRegistry `json:"registry,omitempty" yaml:"registry,omitempty"`
+	// Backend is the configuration of the runtime executing the server-side part of the plugin modules.
+	Backend PluginBackend `json:"backend,omitempty" yaml:"backend,omitempty"`
 }
 
 func (p *Plugin) Verify() error {
@@ -85,6 +178,9 @@ func (p *Plugin) Verify() error {
 			p.ArchivePaths = append(p.ArchivePaths, DefaultArchivePluginPath)
 		}
 	}
+	if p.RequireSigned && len(p.PublicKeys) == 0 {
+		return fmt.Errorf("'require_signed' is enabled but no public key is set in 'public_keys' to verify the plugin archives")
+	}
 	if len(p.Enabled) > 0 && len(p.Disabled) > 0 {
 		return fmt.Errorf("the 'activated' and 'deactivated' attributes can not be used at the same time. Please use either one of them")
 	}
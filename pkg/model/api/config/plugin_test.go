@@ -16,6 +16,7 @@ package config
 import (
 	"testing"
 
+	"github.com/perses/spec/go/common"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -38,3 +39,24 @@ func TestPlugin_VerifyLowercaseDisabled(t *testing.T) {
 	assert.NoError(t, p.Verify())
 	assert.Equal(t, []string{"prometheus", "tempo"}, p.Disabled)
 }
+
+func TestPlugin_VerifyRequireSignedWithoutKeys(t *testing.T) {
+	p := &Plugin{
+		Path:          "plugins",
+		ArchivePaths:  []string{"plugins-archive"},
+		RequireSigned: true,
+	}
+	assert.Error(t, p.Verify())
+	p.PublicKeys = []string{"perses.pub"}
+	assert.NoError(t, p.Verify())
+}
+
+func TestPluginRegistry_Verify(t *testing.T) {
+	r := &PluginRegistry{Modules: []PinnedPluginModule{{Name: "Prometheus", Version: "^0.6.0"}}}
+	assert.Error(t, r.Verify())
+	r.URL = common.MustParseURL("https://registry.example.com")
+	assert.NoError(t, r.Verify())
+	assert.Equal(t, DefaultPluginCachePath, r.CachePath)
+	r.Modules = append(r.Modules, PinnedPluginModule{Version: "1.0.0"})
+	assert.Error(t, r.Verify())
+}
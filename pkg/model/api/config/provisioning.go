@@ -14,9 +14,100 @@
 package config
 
 import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"github.com/perses/perses/pkg/model/api/v1/secret"
 	"github.com/perses/spec/go/common"
 )
 
+const defaultGitBranch = "main"
+
+// maxGitNameLength keeps the tag set on the resources provisioned from a Git repository
+// ("git-provisioning:<name>") under the 50 characters accepted for a tag.
+const maxGitNameLength = 32
+
+var gitNameRegexp = regexp.MustCompile(`^[a-zA-Z0-9_.-]+$`)
+
+type GitAuth struct {
+	// Secret is the name of the GlobalSecret holding the credentials, either in its basicAuth or in its authorization.
+	// The authorization credentials are sent as a bearer token. When it is set, the other fields must be empty.
+	Secret string `json:"secret,omitempty" yaml:"secret,omitempty"`
+	// Username used to authenticate against the repository. When using a token, any non-empty value is usually accepted.
+	Username string `json:"username,omitempty" yaml:"username,omitempty"`
+	// Password or token used to authenticate against the repository.
+	Password secret.Hidden `json:"password,omitempty" yaml:"password,omitempty"`
+	// PasswordFile is a path to a file that contains the password or the token.
+	PasswordFile string `json:"password_file,omitempty" yaml:"password_file,omitempty"`
+}
+
+func (a *GitAuth) Verify() error {
+	if len(a.Secret) > 0 {
+		if len(a.Username) > 0 || len(a.Password) > 0 || len(a.PasswordFile) > 0 {
+			return errors.New("`secret` cannot be used along with `username`, `password` or `password_file`")
+		}
+		return nil
+	}
+	if len(a.Username) == 0 {
+		return errors.New("the username of the git auth cannot be empty")
+	}
+	if len(a.Password) > 0 && len(a.PasswordFile) > 0 {
+		return errors.New("only one of `password` or `password_file` can be set")
+	}
+	if len(a.PasswordFile) > 0 {
+		data, err := os.ReadFile(a.PasswordFile)
+		if err != nil {
+			return fmt.Errorf("failed to read password_file: %w", err)
+		}
+		a.Password = secret.Hidden(data)
+	}
+	if len(a.Password) == 0 {
+		return errors.New("one of `password` or `password_file` must be set")
+	}
+	return nil
+}
+
+type GitProvisioningConfig struct {
+	// Name identifies the repository. It is used to mark the resources provisioned from it and in the sync status.
+	Name string `json:"name" yaml:"name"`
+	// URL of the repository. Supported schemes are http(s) and file (the latter requires a bare repository).
+	URL string `json:"url" yaml:"url"`
+	// Branch to synchronize. Default is "main".
+	Branch string `json:"branch,omitempty" yaml:"branch,omitempty"`
+	// Path is the folder of the repository containing the resources. Default is the root of the repository.
+	Path string `json:"path,omitempty" yaml:"path,omitempty"`
+	// Auth is the basic authentication used to clone the repository.
+	Auth *GitAuth `json:"auth,omitempty" yaml:"auth,omitempty"`
+	// BuildCUE enables the evaluation of the Dashboard-as-Code CUE files found in the repository.
+	BuildCUE bool `json:"build_cue,omitempty" yaml:"build_cue,omitempty"`
+}
+
+func (g *GitProvisioningConfig) Verify() error {
+	if len(g.Name) == 0 {
+		return errors.New("the name of the git provisioning cannot be empty")
+	}
+	if len(g.Name) > maxGitNameLength || !gitNameRegexp.MatchString(g.Name) {
+		return fmt.Errorf("the name %q of the git provisioning must contain at most %d alphanumeric characters, '_', '.' or '-'", g.Name, maxGitNameLength)
+	}
+	if len(g.URL) == 0 {
+		return fmt.Errorf("the url of the git provisioning %q cannot be empty", g.Name)
+	}
+	if _, err := url.Parse(g.URL); err != nil {
+		return fmt.Errorf("invalid url for the git provisioning %q: %w", g.Name, err)
+	}
+	if len(g.Branch) == 0 {
+		g.Branch = defaultGitBranch
+	}
+	if len(g.Path) > 0 && !filepath.IsLocal(g.Path) {
+		return fmt.Errorf("the path %q of the git provisioning %q must be a relative path inside the repository", g.Path, g.Name)
+	}
+	return nil
+}
+
 type ProvisioningConfig struct {
 	Folders []string `json:"folders,omitempty" yaml:"folders,omitempty"`
 	// Interval is the refresh frequency
@@ -24,11 +115,34 @@ type ProvisioningConfig struct {
 	// EnableWatch can be used to update provisioning on change instead of waiting for the configured interval.
 	// Interval is still used to reconcile the state.
 	EnableWatch bool `json:"enable_watch,omitempty" yaml:"enable_watch,omitempty"`
+	// Prune enables the deletion of the resources provisioned from the folders whose file has been removed.
+	Prune bool `json:"prune,omitempty" yaml:"prune,omitempty"`
+	// Lock rejects the update and the deletion through the API of the resources managed by the provisioning,
+	// whether they come from a folder or from a Git repository.
+	Lock bool `json:"lock,omitempty" yaml:"lock,omitempty"`
+	// Git is the list of the Git repositories Perses pulls the resources from.
+	Git []GitProvisioningConfig `json:"git,omitempty" yaml:"git,omitempty"`
+	// GitFolder is the folder where the Git repositories are cloned.
+	GitFolder string `json:"git_folder,omitempty" yaml:"git_folder,omitempty"`
 }
 
 func (p *ProvisioningConfig) Verify() error {
 	if p.Interval <= 0 {
 		p.Interval = common.Duration(defaultInterval)
 	}
+	if len(p.Git) == 0 {
+		return nil
+	}
+	var names []string
+	for _, g := range p.Git {
+		var ok bool
+		names, ok = appendIfMissing(names, g.Name)
+		if !ok {
+			return fmt.Errorf("several git provisionings exist with the same name %q", g.Name)
+		}
+	}
+	if len(p.GitFolder) == 0 {
+		p.GitFolder = filepath.Join(os.TempDir(), "perses", "provisioning")
+	}
 	return nil
 }
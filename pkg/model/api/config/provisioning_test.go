@@ -0,0 +1,70 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/perses/perses/pkg/model/api/v1/secret"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGitProvisioningConfig_Verify(t *testing.T) {
+	g := &GitProvisioningConfig{Name: "dashboards", URL: "https://github.com/perses/dashboards.git"}
+	assert.NoError(t, g.Verify())
+	assert.Equal(t, defaultGitBranch, g.Branch)
+
+	g.Path = "../outside"
+	assert.Error(t, g.Verify())
+	g.Path = "resources/prod"
+	assert.NoError(t, g.Verify())
+
+	g.Name = "a name with spaces"
+	assert.Error(t, g.Verify())
+	g.Name = "a-name-that-is-way-too-long-to-fit-in-a-tag"
+	assert.Error(t, g.Verify())
+	g.Name = ""
+	assert.Error(t, g.Verify())
+}
+
+func TestGitAuth_Verify(t *testing.T) {
+	a := &GitAuth{Username: "perses"}
+	assert.Error(t, a.Verify())
+
+	passwordFile := filepath.Join(t.TempDir(), "token")
+	assert.NoError(t, os.WriteFile(passwordFile, []byte("my-token"), 0600))
+	a.PasswordFile = passwordFile
+	assert.NoError(t, a.Verify())
+	assert.Equal(t, secret.Hidden("my-token"), a.Password)
+
+	a.Password = "another-token"
+	assert.Error(t, a.Verify())
+
+	assert.NoError(t, (&GitAuth{Secret: "github"}).Verify())
+	assert.Error(t, (&GitAuth{Secret: "github", Username: "perses"}).Verify())
+}
+
+func TestProvisioningConfig_VerifyGit(t *testing.T) {
+	p := &ProvisioningConfig{}
+	assert.NoError(t, p.Verify())
+	assert.Empty(t, p.GitFolder)
+
+	p.Git = []GitProvisioningConfig{{Name: "dashboards"}, {Name: "dashboards"}}
+	assert.Error(t, p.Verify())
+	p.Git[1].Name = "datasources"
+	assert.NoError(t, p.Verify())
+	assert.NotEmpty(t, p.GitFolder)
+}
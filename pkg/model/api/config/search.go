@@ -13,10 +13,35 @@
 
 package config
 
-import "github.com/perses/spec/go/common"
+import (
+	"fmt"
 
+	"github.com/perses/spec/go/common"
+)
+
+// IndexKeys are the JSON paths (using the gjson syntax) of the values indexed for each kind.
 type IndexKeys struct {
-	Dashboard []string `json:"dashboard,omitempty" yaml:"dashboard,omitempty"`
+	Dashboard        []string `json:"dashboard,omitempty" yaml:"dashboard,omitempty"`
+	Project          []string `json:"project,omitempty" yaml:"project,omitempty"`
+	Datasource       []string `json:"datasource,omitempty" yaml:"datasource,omitempty"`
+	GlobalDatasource []string `json:"global_datasource,omitempty" yaml:"global_datasource,omitempty"`
+	Variable         []string `json:"variable,omitempty" yaml:"variable,omitempty"`
+	GlobalVariable   []string `json:"global_variable,omitempty" yaml:"global_variable,omitempty"`
+	Folder           []string `json:"folder,omitempty" yaml:"folder,omitempty"`
+}
+
+// SearchDisk is the configuration of the index persisted on disk.
+type SearchDisk struct {
+	// Path is the directory where the index is stored. It is created if it doesn't exist.
+	// The directory must not be shared between several Perses servers.
+	Path string `json:"path" yaml:"path"`
+}
+
+func (s *SearchDisk) Verify() error {
+	if len(s.Path) == 0 {
+		return fmt.Errorf("the path of the search index must be set when the index is persisted on disk")
+	}
+	return nil
 }
 
 type Search struct {
@@ -25,6 +50,9 @@ type Search struct {
 	// ExcludedChars is the list of characters that will be excluded from the search engine.
 	ExcludedChars []string  `json:"excluded_chars,omitempty" yaml:"excluded_chars,omitempty"`
 	IndexKeys     IndexKeys `json:"index_keys,omitempty" yaml:"index_keys,omitempty"`
+	// Disk, when set, persists the index on disk. The index is then loaded back after a restart,
+	// so the search works while the index is reconciled with the database.
+	Disk *SearchDisk `json:"disk,omitempty" yaml:"disk,omitempty"`
 }
 
 func (e *Search) Verify() error {
@@ -32,7 +60,32 @@ func (e *Search) Verify() error {
 		e.CheckLatestUpdateInterval = common.Duration(defaultCacheInterval)
 	}
 	if e.IndexKeys.Dashboard == nil {
-		e.IndexKeys.Dashboard = []string{"metadata.name", "spec.display.name"}
+		e.IndexKeys.Dashboard = []string{
+			"metadata.name",
+			"spec.display.name",
+			// panel titles, descriptions and query expressions
+			"spec.panels.@values.#.spec.display.name",
+			"spec.panels.@values.#.spec.display.description",
+			"spec.panels.@values.#.spec.queries.#.spec.plugin.spec.query",
+		}
+	}
+	if e.IndexKeys.Project == nil {
+		e.IndexKeys.Project = []string{"metadata.name", "spec.display.name"}
+	}
+	if e.IndexKeys.Datasource == nil {
+		e.IndexKeys.Datasource = []string{"metadata.name", "spec.display.name", "spec.plugin.spec"}
+	}
+	if e.IndexKeys.GlobalDatasource == nil {
+		e.IndexKeys.GlobalDatasource = []string{"metadata.name", "spec.display.name", "spec.plugin.spec"}
+	}
+	if e.IndexKeys.Variable == nil {
+		e.IndexKeys.Variable = []string{"metadata.name", "spec.spec.display.name", "spec.spec.plugin.spec"}
+	}
+	if e.IndexKeys.GlobalVariable == nil {
+		e.IndexKeys.GlobalVariable = []string{"metadata.name", "spec.spec.display.name", "spec.spec.plugin.spec"}
+	}
+	if e.IndexKeys.Folder == nil {
+		e.IndexKeys.Folder = []string{"metadata.name", "spec.display.name"}
 	}
 	return nil
 }
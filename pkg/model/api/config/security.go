@@ -191,11 +191,15 @@ func (s *Security) Verify() error {
 		return errors.New("impossible to enable auth if no authentication provider is setup")
 	}
 
-	if s.EnableAuth && !s.Authorization.Provider.Kubernetes.Enable {
+	if s.Authorization.Provider.Kubernetes.Enable && s.Authorization.Provider.CEL.Enable {
+		return errors.New("kubernetes and CEL authorization providers cannot be enabled at the same time")
+	}
+
+	if s.EnableAuth && !s.Authorization.Provider.Kubernetes.Enable && !s.Authorization.Provider.CEL.Enable {
 		s.Authorization.Provider.Native.Enable = true
 	}
 
-	if !s.EnableAuth && (s.Authorization.Provider.Native.Enable || s.Authorization.Provider.Kubernetes.Enable) {
+	if !s.EnableAuth && (s.Authorization.Provider.Native.Enable || s.Authorization.Provider.Kubernetes.Enable || s.Authorization.Provider.CEL.Enable) {
 		return errors.New("authorization provider cannot be setup without auth enabled")
 	}
 
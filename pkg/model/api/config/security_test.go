@@ -359,7 +359,21 @@ security:
 				Search: Search{
 					CheckLatestUpdateInterval: common.Duration(defaultCacheInterval),
 					ExcludedChars:             nil,
-					IndexKeys:                 IndexKeys{Dashboard: []string{"metadata.name", "spec.display.name"}},
+					IndexKeys: IndexKeys{
+						Dashboard: []string{
+							"metadata.name",
+							"spec.display.name",
+							"spec.panels.@values.#.spec.display.name",
+							"spec.panels.@values.#.spec.display.description",
+							"spec.panels.@values.#.spec.queries.#.spec.plugin.spec.query",
+						},
+						Project:          []string{"metadata.name", "spec.display.name"},
+						Datasource:       []string{"metadata.name", "spec.display.name", "spec.plugin.spec"},
+						GlobalDatasource: []string{"metadata.name", "spec.display.name", "spec.plugin.spec"},
+						Variable:         []string{"metadata.name", "spec.spec.display.name", "spec.spec.plugin.spec"},
+						GlobalVariable:   []string{"metadata.name", "spec.spec.display.name", "spec.spec.plugin.spec"},
+						Folder:           []string{"metadata.name", "spec.display.name"},
+					},
 				},
 			},
 		},
@@ -0,0 +1,315 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	modelAPI "github.com/perses/perses/pkg/model/api"
+	"github.com/perses/perses/pkg/model/api/v1/template"
+)
+
+type DashboardTemplateSpec struct {
+	// Description explains what the dashboards rendered from the template are about.
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	// Parameters are the values that can be provided when instantiating the template.
+	Parameters []template.Parameter `json:"parameters,omitempty" yaml:"parameters,omitempty"`
+	// Dashboard is the spec of the dashboard to render.
+	// Any string or key can contain placeholders `[[ parameter ]]` referencing one of the parameters.
+	Dashboard map[string]any `json:"dashboard" yaml:"dashboard"`
+}
+
+func (d *DashboardTemplateSpec) UnmarshalJSON(data []byte) error {
+	var tmp DashboardTemplateSpec
+	type plain DashboardTemplateSpec
+	if err := json.Unmarshal(data, (*plain)(&tmp)); err != nil {
+		return err
+	}
+	if err := (&tmp).validate(); err != nil {
+		return err
+	}
+	*d = tmp
+	return nil
+}
+
+func (d *DashboardTemplateSpec) UnmarshalYAML(unmarshal func(any) error) error {
+	var tmp DashboardTemplateSpec
+	type plain DashboardTemplateSpec
+	if err := unmarshal((*plain)(&tmp)); err != nil {
+		return err
+	}
+	if err := (&tmp).validate(); err != nil {
+		return err
+	}
+	*d = tmp
+	return nil
+}
+
+func (d *DashboardTemplateSpec) validate() error {
+	if len(d.Dashboard) == 0 {
+		return fmt.Errorf("dashboard cannot be empty")
+	}
+	if err := template.VerifyParameters(d.Parameters); err != nil {
+		return err
+	}
+	for _, name := range template.Placeholders(d.Dashboard) {
+		if !slices.ContainsFunc(d.Parameters, func(param template.Parameter) bool { return param.Name == name }) {
+			return fmt.Errorf("parameter %q is used in the dashboard but not declared", name)
+		}
+	}
+	return nil
+}
+
+// Render returns the dashboard obtained by replacing the placeholders with the parameters provided,
+// or with their default value when they are not provided.
+func (d *DashboardTemplateSpec) Render(project string, name string, parameters map[string]string) (*Dashboard, error) {
+	values, err := template.Values(d.Parameters, parameters)
+	if err != nil {
+		return nil, err
+	}
+	spec, err := template.Render(d.Dashboard, values)
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(map[string]any{
+		"kind":     KindDashboard,
+		"metadata": NewProjectMetadata(project, name),
+		"spec":     spec,
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := &Dashboard{}
+	if unmarshalErr := json.Unmarshal(data, result); unmarshalErr != nil {
+		return nil, fmt.Errorf("the dashboard rendered is not valid: %w", unmarshalErr)
+	}
+	return result, nil
+}
+
+// DashboardTemplateInstance is a dashboard rendered from a template.
+type DashboardTemplateInstance struct {
+	Project string `json:"project" yaml:"project"`
+	Name    string `json:"name" yaml:"name"`
+	// Version is the version of the template the dashboard has been rendered from.
+	Version uint64 `json:"version" yaml:"version"`
+	// Parameters are the values provided when instantiating the template.
+	// Defaults are not recorded, so a new default is applied when the instances are rendered again.
+	Parameters map[string]string `json:"parameters,omitempty" yaml:"parameters,omitempty"`
+}
+
+// DashboardTemplateStatus is maintained by the server. Any status sent by a client is ignored.
+type DashboardTemplateStatus struct {
+	// Instances are the dashboards rendered from the template.
+	Instances []DashboardTemplateInstance `json:"instances,omitempty" yaml:"instances,omitempty"`
+}
+
+// GetInstance returns the instance matching the dashboard or nil if the dashboard doesn't come from the template.
+func (s *DashboardTemplateStatus) GetInstance(project string, name string) *DashboardTemplateInstance {
+	if s == nil {
+		return nil
+	}
+	for i := range s.Instances {
+		if s.Instances[i].Project == project && s.Instances[i].Name == name {
+			return &s.Instances[i]
+		}
+	}
+	return nil
+}
+
+// SetInstance adds the instance or replaces the one matching the same dashboard.
+func (s *DashboardTemplateStatus) SetInstance(instance DashboardTemplateInstance) {
+	if existing := s.GetInstance(instance.Project, instance.Name); existing != nil {
+		*existing = instance
+		return
+	}
+	s.Instances = append(s.Instances, instance)
+}
+
+// RemoveInstance removes the instance matching the dashboard, if any.
+func (s *DashboardTemplateStatus) RemoveInstance(project string, name string) {
+	s.Instances = slices.DeleteFunc(s.Instances, func(instance DashboardTemplateInstance) bool {
+		return instance.Project == project && instance.Name == name
+	})
+}
+
+// DashboardTemplateInterface gathers what is common to the DashboardTemplate and the GlobalDashboardTemplate.
+type DashboardTemplateInterface interface {
+	modelAPI.Entity
+	GetTemplateSpec() DashboardTemplateSpec
+	GetStatus() *DashboardTemplateStatus
+	SetStatus(status *DashboardTemplateStatus)
+}
+
+// GlobalDashboardTemplate is a dashboard template that can be instantiated in every project.
+type GlobalDashboardTemplate struct {
+	Kind     Kind                     `json:"kind" yaml:"kind"`
+	Metadata Metadata                 `json:"metadata" yaml:"metadata"`
+	Spec     DashboardTemplateSpec    `json:"spec" yaml:"spec"`
+	Status   *DashboardTemplateStatus `json:"status,omitempty" yaml:"status,omitempty"`
+}
+
+func (g *GlobalDashboardTemplate) UnmarshalJSON(data []byte) error {
+	var tmp GlobalDashboardTemplate
+	type plain GlobalDashboardTemplate
+	if err := json.Unmarshal(data, (*plain)(&tmp)); err != nil {
+		return err
+	}
+	if err := (&tmp).validate(); err != nil {
+		return err
+	}
+	*g = tmp
+	return nil
+}
+
+func (g *GlobalDashboardTemplate) UnmarshalYAML(unmarshal func(any) error) error {
+	var tmp GlobalDashboardTemplate
+	type plain GlobalDashboardTemplate
+	if err := unmarshal((*plain)(&tmp)); err != nil {
+		return err
+	}
+	if err := (&tmp).validate(); err != nil {
+		return err
+	}
+	*g = tmp
+	return nil
+}
+
+func (g *GlobalDashboardTemplate) validate() error {
+	if g.Kind != KindGlobalDashboardTemplate {
+		return fmt.Errorf("invalid kind: %q for a GlobalDashboardTemplate type", g.Kind)
+	}
+	return nil
+}
+
+func (g *GlobalDashboardTemplate) GetMetadata() modelAPI.Metadata {
+	return &g.Metadata
+}
+
+func (g *GlobalDashboardTemplate) GetKind() string {
+	return string(g.Kind)
+}
+
+func (g *GlobalDashboardTemplate) GetSpec() any {
+	return g.Spec
+}
+
+func (g *GlobalDashboardTemplate) GetTemplateSpec() DashboardTemplateSpec {
+	return g.Spec
+}
+
+func (g *GlobalDashboardTemplate) GetStatus() *DashboardTemplateStatus {
+	return g.Status
+}
+
+func (g *GlobalDashboardTemplate) SetStatus(status *DashboardTemplateStatus) {
+	g.Status = status
+}
+
+// DashboardTemplate is a dashboard template that can only be instantiated in its own project.
+type DashboardTemplate struct {
+	Kind     Kind                     `json:"kind" yaml:"kind"`
+	Metadata ProjectMetadata          `json:"metadata" yaml:"metadata"`
+	Spec     DashboardTemplateSpec    `json:"spec" yaml:"spec"`
+	Status   *DashboardTemplateStatus `json:"status,omitempty" yaml:"status,omitempty"`
+}
+
+func (d *DashboardTemplate) UnmarshalJSON(data []byte) error {
+	var tmp DashboardTemplate
+	type plain DashboardTemplate
+	if err := json.Unmarshal(data, (*plain)(&tmp)); err != nil {
+		return err
+	}
+	if err := (&tmp).validate(); err != nil {
+		return err
+	}
+	*d = tmp
+	return nil
+}
+
+func (d *DashboardTemplate) UnmarshalYAML(unmarshal func(any) error) error {
+	var tmp DashboardTemplate
+	type plain DashboardTemplate
+	if err := unmarshal((*plain)(&tmp)); err != nil {
+		return err
+	}
+	if err := (&tmp).validate(); err != nil {
+		return err
+	}
+	*d = tmp
+	return nil
+}
+
+func (d *DashboardTemplate) validate() error {
+	if d.Kind != KindDashboardTemplate {
+		return fmt.Errorf("invalid kind: %q for a DashboardTemplate type", d.Kind)
+	}
+	return nil
+}
+
+func (d *DashboardTemplate) GetMetadata() modelAPI.Metadata {
+	return &d.Metadata
+}
+
+func (d *DashboardTemplate) GetKind() string {
+	return string(d.Kind)
+}
+
+func (d *DashboardTemplate) GetSpec() any {
+	return d.Spec
+}
+
+func (d *DashboardTemplate) GetTemplateSpec() DashboardTemplateSpec {
+	return d.Spec
+}
+
+func (d *DashboardTemplate) GetStatus() *DashboardTemplateStatus {
+	return d.Status
+}
+
+func (d *DashboardTemplate) SetStatus(status *DashboardTemplateStatus) {
+	d.Status = status
+}
+
+// DashboardTemplateInstantiation is the request to render a dashboard from a template.
+type DashboardTemplateInstantiation struct {
+	// Project is the project of the dashboard.
+	// It is only required for a GlobalDashboardTemplate, as a DashboardTemplate is instantiated in its own project.
+	Project string `json:"project,omitempty" yaml:"project,omitempty"`
+	// Name is the name of the dashboard.
+	Name       string            `json:"name" yaml:"name"`
+	Parameters map[string]string `json:"parameters,omitempty" yaml:"parameters,omitempty"`
+}
+
+type DashboardTemplateRenderStatus string
+
+const (
+	// DashboardTemplateRenderUpdated means the dashboard has been rendered with the latest version of the template.
+	DashboardTemplateRenderUpdated DashboardTemplateRenderStatus = "updated"
+	// DashboardTemplateRenderUpToDate means the dashboard was already rendered from the latest version of the template.
+	DashboardTemplateRenderUpToDate DashboardTemplateRenderStatus = "up-to-date"
+	// DashboardTemplateRenderRemoved means the dashboard no longer exists, so it is no longer tracked as an instance.
+	DashboardTemplateRenderRemoved DashboardTemplateRenderStatus = "removed"
+	// DashboardTemplateRenderFailed means the dashboard couldn't be read, rendered or saved. The previous version is kept.
+	DashboardTemplateRenderFailed DashboardTemplateRenderStatus = "failed"
+)
+
+// DashboardTemplateRenderResult is the outcome of rendering again one instance of a template.
+type DashboardTemplateRenderResult struct {
+	Project string                        `json:"project" yaml:"project"`
+	Name    string                        `json:"name" yaml:"name"`
+	Status  DashboardTemplateRenderStatus `json:"status" yaml:"status"`
+	Error   string                        `json:"error,omitempty" yaml:"error,omitempty"`
+}
@@ -0,0 +1,145 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const dashboardTemplateJSON = `
+{
+  "kind": "DashboardTemplate",
+  "metadata": {
+    "name": "service-overview",
+    "project": "perses"
+  },
+  "spec": {
+    "parameters": [
+      {
+        "name": "service"
+      },
+      {
+        "name": "env",
+        "default": "production"
+      }
+    ],
+    "dashboard": {
+      "display": {
+        "name": "[[ service ]] overview"
+      },
+      "duration": "1h",
+      "panels": {},
+      "layouts": []
+    }
+  }
+}
+`
+
+func TestUnmarshalDashboardTemplate(t *testing.T) {
+	result := DashboardTemplate{}
+	assert.NoError(t, json.Unmarshal([]byte(dashboardTemplateJSON), &result))
+	assert.Len(t, result.Spec.Parameters, 2)
+	assert.Nil(t, result.Status)
+}
+
+func TestUnmarshalDashboardTemplateError(t *testing.T) {
+	testSuites := []struct {
+		title string
+		jason string
+	}{
+		{
+			title: "empty dashboard",
+			jason: `
+{
+  "kind": "DashboardTemplate",
+  "metadata": {
+    "name": "service-overview",
+    "project": "perses"
+  },
+  "spec": {
+    "dashboard": {}
+  }
+}
+`,
+		},
+		{
+			title: "undeclared parameter",
+			jason: `
+{
+  "kind": "DashboardTemplate",
+  "metadata": {
+    "name": "service-overview",
+    "project": "perses"
+  },
+  "spec": {
+    "dashboard": {
+      "display": {
+        "name": "[[ service ]] overview"
+      },
+      "duration": "1h"
+    }
+  }
+}
+`,
+		},
+		{
+			title: "duplicated parameter",
+			jason: `
+{
+  "kind": "GlobalDashboardTemplate",
+  "metadata": {
+    "name": "service-overview"
+  },
+  "spec": {
+    "parameters": [
+      {
+        "name": "service"
+      },
+      {
+        "name": "service"
+      }
+    ],
+    "dashboard": {
+      "duration": "1h"
+    }
+  }
+}
+`,
+		},
+	}
+	for _, test := range testSuites {
+		t.Run(test.title, func(t *testing.T) {
+			result := DashboardTemplate{}
+			assert.Error(t, json.Unmarshal([]byte(test.jason), &result))
+		})
+	}
+}
+
+func TestRenderDashboardTemplate(t *testing.T) {
+	tmpl := DashboardTemplate{}
+	assert.NoError(t, json.Unmarshal([]byte(dashboardTemplateJSON), &tmpl))
+
+	dashboard, err := tmpl.Spec.Render("perses", "checkout", map[string]string{"service": "checkout"})
+	assert.NoError(t, err)
+	assert.Equal(t, KindDashboard, dashboard.Kind)
+	assert.Equal(t, "perses", dashboard.Metadata.Project)
+	assert.Equal(t, "checkout", dashboard.Metadata.Name)
+	assert.Equal(t, "checkout overview", dashboard.Spec.Display.Name)
+
+	_, err = tmpl.Spec.Render("perses", "checkout", nil)
+	assert.Error(t, err)
+}
@@ -151,3 +151,25 @@ func (f *Folder) validate() error {
 	}
 	return nil
 }
+
+// DashboardPaths returns the paths of the folders containing the given dashboard, from the root folder to the deepest one.
+// The path of a folder is the name of the Folder resource, followed by the names of the sub folders separated by `/`.
+// It returns nil if the dashboard is not referenced in the folder.
+func (f *Folder) DashboardPaths(dashboard string) []string {
+	return dashboardPaths(f.Metadata.Name, f.Spec.Items, dashboard)
+}
+
+func dashboardPaths(currentPath string, items []FolderItem, dashboard string) []string {
+	for _, item := range items {
+		if item.Kind == KindDashboard {
+			if item.Name == dashboard {
+				return []string{currentPath}
+			}
+			continue
+		}
+		if subPaths := dashboardPaths(currentPath+"/"+item.Name, item.Items, dashboard); subPaths != nil {
+			return append([]string{currentPath}, subPaths...)
+		}
+	}
+	return nil
+}
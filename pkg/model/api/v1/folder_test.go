@@ -306,3 +306,21 @@ func TestUnmarshalFolderSuccess(t *testing.T) {
 		})
 	}
 }
+
+func TestFolderDashboardPaths(t *testing.T) {
+	f := Folder{
+		Kind:     KindFolder,
+		Metadata: ProjectMetadata{Metadata: Metadata{Name: "payments"}},
+		Spec: FolderSpec{
+			Items: []FolderItem{
+				{Kind: KindDashboard, Name: "overview"},
+				{Kind: KindFolder, Name: "api", Items: []FolderItem{
+					{Kind: KindFolder, Name: "v2", Items: []FolderItem{{Kind: KindDashboard, Name: "latency"}}},
+				}},
+			},
+		},
+	}
+	assert.Equal(t, []string{"payments"}, f.DashboardPaths("overview"))
+	assert.Equal(t, []string{"payments", "payments/api", "payments/api/v2"}, f.DashboardPaths("latency"))
+	assert.Nil(t, f.DashboardPaths("unknown"))
+}
@@ -24,39 +24,45 @@ import (
 type Kind string
 
 const (
-	KindDashboard          Kind = "Dashboard"
-	KindDatasource         Kind = "Datasource"
-	KindEphemeralDashboard Kind = "EphemeralDashboard"
-	KindFolder             Kind = "Folder"
-	KindGlobalDatasource   Kind = "GlobalDatasource"
-	KindGlobalRole         Kind = "GlobalRole"
-	KindGlobalRoleBinding  Kind = "GlobalRoleBinding"
-	KindGlobalVariable     Kind = "GlobalVariable"
-	KindGlobalSecret       Kind = "GlobalSecret"
-	KindProject            Kind = "Project"
-	KindRole               Kind = "Role"
-	KindRoleBinding        Kind = "RoleBinding"
-	KindSecret             Kind = "Secret"
-	KindUser               Kind = "User"
-	KindVariable           Kind = "Variable"
+	KindDashboard               Kind = "Dashboard"
+	KindDashboardTemplate       Kind = "DashboardTemplate"
+	KindDatasource              Kind = "Datasource"
+	KindEphemeralDashboard      Kind = "EphemeralDashboard"
+	KindFolder                  Kind = "Folder"
+	KindGlobalDashboardTemplate Kind = "GlobalDashboardTemplate"
+	KindGlobalDatasource        Kind = "GlobalDatasource"
+	KindGlobalPolicy            Kind = "GlobalPolicy"
+	KindGlobalRole              Kind = "GlobalRole"
+	KindGlobalRoleBinding       Kind = "GlobalRoleBinding"
+	KindGlobalVariable          Kind = "GlobalVariable"
+	KindGlobalSecret            Kind = "GlobalSecret"
+	KindProject                 Kind = "Project"
+	KindRole                    Kind = "Role"
+	KindRoleBinding             Kind = "RoleBinding"
+	KindSecret                  Kind = "Secret"
+	KindUser                    Kind = "User"
+	KindVariable                Kind = "Variable"
 )
 
 var PluralKindMap = map[Kind]string{
-	KindDashboard:          "dashboards",
-	KindDatasource:         "datasources",
-	KindEphemeralDashboard: "ephemeraldashboards",
-	KindFolder:             "folders",
-	KindGlobalDatasource:   "globaldatasources",
-	KindGlobalRole:         "globalroles",
-	KindGlobalRoleBinding:  "globalrolebindings",
-	KindGlobalSecret:       "globalsecrets",
-	KindGlobalVariable:     "globalvariables",
-	KindProject:            "projects",
-	KindRole:               "roles",
-	KindRoleBinding:        "rolebindings",
-	KindSecret:             "secrets",
-	KindUser:               "users",
-	KindVariable:           "variables",
+	KindDashboard:               "dashboards",
+	KindDashboardTemplate:       "dashboardtemplates",
+	KindDatasource:              "datasources",
+	KindEphemeralDashboard:      "ephemeraldashboards",
+	KindFolder:                  "folders",
+	KindGlobalDashboardTemplate: "globaldashboardtemplates",
+	KindGlobalDatasource:        "globaldatasources",
+	KindGlobalPolicy:            "globalpolicies",
+	KindGlobalRole:              "globalroles",
+	KindGlobalRoleBinding:       "globalrolebindings",
+	KindGlobalSecret:            "globalsecrets",
+	KindGlobalVariable:          "globalvariables",
+	KindProject:                 "projects",
+	KindRole:                    "roles",
+	KindRoleBinding:             "rolebindings",
+	KindSecret:                  "secrets",
+	KindUser:                    "users",
+	KindVariable:                "variables",
 }
 
 func (k *Kind) UnmarshalJSON(data []byte) error {
@@ -102,14 +108,20 @@ func GetStruct(kind Kind) (modelAPI.Entity, error) {
 	switch kind {
 	case KindDashboard:
 		return &Dashboard{}, nil
+	case KindDashboardTemplate:
+		return &DashboardTemplate{}, nil
 	case KindDatasource:
 		return &Datasource{}, nil
 	case KindEphemeralDashboard:
 		return &EphemeralDashboard{}, nil
 	case KindFolder:
 		return &Folder{}, nil
+	case KindGlobalDashboardTemplate:
+		return &GlobalDashboardTemplate{}, nil
 	case KindGlobalDatasource:
 		return &GlobalDatasource{}, nil
+	case KindGlobalPolicy:
+		return &GlobalPolicy{}, nil
 	case KindGlobalRole:
 		return &GlobalRole{}, nil
 	case KindGlobalRoleBinding:
@@ -137,7 +149,7 @@ func GetStruct(kind Kind) (modelAPI.Entity, error) {
 
 func IsGlobal(kind Kind) bool {
 	switch kind {
-	case KindGlobalDatasource, KindGlobalRole, KindGlobalRoleBinding, KindGlobalSecret, KindGlobalVariable, KindProject, KindUser:
+	case KindGlobalDashboardTemplate, KindGlobalDatasource, KindGlobalPolicy, KindGlobalRole, KindGlobalRoleBinding, KindGlobalSecret, KindGlobalVariable, KindProject, KindUser:
 		return true
 	default:
 		return false
@@ -150,6 +162,9 @@ func GetKind(kind string) (*Kind, error) {
 	case strings.ToLower(string(KindDashboard)):
 		result := KindDashboard
 		return &result, nil
+	case strings.ToLower(string(KindDashboardTemplate)):
+		result := KindDashboardTemplate
+		return &result, nil
 	case strings.ToLower(string(KindDatasource)):
 		result := KindDatasource
 		return &result, nil
@@ -159,9 +174,15 @@ func GetKind(kind string) (*Kind, error) {
 	case strings.ToLower(string(KindFolder)):
 		result := KindFolder
 		return &result, nil
+	case strings.ToLower(string(KindGlobalDashboardTemplate)):
+		result := KindGlobalDashboardTemplate
+		return &result, nil
 	case strings.ToLower(string(KindGlobalDatasource)):
 		result := KindGlobalDatasource
 		return &result, nil
+	case strings.ToLower(string(KindGlobalPolicy)):
+		result := KindGlobalPolicy
+		return &result, nil
 	case strings.ToLower(string(KindGlobalRole)):
 		result := KindGlobalRole
 		return &result, nil
@@ -23,9 +23,36 @@ import (
 
 const PluginModuleKind = "PluginModule"
 
+// ModuleDependency describes another plugin module required by a plugin module.
+type ModuleDependency struct {
+	// Name is the name of the module required.
+	Name string `json:"name" yaml:"name"`
+	// Version is the semver range of the accepted versions (e.g. ">=0.5.0 <1.0.0"). Empty means any version.
+	Version string `json:"version,omitempty" yaml:"version,omitempty"`
+	// Registry is the registry of the module required. Empty means the default registry.
+	Registry string `json:"registry,omitempty" yaml:"registry,omitempty"`
+}
+
+// ModuleRequirements is what a plugin module declares to be compatible with the Perses server and with the other modules.
+// It is read from the `perses` section of the package.json of the module.
+type ModuleRequirements struct {
+	// PersesVersion is the semver range of the Perses versions supported by the module (e.g. ">=0.52.0 <0.60.0"). Empty means any version.
+	PersesVersion string `json:"persesVersion,omitempty" yaml:"persesVersion,omitempty"`
+	// Dependencies is the list of the other modules required by the module.
+	Dependencies []ModuleDependency `json:"dependencies,omitempty" yaml:"dependencies,omitempty"`
+}
+
+// ModuleBackend describes the server-side part of a plugin module.
+type ModuleBackend struct {
+	// Wasm is the path, relative to the root of the module, of the WASM module used by the server to transform the response of the datasources.
+	Wasm string `json:"wasm" yaml:"wasm"`
+}
+
 type ModuleSpec struct {
-	SchemasPath string          `json:"schemasPath" yaml:"schemasPath"`
-	Plugins     []module.Plugin `json:"plugins" yaml:"plugins"`
+	SchemasPath        string          `json:"schemasPath" yaml:"schemasPath"`
+	Plugins            []module.Plugin `json:"plugins" yaml:"plugins"`
+	ModuleRequirements `json:",inline" yaml:",inline"`
+	Backend            *ModuleBackend `json:"backend,omitempty" yaml:"backend,omitempty"`
 }
 
 func NewModuleSpec(module module.Module) *ModuleSpec {
@@ -35,11 +62,22 @@ func NewModuleSpec(module module.Module) *ModuleSpec {
 	}
 }
 
+// PluginConflict describes a plugin that is provided by more than one module.
+type PluginConflict struct {
+	Kind string `json:"kind" yaml:"kind"`
+	Name string `json:"name" yaml:"name"`
+	// Modules is the list of the other modules providing the same plugin.
+	Modules []module.Metadata `json:"modules" yaml:"modules"`
+}
+
 type PluginModule struct {
 	Kind     string          `json:"kind" yaml:"kind"`
 	Metadata module.Metadata `json:"metadata" yaml:"metadata"`
 	Spec     ModuleSpec      `json:"spec" yaml:"spec"`
 	Status   *module.Status  `json:"status,omitempty" yaml:"status,omitempty"`
+	// Conflicts is computed when the modules are loaded.
+	// It lists the plugins of this module that are also registered by another loaded module.
+	Conflicts []PluginConflict `json:"conflicts,omitempty" yaml:"conflicts,omitempty"`
 }
 
 type PluginInDevelopment struct {
@@ -0,0 +1,123 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+
+	modelAPI "github.com/perses/perses/pkg/model/api"
+	"github.com/perses/perses/pkg/model/api/v1/policy"
+)
+
+type PolicySpec struct {
+	// Description explains the purpose of the policy.
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	// Effect is either `allow` or `deny`. Default: allow
+	Effect policy.Effect `json:"effect" yaml:"effect"`
+	// Expression is the CEL expression deciding whether the policy applies to a request.
+	// The variables available are described in the package policy.
+	Expression string `json:"expression" yaml:"expression"`
+}
+
+func (p *PolicySpec) UnmarshalJSON(data []byte) error {
+	var tmp PolicySpec
+	type plain PolicySpec
+	if err := json.Unmarshal(data, (*plain)(&tmp)); err != nil {
+		return err
+	}
+	if err := (&tmp).validate(); err != nil {
+		return err
+	}
+	*p = tmp
+	return nil
+}
+
+func (p *PolicySpec) UnmarshalYAML(unmarshal func(any) error) error {
+	var tmp PolicySpec
+	type plain PolicySpec
+	if err := unmarshal((*plain)(&tmp)); err != nil {
+		return err
+	}
+	if err := (&tmp).validate(); err != nil {
+		return err
+	}
+	*p = tmp
+	return nil
+}
+
+func (p *PolicySpec) validate() error {
+	if len(p.Effect) == 0 {
+		p.Effect = policy.AllowEffect
+	}
+	if _, err := policy.Compile(p.Expression); err != nil {
+		return err
+	}
+	return nil
+}
+
+// GlobalPolicy is an authorization policy written in CEL. It is only used when the CEL authorization provider is enabled.
+type GlobalPolicy struct {
+	Kind     Kind       `json:"kind" yaml:"kind"`
+	Metadata Metadata   `json:"metadata" yaml:"metadata"`
+	Spec     PolicySpec `json:"spec" yaml:"spec"`
+}
+
+func (g *GlobalPolicy) UnmarshalJSON(data []byte) error {
+	var tmp GlobalPolicy
+	type plain GlobalPolicy
+	if err := json.Unmarshal(data, (*plain)(&tmp)); err != nil {
+		return err
+	}
+	if err := (&tmp).validate(); err != nil {
+		return err
+	}
+	*g = tmp
+	return nil
+}
+
+func (g *GlobalPolicy) UnmarshalYAML(unmarshal func(any) error) error {
+	var tmp GlobalPolicy
+	type plain GlobalPolicy
+	if err := unmarshal((*plain)(&tmp)); err != nil {
+		return err
+	}
+	if err := (&tmp).validate(); err != nil {
+		return err
+	}
+	*g = tmp
+	return nil
+}
+
+func (g *GlobalPolicy) validate() error {
+	if g.Kind != KindGlobalPolicy {
+		return fmt.Errorf("invalid kind: %q for a GlobalPolicy type", g.Kind)
+	}
+	if len(g.Spec.Expression) == 0 {
+		return fmt.Errorf("spec.expression cannot be empty")
+	}
+	return nil
+}
+
+func (g *GlobalPolicy) GetMetadata() modelAPI.Metadata {
+	return &g.Metadata
+}
+
+func (g *GlobalPolicy) GetKind() string {
+	return string(g.Kind)
+}
+
+func (g *GlobalPolicy) GetSpec() any {
+	return g.Spec
+}
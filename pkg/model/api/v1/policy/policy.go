@@ -0,0 +1,278 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package policy contains the authorization policies written in CEL, and the way they are evaluated.
+//
+// A policy is a CEL expression returning a boolean. It is evaluated with the following variables:
+//   - user: the name of the user,
+//   - groups: the list of the groups the user belongs to,
+//   - action: the action requested (read, create, update, delete, query),
+//   - scope: the kind of the resource (Dashboard, Datasource, GlobalSecret, etc.),
+//   - project: the project of the resource. It is empty for a global resource and equal to `*` when every project is concerned,
+//   - resource: the metadata of the resource, i.e. `resource.name` and `resource.tags`.
+//
+// A request is allowed when at least one `allow` policy matches it and no `deny` policy matches it.
+package policy
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+	"github.com/perses/perses/pkg/model/api/v1/role"
+)
+
+const (
+	userVariable     = "user"
+	groupsVariable   = "groups"
+	actionVariable   = "action"
+	scopeVariable    = "scope"
+	projectVariable  = "project"
+	resourceVariable = "resource"
+)
+
+type Effect string
+
+const (
+	AllowEffect Effect = "allow"
+	DenyEffect  Effect = "deny"
+)
+
+func (e *Effect) UnmarshalJSON(data []byte) error {
+	var tmp Effect
+	type plain Effect
+	if err := json.Unmarshal(data, (*plain)(&tmp)); err != nil {
+		return err
+	}
+	if err := (&tmp).validate(); err != nil {
+		return err
+	}
+	*e = tmp
+	return nil
+}
+
+func (e *Effect) UnmarshalYAML(unmarshal func(any) error) error {
+	var tmp Effect
+	type plain Effect
+	if err := unmarshal((*plain)(&tmp)); err != nil {
+		return err
+	}
+	if err := (&tmp).validate(); err != nil {
+		return err
+	}
+	*e = tmp
+	return nil
+}
+
+func (e *Effect) validate() error {
+	if len(*e) == 0 {
+		*e = AllowEffect
+	}
+	if *e != AllowEffect && *e != DenyEffect {
+		return fmt.Errorf("invalid effect %q, it must be %q or %q", *e, AllowEffect, DenyEffect)
+	}
+	return nil
+}
+
+// Resource is the metadata of the resource exposed to the policies.
+type Resource struct {
+	Name string   `json:"name,omitempty" yaml:"name,omitempty"`
+	Tags []string `json:"tags,omitempty" yaml:"tags,omitempty"`
+}
+
+// Input is the request on which the policies are evaluated.
+type Input struct {
+	User    string      `json:"user" yaml:"user"`
+	Groups  []string    `json:"groups,omitempty" yaml:"groups,omitempty"`
+	Action  role.Action `json:"action" yaml:"action"`
+	Scope   role.Scope  `json:"scope" yaml:"scope"`
+	Project string      `json:"project,omitempty" yaml:"project,omitempty"`
+	// Resource is nil when the request concerns every resource of the scope.
+	Resource *Resource `json:"resource,omitempty" yaml:"resource,omitempty"`
+}
+
+func (i Input) activation() map[string]any {
+	resource := map[string]any{"name": "", "tags": []string{}}
+	if i.Resource != nil {
+		resource["name"] = i.Resource.Name
+		if i.Resource.Tags != nil {
+			resource["tags"] = i.Resource.Tags
+		}
+	}
+	groups := i.Groups
+	if groups == nil {
+		groups = []string{}
+	}
+	return map[string]any{
+		userVariable:     i.User,
+		groupsVariable:   groups,
+		actionVariable:   string(i.Action),
+		scopeVariable:    string(i.Scope),
+		projectVariable:  i.Project,
+		resourceVariable: resource,
+	}
+}
+
+// Program is a compiled policy expression.
+type Program struct {
+	program cel.Program
+	// usesResource is true when the expression refers to the resource variable.
+	usesResource bool
+}
+
+// Compile checks the expression and returns the program to evaluate it.
+func Compile(expression string) (*Program, error) {
+	if len(expression) == 0 {
+		return nil, errors.New("expression cannot be empty")
+	}
+	env, err := cel.NewEnv(
+		cel.Variable(userVariable, cel.StringType),
+		cel.Variable(groupsVariable, cel.ListType(cel.StringType)),
+		cel.Variable(actionVariable, cel.StringType),
+		cel.Variable(scopeVariable, cel.StringType),
+		cel.Variable(projectVariable, cel.StringType),
+		cel.Variable(resourceVariable, cel.MapType(cel.StringType, cel.DynType)),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error while creating the CEL environment: %w", err)
+	}
+	ast, issues := env.Compile(expression)
+	if issues != nil && issues.Err() != nil {
+		return nil, fmt.Errorf("error while compiling the CEL expression: %w", issues.Err())
+	}
+	if ast.OutputType() != cel.BoolType {
+		return nil, fmt.Errorf("the CEL expression must return a boolean, not a %s", ast.OutputType())
+	}
+	// The partial evaluation is used to evaluate the policies when the resource is unknown.
+	prg, err := env.Program(ast, cel.EvalOptions(cel.OptPartialEval))
+	if err != nil {
+		return nil, fmt.Errorf("error while creating the CEL program: %w", err)
+	}
+	usesResource := false
+	for _, reference := range ast.NativeRep().ReferenceMap() {
+		if reference.Name == resourceVariable {
+			usesResource = true
+			break
+		}
+	}
+	return &Program{program: prg, usesResource: usesResource}, nil
+}
+
+// UsesResource returns true when the result of the program depends on the resource.
+func (p *Program) UsesResource() bool {
+	return p.usesResource
+}
+
+// Eval returns true if the input matches the expression.
+func (p *Program) Eval(input Input) (bool, error) {
+	out, _, err := p.program.Eval(input.activation())
+	if err != nil {
+		return false, err
+	}
+	return asBool(out)
+}
+
+// EvalWithoutResource evaluates the expression with the resource as an unknown.
+// known is false when the result depends on the resource, i.e. when the expression matches some resources but not others.
+func (p *Program) EvalWithoutResource(input Input) (matched bool, known bool, err error) {
+	vars, err := cel.PartialVars(input.activation(), cel.AttributePattern(resourceVariable))
+	if err != nil {
+		return false, false, err
+	}
+	out, _, err := p.program.Eval(vars)
+	if err != nil {
+		return false, false, err
+	}
+	if types.IsUnknown(out) {
+		return false, false, nil
+	}
+	matched, err = asBool(out)
+	return matched, err == nil, err
+}
+
+func asBool(out ref.Val) (bool, error) {
+	if out.Type() != cel.BoolType {
+		return false, fmt.Errorf("the CEL expression returned a %s instead of a boolean", out.Type())
+	}
+	return out.Value().(bool), nil
+}
+
+// Policy is a named policy ready to be evaluated.
+type Policy struct {
+	Name    string
+	Effect  Effect
+	Program *Program
+}
+
+// MatchedPolicy is a policy that matched the request.
+type MatchedPolicy struct {
+	Name   string `json:"name" yaml:"name"`
+	Effect Effect `json:"effect" yaml:"effect"`
+}
+
+// Decision is the result of the evaluation of the policies.
+type Decision struct {
+	Allowed bool `json:"allowed" yaml:"allowed"`
+	// Policies is the list of the policies matching the request.
+	Policies []MatchedPolicy `json:"policies,omitempty" yaml:"policies,omitempty"`
+	// Errors contains the errors that occurred while evaluating the policies.
+	// An allow policy that fails is ignored, while a deny policy that fails is considered as matching.
+	Errors []string `json:"errors,omitempty" yaml:"errors,omitempty"`
+}
+
+// Evaluate returns the decision of the policies for the given input.
+// When the input has no resource, the decision must hold for every resource of the scope. The policies are then
+// evaluated with the resource as an unknown: when the result depends on the resource, an allow policy is considered as
+// not matching and a deny policy as matching.
+func Evaluate(policies []Policy, input Input) Decision {
+	decision := Decision{}
+	allowed := false
+	denied := false
+	for _, p := range policies {
+		var matched bool
+		var err error
+		if input.Resource == nil && p.Program.UsesResource() {
+			var known bool
+			matched, known, err = p.Program.EvalWithoutResource(input)
+			if err == nil && !known {
+				matched = p.Effect == DenyEffect
+			}
+		} else {
+			matched, err = p.Program.Eval(input)
+		}
+		if err != nil {
+			decision.Errors = append(decision.Errors, fmt.Sprintf("policy %q: %s", p.Name, err))
+			matched = p.Effect == DenyEffect
+		}
+		if !matched {
+			continue
+		}
+		decision.Policies = append(decision.Policies, MatchedPolicy{Name: p.Name, Effect: p.Effect})
+		if p.Effect == DenyEffect {
+			denied = true
+		} else {
+			allowed = true
+		}
+	}
+	decision.Allowed = allowed && !denied
+	return decision
+}
+
+// DependsOnResource returns true if at least one of the policies depends on the resource.
+func DependsOnResource(policies []Policy) bool {
+	return slices.ContainsFunc(policies, func(p Policy) bool { return p.Program.UsesResource() })
+}
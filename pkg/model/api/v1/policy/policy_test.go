@@ -0,0 +1,207 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"testing"
+
+	"github.com/perses/perses/pkg/model/api/v1/role"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mustCompile(t *testing.T, name string, effect Effect, expression string) Policy {
+	program, err := Compile(expression)
+	require.NoError(t, err)
+	return Policy{Name: name, Effect: effect, Program: program}
+}
+
+func TestCompile(t *testing.T) {
+	testSuites := []struct {
+		title        string
+		expression   string
+		usesResource bool
+		wantErr      bool
+	}{
+		{
+			title:      "valid expression",
+			expression: `"admin" in groups`,
+		},
+		{
+			title:        "expression using the resource",
+			expression:   `scope == "Dashboard" && "on-call" in resource.tags`,
+			usesResource: true,
+		},
+		{
+			title:      "empty expression",
+			expression: "",
+			wantErr:    true,
+		},
+		{
+			title:      "syntax error",
+			expression: `user ==`,
+			wantErr:    true,
+		},
+		{
+			title:      "unknown variable",
+			expression: `team == "a"`,
+			wantErr:    true,
+		},
+		{
+			title:      "not a boolean",
+			expression: `user`,
+			wantErr:    true,
+		},
+	}
+	for _, test := range testSuites {
+		t.Run(test.title, func(t *testing.T) {
+			program, err := Compile(test.expression)
+			if test.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.usesResource, program.UsesResource())
+		})
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	policies := []Policy{
+		mustCompile(t, "admins", AllowEffect, `"admin" in groups`),
+		mustCompile(t, "on-call", AllowEffect, `"on-call" in groups && scope == "Dashboard" && action == "update" && "on-call" in resource.tags`),
+		mustCompile(t, "readers", AllowEffect, `action == "read" && project == "demo"`),
+		mustCompile(t, "no-secret", DenyEffect, `scope == "GlobalSecret" && user != "root"`),
+	}
+	testSuites := []struct {
+		title    string
+		input    Input
+		allowed  bool
+		policies []string
+	}{
+		{
+			title:    "admin group",
+			input:    Input{User: "alice", Groups: []string{"admin"}, Action: role.DeleteAction, Scope: role.DashboardScope, Project: "demo"},
+			allowed:  true,
+			policies: []string{"admins"},
+		},
+		{
+			title:    "denied even for an admin",
+			input:    Input{User: "alice", Groups: []string{"admin"}, Action: role.ReadAction, Scope: role.GlobalSecretScope},
+			allowed:  false,
+			policies: []string{"admins", "no-secret"},
+		},
+		{
+			title:    "on-call dashboard",
+			input:    Input{User: "bob", Groups: []string{"on-call"}, Action: role.UpdateAction, Scope: role.DashboardScope, Project: "demo", Resource: &Resource{Name: "incidents", Tags: []string{"on-call"}}},
+			allowed:  true,
+			policies: []string{"on-call"},
+		},
+		{
+			title:   "dashboard not tagged on-call",
+			input:   Input{User: "bob", Groups: []string{"on-call"}, Action: role.UpdateAction, Scope: role.DashboardScope, Project: "demo", Resource: &Resource{Name: "sales"}},
+			allowed: false,
+		},
+		{
+			title:   "every resource of the scope",
+			input:   Input{User: "bob", Groups: []string{"on-call"}, Action: role.UpdateAction, Scope: role.DashboardScope, Project: "demo"},
+			allowed: false,
+		},
+		{
+			title:    "project reader",
+			input:    Input{User: "carol", Action: role.ReadAction, Scope: role.DatasourceScope, Project: "demo"},
+			allowed:  true,
+			policies: []string{"readers"},
+		},
+	}
+	for _, test := range testSuites {
+		t.Run(test.title, func(t *testing.T) {
+			decision := Evaluate(policies, test.input)
+			assert.Equal(t, test.allowed, decision.Allowed)
+			var names []string
+			for _, p := range decision.Policies {
+				names = append(names, p.Name)
+			}
+			assert.Equal(t, test.policies, names)
+			assert.Empty(t, decision.Errors)
+		})
+	}
+}
+
+func TestEvaluateDenyDependingOnResource(t *testing.T) {
+	policies := []Policy{
+		mustCompile(t, "everyone", AllowEffect, `true`),
+		mustCompile(t, "no-private", DenyEffect, `"private" in resource.tags`),
+	}
+	input := Input{User: "alice", Action: role.ReadAction, Scope: role.DashboardScope, Project: "demo"}
+	// Without resource, the deny policy could match some resources of the scope.
+	decision := Evaluate(policies, input)
+	assert.False(t, decision.Allowed)
+	assert.Equal(t, []MatchedPolicy{{Name: "everyone", Effect: AllowEffect}, {Name: "no-private", Effect: DenyEffect}}, decision.Policies)
+
+	input.Resource = &Resource{Name: "public"}
+	assert.True(t, Evaluate(policies, input).Allowed)
+	input.Resource = &Resource{Name: "hidden", Tags: []string{"private"}}
+	assert.False(t, Evaluate(policies, input).Allowed)
+}
+
+func TestEvaluateWithoutResource(t *testing.T) {
+	policies := []Policy{
+		mustCompile(t, "everyone", AllowEffect, `true`),
+		// It depends on the resource, but only for the secrets.
+		mustCompile(t, "no-private-secret", DenyEffect, `scope == "Secret" && "private" in resource.tags`),
+		mustCompile(t, "own-dashboards", AllowEffect, `scope == "Dashboard" && resource.name.startsWith(user)`),
+	}
+	testSuites := []struct {
+		title    string
+		input    Input
+		allowed  bool
+		policies []string
+	}{
+		{
+			title:    "deny policy ruled out by the scope",
+			input:    Input{User: "alice", Action: role.ReadAction, Scope: role.DashboardScope, Project: "demo"},
+			allowed:  true,
+			policies: []string{"everyone"},
+		},
+		{
+			title:    "deny policy depending on the resource",
+			input:    Input{User: "alice", Action: role.ReadAction, Scope: role.SecretScope, Project: "demo"},
+			allowed:  false,
+			policies: []string{"everyone", "no-private-secret"},
+		},
+	}
+	for _, test := range testSuites {
+		t.Run(test.title, func(t *testing.T) {
+			decision := Evaluate(policies, test.input)
+			assert.Equal(t, test.allowed, decision.Allowed)
+			var names []string
+			for _, p := range decision.Policies {
+				names = append(names, p.Name)
+			}
+			assert.Equal(t, test.policies, names)
+			assert.Empty(t, decision.Errors)
+		})
+	}
+
+	// An allow policy depending on the resource doesn't hold for every resource of the scope.
+	matched, known, err := policies[2].Program.EvalWithoutResource(Input{User: "alice", Action: role.ReadAction, Scope: role.DashboardScope})
+	require.NoError(t, err)
+	assert.False(t, known)
+	assert.False(t, matched)
+	matched, known, err = policies[2].Program.EvalWithoutResource(Input{User: "alice", Action: role.ReadAction, Scope: role.SecretScope})
+	require.NoError(t, err)
+	assert.True(t, known)
+	assert.False(t, matched)
+}
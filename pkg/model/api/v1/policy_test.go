@@ -0,0 +1,82 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/perses/perses/pkg/model/api/v1/policy"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnmarshalGlobalPolicy(t *testing.T) {
+	jason := `
+{
+  "kind": "GlobalPolicy",
+  "metadata": {
+    "name": "on-call"
+  },
+  "spec": {
+    "expression": "'on-call' in groups && scope == 'Dashboard' && 'on-call' in resource.tags"
+  }
+}
+`
+	result := GlobalPolicy{}
+	assert.NoError(t, json.Unmarshal([]byte(jason), &result))
+	assert.Equal(t, policy.AllowEffect, result.Spec.Effect)
+}
+
+func TestUnmarshalGlobalPolicyError(t *testing.T) {
+	testSuites := []struct {
+		title string
+		jason string
+	}{
+		{
+			title: "invalid effect",
+			jason: `
+{
+  "kind": "GlobalPolicy",
+  "metadata": {
+    "name": "on-call"
+  },
+  "spec": {
+    "effect": "maybe",
+    "expression": "true"
+  }
+}
+`,
+		},
+		{
+			title: "invalid expression",
+			jason: `
+{
+  "kind": "GlobalPolicy",
+  "metadata": {
+    "name": "on-call"
+  },
+  "spec": {
+    "expression": "team == 'a'"
+  }
+}
+`,
+		},
+	}
+	for _, test := range testSuites {
+		t.Run(test.title, func(t *testing.T) {
+			result := GlobalPolicy{}
+			assert.Error(t, json.Unmarshal([]byte(test.jason), &result))
+		})
+	}
+}
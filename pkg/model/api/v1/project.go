@@ -21,8 +21,25 @@ import (
 	"github.com/perses/spec/go/common"
 )
 
+// ProjectPluginPolicy restricts the plugins (panels, datasources, queries, variables, annotations) that can be used in a project.
+// A name can be the name of a plugin (e.g. `TimeSeriesChart`) or the name of the module providing it (e.g. `Prometheus`).
+// It works like the `enabled` / `disabled` attributes of the plugin configuration, but only for the resources of the project.
+// Use either Enabled or Disabled. Both can not be used at the same time.
+type ProjectPluginPolicy struct {
+	// Enabled is the list of plugins or modules allowed in the project. If not empty, any other plugin is refused.
+	Enabled []string `json:"enabled,omitempty" yaml:"enabled,omitempty"`
+	// Disabled is the list of plugins or modules refused in the project.
+	Disabled []string `json:"disabled,omitempty" yaml:"disabled,omitempty"`
+}
+
+func (p *ProjectPluginPolicy) IsEmpty() bool {
+	return p == nil || (len(p.Enabled) == 0 && len(p.Disabled) == 0)
+}
+
 type ProjectSpec struct {
 	Display *common.Display `json:"display,omitempty" yaml:"display,omitempty"`
+	// Plugins is the policy restricting the plugins that can be used in the project.
+	Plugins *ProjectPluginPolicy `json:"plugins,omitempty" yaml:"plugins,omitempty"`
 }
 
 type Project struct {
@@ -73,5 +90,8 @@ func (p *Project) validate() error {
 	if p.Kind != KindProject {
 		return fmt.Errorf("invalid kind: %q for a Project type", p.Kind)
 	}
+	if p.Spec.Plugins != nil && len(p.Spec.Plugins.Enabled) > 0 && len(p.Spec.Plugins.Disabled) > 0 {
+		return fmt.Errorf("spec.plugins.enabled and spec.plugins.disabled can not be used at the same time")
+	}
 	return nil
 }
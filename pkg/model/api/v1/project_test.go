@@ -0,0 +1,61 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnmarshalProjectPluginPolicy(t *testing.T) {
+	jason := `
+{
+  "kind": "Project",
+  "metadata": {
+    "name": "payments"
+  },
+  "spec": {
+    "plugins": {
+      "enabled": ["Prometheus", "TimeSeriesChart"]
+    }
+  }
+}
+`
+	result := Project{}
+	assert.NoError(t, json.Unmarshal([]byte(jason), &result))
+	assert.Equal(t, &ProjectPluginPolicy{Enabled: []string{"Prometheus", "TimeSeriesChart"}}, result.Spec.Plugins)
+	assert.False(t, result.Spec.Plugins.IsEmpty())
+}
+
+func TestUnmarshalProjectError(t *testing.T) {
+	jason := `
+{
+  "kind": "Project",
+  "metadata": {
+    "name": "payments"
+  },
+  "spec": {
+    "plugins": {
+      "enabled": ["Prometheus"],
+      "disabled": ["Tempo"]
+    }
+  }
+}
+`
+	result := Project{}
+	assert.Equal(t, fmt.Errorf("spec.plugins.enabled and spec.plugins.disabled can not be used at the same time"), json.Unmarshal([]byte(jason), &result))
+}
@@ -0,0 +1,65 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package role
+
+// GrantSource is what a grant comes from.
+type GrantSource string
+
+const (
+	GuestPermissionsSource GrantSource = "GuestPermissions"
+	GlobalRoleSource       GrantSource = "GlobalRole"
+	RoleSource             GrantSource = "Role"
+	PolicySource           GrantSource = "Policy"
+)
+
+// Grant is a role, or any other source of permissions, granting the permission explained.
+type Grant struct {
+	Source GrantSource `json:"source" yaml:"source"`
+	// Name of the role or of the policy. It is empty for the guest permissions.
+	Name string `json:"name,omitempty" yaml:"name,omitempty"`
+	// Project of the role. It is empty for the global sources.
+	Project string `json:"project,omitempty" yaml:"project,omitempty"`
+	// Bindings are the names of the bindings giving the role to the user.
+	Bindings []string `json:"bindings,omitempty" yaml:"bindings,omitempty"`
+	// Bound is true when the grant applies to the user.
+	// When it is false, the grant would give the permission if the user was bound to it.
+	Bound bool `json:"bound" yaml:"bound"`
+	// Restricted is true when the permission granted only applies to some resources of the scope.
+	Restricted bool `json:"restricted,omitempty" yaml:"restricted,omitempty"`
+}
+
+// Explanation is the decision taken for a user asking to perform an action, and the reasons of this decision.
+type Explanation struct {
+	User    string `json:"user" yaml:"user"`
+	Action  Action `json:"action" yaml:"action"`
+	Scope   Scope  `json:"scope" yaml:"scope"`
+	Project string `json:"project,omitempty" yaml:"project,omitempty"`
+	// Allowed is true when the user is allowed to perform the action on every resource of the scope.
+	Allowed bool `json:"allowed" yaml:"allowed"`
+	// Grants are the sources granting, or that would grant, the permission.
+	Grants []Grant `json:"grants,omitempty" yaml:"grants,omitempty"`
+	// Reason is a human-readable explanation of the decision, when the grants are not enough to understand it.
+	Reason string `json:"reason,omitempty" yaml:"reason,omitempty"`
+}
+
+// BoundGrants returns the grants applying to the user.
+func (e *Explanation) BoundGrants() []Grant {
+	var result []Grant
+	for _, g := range e.Grants {
+		if g.Bound {
+			result = append(result, g)
+		}
+	}
+	return result
+}
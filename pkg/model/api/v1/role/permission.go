@@ -24,6 +24,14 @@ type Permission struct {
 	// The list of kind targeted by the permission. For example: `Datasource`, `Dashboard`, ...
 	// With Role, you can't target global kinds
 	Scopes []Scope `json:"scopes" yaml:"scopes"`
+	// Resources restricts the permission to the resources matching the selector.
+	// When it is not set, the permission applies to every resource of the scopes.
+	Resources *ResourceSelector `json:"resources,omitempty" yaml:"resources,omitempty"`
+}
+
+// IsRestricted returns true if the permission only applies to some resources of its scopes.
+func (p *Permission) IsRestricted() bool {
+	return p.Resources != nil
 }
 
 func (p *Permission) UnmarshalJSON(data []byte) error {
@@ -59,5 +67,8 @@ func (p *Permission) validate() error {
 	if len(p.Scopes) == 0 {
 		return fmt.Errorf("permission scopes cannot be empty")
 	}
+	if p.Resources != nil {
+		return p.Resources.validate()
+	}
 	return nil
 }
@@ -0,0 +1,90 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package role
+
+import (
+	"fmt"
+	"path"
+	"slices"
+)
+
+// ResourceSelector narrows a permission to a subset of the resources of its scopes.
+// Every criterion set must be satisfied by the resource, and a criterion is satisfied when at least one of its values matches.
+type ResourceSelector struct {
+	// Names is a list of patterns matched against the name of the resource, e.g. `payments-*`.
+	// The syntax of the patterns is the one described in https://pkg.go.dev/path#Match.
+	Names []string `json:"names,omitempty" yaml:"names,omitempty"`
+	// Tags is a list of tags. The resource must have at least one of them.
+	Tags []string `json:"tags,omitempty" yaml:"tags,omitempty"`
+	// Folders is a list of patterns matched against the path of the folders containing the resource, e.g. `payments/*`.
+	// The path of a folder is the name of the Folder resource, followed by the names of the sub folders separated by `/`.
+	// As a resource is contained by a folder and all its parents, the pattern `payments` matches every dashboard of the folder `payments`, including the ones in its sub folders.
+	// This criterion only applies to dashboards, as they are the only resources that can be referenced by a folder.
+	Folders []string `json:"folders,omitempty" yaml:"folders,omitempty"`
+}
+
+// Resource describes the resource on which a restricted permission is evaluated.
+type Resource struct {
+	Name string
+	Tags []string
+	// Folders is the list of the paths of the folders containing the resource.
+	Folders []string
+}
+
+// Match returns true if the resource satisfies every criterion of the selector.
+// A nil selector matches every resource.
+func (s *ResourceSelector) Match(resource Resource) bool {
+	if s == nil {
+		return true
+	}
+	if len(s.Names) > 0 && !matchAnyPattern(s.Names, []string{resource.Name}) {
+		return false
+	}
+	if len(s.Tags) > 0 && !slices.ContainsFunc(s.Tags, func(tag string) bool { return slices.Contains(resource.Tags, tag) }) {
+		return false
+	}
+	if len(s.Folders) > 0 && !matchAnyPattern(s.Folders, resource.Folders) {
+		return false
+	}
+	return true
+}
+
+// HasFolders returns true if the selector restricts the resources by folder.
+func (s *ResourceSelector) HasFolders() bool {
+	return s != nil && len(s.Folders) > 0
+}
+
+func (s *ResourceSelector) validate() error {
+	if len(s.Names) == 0 && len(s.Tags) == 0 && len(s.Folders) == 0 {
+		return fmt.Errorf("permission resources must define at least one name, tag or folder")
+	}
+	for _, pattern := range append(slices.Clone(s.Names), s.Folders...) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid resource pattern %q: %w", pattern, err)
+		}
+	}
+	return nil
+}
+
+func matchAnyPattern(patterns []string, values []string) bool {
+	for _, pattern := range patterns {
+		for _, value := range values {
+			// The patterns are validated when the permission is decoded, so the error can be ignored.
+			if ok, _ := path.Match(pattern, value); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
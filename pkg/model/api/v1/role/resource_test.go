@@ -0,0 +1,98 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package role
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResourceSelector_Match(t *testing.T) {
+	testSuite := []struct {
+		title    string
+		selector *ResourceSelector
+		resource Resource
+		expected bool
+	}{
+		{
+			title:    "nil selector matches everything",
+			selector: nil,
+			resource: Resource{Name: "anything"},
+			expected: true,
+		},
+		{
+			title:    "name pattern",
+			selector: &ResourceSelector{Names: []string{"payments-*"}},
+			resource: Resource{Name: "payments-api"},
+			expected: true,
+		},
+		{
+			title:    "name pattern not matching",
+			selector: &ResourceSelector{Names: []string{"payments-*"}},
+			resource: Resource{Name: "billing"},
+			expected: false,
+		},
+		{
+			title:    "one of the tags",
+			selector: &ResourceSelector{Tags: []string{"payments", "billing"}},
+			resource: Resource{Name: "overview", Tags: []string{"team-a", "billing"}},
+			expected: true,
+		},
+		{
+			title:    "missing tag",
+			selector: &ResourceSelector{Tags: []string{"payments"}},
+			resource: Resource{Name: "overview"},
+			expected: false,
+		},
+		{
+			title:    "sub folder pattern",
+			selector: &ResourceSelector{Folders: []string{"payments/*"}},
+			resource: Resource{Name: "overview", Folders: []string{"payments", "payments/api"}},
+			expected: true,
+		},
+		{
+			title:    "sub folder pattern doesn't match the parent folder",
+			selector: &ResourceSelector{Folders: []string{"payments/*"}},
+			resource: Resource{Name: "overview", Folders: []string{"payments"}},
+			expected: false,
+		},
+		{
+			title:    "every criterion must match",
+			selector: &ResourceSelector{Names: []string{"payments-*"}, Tags: []string{"prod"}},
+			resource: Resource{Name: "payments-api", Tags: []string{"dev"}},
+			expected: false,
+		},
+	}
+	for _, test := range testSuite {
+		t.Run(test.title, func(t *testing.T) {
+			assert.Equal(t, test.expected, test.selector.Match(test.resource))
+		})
+	}
+}
+
+func TestUnmarshalPermissionWithResources(t *testing.T) {
+	var p Permission
+	err := json.Unmarshal([]byte(`{"actions":["update"],"scopes":["Dashboard"],"resources":{"folders":["payments/*"]}}`), &p)
+	assert.NoError(t, err)
+	assert.True(t, p.IsRestricted())
+	assert.Equal(t, []string{"payments/*"}, p.Resources.Folders)
+
+	err = json.Unmarshal([]byte(`{"actions":["update"],"scopes":["Dashboard"],"resources":{}}`), &p)
+	assert.Error(t, err)
+
+	err = json.Unmarshal([]byte(`{"actions":["update"],"scopes":["Dashboard"],"resources":{"names":["[payments"]}}`), &p)
+	assert.Error(t, err)
+}
@@ -22,22 +22,25 @@ import (
 type Scope string
 
 const (
-	DashboardScope          Scope = "Dashboard"
-	DatasourceScope         Scope = "Datasource"
-	EphemeralDashboardScope Scope = "EphemeralDashboard"
-	FolderScope             Scope = "Folder"
-	GlobalDatasourceScope   Scope = "GlobalDatasource"
-	GlobalRoleScope         Scope = "GlobalRole"
-	GlobalRoleBindingScope  Scope = "GlobalRoleBinding"
-	GlobalSecretScope       Scope = "GlobalSecret"
-	GlobalVariableScope     Scope = "GlobalVariable"
-	ProjectScope            Scope = "Project"
-	RoleScope               Scope = "Role"
-	RoleBindingScope        Scope = "RoleBinding"
-	SecretScope             Scope = "Secret"
-	UserScope               Scope = "User"
-	VariableScope           Scope = "Variable"
-	WildcardScope           Scope = "*"
+	DashboardScope               Scope = "Dashboard"
+	DashboardTemplateScope       Scope = "DashboardTemplate"
+	DatasourceScope              Scope = "Datasource"
+	EphemeralDashboardScope      Scope = "EphemeralDashboard"
+	FolderScope                  Scope = "Folder"
+	GlobalDashboardTemplateScope Scope = "GlobalDashboardTemplate"
+	GlobalDatasourceScope        Scope = "GlobalDatasource"
+	GlobalPolicyScope            Scope = "GlobalPolicy"
+	GlobalRoleScope              Scope = "GlobalRole"
+	GlobalRoleBindingScope       Scope = "GlobalRoleBinding"
+	GlobalSecretScope            Scope = "GlobalSecret"
+	GlobalVariableScope          Scope = "GlobalVariable"
+	ProjectScope                 Scope = "Project"
+	RoleScope                    Scope = "Role"
+	RoleBindingScope             Scope = "RoleBinding"
+	SecretScope                  Scope = "Secret"
+	UserScope                    Scope = "User"
+	VariableScope                Scope = "Variable"
+	WildcardScope                Scope = "*"
 )
 
 func (k *Scope) UnmarshalJSON(data []byte) error {
@@ -82,6 +85,9 @@ func GetScope(scope string) (*Scope, error) {
 	case strings.ToLower(string(DashboardScope)):
 		result := DashboardScope
 		return &result, nil
+	case strings.ToLower(string(DashboardTemplateScope)):
+		result := DashboardTemplateScope
+		return &result, nil
 	case strings.ToLower(string(DatasourceScope)):
 		result := DatasourceScope
 		return &result, nil
@@ -91,9 +97,15 @@ func GetScope(scope string) (*Scope, error) {
 	case strings.ToLower(string(FolderScope)):
 		result := FolderScope
 		return &result, nil
+	case strings.ToLower(string(GlobalDashboardTemplateScope)):
+		result := GlobalDashboardTemplateScope
+		return &result, nil
 	case strings.ToLower(string(GlobalDatasourceScope)):
 		result := GlobalDatasourceScope
 		return &result, nil
+	case strings.ToLower(string(GlobalPolicyScope)):
+		result := GlobalPolicyScope
+		return &result, nil
 	case strings.ToLower(string(GlobalRoleScope)):
 		result := GlobalRoleScope
 		return &result, nil
@@ -136,7 +148,7 @@ func IsGlobalScope(scope Scope) bool {
 	switch scope {
 	// ProjectScope is not global even if it should be. Owners of projects should be able to delete their own projects
 	// As ProjectScope is not Global, it can be added in Role scopes and allow this flow.
-	case GlobalDatasourceScope, GlobalRoleScope, GlobalRoleBindingScope, GlobalSecretScope, GlobalVariableScope, UserScope:
+	case GlobalDashboardTemplateScope, GlobalDatasourceScope, GlobalPolicyScope, GlobalRoleScope, GlobalRoleBindingScope, GlobalSecretScope, GlobalVariableScope, UserScope:
 		return true
 	default:
 		return false
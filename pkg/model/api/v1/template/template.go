@@ -0,0 +1,155 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package template
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+)
+
+// placeholderRegexp matches the placeholders `[[ parameter ]]` used in a template.
+// The Go template syntax `{{ parameter }}` is not used, as it is already the one of the legends of several query plugins.
+var placeholderRegexp = regexp.MustCompile(`\[\[\s*([a-zA-Z0-9_-]+)\s*\]\]`)
+
+// parameterNameRegexp is the format a parameter name must follow to be usable in a placeholder.
+var parameterNameRegexp = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+
+// Parameter is a value that must be, or can be, provided when instantiating a template.
+type Parameter struct {
+	Name        string `json:"name" yaml:"name"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	// Default is the value used when the parameter is not provided.
+	// When no default is set, the parameter is required.
+	Default *string `json:"default,omitempty" yaml:"default,omitempty"`
+}
+
+// VerifyParameters checks that the parameters are unique and that their names can be used in a placeholder.
+func VerifyParameters(parameters []Parameter) error {
+	names := make(map[string]bool, len(parameters))
+	for _, param := range parameters {
+		if !parameterNameRegexp.MatchString(param.Name) {
+			return fmt.Errorf("parameter name %q is not valid, it must match %s", param.Name, parameterNameRegexp.String())
+		}
+		if names[param.Name] {
+			return fmt.Errorf("parameter %q is declared more than once", param.Name)
+		}
+		names[param.Name] = true
+	}
+	return nil
+}
+
+// Placeholders returns the sorted list of the parameter names used in the placeholders of the object.
+// The placeholders are looked up in every string and every key of the object.
+func Placeholders(object any) []string {
+	var result []string
+	walkStrings(object, func(s string) {
+		for _, match := range placeholderRegexp.FindAllStringSubmatch(s, -1) {
+			if !slices.Contains(result, match[1]) {
+				result = append(result, match[1])
+			}
+		}
+	})
+	slices.Sort(result)
+	return result
+}
+
+// Values merges the values provided with the default of the parameters.
+// It returns an error if a value is provided for an unknown parameter or if a required parameter is missing.
+func Values(parameters []Parameter, provided map[string]string) (map[string]string, error) {
+	result := make(map[string]string, len(parameters))
+	for name := range provided {
+		if !slices.ContainsFunc(parameters, func(param Parameter) bool { return param.Name == name }) {
+			return nil, fmt.Errorf("unknown parameter %q", name)
+		}
+	}
+	for _, param := range parameters {
+		if value, ok := provided[param.Name]; ok {
+			result[param.Name] = value
+		} else if param.Default != nil {
+			result[param.Name] = *param.Default
+		} else {
+			return nil, fmt.Errorf("parameter %q is required", param.Name)
+		}
+	}
+	return result, nil
+}
+
+// Render returns a copy of the object where every placeholder is replaced by the value of the parameter.
+// The object is expected to be the result of a JSON or a YAML decoding (i.e. made of maps, slices and scalars).
+func Render(object any, values map[string]string) (any, error) {
+	switch obj := object.(type) {
+	case string:
+		return renderString(obj, values)
+	case map[string]any:
+		result := make(map[string]any, len(obj))
+		for key, value := range obj {
+			renderedKey, err := renderString(key, values)
+			if err != nil {
+				return nil, err
+			}
+			if _, exists := result[renderedKey]; exists {
+				return nil, fmt.Errorf("key %q is duplicated once rendered", renderedKey)
+			}
+			renderedValue, err := Render(value, values)
+			if err != nil {
+				return nil, err
+			}
+			result[renderedKey] = renderedValue
+		}
+		return result, nil
+	case []any:
+		result := make([]any, 0, len(obj))
+		for _, value := range obj {
+			renderedValue, err := Render(value, values)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, renderedValue)
+		}
+		return result, nil
+	default:
+		return object, nil
+	}
+}
+
+func renderString(s string, values map[string]string) (string, error) {
+	var err error
+	result := placeholderRegexp.ReplaceAllStringFunc(s, func(placeholder string) string {
+		name := placeholderRegexp.FindStringSubmatch(placeholder)[1]
+		value, ok := values[name]
+		if !ok {
+			err = fmt.Errorf("no value for the parameter %q", name)
+			return placeholder
+		}
+		return value
+	})
+	return result, err
+}
+
+func walkStrings(object any, f func(s string)) {
+	switch obj := object.(type) {
+	case string:
+		f(obj)
+	case map[string]any:
+		for key, value := range obj {
+			f(key)
+			walkStrings(value, f)
+		}
+	case []any:
+		for _, value := range obj {
+			walkStrings(value, f)
+		}
+	}
+}
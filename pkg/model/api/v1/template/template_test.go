@@ -0,0 +1,164 @@
+// Copyright The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package template
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifyParameters(t *testing.T) {
+	testSuites := []struct {
+		title      string
+		parameters []Parameter
+		wantErr    bool
+	}{
+		{
+			title:      "valid parameters",
+			parameters: []Parameter{{Name: "service"}, {Name: "job_name"}},
+		},
+		{
+			title:      "invalid name",
+			parameters: []Parameter{{Name: "service name"}},
+			wantErr:    true,
+		},
+		{
+			title:      "duplicated name",
+			parameters: []Parameter{{Name: "service"}, {Name: "service"}},
+			wantErr:    true,
+		},
+	}
+	for _, test := range testSuites {
+		t.Run(test.title, func(t *testing.T) {
+			err := VerifyParameters(test.parameters)
+			assert.Equal(t, test.wantErr, err != nil)
+		})
+	}
+}
+
+func TestPlaceholders(t *testing.T) {
+	object := map[string]any{
+		"display": map[string]any{"name": "[[ service ]] overview"},
+		"[[env]]": []any{"[[ service ]]", "rate(http_requests_total{job=\"[[job]]\"}[5m])", 12},
+	}
+	assert.Equal(t, []string{"env", "job", "service"}, Placeholders(object))
+}
+
+func TestValues(t *testing.T) {
+	env := "production"
+	parameters := []Parameter{{Name: "service"}, {Name: "env", Default: &env}}
+	testSuites := []struct {
+		title    string
+		provided map[string]string
+		expected map[string]string
+		wantErr  bool
+	}{
+		{
+			title:    "default used",
+			provided: map[string]string{"service": "checkout"},
+			expected: map[string]string{"service": "checkout", "env": "production"},
+		},
+		{
+			title:    "default overridden",
+			provided: map[string]string{"service": "checkout", "env": "staging"},
+			expected: map[string]string{"service": "checkout", "env": "staging"},
+		},
+		{
+			title:    "required parameter missing",
+			provided: map[string]string{"env": "staging"},
+			wantErr:  true,
+		},
+		{
+			title:    "unknown parameter",
+			provided: map[string]string{"service": "checkout", "team": "payments"},
+			wantErr:  true,
+		},
+	}
+	for _, test := range testSuites {
+		t.Run(test.title, func(t *testing.T) {
+			result, err := Values(parameters, test.provided)
+			if test.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, result)
+		})
+	}
+}
+
+func TestRender(t *testing.T) {
+	object := map[string]any{
+		"display": map[string]any{"name": "[[ service ]] overview"},
+		"panels": map[string]any{
+			"[[service]]_latency": map[string]any{
+				"query": "histogram_quantile(0.99, rate(http_duration_seconds_bucket{job=\"[[ service ]]\",env=\"[[env]]\"}[5m]))",
+				"max":   1,
+			},
+		},
+		"tags": []any{"[[ env ]]", true},
+	}
+	expected := map[string]any{
+		"display": map[string]any{"name": "checkout overview"},
+		"panels": map[string]any{
+			"checkout_latency": map[string]any{
+				"query": "histogram_quantile(0.99, rate(http_duration_seconds_bucket{job=\"checkout\",env=\"production\"}[5m]))",
+				"max":   1,
+			},
+		},
+		"tags": []any{"production", true},
+	}
+	result, err := Render(object, map[string]string{"service": "checkout", "env": "production"})
+	assert.NoError(t, err)
+	assert.Equal(t, expected, result)
+	// the template must be left untouched
+	assert.Equal(t, "[[ service ]] overview", object["display"].(map[string]any)["name"])
+}
+
+func TestRenderKeepsSeriesNameFormat(t *testing.T) {
+	object := map[string]any{
+		"query":            "up{job=\"[[ service ]]\"}",
+		"seriesNameFormat": "{{ instance }} - [[ service ]]",
+	}
+	assert.Equal(t, []string{"service"}, Placeholders(object))
+	result, err := Render(object, map[string]string{"service": "checkout"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"query":            "up{job=\"checkout\"}",
+		"seriesNameFormat": "{{ instance }} - checkout",
+	}, result)
+}
+
+func TestRenderError(t *testing.T) {
+	testSuites := []struct {
+		title  string
+		object any
+	}{
+		{
+			title:  "missing value",
+			object: map[string]any{"name": "[[ team ]]"},
+		},
+		{
+			title:  "duplicated key",
+			object: map[string]any{"[[ service ]]": 1, "checkout": 2},
+		},
+	}
+	for _, test := range testSuites {
+		t.Run(test.title, func(t *testing.T) {
+			_, err := Render(test.object, map[string]string{"service": "checkout"})
+			assert.Error(t, err)
+		})
+	}
+}
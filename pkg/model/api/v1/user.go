@@ -16,10 +16,18 @@ package v1
 import (
 	"encoding/json"
 	"fmt"
+	"slices"
+	"time"
 
 	modelAPI "github.com/perses/perses/pkg/model/api"
 )
 
+// MaxRecentlyViewedDashboards is the number of dashboards returned in the recently viewed dashboards of a user.
+const MaxRecentlyViewedDashboards = 20
+
+// MaxStarredDashboards is the maximum number of dashboards a user can star.
+const MaxStarredDashboards = 100
+
 // WildcardProject is a special project name that can be used to refer to all projects.
 // It is used in the context of user permissions to indicate that the permission applies to all projects.
 const WildcardProject = "*"
@@ -34,11 +42,89 @@ type OAuthProvider struct {
 	Subject string `json:"subject,omitempty" yaml:"subject,omitempty"`
 }
 
+// DashboardReference is the reference to a dashboard in the preferences of a user.
+type DashboardReference struct {
+	Project   string `json:"project" yaml:"project"`
+	Dashboard string `json:"dashboard" yaml:"dashboard"`
+}
+
+func (d *DashboardReference) validate() error {
+	if len(d.Project) == 0 || len(d.Dashboard) == 0 {
+		return fmt.Errorf("a dashboard reference must contain the project and the dashboard")
+	}
+	return nil
+}
+
+type RecentDashboard struct {
+	DashboardReference `json:",inline" yaml:",inline"`
+	ViewedAt           time.Time `json:"viewedAt" yaml:"viewedAt"`
+}
+
+// UserPreferences are the preferences of a user, stored server-side so they follow the user across browsers.
+type UserPreferences struct {
+	// Timezone is the timezone used to display the dates. It is either "local" or a timezone of the IANA database.
+	Timezone      string              `json:"timezone,omitempty" yaml:"timezone,omitempty"`
+	HomeDashboard *DashboardReference `json:"homeDashboard,omitempty" yaml:"homeDashboard,omitempty"`
+	// StarredDashboards are the favourite dashboards of the user.
+	StarredDashboards []DashboardReference `json:"starredDashboards,omitempty" yaml:"starredDashboards,omitempty"`
+	// RecentlyViewedDashboards is filled by the server from the views of the user, the most recent view first.
+	// The views are stored apart from the user, so they are not part of the stored preferences.
+	RecentlyViewedDashboards []RecentDashboard `json:"recentlyViewedDashboards,omitempty" yaml:"recentlyViewedDashboards,omitempty"`
+}
+
+func (p *UserPreferences) validate() error {
+	if len(p.Timezone) > 0 && p.Timezone != "local" {
+		if _, err := time.LoadLocation(p.Timezone); err != nil {
+			return fmt.Errorf("invalid timezone %q: %w", p.Timezone, err)
+		}
+	}
+	if p.HomeDashboard != nil {
+		if err := p.HomeDashboard.validate(); err != nil {
+			return fmt.Errorf("invalid home dashboard: %w", err)
+		}
+	}
+	if len(p.StarredDashboards) > MaxStarredDashboards {
+		return fmt.Errorf("cannot star more than %d dashboards", MaxStarredDashboards)
+	}
+	for i, starred := range p.StarredDashboards {
+		if err := starred.validate(); err != nil {
+			return fmt.Errorf("invalid starred dashboard: %w", err)
+		}
+		if slices.Contains(p.StarredDashboards[:i], starred) {
+			return fmt.Errorf("dashboard %q of the project %q is starred twice", starred.Dashboard, starred.Project)
+		}
+	}
+	if len(p.RecentlyViewedDashboards) > MaxRecentlyViewedDashboards {
+		return fmt.Errorf("cannot contain more than %d recently viewed dashboards", MaxRecentlyViewedDashboards)
+	}
+	for _, recent := range p.RecentlyViewedDashboards {
+		if err := recent.validate(); err != nil {
+			return fmt.Errorf("invalid recently viewed dashboard: %w", err)
+		}
+	}
+	return nil
+}
+
+func (p *UserPreferences) UnmarshalJSON(data []byte) error {
+	var tmp UserPreferences
+	type plain UserPreferences
+	if err := json.Unmarshal(data, (*plain)(&tmp)); err != nil {
+		return err
+	}
+	if err := (&tmp).validate(); err != nil {
+		return err
+	}
+	*p = tmp
+	return nil
+}
+
 type UserSpec struct {
 	FirstName      string          `json:"firstName,omitempty" yaml:"firstName,omitempty"`
 	LastName       string          `json:"lastName,omitempty" yaml:"lastName,omitempty"`
 	NativeProvider NativeProvider  `json:"nativeProvider,omitempty" yaml:"nativeProvider,omitempty"`
 	OauthProviders []OAuthProvider `json:"oauthProviders,omitempty" yaml:"oauthProviders,omitempty"`
+	// Preferences are only available to the user itself, through the /api/v1/user/preferences endpoint.
+	Preferences *UserPreferences `json:"preferences,omitempty" yaml:"preferences,omitempty"`
 }
 
 type User struct {
@@ -15,6 +15,7 @@ package v1
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"testing"
 
@@ -60,6 +61,30 @@ func TestUnmarshalUser(t *testing.T) {
     ]
   }
 }
+`,
+		},
+		{
+			title: "preferences",
+			jason: `
+{
+  "kind": "User",
+  "metadata": {
+    "name": "alice"
+  },
+  "spec": {
+    "preferences": {
+      "timezone": "Europe/Paris",
+      "homeDashboard": {"project": "perses", "dashboard": "home"},
+      "starredDashboards": [
+        {"project": "perses", "dashboard": "home"},
+        {"project": "perses", "dashboard": "kafka"}
+      ],
+      "recentlyViewedDashboards": [
+        {"project": "perses", "dashboard": "kafka", "viewedAt": "2026-10-18T12:00:00Z"}
+      ]
+    }
+  }
+}
 `,
 		},
 		{
@@ -116,6 +141,60 @@ func TestUnmarshalUserError(t *testing.T) {
 `,
 			err: fmt.Errorf("nativeProvider and oauthProviders are mutually exclusive, use one of them"),
 		},
+		{
+			title: "invalid timezone",
+			jason: `
+{
+  "kind": "User",
+  "metadata": {
+    "name": "alice"
+  },
+  "spec": {
+    "preferences": {
+      "timezone": "Mars/Olympus"
+    }
+  }
+}
+`,
+			err: fmt.Errorf("invalid timezone %q: %w", "Mars/Olympus", errors.New("unknown time zone Mars/Olympus")),
+		},
+		{
+			title: "dashboard starred twice",
+			jason: `
+{
+  "kind": "User",
+  "metadata": {
+    "name": "alice"
+  },
+  "spec": {
+    "preferences": {
+      "starredDashboards": [
+        {"project": "perses", "dashboard": "kafka"},
+        {"project": "perses", "dashboard": "kafka"}
+      ]
+    }
+  }
+}
+`,
+			err: fmt.Errorf("dashboard \"kafka\" of the project \"perses\" is starred twice"),
+		},
+		{
+			title: "incomplete home dashboard",
+			jason: `
+{
+  "kind": "User",
+  "metadata": {
+    "name": "alice"
+  },
+  "spec": {
+    "preferences": {
+      "homeDashboard": {"dashboard": "kafka"}
+    }
+  }
+}
+`,
+			err: fmt.Errorf("invalid home dashboard: %w", fmt.Errorf("a dashboard reference must contain the project and the dashboard")),
+		},
 	}
 	for _, test := range testSuite {
 		t.Run(test.title, func(t *testing.T) {